@@ -25,6 +25,7 @@ if [ -a build/docker-compose.yml ]; then
 	rm -rf odimra/odimra_config/odimra_config.json
 	rm -rf odimra/odimra_config/platformconfig.toml
 	rm -rf odimra/odimra_config/schema.json
+	rm -rf odimra/odimra_config/resource_filter_schema.json
 	rm -rf odimra/odimra_config/registrystore/*
         rm -rf RFPlugin/plugin_config/*
 	rm -rf DELLPlugin/dellplugin_config/*
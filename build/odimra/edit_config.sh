@@ -26,7 +26,9 @@ e=/etc
 sed -i "s#\"LocalhostFQDN\".*#\"LocalhostFQDN\": \"$fqdn\",#" /etc/odimra_config/odimra_config.json
 sed -i "s#\"MessageBusConfigFilePath\".*#\"MessageBusConfigFilePath\": \"$t/platformconfig.toml\",#" /etc/odimra_config/odimra_config.json
 sed -i "s#\"SearchAndFilterSchemaPath\".*#\"SearchAndFilterSchemaPath\": \"$e/schema.json\",#" /etc/odimra_config/odimra_config.json
+sed -i "s#\"ResourceFilterSchemaPath\".*#\"ResourceFilterSchemaPath\": \"$e/resource_filter_schema.json\",#" /etc/odimra_config/odimra_config.json
 sed -i "s#\"RegistryStorePath\".*#\"RegistryStorePath\": \"$d\",#" /etc/odimra_config/odimra_config.json
+sed -i "s#\"JSONSchemaStorePath\".*#\"JSONSchemaStorePath\": \"$e/jsonschemastore\",#" /etc/odimra_config/odimra_config.json
 sed -i "s#\"RootCACertificatePath\".*#\"RootCACertificatePath\": \"$c/rootCA.crt\",#" /etc/odimra_config/odimra_config.json
 sed -i "s#\"RPCPrivateKeyPath\".*#\"RPCPrivateKeyPath\": \"$c/odimra_server.key\",#" /etc/odimra_config/odimra_config.json
 sed -i "s#\"RPCCertificatePath\".*#\"RPCCertificatePath\": \"$c/odimra_server.crt\",#" /etc/odimra_config/odimra_config.json
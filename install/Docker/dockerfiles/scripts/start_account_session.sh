@@ -46,9 +46,9 @@ start_account_session()
         client_request_timeout=$(echo $(cat $CONFIG_FILE_PATH | grep SouthBoundRequestTimeoutInSecs | cut -d : -f2 | cut -d , -f1 | tr -d " " )s)
         if [[ $logs_on_console == "true" ]]
         then
-	/bin/svc-account-session --registry=etcd --registry_address=${registry_address} --server_address=account-session:45101 --client_request_timeout=${client_request_timeout} 2>&1 &
+	/bin/svc-account-session --registry=etcd --registry_address=${registry_address} --server_address=account-session:45101 --metrics_address=:45201 --client_request_timeout=${client_request_timeout} 2>&1 &
         else
-        nohup /bin/svc-account-session --registry=etcd --registry_address=${registry_address} --server_address=account-session:45101 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/account_session.log 2>&1 &
+        nohup /bin/svc-account-session --registry=etcd --registry_address=${registry_address} --server_address=account-session:45101 --metrics_address=:45201 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/account_session.log 2>&1 &
         fi
 	PID=$!
 	sleep 3
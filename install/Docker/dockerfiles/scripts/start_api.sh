@@ -46,9 +46,9 @@ start_api()
 	logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
 	if [[ $logs_on_console == "true" ]]
     then
-	/bin/svc-api --registry=etcd --registry_address=${registry_address} --client_request_timeout=${client_request_timeout} 2>&1 &
+	/bin/svc-api --registry=etcd --registry_address=${registry_address} --metrics_address=:45200 --client_request_timeout=${client_request_timeout} 2>&1 &
 	else
-	nohup /bin/svc-api --registry=etcd --registry_address=${registry_address} --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/api.log 2>&1 &
+	nohup /bin/svc-api --registry=etcd --registry_address=${registry_address} --metrics_address=:45200 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/api.log 2>&1 &
 	fi
 	PID=$!
 	sleep 3
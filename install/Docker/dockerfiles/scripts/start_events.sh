@@ -46,9 +46,9 @@ start_event()
 	logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
 	if [[ $logs_on_console == "true" ]]
     then
-	/bin/svc-events --registry=etcd --registry_address=${registry_address} --server_address=events:45103  --client_request_timeout=${client_request_timeout} 2>&1 &
+	/bin/svc-events --registry=etcd --registry_address=${registry_address} --server_address=events:45103 --metrics_address=:45203  --client_request_timeout=${client_request_timeout} 2>&1 &
 	else
-	nohup /bin/svc-events --registry=etcd --registry_address=${registry_address} --server_address=events:45103  --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/events.log 2>&1 &
+	nohup /bin/svc-events --registry=etcd --registry_address=${registry_address} --server_address=events:45103 --metrics_address=:45203  --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/events.log 2>&1 &
 	fi
 	PID=$!
 	sleep 3
@@ -46,9 +46,9 @@ start_fabrics()
         logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
 	if [[ $logs_on_console == "true" ]]
         then
-        /bin/svc-fabrics  --registry=etcd --registry_address=${registry_address} --server_address=fabrics:45106 --client_request_timeout=${client_request_timeout} 2>&1 &
+        /bin/svc-fabrics  --registry=etcd --registry_address=${registry_address} --server_address=fabrics:45106 --metrics_address=:45206 --client_request_timeout=${client_request_timeout} 2>&1 &
         else
-	nohup /bin/svc-fabrics  --registry=etcd --registry_address=${registry_address} --server_address=fabrics:45106 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/fabrics.log 2>&1 &
+	nohup /bin/svc-fabrics  --registry=etcd --registry_address=${registry_address} --server_address=fabrics:45106 --metrics_address=:45206 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/fabrics.log 2>&1 &
         fi
 	PID=$!
 	sleep 3
@@ -46,9 +46,9 @@ start_licenses()
         logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
         if [[ $logs_on_console == "true" ]]
         then
-        /bin/svc-licenses --registry=etcd --registry_address=${registry_address} --server_address=licenses:45113 --client_request_timeout=${client_request_timeout} 2>&1 &
+        /bin/svc-licenses --registry=etcd --registry_address=${registry_address} --server_address=licenses:45113 --metrics_address=:45213 --client_request_timeout=${client_request_timeout} 2>&1 &
         else
-	nohup /bin/svc-licenses --registry=etcd --registry_address=${registry_address} --server_address=licenses:45113   --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/licenses.log 2>&1 &
+	nohup /bin/svc-licenses --registry=etcd --registry_address=${registry_address} --server_address=licenses:45113 --metrics_address=:45213   --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/licenses.log 2>&1 &
 	fi
         PID=$!
 	sleep 3
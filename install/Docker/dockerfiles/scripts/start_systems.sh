@@ -46,9 +46,9 @@ start_systems()
         logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
         if [[ $logs_on_console == "true" ]]
         then
-        /bin/svc-systems --registry=etcd --registry_address=${registry_address} --server_address=systems:45104  --client_request_timeout=${client_request_timeout} 2>&1 &
+        /bin/svc-systems --registry=etcd --registry_address=${registry_address} --server_address=systems:45104 --metrics_address=:45204  --client_request_timeout=${client_request_timeout} 2>&1 &
         else
-	nohup /bin/svc-systems --registry=etcd --registry_address=${registry_address} --server_address=systems:45104   --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/systems.log 2>&1 &
+	nohup /bin/svc-systems --registry=etcd --registry_address=${registry_address} --server_address=systems:45104 --metrics_address=:45204   --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/systems.log 2>&1 &
 	fi
         PID=$!
 	sleep 3
@@ -46,9 +46,9 @@ start_telemetry()
         logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
         if [[ $logs_on_console == "true" ]]
         then
-        /bin/svc-telemetry  --registry=etcd --registry_address=${registry_address} --server_address=telemetry:45111 --client_request_timeout=${client_request_timeout} 2>&1 &
+        /bin/svc-telemetry  --registry=etcd --registry_address=${registry_address} --server_address=telemetry:45111 --metrics_address=:45211 --client_request_timeout=${client_request_timeout} 2>&1 &
 	else
-        nohup /bin/svc-telemetry  --registry=etcd --registry_address=${registry_address} --server_address=telemetry:45111 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/telemetry.log 2>&1 &
+        nohup /bin/svc-telemetry  --registry=etcd --registry_address=${registry_address} --server_address=telemetry:45111 --metrics_address=:45211 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/telemetry.log 2>&1 &
 	fi
         PID=$!
 	sleep 3
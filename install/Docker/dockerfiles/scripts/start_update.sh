@@ -46,9 +46,9 @@ start_update()
         logs_on_console=$(cat $CONFIG_FILE_PATH | grep logsRedirectionToConsole| cut -d : -f2 | cut -d , -f1 | tr -d " " )
         if [[ $logs_on_console == "true" ]]
         then
-        /bin/svc-update  --registry=etcd --registry_address=${registry_address} --server_address=update:45108  --client_request_timeout=${client_request_timeout} 2>&1 &
+        /bin/svc-update  --registry=etcd --registry_address=${registry_address} --server_address=update:45108 --metrics_address=:45208  --client_request_timeout=${client_request_timeout} 2>&1 &
 	else
-        nohup /bin/svc-update  --registry=etcd --registry_address=${registry_address} --server_address=update:45108 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/update.log 2>&1 &
+        nohup /bin/svc-update  --registry=etcd --registry_address=${registry_address} --server_address=update:45108 --metrics_address=:45208 --client_request_timeout=${client_request_timeout} >> /var/log/odimra_logs/update.log 2>&1 &
 	fi
         PID=$!
 	sleep 3
@@ -414,24 +414,26 @@ type AdditionalExternalAccountProviders struct {
 
 // PrivilegeMap redfish structure
 type PrivilegeMap struct {
+	ODataContext      string      `json:"@odata.context,omitempty"`
+	ODataID           string      `json:"@odata.id,omitempty"`
 	ODataType         string      `json:"@odata.type"`
 	Actions           *OemActions `json:"Actions,omitempty"`
 	Description       string      `json:"Description,omitempty"`
 	ID                string      `json:"Id"`
-	Mapping           *Mapping    `json:"Mapping,omitempty"`
+	Mappings          []Mapping   `json:"Mappings,omitempty"`
 	Name              string      `json:"Name"`
 	OEMPrivilegesUsed []string    `json:"OEMPrivilegesUsed,omitempty"`
-	Oem               Oem         `json:"Oem,omitempty"`
+	Oem               *Oem        `json:"Oem,omitempty"`
 	PrivilegesUsed    []string    `json:"PrivilegesUsed,omitempty"` //enum
 }
 
 // Mapping redfish structure
 type Mapping struct {
-	Entity               string             `json:"Entity,omitempty"`
-	OperationMap         OperationMap       `json:"OperationMap,omitempty"`
-	PropertyOverrides    TargetPrivilegeMap `json:"PropertyOverrides,omitempty"`
-	ResourceURIOverrides TargetPrivilegeMap `json:"ResourceURIOverrides,omitempty"`
-	SubordinateOverrides TargetPrivilegeMap `json:"SubordinateOverrides,omitempty"`
+	Entity               string               `json:"Entity,omitempty"`
+	OperationMap         OperationMap         `json:"OperationMap,omitempty"`
+	PropertyOverrides    []TargetPrivilegeMap `json:"PropertyOverrides,omitempty"`
+	ResourceURIOverrides []TargetPrivilegeMap `json:"ResourceURIOverrides,omitempty"`
+	SubordinateOverrides []TargetPrivilegeMap `json:"SubordinateOverrides,omitempty"`
 }
 
 // TargetPrivilegeMap redfish structure
@@ -442,12 +444,12 @@ type TargetPrivilegeMap struct {
 
 // OperationMap redfish structure
 type OperationMap struct {
-	DELETE OperationPrivilege `json:"DELETE,omitempty"`
-	GET    OperationPrivilege `json:"GET,omitempty"`
-	HEAD   OperationPrivilege `json:"HEAD,omitempty"`
-	POST   OperationPrivilege `json:"POST,omitempty"`
-	PUT    OperationPrivilege `json:"PUT,omitempty"`
-	PATCH  OperationPrivilege `json:"PATCH,omitempty"`
+	DELETE []OperationPrivilege `json:"DELETE,omitempty"`
+	GET    []OperationPrivilege `json:"GET,omitempty"`
+	HEAD   []OperationPrivilege `json:"HEAD,omitempty"`
+	POST   []OperationPrivilege `json:"POST,omitempty"`
+	PUT    []OperationPrivilege `json:"PUT,omitempty"`
+	PATCH  []OperationPrivilege `json:"PATCH,omitempty"`
 }
 
 // OperationPrivilege redfish structure
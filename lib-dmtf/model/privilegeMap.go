@@ -0,0 +1,365 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// PrivilegeRegistryType is the prefix of the @odata.type of the privilege registry
+	PrivilegeRegistryType = "#PrivilegeRegistry."
+	// PrivilegeNoAuth is the pseudo privilege of the operations which do not need authentication
+	PrivilegeNoAuth = "NoAuth"
+	// serviceRootEntity is the entity of /redfish/v1
+	serviceRootEntity = "ServiceRoot"
+)
+
+// redfishCollections maps the URI segments of the Redfish collections to the
+// entity of the collection and the entity of its members
+var redfishCollections = map[string][2]string{
+	"Accounts":                {"ManagerAccountCollection", "ManagerAccount"},
+	"AddressPools":            {"AddressPoolCollection", "AddressPool"},
+	"Aggregates":              {"AggregateCollection", "Aggregate"},
+	"AggregationSources":      {"AggregationSourceCollection", "AggregationSource"},
+	"BootOptions":             {"BootOptionCollection", "BootOption"},
+	"Certificates":            {"CertificateCollection", "Certificate"},
+	"Chassis":                 {"ChassisCollection", "Chassis"},
+	"ConnectionMethods":       {"ConnectionMethodCollection", "ConnectionMethod"},
+	"Connections":             {"ConnectionCollection", "Connection"},
+	"Controllers":             {"StorageControllerCollection", "StorageController"},
+	"Controls":                {"ControlCollection", "Control"},
+	"Drives":                  {"DriveCollection", "Drive"},
+	"Endpoints":               {"EndpointCollection", "Endpoint"},
+	"Entries":                 {"LogEntryCollection", "LogEntry"},
+	"EthernetInterfaces":      {"EthernetInterfaceCollection", "EthernetInterface"},
+	"Fabrics":                 {"FabricCollection", "Fabric"},
+	"Fans":                    {"FanCollection", "Fan"},
+	"FirmwareInventory":       {"SoftwareInventoryCollection", "SoftwareInventory"},
+	"HostInterfaces":          {"HostInterfaceCollection", "HostInterface"},
+	"Jobs":                    {"JobCollection", "Job"},
+	"JsonSchemas":             {"JsonSchemaFileCollection", "JsonSchemaFile"},
+	"LogServices":             {"LogServiceCollection", "LogService"},
+	"Managers":                {"ManagerCollection", "Manager"},
+	"Memory":                  {"MemoryCollection", "Memory"},
+	"MemoryDomains":           {"MemoryDomainCollection", "MemoryDomain"},
+	"MetricDefinitions":       {"MetricDefinitionCollection", "MetricDefinition"},
+	"MetricReportDefinitions": {"MetricReportDefinitionCollection", "MetricReportDefinition"},
+	"MetricReports":           {"MetricReportCollection", "MetricReport"},
+	"NetworkAdapters":         {"NetworkAdapterCollection", "NetworkAdapter"},
+	"NetworkDeviceFunctions":  {"NetworkDeviceFunctionCollection", "NetworkDeviceFunction"},
+	"NetworkInterfaces":       {"NetworkInterfaceCollection", "NetworkInterface"},
+	"NetworkPorts":            {"NetworkPortCollection", "NetworkPort"},
+	"PCIeDevices":             {"PCIeDeviceCollection", "PCIeDevice"},
+	"PCIeFunctions":           {"PCIeFunctionCollection", "PCIeFunction"},
+	"Ports":                   {"PortCollection", "Port"},
+	"PowerSupplies":           {"PowerSupplyCollection", "PowerSupply"},
+	"Processors":              {"ProcessorCollection", "Processor"},
+	"Registries":              {"MessageRegistryFileCollection", "MessageRegistryFile"},
+	"ResourceBlocks":          {"ResourceBlockCollection", "ResourceBlock"},
+	"ResourceZones":           {"ZoneCollection", "Zone"},
+	"Roles":                   {"RoleCollection", "Role"},
+	"SecureBootDatabases":     {"SecureBootDatabaseCollection", "SecureBootDatabase"},
+	"Sensors":                 {"SensorCollection", "Sensor"},
+	"SerialInterfaces":        {"SerialInterfaceCollection", "SerialInterface"},
+	"Sessions":                {"SessionCollection", "Session"},
+	"Signatures":              {"SignatureCollection", "Signature"},
+	"SimpleStorage":           {"SimpleStorageCollection", "SimpleStorage"},
+	"SoftwareInventory":       {"SoftwareInventoryCollection", "SoftwareInventory"},
+	"Storage":                 {"StorageCollection", "Storage"},
+	"StorageControllers":      {"StorageControllerCollection", "StorageController"},
+	"SubTasks":                {"TaskCollection", "Task"},
+	"Subscriptions":           {"EventDestinationCollection", "EventDestination"},
+	"Switches":                {"SwitchCollection", "Switch"},
+	"Systems":                 {"ComputerSystemCollection", "ComputerSystem"},
+	"Tasks":                   {"TaskCollection", "Task"},
+	"Triggers":                {"TriggersCollection", "Triggers"},
+	"VLANs":                   {"VLanNetworkInterfaceCollection", "VLanNetworkInterface"},
+	"VirtualMedia":            {"VirtualMediaCollection", "VirtualMedia"},
+	"Volumes":                 {"VolumeCollection", "Volume"},
+	"Zones":                   {"ZoneCollection", "Zone"},
+}
+
+// redfishSingletons maps the URI segments of the Redfish singleton resources to their entity
+var redfishSingletons = map[string]string{
+	"AccountService":     "AccountService",
+	"AggregationService": "AggregationService",
+	"Assembly":           "Assembly",
+	"Bios":               "Bios",
+	"CertificateService": "CertificateService",
+	"CompositionService": "CompositionService",
+	"EnvironmentMetrics": "EnvironmentMetrics",
+	"EventService":       "EventService",
+	"JobService":         "JobService",
+	"MemoryMetrics":      "MemoryMetrics",
+	"NetworkProtocol":    "ManagerNetworkProtocol",
+	"PCIeSlots":          "PCIeSlots",
+	"Power":              "Power",
+	"PowerSubsystem":     "PowerSubsystem",
+	"PrivilegeMap":       "PrivilegeRegistry",
+	"ProcessorMetrics":   "ProcessorMetrics",
+	"SecureBoot":         "SecureBoot",
+	"SessionService":     "SessionService",
+	"TaskService":        "TaskService",
+	"TelemetryService":   "TelemetryService",
+	"Thermal":            "Thermal",
+	"ThermalSubsystem":   "ThermalSubsystem",
+	"UpdateService":      "UpdateService",
+}
+
+// EntityPath returns the entities of the resources on the path of the URI, from
+// ServiceRoot to the resource addressed by the URI. The actions of a resource are
+// mapped to the resource. Nil is returned when a segment of the URI is not known
+func EntityPath(uri string) []string {
+	uri = strings.SplitN(uri, "?", 2)[0]
+	if !strings.HasPrefix(uri, "/redfish/v1") {
+		return nil
+	}
+	entities := []string{serviceRootEntity}
+	// memberEntity holds the entity of the members when the previous segment is a collection
+	memberEntity := ""
+	for _, segment := range strings.Split(strings.Trim(strings.TrimPrefix(uri, "/redfish/v1"), "/"), "/") {
+		switch {
+		case segment == "":
+		case memberEntity != "":
+			entities = append(entities, memberEntity)
+			memberEntity = ""
+		case segment == "Actions":
+			return entities
+		default:
+			if collection, exist := redfishCollections[segment]; exist {
+				entities = append(entities, collection[0])
+				memberEntity = collection[1]
+			} else if entity, exist := redfishSingletons[segment]; exist {
+				entities = append(entities, entity)
+			} else {
+				return nil
+			}
+		}
+	}
+	return entities
+}
+
+// RequiredPrivileges resolves the privileges required for the HTTP method on the URI.
+// Every element of the result has to be satisfied by the privileges of the user,
+// and an element is satisfied when any one of its OperationPrivilege is granted.
+// The ResourceURIOverrides take precedence over the SubordinateOverrides, which take
+// precedence over the OperationMap of the entity. The PropertyOverrides are applied
+// for the properties passed. The returned bool is false when the registry
+// does not define the privileges for the method on the URI
+func (p *PrivilegeMap) RequiredPrivileges(uri, method string, properties []string) ([][]OperationPrivilege, bool) {
+	entities := EntityPath(uri)
+	if len(entities) == 0 {
+		return nil, false
+	}
+	mapping := p.mapping(entities[len(entities)-1])
+	if mapping == nil {
+		return nil, false
+	}
+	privileges := resourcePrivileges(mapping, uri, entities[:len(entities)-1]).privileges(method)
+	if len(privileges) == 0 {
+		return nil, false
+	}
+
+	var required [][]OperationPrivilege
+	baseRequired := len(properties) == 0
+	for _, property := range properties {
+		override := propertyOverride(mapping.PropertyOverrides, property, method)
+		if override == nil {
+			baseRequired = true
+			continue
+		}
+		required = append(required, override)
+	}
+	if baseRequired {
+		required = append([][]OperationPrivilege{privileges}, required...)
+	}
+	return required, true
+}
+
+// mapping returns the mapping of the entity
+func (p *PrivilegeMap) mapping(entity string) *Mapping {
+	for i := range p.Mappings {
+		if p.Mappings[i].Entity == entity {
+			return &p.Mappings[i]
+		}
+	}
+	return nil
+}
+
+// resourcePrivileges returns the operation map which applies to the resource
+func resourcePrivileges(mapping *Mapping, uri string, parents []string) OperationMap {
+	for _, override := range mapping.ResourceURIOverrides {
+		for _, target := range override.Targets {
+			if matchURI(target, uri) {
+				return mergeOperationMap(mapping.OperationMap, override.OperationMap)
+			}
+		}
+	}
+	for _, override := range mapping.SubordinateOverrides {
+		if isSubordinate(override.Targets, parents) {
+			return mergeOperationMap(mapping.OperationMap, override.OperationMap)
+		}
+	}
+	return mapping.OperationMap
+}
+
+// mergeOperationMap returns the operation map with the operations of the override
+// replacing the operations of the base
+func mergeOperationMap(base, override OperationMap) OperationMap {
+	pick := func(b, o []OperationPrivilege) []OperationPrivilege {
+		if len(o) > 0 {
+			return o
+		}
+		return b
+	}
+	return OperationMap{
+		DELETE: pick(base.DELETE, override.DELETE),
+		GET:    pick(base.GET, override.GET),
+		HEAD:   pick(base.HEAD, override.HEAD),
+		POST:   pick(base.POST, override.POST),
+		PUT:    pick(base.PUT, override.PUT),
+		PATCH:  pick(base.PATCH, override.PATCH),
+	}
+}
+
+// privileges returns the privileges of the HTTP method
+func (o OperationMap) privileges(method string) []OperationPrivilege {
+	switch strings.ToUpper(method) {
+	case "DELETE":
+		return o.DELETE
+	case "GET":
+		return o.GET
+	case "HEAD":
+		return o.HEAD
+	case "POST":
+		return o.POST
+	case "PUT":
+		return o.PUT
+	case "PATCH":
+		return o.PATCH
+	}
+	return nil
+}
+
+// propertyOverride returns the privileges of the property override for the method, if any
+func propertyOverride(overrides []TargetPrivilegeMap, property, method string) []OperationPrivilege {
+	for _, override := range overrides {
+		for _, target := range override.Targets {
+			if target == property {
+				if privileges := override.OperationMap.privileges(method); len(privileges) > 0 {
+					return privileges
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isSubordinate checks the targets appear in order among the parents of the resource
+func isSubordinate(targets, parents []string) bool {
+	if len(targets) == 0 {
+		return false
+	}
+	i := 0
+	for _, parent := range parents {
+		if parent == targets[i] {
+			i++
+			if i == len(targets) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchURI matches the URI against the target of a ResourceURIOverride. The
+// segments of the target in braces, such as {ComputerSystemId}, match any value
+func matchURI(target, uri string) bool {
+	targetSegments := strings.Split(strings.Trim(target, "/"), "/")
+	uriSegments := strings.Split(strings.Trim(strings.SplitN(uri, "?", 2)[0], "/"), "/")
+	if len(targetSegments) != len(uriSegments) {
+		return false
+	}
+	for i, segment := range targetSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			continue
+		}
+		if segment != uriSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGranted checks the granted privileges satisfy the required privileges,
+// as resolved by RequiredPrivileges. NoAuth is always satisfied
+func IsGranted(required [][]OperationPrivilege, granted func(privilege string) bool) bool {
+	for _, alternatives := range required {
+		satisfied := false
+		for _, alternative := range alternatives {
+			if hasAll(alternative.Privilege, granted) {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAll checks all the privileges are granted
+func hasAll(privileges []string, granted func(privilege string) bool) bool {
+	if len(privileges) == 0 {
+		return false
+	}
+	for _, privilege := range privileges {
+		if privilege != PrivilegeNoAuth && !granted(privilege) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadPrivilegeMap reads the privilege registry from the registry store. The
+// registry is the JSON file whose @odata.type is a PrivilegeRegistry
+func LoadPrivilegeMap(registryStore string) (*PrivilegeMap, string, error) {
+	files, err := ioutil.ReadDir(registryStore)
+	if err != nil {
+		return nil, "", fmt.Errorf("while reading the registry store: %v", err)
+	}
+	for _, file := range files {
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(registryStore, file.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("while reading %s: %v", path, err)
+		}
+		var privilegeMap PrivilegeMap
+		if err := json.Unmarshal(data, &privilegeMap); err != nil || !strings.HasPrefix(privilegeMap.ODataType, PrivilegeRegistryType) {
+			continue
+		}
+		return &privilegeMap, path, nil
+	}
+	return nil, "", fmt.Errorf("no privilege registry found in %s", registryStore)
+}
@@ -0,0 +1,125 @@
+//(C) Copyright [2022] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func loadTestPrivilegeMap(t *testing.T) *PrivilegeMap {
+	privilegeMap, _, err := LoadPrivilegeMap("../../lib-utilities/etc")
+	if err != nil {
+		t.Fatalf("error while trying to load the privilege registry: %v", err)
+	}
+	return privilegeMap
+}
+
+func grantedPrivileges(privileges ...string) func(string) bool {
+	return func(privilege string) bool {
+		for _, p := range privileges {
+			if p == privilege {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func TestEntityPath(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want []string
+	}{
+		{"/redfish/v1", []string{"ServiceRoot"}},
+		{"/redfish/v1/Systems", []string{"ServiceRoot", "ComputerSystemCollection"}},
+		{"/redfish/v1/Systems/uuid.1/", []string{"ServiceRoot", "ComputerSystemCollection", "ComputerSystem"}},
+		{"/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", []string{"ServiceRoot", "ComputerSystemCollection", "ComputerSystem"}},
+		{"/redfish/v1/Managers/uuid.1/EthernetInterfaces/1?$select=Name", []string{"ServiceRoot", "ManagerCollection", "Manager", "EthernetInterfaceCollection", "EthernetInterface"}},
+		{"/redfish/v1/AccountService/Accounts/admin", []string{"ServiceRoot", "AccountService", "ManagerAccountCollection", "ManagerAccount"}},
+		{"/redfish/v1/Systems/uuid.1/Oem/Unknown", nil},
+		{"/ODIM/v1/Status", nil},
+	}
+	for _, tt := range tests {
+		if got := EntityPath(tt.uri); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("EntityPath(%s) = %v, want %v", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestRequiredPrivileges(t *testing.T) {
+	privilegeMap := loadTestPrivilegeMap(t)
+	tests := []struct {
+		name       string
+		uri        string
+		method     string
+		properties []string
+		granted    []string
+		want       bool
+	}{
+		{"read with Login", "/redfish/v1/Systems/uuid.1", "GET", nil, []string{"Login"}, true},
+		{"reset without ConfigureComponents", "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", "POST", nil, []string{"Login"}, false},
+		{"reset with ConfigureComponents", "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", "POST", nil, []string{"ConfigureComponents"}, true},
+		{"service root without authentication", "/redfish/v1", "GET", nil, nil, true},
+		{"system ethernet interface", "/redfish/v1/Systems/uuid.1/EthernetInterfaces/1", "PATCH", nil, []string{"ConfigureComponents"}, true},
+		{"manager ethernet interface subordinate override", "/redfish/v1/Managers/uuid.1/EthernetInterfaces/1", "PATCH", nil, []string{"ConfigureComponents"}, false},
+		{"manager ethernet interface with ConfigureManager", "/redfish/v1/Managers/uuid.1/EthernetInterfaces/1", "PATCH", nil, []string{"ConfigureManager"}, true},
+		{"password property override", "/redfish/v1/AccountService/Accounts/user", "PATCH", []string{"Password"}, []string{"ConfigureSelf"}, true},
+		{"role change without ConfigureUsers", "/redfish/v1/AccountService/Accounts/user", "PATCH", []string{"Password", "RoleId"}, []string{"ConfigureSelf"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			required, exist := privilegeMap.RequiredPrivileges(tt.uri, tt.method, tt.properties)
+			if !exist {
+				t.Fatalf("RequiredPrivileges() found no privileges for %s %s", tt.method, tt.uri)
+			}
+			if got := IsGranted(required, grantedPrivileges(tt.granted...)); got != tt.want {
+				t.Errorf("IsGranted() = %v, want %v for %v", got, tt.want, required)
+			}
+		})
+	}
+
+	if _, exist := privilegeMap.RequiredPrivileges("/redfish/v1/Systems/uuid.1/Oem/Unknown", "GET", nil); exist {
+		t.Errorf("RequiredPrivileges() expected no privileges for an unknown URI")
+	}
+}
+
+func TestResourceURIOverride(t *testing.T) {
+	privilegeMap := &PrivilegeMap{
+		Mappings: []Mapping{
+			{
+				Entity: "ComputerSystem",
+				OperationMap: OperationMap{
+					GET: []OperationPrivilege{{Privilege: []string{"Login"}}},
+				},
+				ResourceURIOverrides: []TargetPrivilegeMap{
+					{
+						Targets: []string{"/redfish/v1/Systems/{ComputerSystemId}"},
+						OperationMap: OperationMap{
+							GET: []OperationPrivilege{{Privilege: []string{"ConfigureManager"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+	required, _ := privilegeMap.RequiredPrivileges("/redfish/v1/Systems/uuid.1", "GET", nil)
+	if IsGranted(required, grantedPrivileges("Login")) {
+		t.Errorf("IsGranted() expected the resource URI override to require ConfigureManager")
+	}
+	if _, exist := privilegeMap.RequiredPrivileges("/redfish/v1/Systems/uuid.1", "DELETE", nil); exist {
+		t.Errorf("RequiredPrivileges() expected no privileges for an operation missing in the registry")
+	}
+}
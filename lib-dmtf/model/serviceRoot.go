@@ -121,6 +121,7 @@ type ProtocolFeaturesSupported struct {
 	FilterQuery          bool            `json:"FilterQuery"`
 	OnlyMemberQuery      bool            `json:"OnlyMemberQuery"`
 	SelectQuery          bool            `json:"SelectQuery"`
+	TopSkipQuery         bool            `json:"TopSkipQuery"`
 	MultipleHTTPRequests bool            `json:"MultipleHTTPRequests,omitempty"`
 	DeepOperations       *DeepOperations `json:"DeepOperations,omitempty"`
 }
@@ -31,6 +31,9 @@ const (
 	EVENTREADERGROUPNAME = "eventreaders_grp"
 )
 
+// TraceParentHeader is the header of the messages which carries the W3C trace context
+const TraceParentHeader = "traceparent"
+
 // MQBus Interface defines the Process interface function (Only function user
 // should call). These functions are implemented as part of Packet struct.
 // Distribute - API to Publish Messages into specified Pipe (Topic / Subject)
@@ -61,6 +64,34 @@ type Packet struct {
 	BrokerType string
 }
 
+// TraceContextCarrier is implemented by the messages which carry the W3C trace
+// context of the operation that published them. Distribute sends the trace context
+// in the TraceParentHeader of the message, so that the consumers continue the trace
+type TraceContextCarrier interface {
+	GetTraceParent() string
+}
+
+// traceParent returns the trace context carried by the message, if any
+func traceParent(d interface{}) string {
+	if carrier, ok := d.(TraceContextCarrier); ok {
+		return carrier.GetTraceParent()
+	}
+	return ""
+}
+
+// withTraceParent adds the trace context received in the TraceParentHeader
+// to the decoded message, when the message body does not carry it already
+func withTraceParent(d interface{}, traceParent string) interface{} {
+	message, ok := d.(map[string]interface{})
+	if !ok || traceParent == "" {
+		return d
+	}
+	if _, exist := message[TraceParentHeader]; !exist {
+		message[TraceParentHeader] = traceParent
+	}
+	return message
+}
+
 // Communicator defines the Broker platform Middleware selection and corresponding
 // communication object would be created to send / receive the messages. Broker
 // type would be stored as part of Connection Object "Packet".
@@ -94,6 +125,30 @@ func Communicator(bt string, messageQueueConfigPath, pipe string) (MQBus, error)
 	}
 }
 
+// Ping checks the connectivity with the Broker platform. It is used by the
+// micro services to report the health of the message bus
+func Ping(bt string) error {
+	switch bt {
+	case KAFKA:
+		kp := new(KafkaPacket)
+		if e := kafkaConnect(kp); e != nil {
+			return e
+		}
+		return kp.ping()
+	case REDISSTREAMS:
+		rp := new(RedisStreamsPacket)
+		if e := rp.getDBConnection(); e != nil {
+			return e
+		}
+		if !rp.Ping() {
+			return fmt.Errorf("unable to ping the redis streams server")
+		}
+		return nil
+	default:
+		return fmt.Errorf("Broker: \"Broker Type\" is not supported - %s", bt)
+	}
+}
+
 // Encode converts the interface into Byte stream (ENCODE).
 func Encode(d interface{}) ([]byte, error) {
 
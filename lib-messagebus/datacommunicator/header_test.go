@@ -16,6 +16,8 @@ package datacommunicator
 import (
 	"reflect"
 	"testing"
+
+	"github.com/segmentio/kafka-go"
 )
 
 func TestCommunicator(t *testing.T) {
@@ -92,3 +94,73 @@ func TestDecode(t *testing.T) {
 		})
 	}
 }
+
+type tracedMessage struct {
+	Data        string `json:"data"`
+	TraceParent string `json:"traceparent,omitempty"`
+}
+
+func (m tracedMessage) GetTraceParent() string {
+	return m.TraceParent
+}
+
+func TestTraceParent(t *testing.T) {
+	tp := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	if got := traceParent(tracedMessage{TraceParent: tp}); got != tp {
+		t.Errorf("traceParent() = %v, want %v", got, tp)
+	}
+	if got := traceParent(map[string]interface{}{"data": "event"}); got != "" {
+		t.Errorf("traceParent() = %v, want empty trace context", got)
+	}
+}
+
+func TestWithTraceParent(t *testing.T) {
+	tp := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	tests := []struct {
+		name        string
+		d           interface{}
+		traceParent string
+		want        interface{}
+	}{
+		{
+			name:        "trace context added from the header",
+			d:           map[string]interface{}{"data": "event"},
+			traceParent: tp,
+			want:        map[string]interface{}{"data": "event", TraceParentHeader: tp},
+		},
+		{
+			name:        "trace context of the body is kept",
+			d:           map[string]interface{}{"data": "event", TraceParentHeader: "body"},
+			traceParent: tp,
+			want:        map[string]interface{}{"data": "event", TraceParentHeader: "body"},
+		},
+		{
+			name:        "no trace context in the header",
+			d:           map[string]interface{}{"data": "event"},
+			traceParent: "",
+			want:        map[string]interface{}{"data": "event"},
+		},
+		{
+			name:        "message which is not an object",
+			d:           "event",
+			traceParent: tp,
+			want:        "event",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withTraceParent(tt.d, tt.traceParent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("withTraceParent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPing(t *testing.T) {
+	if err := Ping("Unknown"); err == nil {
+		t.Errorf("Ping() expected an error for an unsupported broker type")
+	}
+	if err := (&KafkaPacket{DialerConn: &kafka.Dialer{}}).ping(); err == nil {
+		t.Errorf("ping() expected an error when no KAFKA server is configured")
+	}
+}
@@ -149,6 +149,22 @@ func kafkaConnect(kp *KafkaPacket) error {
 	return nil
 }
 
+// ping dials the configured KAFKA servers until one of them accepts the connection
+func (kp *KafkaPacket) ping() error {
+	err := fmt.Errorf("no KAFKA server is configured")
+	for _, server := range kp.ServersInfo {
+		ctx, cancel := context.WithTimeout(context.Background(), kp.DialerConn.Timeout)
+		conn, e := kp.DialerConn.DialContext(ctx, "tcp", server)
+		cancel()
+		if e == nil {
+			conn.Close()
+			return nil
+		}
+		err = fmt.Errorf("error: connection to %s failed: %s", server, e.Error())
+	}
+	return err
+}
+
 // Distribute defines the Producer / Publisher role and functionality. Writer
 // would be created for each Pipe comes-in for communication. If Writer already
 // exists, that connection would be used for this call. Before publishing the
@@ -199,6 +215,9 @@ func (kp *KafkaPacket) Distribute(d interface{}) error {
 		Key:   []byte(kp.pipe),
 		Value: b,
 	}
+	if tp := traceParent(d); tp != "" {
+		km.Headers = []kafka.Header{{Key: TraceParentHeader, Value: []byte(tp)}}
+	}
 
 	// Write the messgae in the specified Pipe.
 	if e = writer.WriteMessages(context.Background(), km); e != nil {
@@ -275,6 +294,11 @@ func (kp *KafkaPacket) Read(fn MsgProcess) error {
 		if e = Decode(m.Value, &d); e != nil {
 			continue
 		}
+		for _, header := range m.Headers {
+			if header.Key == TraceParentHeader {
+				d = withTraceParent(d, string(header.Value))
+			}
+		}
 		// Callback Function call.
 		fn(d)
 	}
@@ -106,9 +106,13 @@ func (rp *RedisStreamsPacket) Distribute(data interface{}) error {
 		return fmt.Errorf("while trying to encode message: %s", e.Error())
 	}
 
+	values := map[string]interface{}{"data": b}
+	if tp := traceParent(data); tp != "" {
+		values[TraceParentHeader] = tp
+	}
 	_, rerr := rp.client.XAdd(ctx, &redis.XAddArgs{
 		Stream: rp.pipe,
-		Values: map[string]interface{}{"data": b},
+		Values: values,
 	}).Result()
 
 	if rerr != nil {
@@ -119,7 +123,7 @@ func (rp *RedisStreamsPacket) Distribute(data interface{}) error {
 			}
 			rp.client.XAdd(ctx, &redis.XAddArgs{
 				Stream: rp.pipe,
-				Values: map[string]interface{}{"data": b},
+				Values: values,
 			}).Result()
 		}
 		return fmt.Errorf("unable to publish event to redis, got: %s", rerr.Error())
@@ -190,7 +194,8 @@ func processEvent(rp *RedisStreamsPacket, events []redis.XStream, errChan chan<-
 			errChan <- err
 			return
 		}
-		fn(evt)
+		tp, _ := events[0].Messages[0].Values[TraceParentHeader].(string)
+		fn(withTraceParent(evt, tp))
 		rp.client.XAck(context.Background(), rp.pipe, EVENTREADERGROUPNAME, messageID)
 	}
 }
@@ -245,7 +250,8 @@ func (rp *RedisStreamsPacket) checkUnacknowledgedEvents(fn MsgProcess, id string
 				errChan <- err
 				return
 			}
-			fn(evt)
+			tp, _ := event.Values[TraceParentHeader].(string)
+			fn(withTraceParent(evt, tp))
 			rp.client.XAck(context.Background(), rp.pipe, EVENTREADERGROUPNAME, messageID)
 		}
 		// Pass the nil to errChan when no error encountered
@@ -274,6 +274,26 @@ func GetDBConnection(dbFlag DbType) (*ConnPool, *errors.Error) {
 	}
 }
 
+// PoolStats returns the statistics of the read and write pools of the Inmemory/OnDisk DB
+// connection, keyed by "read" and "write". The pools not created yet are not included
+func PoolStats(dbFlag DbType) map[string]*redis.PoolStats {
+	stats := make(map[string]*redis.PoolStats)
+	connPool := inMemDBConnPool
+	if dbFlag == OnDisk {
+		connPool = onDiskDBConnPool
+	}
+	if connPool == nil {
+		return stats
+	}
+	if connPool.ReadPool != nil {
+		stats["read"] = connPool.ReadPool.PoolStats()
+	}
+	if connPool.WritePool != nil {
+		stats["write"] = connPool.WritePool.PoolStats()
+	}
+	return stats
+}
+
 func goRedisNewClient(dbConfig *Config, host, port string) (*redis.Client, error) {
 	tlsConfig, err := getTLSConfig()
 	if err != nil {
@@ -1233,6 +1253,23 @@ func (p *ConnPool) GetRange(index string, min, max int, regexFlag bool) ([]strin
 	return getList, nil
 }
 
+// RemoveRange is used to remove the members of the index within a range of values
+/*
+1. index is the name of the index to remove from
+2. min is the minimum value of the members to be removed
+3. max is the maximum value of the members to be removed
+*/
+func (p *ConnPool) RemoveRange(index string, min, max int64) error {
+	delErr := p.WritePool.ZRemRangeByScore(index, strconv.FormatInt(min, 10), strconv.FormatInt(max, 10)).Err()
+	if delErr != nil {
+		if errs, aye := isDbConnectError(delErr); aye {
+			return errs
+		}
+		return fmt.Errorf("error while trying to delete data: " + delErr.Error())
+	}
+	return nil
+}
+
 // GetTaskList is used to range over float type values
 /*
 1. index is the name of the index to search under
@@ -1516,6 +1553,22 @@ func (p *ConnPool) Incr(table, key string) (int, *errors.Error) {
 	return int(value), nil
 }
 
+// IncrExpire increments the count and sets the expiry time of the count in a transaction,
+// so the count is shared by the service instances and removed when it is not incremented
+// for expiretime seconds
+func (p *ConnPool) IncrExpire(table, key string, expiretime int) (int, *errors.Error) {
+	tx := p.WritePool.TxPipeline()
+	value := tx.Incr(table + ":" + key)
+	tx.Expire(table+":"+key, time.Duration(expiretime)*time.Second)
+	if _, err := tx.Exec(); err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			return 0, errs
+		}
+		return 0, errors.PackError(errors.UndefinedErrorType, writeToDBErrMsg+err.Error())
+	}
+	return int(value.Val()), nil
+}
+
 // Decr is for decrementing the count
 // Decr takes "key" string as input which acts as a unique ID to decrement the count and return same
 func (p *ConnPool) Decr(table, key string) (int, *errors.Error) {
@@ -1568,6 +1621,51 @@ func (p *ConnPool) SetExpire(table, key string, data interface{}, expiretime int
 	return nil
 }
 
+// SetNX sets the key to hold the data only when the key does not exist, which is done
+// atomically so that it can be used as a lock shared by the service instances
+/* SetNX takes the following keys as input:
+1."table" is a string which is used identify what kind of data we are storing.
+2."key" is a string which acts as a unique ID to the data entry.
+3."data" is of type interface and is the userdata sent to be stored in DB.
+4. "expiretime" is of type int, which acts as expiry time for the key in seconds
+*/
+// It returns false when the key already exists
+func (p *ConnPool) SetNX(table, key string, data interface{}, expiretime int) (bool, *errors.Error) {
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return false, errors.PackError(errors.UndefinedErrorType, writeToDBJSONErrMsg+err.Error())
+	}
+	value, createErr := p.WritePool.SetNX(table+":"+key, jsondata, time.Duration(expiretime)*time.Second).Result()
+	if createErr != nil {
+		if errs, aye := isDbConnectError(createErr); aye {
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, writeToDBErrMsg+createErr.Error())
+	}
+	return value, nil
+}
+
+// deleteIfEqualScript deletes the key only when it holds the value of the argument
+var deleteIfEqualScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`)
+
+// DeleteIfEqual deletes the data of the key only when it is equal to the given data, in a single
+// operation of the DB, so that a lock set with SetNX is only released by its holder.
+// The returned bool is false when the key holds other data or does not exist
+func (p *ConnPool) DeleteIfEqual(table, key string, data interface{}) (bool, *errors.Error) {
+	jsondata, err := json.Marshal(data)
+	if err != nil {
+		return false, errors.PackError(errors.UndefinedErrorType, writeToDBJSONErrMsg+err.Error())
+	}
+	deleted, doErr := deleteIfEqualScript.Run(p.WritePool, []string{table + ":" + key}, jsondata).Int64()
+	if doErr != nil {
+		if errs, aye := isDbConnectError(doErr); aye {
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to delete data: ", doErr)
+	}
+	return deleted == 1, nil
+}
+
 // TTL is for getting singular data
 // TTL takes "key" string as input which acts as a unique ID to fetch time left
 func (p *ConnPool) TTL(table, key string) (int, *errors.Error) {
@@ -1732,6 +1830,41 @@ func (p *ConnPool) GetAllKeysFromDb(table, pattern string, nextCursor int) ([]st
 	return keys, int(nextCursor), nil
 }
 
+// GetKeysPage will fetch the keys of the table using cursor based scan and returns
+// only the keys in the range [skip, skip+top) of the sorted keys, along with the total number
+// of keys present in the table. All the keys after skip are returned if top is not a positive number.
+// The keys are sorted since the order of a scan is not stable across the requests of the pages.
+func (p *ConnPool) GetKeysPage(table string, skip, top int) ([]string, int, *errors.Error) {
+	var keys []string
+	var nextCursor uint64
+	for {
+		data, cursor, err := p.ReadPool.Scan(nextCursor, table+":*", int64(count)).Result()
+		if err != nil {
+			if errs, aye := isDbConnectError(err); aye {
+				return nil, 0, errs
+			}
+			return nil, 0, errors.PackError(errors.UndefinedErrorType, errorCollectingData, err)
+		}
+		for _, key := range data {
+			keys = append(keys, strings.TrimPrefix(key, table+":"))
+		}
+		if cursor == 0 {
+			break
+		}
+		nextCursor = cursor
+	}
+	sort.Strings(keys)
+	total := len(keys)
+	if skip > total {
+		skip = total
+	}
+	end := total
+	if top > 0 && skip+top < total {
+		end = skip + top
+	}
+	return keys[skip:end], total, nil
+}
+
 // GetKeyValue takes "key" sting as input which acts as a unique ID to fetch specific data from DB
 func (p *ConnPool) GetKeyValue(key string) (string, *errors.Error) {
 	value, err := p.ReadPool.Get(key).Result()
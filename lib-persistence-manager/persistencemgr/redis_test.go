@@ -322,6 +322,40 @@ func TestGetall(t *testing.T) {
 	}()
 }
 
+func TestGetKeysPage(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(mockDBConnection, err)
+	}
+	data := sample{Data1: "Value1", Data2: "Value2", Data3: "Value3"}
+	for _, key := range []string{"key1", "key2", "key3"} {
+		if errs := c.Create("pagetable", key, data); errs != nil {
+			t.Errorf(createDataErrMsg, errs.Error())
+		}
+	}
+	defer func() {
+		for _, key := range []string{"key1", "key2", "key3"} {
+			if derr := c.Delete("pagetable", key); derr != nil {
+				t.Errorf(deleteDataErrMsg, derr.Error())
+			}
+		}
+	}()
+	keys, total, errs := c.GetKeysPage("pagetable", 1, 1)
+	if errs != nil {
+		t.Errorf(fetchDataErrMsg, errs.Error())
+	}
+	if total != 3 || len(keys) != 1 || keys[0] != "key2" {
+		t.Errorf("Error in fetching the page of keys, got %v keys of total %v", keys, total)
+	}
+	keys, total, errs = c.GetKeysPage("pagetable", 1, 0)
+	if errs != nil {
+		t.Errorf(fetchDataErrMsg, errs.Error())
+	}
+	if total != 3 || len(keys) != 2 || keys[0] != "key2" || keys[1] != "key3" {
+		t.Errorf("Error in fetching the keys after skip, got %v keys of total %v", keys, total)
+	}
+}
+
 func TestGetallNonExistingtable(t *testing.T) {
 	c, err := MockDBConnection(t)
 	if err != nil {
@@ -749,6 +783,33 @@ func TestGetTaskList(t *testing.T) {
 	}()
 
 }
+func TestRemoveRange(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(mockDBConnection, err)
+	}
+	for i, key := range []string{"key1", "key2", "key3"} {
+		if cerr := c.CreateTaskIndex("rangeindex", int64(i+1), key); cerr != nil {
+			t.Errorf(genericErrorMsg, cerr.Error())
+		}
+	}
+	if rerr := c.RemoveRange("rangeindex", 0, 2); rerr != nil {
+		t.Errorf(deleteDataErrMsg, rerr.Error())
+	}
+	got, rerr := c.GetTaskList("rangeindex", 0, -1)
+	if rerr != nil {
+		t.Errorf(readDataErrMsg, rerr.Error())
+	}
+	if len(got) != 1 || got[0] != "key3" {
+		t.Errorf("Error in removing the range of the index, got %v", got)
+	}
+	defer func() {
+		if derr := c.Del("rangeindex", "key3"); derr != nil {
+			t.Errorf(deleteDataErrMsg, derr.Error())
+		}
+	}()
+}
+
 func TestGetRange(t *testing.T) {
 
 	c, err := MockDBConnection(t)
@@ -1354,6 +1415,53 @@ func TestIncr(t *testing.T) {
 
 }
 
+func TestIncrExpire(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(mockDBConnection, err)
+	}
+	defer func() {
+		if derr := c.Delete("table", "expiringkey"); derr != nil {
+			t.Errorf(deleteDataErrMsg, derr.Error())
+		}
+	}()
+	for want := 1; want <= 2; want++ {
+		got, rerr := c.IncrExpire("table", "expiringkey", 60)
+		if rerr != nil {
+			t.Errorf(dataIncrementErrMsg, rerr.Error())
+		}
+		if got != want {
+			t.Errorf(dataMismatchErrMsg)
+		}
+	}
+	if ttl, terr := c.TTL("table", "expiringkey"); terr != nil || ttl <= 0 || ttl > 60 {
+		t.Errorf("Error in the expiry time of the count, got %v: %v", ttl, terr)
+	}
+}
+
+func TestDeleteIfEqual(t *testing.T) {
+	c, err := MockDBConnection(t)
+	if err != nil {
+		t.Fatal(mockDBConnection, err)
+	}
+	defer c.Delete("table", "lockkey")
+	if _, serr := c.SetNX("table", "lockkey", "token1", 60); serr != nil {
+		t.Fatalf("Error while setting the data: %v", serr.Error())
+	}
+	if deleted, derr := c.DeleteIfEqual("table", "lockkey", "token2"); derr != nil || deleted {
+		t.Errorf("DeleteIfEqual() of other data = %v, %v, want false", deleted, derr)
+	}
+	if _, rerr := c.Read("table", "lockkey"); rerr != nil {
+		t.Errorf("DeleteIfEqual() deleted the key holding other data: %v", rerr.Error())
+	}
+	if deleted, derr := c.DeleteIfEqual("table", "lockkey", "token1"); derr != nil || !deleted {
+		t.Errorf("DeleteIfEqual() = %v, %v, want true", deleted, derr)
+	}
+	if _, rerr := c.Read("table", "lockkey"); rerr == nil {
+		t.Errorf("DeleteIfEqual() did not delete the key")
+	}
+}
+
 func TestDecr(t *testing.T) {
 
 	c, err := MockDBConnection(t)
@@ -26,6 +26,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 )
@@ -55,6 +56,11 @@ type PluginContactRequest struct {
 
 // ContactPlugin is used to send a request to plugin to add a resource
 func ContactPlugin(ctx context.Context, url, method, token string, odataID string, body interface{}, collaboratedInfo map[string]string) (*http.Response, error) {
+	ctx, span := tracing.StartSpan(ctx, "HTTP "+method, tracing.SpanKindClient)
+	defer span.End()
+	span.SetAttribute("http.method", method)
+	span.SetAttribute("http.url", url)
+
 	jsonStr, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
@@ -91,8 +97,13 @@ func ContactPlugin(ctx context.Context, url, method, token string, odataID strin
 	resp, err := httpClient.Do(req)
 	config.TLSConfMutex.RUnlock()
 	if err != nil {
+		span.SetError(err)
 		return nil, err
 	}
+	span.SetAttribute("http.status_code", resp.StatusCode)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(tracing.StatusError, resp.Status)
+	}
 
 	if resp.StatusCode >= 300 {
 		l.Log.Warn("got " + resp.Status + " while fetching " + url + " with method " + method)
@@ -125,8 +136,10 @@ func ContactPluginWithAuth(ctx context.Context, req PluginContactRequest, server
 	return ContactPlugin(ctx, reqURL, req.HTTPMethodType, req.Token, "", req.PostBody, req.BasicAuth)
 }
 
-// CreateHeader is used to get data from context and set it to header for http request call
+// CreateHeader is used to get data from context and set it to header for http request call,
+// along with the W3C trace context of the request
 func CreateHeader(ctx context.Context, req *http.Request) *http.Request {
+	tracing.Inject(ctx, tracing.HeaderCarrier(req.Header))
 	if ctx.Value("transactionid") != nil {
 		transactionID := ctx.Value("transactionid").(string)
 		actionID := ctx.Value("actionid").(string)
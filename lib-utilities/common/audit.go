@@ -0,0 +1,152 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package common ...
+package common
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// AuditLogTable is the table of the audit records in the on-disk DB
+	AuditLogTable = "AuditLog"
+	// AuditLogIndex is the index of the audit records sorted by the time of the request
+	AuditLogIndex = "AuditLogIndex"
+	// AuditLogEntriesFilterResource is the resource type of the audit records in the resource filter schema
+	AuditLogEntriesFilterResource = "AuditLogEntries"
+)
+
+// AuditRecord is the record of a request served by the API gateway in the audit trail.
+// The request body is masked with logs.MaskRequestBody before it is recorded.
+type AuditRecord struct {
+	ID            string    `json:"Id"`
+	Created       time.Time `json:"Created"`
+	UserName      string    `json:"UserName"`
+	RoleID        string    `json:"RoleId"`
+	Method        string    `json:"Method"`
+	RequestURI    string    `json:"RequestURI"`
+	RequestBody   string    `json:"RequestBody,omitempty"`
+	StatusCode    int32     `json:"StatusCode"`
+	TransactionID string    `json:"TransactionId"`
+}
+
+// FilterValue returns the value of the searchable property of the audit record
+func (r *AuditRecord) FilterValue(property string) (interface{}, bool) {
+	switch property {
+	case "Created":
+		return r.Created, true
+	case "UserName":
+		return r.UserName, r.UserName != ""
+	case "RoleId":
+		return r.RoleID, r.RoleID != ""
+	case "Method":
+		return r.Method, true
+	case "RequestURI":
+		return r.RequestURI, true
+	case "StatusCode":
+		return float64(r.StatusCode), true
+	}
+	return nil, false
+}
+
+// SaveAuditRecord stores the audit record in the on-disk DB. The record expires after
+// the retention days of the audit trail, and the expired records are removed from the index
+func SaveAuditRecord(record AuditRecord) *errors.Error {
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return err
+	}
+	retention := time.Duration(config.Data.AuditLogConf.RetentionDays) * 24 * time.Hour
+	if err = conn.SetExpire(AuditLogTable, record.ID, record, int(retention.Seconds())); err != nil {
+		return err
+	}
+	if ierr := conn.CreateTaskIndex(AuditLogIndex, record.Created.UnixMilli(), record.ID); ierr != nil {
+		return errors.PackError(errors.DBUpdateFailed, "error while trying to index the audit record: ", ierr.Error())
+	}
+	if rerr := conn.RemoveRange(AuditLogIndex, 0, time.Now().Add(-retention).UnixMilli()); rerr != nil {
+		return errors.PackError(errors.DBUpdateFailed, "error while trying to remove the expired audit records: ", rerr.Error())
+	}
+	return nil
+}
+
+// GetAuditRecordIDs returns the IDs of the audit records in the retention days, the latest first
+func GetAuditRecordIDs() ([]string, *errors.Error) {
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Now().Add(-time.Duration(config.Data.AuditLogConf.RetentionDays) * 24 * time.Hour)
+	ids, gerr := conn.GetRange(AuditLogIndex, int(from.UnixMilli()), int(time.Now().UnixMilli()), true)
+	if gerr != nil {
+		return nil, errors.PackError(errors.DBKeyFetchFailed, "error while trying to get the audit records: ", gerr.Error())
+	}
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+	return ids, nil
+}
+
+// GetAuditRecords returns the audit records of the IDs, the records which
+// are expired after the IDs are read are left out
+func GetAuditRecords(ids []string) ([]AuditRecord, *errors.Error) {
+	records := []AuditRecord{}
+	if len(ids) == 0 {
+		return records, nil
+	}
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = AuditLogTable + ":" + id
+	}
+	data, err := conn.ReadMultipleKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range data {
+		if value == "" {
+			continue
+		}
+		var record AuditRecord
+		if jerr := json.Unmarshal([]byte(value), &record); jerr != nil {
+			return nil, errors.PackError(errors.JSONUnmarshalFailed, "error while trying to unmarshal the audit record: ", jerr.Error())
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// GetAuditRecord returns the audit record of the ID
+func GetAuditRecord(id string) (AuditRecord, *errors.Error) {
+	var record AuditRecord
+	conn, err := GetDBConnection(OnDisk)
+	if err != nil {
+		return record, err
+	}
+	data, err := conn.Read(AuditLogTable, id)
+	if err != nil {
+		return record, err
+	}
+	if jerr := json.Unmarshal([]byte(data), &record); jerr != nil {
+		return record, errors.PackError(errors.JSONUnmarshalFailed, "error while trying to unmarshal the audit record: ", jerr.Error())
+	}
+	return record, nil
+}
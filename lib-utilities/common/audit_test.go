@@ -0,0 +1,59 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuditRecord_FilterValue(t *testing.T) {
+	if err := LoadFilterSchemas("../config/resource_filter_schema.json"); err != nil {
+		t.Fatalf("error while loading the resource filter schema: %v", err)
+	}
+	created, _ := time.Parse(time.RFC3339, "2022-06-14T10:00:00Z")
+	record := AuditRecord{
+		ID:         "2a3c1fd0-8f8e-4b79-a8b2-54e0d1b1a5c1",
+		Created:    created,
+		UserName:   "admin",
+		RoleID:     RoleAdmin,
+		Method:     "POST",
+		RequestURI: "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset",
+		StatusCode: 202,
+	}
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"UserName eq admin", true},
+		{"UserName eq operator", false},
+		{"RequestURI eq '/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset' and Method eq POST", true},
+		{"Created ge 2022-06-14T00:00:00Z and Created lt 2022-06-15T00:00:00Z", true},
+		{"Created gt 2022-06-14T10:00:00Z", false},
+		{"StatusCode ge 400", false},
+		{"RoleId eq Administrator", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			filter, err := ParseFilter(tt.expression, AuditLogEntriesFilterResource)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := filter.Match(record.FilterValue); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
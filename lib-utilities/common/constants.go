@@ -122,6 +122,8 @@ const (
 	ManagerAccountType = "#ManagerAccount.v1_10_0.ManagerAccount"
 	// AccountServiceType has schema version to be returned with accountservice
 	AccountServiceType = "#AccountService.v1_13_0.AccountService"
+	// APIKeyType has schema version to be returned with the API key of an account
+	APIKeyType = "#OdimAPIKey.v1_0_0.OdimAPIKey"
 	// RoleType has schema version to be returned with Role
 	RoleType = "#Role.v1_3_1.Role"
 	// SessionServiceType has schema version to be returned with sessionservice
@@ -208,6 +210,17 @@ const (
 	RequestBody   = "requestbody"
 )
 
+// Below fields carry the HTTP request, for which the gRPC calls are made, to the services.
+// They are used for resolving the privileges of the request from the privilege registry,
+// RequestProperties holding the names of the properties patched by the request,
+// and SessionAggregates for restricting the resources of the session to its aggregates
+const (
+	RequestURI        = "requesturi"
+	RequestMethod     = "requestmethod"
+	RequestProperties = "requestproperties"
+	SessionAggregates = "sessionaggregates"
+)
+
 // Below fields are service names for logging
 const (
 	ManagerService     = "svc-managers"
@@ -249,11 +262,16 @@ const (
 	SetBootOrder                           = "SettingBootOrder"
 	CollectAndSetDefaultBootOrder          = "CollectAndSetDefaultBoorOrder"
 	AddAggregationSource                   = "AddingAggregationSource"
+	ImportSources                          = "ImportingAggregationSources"
+	DiscoverSources                        = "DiscoveringAggregationSources"
+	ApproveDiscoveredSource                = "ApprovingDiscoveredSource"
+	RotateCredentials                      = "RotatingBMCCredentials"
 	DeleteAggregationSource                = "DeleteAggregationSource"
 	SubTaskStatusUpdate                    = "SubTaskStatusUpdate"
 	ResetSystem                            = "ResetSystem"
 	SetDefaultBootOrderElementsOfAggregate = "SetDefaultBootOrderElementsOfAggregate"
 	RediscoverSystemInventory              = "RediscoverSystemInventory"
+	ResyncInventory                        = "ResyncInventory"
 	CheckPluginStatus                      = "CheckPluginStatus"
 	GetTelemetryResource                   = "GetTelemetryResource"
 	PollPlugin                             = "PollPlugin"
@@ -342,12 +360,17 @@ var Actions = map[ActionKey]ActionType{
 	{"Roles", RolesID, "PATCH"}:  {"032", "UpdateRole"},
 	{"Roles", RolesID, "DELETE"}: {"033", "DeleteRole"},
 	// Account Service URI
-	{"AccountService", "AccountService", "GET"}: {"034", "GetAccountService"},
-	{"AccountService", "Accounts", "GET"}:       {"035", "GetAllAccounts"},
-	{"AccountService", AccountsID, "GET"}:       {"036", "GetAccount"},
-	{"AccountService", "Accounts", "POST"}:      {"037", "CreateAccount"},
-	{"AccountService", AccountsID, "PATCH"}:     {"038", "UpdateAccount"},
-	{"AccountService", AccountsID, "DELETE"}:    {"039", "DeleteAccount"},
+	{"AccountService", "AccountService", "GET"}:   {"034", "GetAccountService"},
+	{"AccountService", "AccountService", "PATCH"}: {"225", "UpdateAccountService"},
+	{"AccountService", "Accounts", "GET"}:         {"035", "GetAllAccounts"},
+	{"AccountService", AccountsID, "GET"}:         {"036", "GetAccount"},
+	{"AccountService", "Accounts", "POST"}:        {"037", "CreateAccount"},
+	{"AccountService", AccountsID, "PATCH"}:       {"038", "UpdateAccount"},
+	{"AccountService", AccountsID, "DELETE"}:      {"039", "DeleteAccount"},
+	{"AccountService", "APIKeys", "GET"}:          {"226", "GetAllAPIKeys"},
+	{"AccountService", "APIKeys", "POST"}:         {"227", "CreateAPIKey"},
+	{"AccountService", "APIKeys/{id}", "GET"}:     {"228", "GetAPIKey"},
+	{"AccountService", "APIKeys/{id}", "DELETE"}:  {"229", "DeleteAPIKey"},
 	// Session Service URI
 	{"SessionService", "SessionService", "GET"}:   {"040", "GetSessionService"},
 	{"SessionService", "Sessions", "GET"}:         {"041", "GetAllActiveSessions"},
@@ -392,12 +415,22 @@ var Actions = map[ActionKey]ActionType{
 	// Actions URI
 	{"Systems", "ComputerSystem.Reset", "POST"}:               {"075", "ComputerSystemReset"},
 	{"Systems", "ComputerSystem.SetDefaultBootOrder", "POST"}: {"076", "SetDefaultBootOrder"},
+	{"Systems", "Odim.InventoryHistory", "POST"}:              {"239", "GetInventoryHistory"},
 	// Aggregation URI
 	{"AggregationService", "AggregationService", "GET"}:                      {"077", "GetAggregationService"},
 	{"AggregationService", "ResetActionInfo", "GET"}:                         {"078", "GetResetActionInfoService"},
 	{"AggregationService", "SetDefaultBootOrderActionInfo", "GET"}:           {"079", "GetSetDefaultBootOrderActionInfo"},
 	{"AggregationService", "AggregationService.Reset", "POST"}:               {"080", "AggregationServiceReset"},
 	{"AggregationService", "AggregationService.SetDefaultBootOrder", "POST"}: {"081", "SetDefaultBootOrder"},
+	{"AggregationService", "AggregationService.ImportSources", "POST"}:       {"230", "ImportSources"},
+	{"AggregationService", "ImportSourcesReports/{id}", "GET"}:               {"231", "GetImportSourcesReport"},
+	{"AggregationService", "Report.csv", "GET"}:                              {"232", "GetImportSourcesReportCSV"},
+	{"AggregationService", "AggregationService.DiscoverSources", "POST"}:     {"233", "DiscoverSources"},
+	{"AggregationService", "DiscoveredSources", "GET"}:                       {"234", "GetAllDiscoveredSources"},
+	{"AggregationService", "DiscoveredSources/{id}", "GET"}:                  {"235", "GetDiscoveredSource"},
+	{"AggregationService", "DiscoveredSources/{id}", "DELETE"}:               {"236", "DeleteDiscoveredSource"},
+	{"AggregationService", "DiscoveredSource.Approve", "POST"}:               {"237", "ApproveDiscoveredSource"},
+	{"AggregationService", "AggregationService.RotateCredentials", "POST"}:   {"240", "RotateCredentials"},
 	//AggregationSources URI
 	{"AggregationService", "AggregationSources", "POST"}:   {"082", "AddAggregationSource"},
 	{"AggregationService", "AggregationSources", "GET"}:    {"083", "GetAllAggregationSource"},
@@ -671,6 +704,14 @@ type Events struct {
 	IP        string `json:"ip"`
 	Request   []byte `json:"request"`
 	EventType string `json:"eventType"`
+	// TraceParent holds the W3C trace context of the operation which published the event
+	TraceParent string `json:"traceparent,omitempty"`
+}
+
+// GetTraceParent returns the W3C trace context of the event,
+// which the message bus sends in the traceparent header
+func (e Events) GetTraceParent() string {
+	return e.TraceParent
 }
 
 // TaskEvent contains the task progress data sent form plugin to PMB
@@ -740,6 +781,8 @@ var URIWithNoAuth = []string{
 	"/redfish/v1/odata",
 	"/redfish/v1/SessionService",
 	"/redfish/v1/SessionService/Sessions",
+	"/healthz",
+	"/readyz",
 }
 
 // SessionURI is redfish URI for sessions
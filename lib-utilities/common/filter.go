@@ -0,0 +1,833 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package common ...
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// FilterQueryOption is the query option for filtering the members of a collection
+const FilterQueryOption = "$filter"
+
+// Resource types which can have their searchable properties declared in the resource filter schema
+const (
+	ChassisFilterResource            = "Chassis"
+	ManagersFilterResource           = "Managers"
+	TasksFilterResource              = "Tasks"
+	EventSubscriptionsFilterResource = "EventSubscriptions"
+)
+
+// Types of the searchable properties in the resource filter schema
+const (
+	FilterTypeString      = "string"
+	FilterTypeFloat64     = "float64"
+	FilterTypeDateTime    = "datetime"
+	FilterTypeStringList  = "[]string"
+	FilterTypeFloat64List = "[]float64"
+)
+
+// FilterSchema holds the searchable properties of a resource type along with their type
+type FilterSchema struct {
+	SearchKeys []map[string]map[string]string `json:"searchKeys"`
+}
+
+var (
+	filterSchemas     = map[string]FilterSchema{}
+	filterSchemaMutex sync.RWMutex
+)
+
+// LoadFilterSchemas reads the searchable properties of each of the resource types
+// from the resource filter schema file
+func LoadFilterSchemas(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error while trying to read resource filter schema json: %v", err)
+	}
+	schemas := map[string]FilterSchema{}
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		return fmt.Errorf("error while trying to fetch resource filter schema json: %v", err)
+	}
+	filterSchemaMutex.Lock()
+	filterSchemas = schemas
+	filterSchemaMutex.Unlock()
+	return nil
+}
+
+// SetFilterSchema sets the searchable properties of a resource type
+func SetFilterSchema(resourceType string, schema FilterSchema) {
+	filterSchemaMutex.Lock()
+	filterSchemas[resourceType] = schema
+	filterSchemaMutex.Unlock()
+}
+
+// getFilterKeys returns the searchable properties of the resource type mapped to their type
+func getFilterKeys(resourceType string) map[string]string {
+	filterSchemaMutex.RLock()
+	defer filterSchemaMutex.RUnlock()
+	schema, ok := filterSchemas[resourceType]
+	if !ok {
+		return nil
+	}
+	keys := make(map[string]string)
+	for _, value := range schema.SearchKeys {
+		for k, v := range value {
+			keys[k] = v["type"]
+		}
+	}
+	return keys
+}
+
+// filterNode is a node of a parsed $filter expression. For the logical operators
+// and, or and not the operands are the children of the node, for the comparison
+// operators, the string functions and the in operator the node holds the property,
+// its type and the values to compare with.
+type filterNode struct {
+	operator     string
+	children     []*filterNode
+	property     string
+	propertyType string
+	values       []string
+}
+
+// Filter is a parsed $filter query option of a collection request
+type Filter struct {
+	root *filterNode
+}
+
+// FilterCondition is a condition on a property in the $filter expression,
+// Values holds more than one value only for the in operator
+type FilterCondition struct {
+	Property     string
+	PropertyType string
+	Operator     string
+	Values       []string
+}
+
+// filterFunctions are the string functions supported in the $filter expression,
+// which are written as function(<property>,'<value>')
+var filterFunctions = map[string]bool{
+	"contains":   true,
+	"startswith": true,
+	"endswith":   true,
+}
+
+// filterKeywords are the words which are followed by a parenthesis without being a part of the value
+var filterKeywords = map[string]bool{
+	"and":        true,
+	"or":         true,
+	"not":        true,
+	"in":         true,
+	"contains":   true,
+	"startswith": true,
+	"endswith":   true,
+}
+
+// GetFilter reads the $filter query option from the request URL and parses it with
+// the searchable properties of the resource type. Filter will be nil when $filter is
+// not requested, and error is returned when the expression is not a valid one.
+func GetFilter(requestURL, resourceType string) (*Filter, error) {
+	uri := strings.SplitN(requestURL, "?", 2)
+	if len(uri) < 2 {
+		return nil, nil
+	}
+	for _, param := range strings.Split(uri[1], "&") {
+		kv := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil || key != FilterQueryOption {
+			continue
+		}
+		if len(kv) < 2 {
+			return nil, fmt.Errorf("%s should have an expression", FilterQueryOption)
+		}
+		expression, err := url.QueryUnescape(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid expression: %v", FilterQueryOption, err)
+		}
+		return ParseFilter(expression, resourceType)
+	}
+	return nil, nil
+}
+
+// ParseFilter parses the $filter expression with the searchable properties of the resource type.
+// The expression supports the comparison operators eq, ne, gt, ge, lt, le, the in operator,
+// the string functions contains, startswith, endswith, the logical operators and, or, not
+// and grouping with parentheses, which can be nested.
+func ParseFilter(expression, resourceType string) (*Filter, error) {
+	keys := getFilterKeys(resourceType)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s is not supported for the %s collection", FilterQueryOption, resourceType)
+	}
+	return parseFilter(expression, keys)
+}
+
+// ParseFilterWithSchema parses the $filter expression with the searchable properties in the schema,
+// it is used by the services which maintain the filter schema of their resources on their own
+func ParseFilterWithSchema(expression string, schema FilterSchema) (*Filter, error) {
+	keys := make(map[string]string)
+	for _, value := range schema.SearchKeys {
+		for k, v := range value {
+			keys[k] = v["type"]
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%s is not supported for the collection", FilterQueryOption)
+	}
+	return parseFilter(expression, keys)
+}
+
+func parseFilter(expression string, keys map[string]string) (*Filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no valid expression found")
+	}
+	p := filterParser{tokens: tokens, keys: keys}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %v in the expression", p.tokens[p.pos].text)
+	}
+	return &Filter{root: root}, nil
+}
+
+// filterToken is a token of the $filter expression, quoted is set for the string literals
+type filterToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, filterToken{text: string(r)})
+			i++
+		case r == '\'':
+			// single quotes in a string literal are escaped with another single quote
+			var literal []rune
+			closed := false
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						literal = append(literal, '\'')
+						i++
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				literal = append(literal, runes[i])
+			}
+			if !closed {
+				return nil, fmt.Errorf("string literal is not terminated in the expression")
+			}
+			tokens = append(tokens, filterToken{text: string(literal), quoted: true})
+		default:
+			// parentheses within a word are a part of the value, eg: Intel(R)
+			start := i
+			depth := 0
+		word:
+			for ; i < len(runes); i++ {
+				switch runes[i] {
+				case '(':
+					if filterKeywords[string(runes[start:i])] {
+						break word
+					}
+					depth++
+				case ')':
+					if depth == 0 {
+						break word
+					}
+					depth--
+				case ',':
+					if depth == 0 {
+						break word
+					}
+				default:
+					if unicode.IsSpace(runes[i]) {
+						break word
+					}
+				}
+			}
+			tokens = append(tokens, filterToken{text: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+	keys   map[string]string
+}
+
+// isKeyword checks whether the next token is the unquoted keyword
+func (p *filterParser) isKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == keyword
+}
+
+// expect consumes the next token when it is the unquoted keyword
+func (p *filterParser) expect(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return fmt.Errorf("missing %v in the expression", keyword)
+	}
+	p.pos++
+	return nil
+}
+
+// nextValue consumes the next token as a value, which is a string literal or a word
+func (p *filterParser) nextValue() (string, error) {
+	if p.pos >= len(p.tokens) || p.isKeyword("(") || p.isKeyword(")") || p.isKeyword(",") {
+		return "", fmt.Errorf("incomplete expression")
+	}
+	p.pos++
+	return p.tokens[p.pos-1].text, nil
+}
+
+// property consumes the next token as a searchable property and returns its type
+func (p *filterParser) property() (string, string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", "", fmt.Errorf("incomplete expression")
+	}
+	property := p.tokens[p.pos]
+	propertyType, ok := p.keys[property.text]
+	if property.quoted || !ok {
+		return "", "", fmt.Errorf("%v is not a supported property for %s", property.text, FilterQueryOption)
+	}
+	p.pos++
+	return property.text, propertyType, nil
+}
+
+func (p *filterParser) parseOr() (*filterNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		node = &filterNode{operator: "or", children: []*filterNode{node, right}}
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseAnd() (*filterNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		node = &filterNode{operator: "and", children: []*filterNode{node, right}}
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseUnary() (*filterNode, error) {
+	if p.isKeyword("not") {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNode{operator: "not", children: []*filterNode{operand}}, nil
+	}
+	if p.isKeyword("(") {
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isKeyword(")") {
+			return nil, fmt.Errorf("missing closing parenthesis in the expression")
+		}
+		p.pos++
+		return node, nil
+	}
+	if p.pos+1 < len(p.tokens) && !p.tokens[p.pos].quoted && filterFunctions[p.tokens[p.pos].text] && p.tokens[p.pos+1].text == "(" {
+		return p.parseFunction()
+	}
+	return p.parseComparison()
+}
+
+// parseFunction parses a string function of the form function(<property>,'<value>')
+func (p *filterParser) parseFunction() (*filterNode, error) {
+	function := p.tokens[p.pos].text
+	p.pos += 2
+	property, propertyType, err := p.property()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(","); err != nil {
+		return nil, fmt.Errorf("%v function is malformed, expected %v(<property>,'<value>')", function, function)
+	}
+	value, err := p.nextValue()
+	if err != nil {
+		return nil, fmt.Errorf("%v function is malformed, expected %v(<property>,'<value>')", function, function)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, fmt.Errorf("%v function is malformed, expected %v(<property>,'<value>')", function, function)
+	}
+	if propertyType != FilterTypeString && propertyType != FilterTypeFloat64 {
+		return nil, fmt.Errorf("%v is not supported for the property %v", function, property)
+	}
+	return &filterNode{operator: function, property: property, propertyType: propertyType, values: []string{value}}, nil
+}
+
+func (p *filterParser) parseComparison() (*filterNode, error) {
+	property, propertyType, err := p.property()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("incomplete expression")
+	}
+	operator := p.tokens[p.pos].text
+	p.pos++
+	node := &filterNode{operator: operator, property: property, propertyType: propertyType}
+	switch operator {
+	case "eq", "ne":
+	case "gt", "ge", "lt", "le":
+		if propertyType != FilterTypeFloat64 && propertyType != FilterTypeDateTime && propertyType != FilterTypeFloat64List {
+			return nil, fmt.Errorf("%v operator is not supported for the property %v", operator, property)
+		}
+	case "in":
+		// the in operator takes a list of values in parentheses, eg: PowerState in (On,Off)
+		if err := p.expect("("); err != nil {
+			return nil, fmt.Errorf("in operator expects a list of values in parentheses, eg: PowerState in (On,Off)")
+		}
+		for {
+			value, err := p.nextValue()
+			if err != nil {
+				return nil, fmt.Errorf("in operator has an empty value in its list")
+			}
+			node.values = append(node.values, value)
+			if p.isKeyword(")") {
+				p.pos++
+				break
+			}
+			if err := p.expect(","); err != nil {
+				return nil, fmt.Errorf("in operator expects a list of values in parentheses, eg: PowerState in (On,Off)")
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%v is not a supported operator for %s", operator, FilterQueryOption)
+	}
+	if operator != "in" {
+		value, err := p.nextValue()
+		if err != nil {
+			return nil, err
+		}
+		node.values = []string{value}
+	}
+	for _, value := range node.values {
+		switch strings.TrimPrefix(propertyType, "[]") {
+		case FilterTypeFloat64:
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("%v is not a valid value for the property %v", value, property)
+			}
+		case FilterTypeDateTime:
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return nil, fmt.Errorf("%v is not a valid value for the property %v", value, property)
+			}
+		}
+	}
+	return node, nil
+}
+
+// Properties returns the properties used in the filter expression
+func (f *Filter) Properties() []string {
+	properties := make(map[string]bool)
+	var list []string
+	var collect func(n *filterNode)
+	collect = func(n *filterNode) {
+		if n.property != "" && !properties[n.property] {
+			properties[n.property] = true
+			list = append(list, n.property)
+		}
+		for _, child := range n.children {
+			collect(child)
+		}
+	}
+	collect(f.root)
+	return list
+}
+
+// Match evaluates the filter expression for a resource, value returns the value of
+// a property of the resource and whether the property is present in the resource
+func (f *Filter) Match(value func(property string) (interface{}, bool)) bool {
+	return f.root.match(value)
+}
+
+// MatchResource evaluates the filter expression for the resource
+func (f *Filter) MatchResource(resource map[string]interface{}) bool {
+	return f.Match(func(property string) (interface{}, bool) {
+		return GetFilterProperty(resource, property)
+	})
+}
+
+// MatchIndexed returns the members matching the filter expression, evaluated with the
+// indexes of the properties which are mapped from the member to the value of the property
+func (f *Filter) MatchIndexed(members []string, indexes map[string]map[string]string) []string {
+	matched := []string{}
+	for _, member := range members {
+		if f.Match(func(property string) (interface{}, bool) {
+			value, ok := indexes[property][member]
+			return value, ok
+		}) {
+			matched = append(matched, member)
+		}
+	}
+	return matched
+}
+
+// Evaluate evaluates the filter expression with sets of members, members returns the members
+// matching a condition of the expression and all returns every member of the collection,
+// which is needed for the not operator. The members are returned in the order they are found.
+func (f *Filter) Evaluate(members func(FilterCondition) ([]string, error), all func() ([]string, error)) ([]string, error) {
+	return f.root.evaluate(members, all)
+}
+
+func (n *filterNode) evaluate(members func(FilterCondition) ([]string, error), all func() ([]string, error)) ([]string, error) {
+	switch n.operator {
+	case "and", "or":
+		left, err := n.children[0].evaluate(members, all)
+		if err != nil {
+			return nil, err
+		}
+		right, err := n.children[1].evaluate(members, all)
+		if err != nil {
+			return nil, err
+		}
+		if n.operator == "and" {
+			return intersectMembers(left, right), nil
+		}
+		return unionMembers(left, right), nil
+	case "not":
+		operand, err := n.children[0].evaluate(members, all)
+		if err != nil {
+			return nil, err
+		}
+		everything, err := all()
+		if err != nil {
+			return nil, err
+		}
+		return subtractMembers(everything, operand), nil
+	}
+	list, err := members(FilterCondition{Property: n.property, PropertyType: n.propertyType, Operator: n.operator, Values: n.values})
+	if err != nil {
+		return nil, err
+	}
+	return unionMembers(list, nil), nil
+}
+
+func intersectMembers(left, right []string) []string {
+	found := make(map[string]bool, len(right))
+	for _, member := range right {
+		found[member] = true
+	}
+	list := []string{}
+	for _, member := range left {
+		if found[member] {
+			list = append(list, member)
+			delete(found, member)
+		}
+	}
+	return list
+}
+
+func unionMembers(left, right []string) []string {
+	found := make(map[string]bool, len(left)+len(right))
+	list := []string{}
+	for _, members := range [][]string{left, right} {
+		for _, member := range members {
+			if !found[member] {
+				found[member] = true
+				list = append(list, member)
+			}
+		}
+	}
+	return list
+}
+
+func subtractMembers(left, right []string) []string {
+	found := make(map[string]bool, len(right))
+	for _, member := range right {
+		found[member] = true
+	}
+	list := []string{}
+	for _, member := range unionMembers(left, nil) {
+		if !found[member] {
+			list = append(list, member)
+		}
+	}
+	return list
+}
+
+func (n *filterNode) match(value func(property string) (interface{}, bool)) bool {
+	switch n.operator {
+	case "and":
+		return n.children[0].match(value) && n.children[1].match(value)
+	case "or":
+		return n.children[0].match(value) || n.children[1].match(value)
+	case "not":
+		return !n.children[0].match(value)
+	}
+	actual, ok := value(n.property)
+	if !ok {
+		return n.operator == "ne"
+	}
+	if n.operator == "ne" {
+		return !n.matchValue(actual, "eq")
+	}
+	return n.matchValue(actual, n.operator)
+}
+
+// matchValue checks the actual value of the property with the operator,
+// a list matches when any of its items matches
+func (n *filterNode) matchValue(actual interface{}, operator string) bool {
+	switch items := actual.(type) {
+	case []interface{}:
+		for _, item := range items {
+			if n.matchValue(item, operator) {
+				return true
+			}
+		}
+		return false
+	case []string:
+		for _, item := range items {
+			if n.matchValue(item, operator) {
+				return true
+			}
+		}
+		return false
+	case []float64:
+		for _, item := range items {
+			if n.matchValue(item, operator) {
+				return true
+			}
+		}
+		return false
+	}
+	switch operator {
+	case "contains", "startswith", "endswith":
+		got := strings.ToLower(filterString(actual))
+		want := strings.ToLower(n.values[0])
+		switch operator {
+		case "contains":
+			return strings.Contains(got, want)
+		case "startswith":
+			return strings.HasPrefix(got, want)
+		}
+		return strings.HasSuffix(got, want)
+	case "in":
+		for _, want := range n.values {
+			if result, ok := n.compare(actual, want); ok && result == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	result, ok := n.compare(actual, n.values[0])
+	if !ok {
+		return false
+	}
+	switch operator {
+	case "eq":
+		return result == 0
+	case "gt":
+		return result > 0
+	case "ge":
+		return result >= 0
+	case "lt":
+		return result < 0
+	case "le":
+		return result <= 0
+	}
+	return false
+}
+
+// filterString returns the string form of a value of a property
+func filterString(actual interface{}) string {
+	switch v := actual.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(actual)
+}
+
+// compare compares the actual value of the property with the value in the expression,
+// the result is false when the actual value is not of the type of the property
+func (n *filterNode) compare(actual interface{}, value string) (int, bool) {
+	switch strings.TrimPrefix(n.propertyType, "[]") {
+	case FilterTypeFloat64:
+		var got float64
+		switch v := actual.(type) {
+		case float64:
+			got = v
+		case int:
+			got = float64(v)
+		case string:
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return 0, false
+			}
+			got = parsed
+		default:
+			return 0, false
+		}
+		want, _ := strconv.ParseFloat(value, 64)
+		switch {
+		case got < want:
+			return -1, true
+		case got > want:
+			return 1, true
+		}
+		return 0, true
+	case FilterTypeDateTime:
+		var got time.Time
+		switch v := actual.(type) {
+		case time.Time:
+			got = v
+		case string:
+			// indexed values are saved in lower case
+			parsed, err := time.Parse(time.RFC3339, strings.ToUpper(v))
+			if err != nil {
+				return 0, false
+			}
+			got = parsed
+		default:
+			return 0, false
+		}
+		want, _ := time.Parse(time.RFC3339, value)
+		switch {
+		case got.Before(want):
+			return -1, true
+		case got.After(want):
+			return 1, true
+		}
+		return 0, true
+	}
+	got := filterString(actual)
+	if strings.EqualFold(got, value) {
+		return 0, true
+	}
+	return strings.Compare(strings.ToLower(got), strings.ToLower(value)), true
+}
+
+// GetFilterProperty returns the value of the property in the resource,
+// where the property can be a path of the nested property like Status/Health
+func GetFilterProperty(resource map[string]interface{}, property string) (interface{}, bool) {
+	var value interface{} = resource
+	for _, name := range strings.Split(property, "/") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// FilterIndexName returns the name of the index of a searchable property of the resource type
+func FilterIndexName(resourceType, property string) string {
+	return resourceType + "Index:" + property
+}
+
+// FilterIndexNames returns the names of the indexes of all the searchable properties of the resource type
+func FilterIndexNames(resourceType string) []string {
+	var names []string
+	for property := range getFilterKeys(resourceType) {
+		names = append(names, FilterIndexName(resourceType, property))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CreateFilterIndexForm returns the values of the searchable properties of the resource type
+// present in the resource, mapped with the name of their index
+func CreateFilterIndexForm(resourceType string, resource map[string]interface{}) map[string]interface{} {
+	form := make(map[string]interface{})
+	for property, propertyType := range getFilterKeys(resourceType) {
+		value, ok := GetFilterProperty(resource, property)
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			form[FilterIndexName(resourceType, property)] = v
+		case float64:
+			if propertyType == FilterTypeFloat64 {
+				form[FilterIndexName(resourceType, property)] = v
+			}
+		}
+	}
+	return form
+}
+
+// GetFilterIndexes reads the indexes of the properties of the resource type from the in-memory DB.
+// The index of a property maps the resource ID to the value of the property.
+func GetFilterIndexes(resourceType string, properties []string) (map[string]map[string]string, error) {
+	conn, dbErr := GetDBConnection(InMemory)
+	if dbErr != nil {
+		return nil, fmt.Errorf("error while trying to connect to DB: %v", dbErr.Error())
+	}
+	indexes := make(map[string]map[string]string)
+	for _, property := range properties {
+		data, err := conn.GetTaskList(FilterIndexName(resourceType, property), 0, -1)
+		if err != nil {
+			return nil, err
+		}
+		index := make(map[string]string)
+		for _, member := range data {
+			// index members are of the format value::ID
+			if sep := strings.LastIndex(member, "::"); sep >= 0 {
+				index[member[sep+2:]] = member[:sep]
+			}
+		}
+		indexes[property] = index
+	}
+	return indexes, nil
+}
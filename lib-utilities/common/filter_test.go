@@ -0,0 +1,241 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func setMockFilterSchema() {
+	SetFilterSchema("Mock", FilterSchema{
+		SearchKeys: []map[string]map[string]string{
+			{"Name": {"type": "string"}},
+			{"Status/Health": {"type": "string"}},
+			{"PowerWatts": {"type": "float64"}},
+			{"StartTime": {"type": "datetime"}},
+		},
+	})
+}
+
+func TestParseFilter_Invalid(t *testing.T) {
+	setMockFilterSchema()
+	for _, expression := range []string{
+		"",
+		"Name eq",
+		"Unknown eq abc",
+		"Name gt abc",
+		"Name like abc",
+		"PowerWatts eq abc",
+		"StartTime lt yesterday",
+		"(Name eq abc",
+		"Name eq abc Name",
+		"Name eq 'abc",
+		"'Name' eq abc",
+		"contains(Name)",
+		"contains(Name,'abc'",
+		"contains(StartTime,'2023')",
+		"Name in abc",
+		"Name in (abc,,def)",
+		"PowerWatts in (100,abc)",
+		"((Name eq abc) and PowerWatts gt 100",
+	} {
+		if _, err := ParseFilter(expression, "Mock"); err == nil {
+			t.Errorf("ParseFilter(%q) expected an error", expression)
+		}
+	}
+	if _, err := ParseFilter("Name eq abc", "Unknown"); err == nil {
+		t.Errorf("ParseFilter() expected an error for resource type without schema")
+	}
+}
+
+func TestFilter_MatchResource(t *testing.T) {
+	setMockFilterSchema()
+	resource := map[string]interface{}{
+		"Name":       "Chassis 1",
+		"Status":     map[string]interface{}{"Health": "OK"},
+		"PowerWatts": float64(250),
+		"StartTime":  "2023-01-02T10:00:00Z",
+	}
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"Name eq 'chassis 1'", true},
+		{"Status/Health eq Warning", false},
+		{"Status/Health ne Warning", true},
+		{"PowerWatts gt 200 and PowerWatts le 250", true},
+		{"PowerWatts lt 200 or Status/Health eq OK", true},
+		{"not (PowerWatts ge 100)", false},
+		{"StartTime gt 2023-01-01T00:00:00Z", true},
+		{"StartTime lt 2023-01-01T00:00:00Z", false},
+		{"Name eq 'it''s'", false},
+		{"contains(Name,'SIS')", true},
+		{"startswith(Name,chassis) and endswith(Name,'1')", true},
+		{"not contains(Status/Health,'warn')", true},
+		{"Status/Health in (Warning,'OK')", true},
+		{"PowerWatts in (100,200)", false},
+		{"((PowerWatts gt 200 or Name eq abc) and (Status/Health eq OK or (not Name eq abc)))", true},
+		{"(Name eq abc or (PowerWatts lt 300 and Status/Health ne OK))", false},
+	}
+	for _, tt := range tests {
+		filter, err := ParseFilter(tt.expression, "Mock")
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) error = %v", tt.expression, err)
+		}
+		if got := filter.MatchResource(resource); got != tt.want {
+			t.Errorf("MatchResource(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	setMockFilterSchema()
+	filter, err := ParseFilter("StartTime ge 2023-01-01T00:00:00Z", "Mock")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	startTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !filter.Match(func(string) (interface{}, bool) { return startTime, true }) {
+		t.Errorf("Match() = false, want true for time value")
+	}
+	if filter.Match(func(string) (interface{}, bool) { return nil, false }) {
+		t.Errorf("Match() = true, want false for missing property")
+	}
+}
+
+func TestFilter_MatchIndexed(t *testing.T) {
+	setMockFilterSchema()
+	filter, err := GetFilter("/redfish/v1/Chassis?$filter=Status%2FHealth%20eq%20OK%20and%20PowerWatts%20gt%20100&$top=2", "Mock")
+	if err != nil {
+		t.Fatalf("GetFilter() error = %v", err)
+	}
+	properties := filter.Properties()
+	sort.Strings(properties)
+	if !reflect.DeepEqual(properties, []string{"PowerWatts", "Status/Health"}) {
+		t.Errorf("Properties() = %v", properties)
+	}
+	indexes := map[string]map[string]string{
+		"Status/Health": {"/redfish/v1/Chassis/1": "ok", "/redfish/v1/Chassis/2": "ok", "/redfish/v1/Chassis/3": "critical"},
+		"PowerWatts":    {"/redfish/v1/Chassis/1": "50", "/redfish/v1/Chassis/2": "150", "/redfish/v1/Chassis/3": "150"},
+	}
+	members := []string{"/redfish/v1/Chassis/1", "/redfish/v1/Chassis/2", "/redfish/v1/Chassis/3", "/redfish/v1/Chassis/4"}
+	if got := filter.MatchIndexed(members, indexes); !reflect.DeepEqual(got, []string{"/redfish/v1/Chassis/2"}) {
+		t.Errorf("MatchIndexed() = %v", got)
+	}
+	if filter, err := GetFilter("/redfish/v1/Chassis?$top=2", "Mock"); filter != nil || err != nil {
+		t.Errorf("GetFilter() = %v, %v, want nil without $filter", filter, err)
+	}
+}
+
+func TestCreateFilterIndexForm(t *testing.T) {
+	setMockFilterSchema()
+	form := CreateFilterIndexForm("Mock", map[string]interface{}{
+		"Name":       "Chassis 1",
+		"Status":     map[string]interface{}{"State": "Enabled"},
+		"PowerWatts": float64(250),
+	})
+	want := map[string]interface{}{
+		"MockIndex:Name":       "Chassis 1",
+		"MockIndex:PowerWatts": float64(250),
+	}
+	if !reflect.DeepEqual(form, want) {
+		t.Errorf("CreateFilterIndexForm() = %v, want %v", form, want)
+	}
+	wantNames := []string{"MockIndex:Name", "MockIndex:PowerWatts", "MockIndex:StartTime", "MockIndex:Status/Health"}
+	if names := FilterIndexNames("Mock"); !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("FilterIndexNames() = %v, want %v", names, wantNames)
+	}
+}
+
+func TestParseFilterWithSchema(t *testing.T) {
+	schema := FilterSchema{
+		SearchKeys: []map[string]map[string]string{
+			{"ProcessorSummary/Model": {"type": "string"}},
+			{"Storage/Drives/Capacity": {"type": "[]float64"}},
+			{"Storage/Drives/Type": {"type": "[]string"}},
+		},
+	}
+	resource := map[string]interface{}{
+		"ProcessorSummary": map[string]interface{}{"Model": "Intel(R) Xeon(R) Gold"},
+		"Storage": map[string]interface{}{
+			"Drives": map[string]interface{}{
+				"Capacity": []interface{}{float64(40), float64(80)},
+				"Type":     []interface{}{"HDD", "SSD"},
+			},
+		},
+	}
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"ProcessorSummary/Model eq 'intel(r) xeon(r) gold'", true},
+		{"(startswith(ProcessorSummary/Model,Intel(R)))", true},
+		{"Storage/Drives/Capacity gt 60", true},
+		{"Storage/Drives/Capacity in (20,30)", false},
+		{"Storage/Drives/Type eq SSD", true},
+		{"Storage/Drives/Type ne HDD", false},
+	}
+	for _, tt := range tests {
+		filter, err := ParseFilterWithSchema(tt.expression, schema)
+		if err != nil {
+			t.Fatalf("ParseFilterWithSchema(%q) error = %v", tt.expression, err)
+		}
+		if got := filter.MatchResource(resource); got != tt.want {
+			t.Errorf("MatchResource(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+	if _, err := ParseFilterWithSchema("Storage/Drives/Type gt SSD", schema); err == nil {
+		t.Errorf("ParseFilterWithSchema() expected an error for gt on a list of strings")
+	}
+	if _, err := ParseFilterWithSchema("ProcessorSummary/Model eq abc", FilterSchema{}); err == nil {
+		t.Errorf("ParseFilterWithSchema() expected an error without searchable properties")
+	}
+}
+
+func TestFilter_Evaluate(t *testing.T) {
+	setMockFilterSchema()
+	filter, err := ParseFilter("(Name eq a or Name eq b) and not (PowerWatts gt 100)", "Mock")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	var conditions []FilterCondition
+	members := func(condition FilterCondition) ([]string, error) {
+		conditions = append(conditions, condition)
+		switch condition.Values[0] {
+		case "a":
+			return []string{"1", "2"}, nil
+		case "b":
+			return []string{"2", "3"}, nil
+		}
+		return []string{"3"}, nil
+	}
+	all := func() ([]string, error) {
+		return []string{"1", "2", "3", "4"}, nil
+	}
+	got, err := filter.Evaluate(members, all)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("Evaluate() = %v, want [1 2]", got)
+	}
+	want := FilterCondition{Property: "PowerWatts", PropertyType: FilterTypeFloat64, Operator: "gt", Values: []string{"100"}}
+	if len(conditions) != 3 || !reflect.DeepEqual(conditions[2], want) {
+		t.Errorf("Evaluate() conditions = %v", conditions)
+	}
+}
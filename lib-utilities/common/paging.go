@@ -0,0 +1,162 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package common ...
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+const (
+	// TopQueryOption is the query option for limiting the number of members in a collection response
+	TopQueryOption = "$top"
+	// SkipQueryOption is the query option for skipping the members in a collection response
+	SkipQueryOption = "$skip"
+)
+
+// Paging holds the $top and $skip query options of a collection request.
+// Top is the maximum number of members in the page, zero means all the members.
+type Paging struct {
+	Top  int
+	Skip int
+	// query holds the rest of the query options of the request,
+	// which are added to the next link as they are
+	query []string
+}
+
+// GetPaging reads the $top and $skip query options from the request URL.
+// When $top is not requested or when it is more than the CollectionPageSize
+// configured, the CollectionPageSize is used as the page size.
+func GetPaging(requestURL string) (Paging, error) {
+	paging := Paging{Top: config.Data.CollectionPageSize}
+	uri := strings.SplitN(requestURL, "?", 2)
+	if len(uri) < 2 {
+		return paging, nil
+	}
+	for _, param := range strings.Split(uri[1], "&") {
+		if param == "" {
+			continue
+		}
+		kv := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			key = kv[0]
+		}
+		if key != TopQueryOption && key != SkipQueryOption {
+			paging.query = append(paging.query, param)
+			continue
+		}
+		var value int
+		if len(kv) == 2 {
+			value, err = strconv.Atoi(kv[1])
+		}
+		if len(kv) < 2 || err != nil || value < 0 {
+			return paging, fmt.Errorf("%s should be a non negative integer", key)
+		}
+		if key == SkipQueryOption {
+			paging.Skip = value
+			continue
+		}
+		if value == 0 {
+			return paging, fmt.Errorf("%s should be a positive integer", key)
+		}
+		if paging.Top <= 0 || value < paging.Top {
+			paging.Top = value
+		}
+	}
+	return paging, nil
+}
+
+// Query returns the query options of the request other than $top and $skip
+func (p Paging) Query() string {
+	return strings.Join(p.query, "&")
+}
+
+// Bounds returns the start and end index of the members in the page,
+// out of a collection of total members
+func (p Paging) Bounds(total int) (int, int) {
+	start := p.Skip
+	if start > total {
+		start = total
+	}
+	end := total
+	if p.Top > 0 && start+p.Top < total {
+		end = start + p.Top
+	}
+	return start, end
+}
+
+// AppendQueryOption adds the query option to the link, used for retaining the query options
+// which are handled by the API gateway in the Members@odata.nextLink of a collection
+func AppendQueryOption(link, option, value string) string {
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	return link + separator + option + "=" + url.QueryEscape(value)
+}
+
+// NextLink returns the link to the next page of the collection,
+// which will be empty if the page has the last member of the collection
+func (p Paging) NextLink(collectionURI string, total int) string {
+	if _, end := p.Bounds(total); end >= total {
+		return ""
+	}
+	params := append([]string{}, p.query...)
+	params = append(params, fmt.Sprintf("%s=%d", TopQueryOption, p.Top), fmt.Sprintf("%s=%d", SkipQueryOption, p.Skip+p.Top))
+	return collectionURI + "?" + strings.Join(params, "&")
+}
+
+// IsLogEntryCollection reports whether the path is of the collection of the entries of a LogService
+func IsLogEntryCollection(path string) bool {
+	segments := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	n := len(segments)
+	return n > 3 && segments[n-1] == "Entries" && segments[n-3] == "LogServices"
+}
+
+// GetLogEntriesPaging reads the paging of a request for the entries of a LogService, which are
+// read in one response from the resource, and returns it along with the path of the request.
+// The paging is nil for the requests of other resources.
+func GetLogEntriesPaging(requestURL string) (*Paging, string, error) {
+	path := strings.SplitN(requestURL, "?", 2)[0]
+	if !IsLogEntryCollection(path) {
+		return nil, requestURL, nil
+	}
+	paging, err := GetPaging(requestURL)
+	if err != nil {
+		return nil, requestURL, err
+	}
+	return &paging, path, nil
+}
+
+// PageMembers reduces the Members of a collection resource to the page and sets the link
+// to the next page. Members@odata.count will have the total number of members.
+func (p Paging) PageMembers(resource map[string]interface{}, collectionURI string) {
+	members, ok := resource["Members"].([]interface{})
+	if !ok {
+		return
+	}
+	start, end := p.Bounds(len(members))
+	resource["Members"] = members[start:end]
+	resource["Members@odata.count"] = len(members)
+	if nextLink := p.NextLink(collectionURI, len(members)); nextLink != "" {
+		resource["Members@odata.nextLink"] = nextLink
+	}
+}
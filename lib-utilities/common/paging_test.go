@@ -0,0 +1,132 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestGetPaging(t *testing.T) {
+	config.Data.CollectionPageSize = 100
+	defer func() {
+		config.Data.CollectionPageSize = 0
+	}()
+	tests := []struct {
+		name     string
+		url      string
+		wantTop  int
+		wantSkip int
+		wantErr  bool
+	}{
+		{name: "page size", url: "/redfish/v1/Systems", wantTop: 100},
+		{name: "top and skip", url: "/redfish/v1/Systems?$top=10&$skip=20", wantTop: 10, wantSkip: 20},
+		{name: "encoded options", url: "/redfish/v1/Systems?%24top=10&%24skip=20", wantTop: 10, wantSkip: 20},
+		{name: "top more than page size", url: "/redfish/v1/Systems?$top=1000", wantTop: 100},
+		{name: "invalid top", url: "/redfish/v1/Systems?$top=abc", wantErr: true},
+		{name: "zero top", url: "/redfish/v1/Systems?$top=0", wantErr: true},
+		{name: "negative skip", url: "/redfish/v1/Systems?$skip=-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetPaging(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPaging() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Top != tt.wantTop || got.Skip != tt.wantSkip {
+				t.Errorf("GetPaging() = %+v, want top %v and skip %v", got, tt.wantTop, tt.wantSkip)
+			}
+		})
+	}
+}
+
+func TestAppendQueryOption(t *testing.T) {
+	if got := AppendQueryOption("/redfish/v1/Systems?$skip=2", "$select", "Status/Health,Model"); got != "/redfish/v1/Systems?$skip=2&$select=Status%2FHealth%2CModel" {
+		t.Errorf("AppendQueryOption() = %v", got)
+	}
+	if got := AppendQueryOption("/redfish/v1/Systems", "$expand", "."); got != "/redfish/v1/Systems?$expand=." {
+		t.Errorf("AppendQueryOption() = %v", got)
+	}
+}
+
+func TestPaging_NextLink(t *testing.T) {
+	paging, err := GetPaging("/redfish/v1/Systems?$filter=PowerState%20eq%20On&$top=2")
+	if err != nil {
+		t.Fatalf("GetPaging() error = %v", err)
+	}
+	if start, end := paging.Bounds(5); start != 0 || end != 2 {
+		t.Errorf("Bounds() = %v, %v, want 0, 2", start, end)
+	}
+	want := "/redfish/v1/Systems?$filter=PowerState%20eq%20On&$top=2&$skip=2"
+	if got := paging.NextLink("/redfish/v1/Systems", 5); got != want {
+		t.Errorf("NextLink() = %v, want %v", got, want)
+	}
+	paging.Skip = 4
+	if start, end := paging.Bounds(5); start != 4 || end != 5 {
+		t.Errorf("Bounds() = %v, %v, want 4, 5", start, end)
+	}
+	if got := paging.NextLink("/redfish/v1/Systems", 5); got != "" {
+		t.Errorf("NextLink() of the last page = %v, want empty", got)
+	}
+	if start, end := (Paging{Skip: 10}).Bounds(5); start != 5 || end != 5 {
+		t.Errorf("Bounds() = %v, %v, want 5, 5", start, end)
+	}
+}
+
+func TestGetLogEntriesPaging(t *testing.T) {
+	paging, path, err := GetLogEntriesPaging("/redfish/v1/Systems/uuid.1/LogServices/SEL/Entries?$top=2&$skip=1")
+	if err != nil || paging == nil {
+		t.Fatalf("GetLogEntriesPaging() = %v, %v", paging, err)
+	}
+	if path != "/redfish/v1/Systems/uuid.1/LogServices/SEL/Entries" || paging.Top != 2 || paging.Skip != 1 {
+		t.Errorf("GetLogEntriesPaging() = %+v, %v", paging, path)
+	}
+	if _, _, err := GetLogEntriesPaging("/redfish/v1/Managers/uuid.1/LogServices/SL/Entries?$top=abc"); err == nil {
+		t.Error("GetLogEntriesPaging() expected an error for an invalid $top")
+	}
+	for _, url := range []string{"/redfish/v1/Systems/uuid.1/LogServices/SEL", "/redfish/v1/Systems/uuid.1/LogServices/SEL/Entries/1"} {
+		if paging, path, err := GetLogEntriesPaging(url); paging != nil || path != url || err != nil {
+			t.Errorf("GetLogEntriesPaging(%v) = %v, %v, %v, want no paging", url, paging, path, err)
+		}
+	}
+}
+
+func TestPaging_PageMembers(t *testing.T) {
+	entriesURI := "/redfish/v1/Chassis/uuid.1/LogServices/SEL/Entries"
+	resource := map[string]interface{}{
+		"Members":             []interface{}{"1", "2", "3"},
+		"Members@odata.count": 3,
+	}
+	Paging{Top: 2}.PageMembers(resource, entriesURI)
+	if members := resource["Members"].([]interface{}); len(members) != 2 || members[0] != "1" {
+		t.Errorf("PageMembers() members = %v", members)
+	}
+	if resource["Members@odata.count"] != 3 || resource["Members@odata.nextLink"] != entriesURI+"?$top=2&$skip=2" {
+		t.Errorf("PageMembers() = %v", resource)
+	}
+
+	resource = map[string]interface{}{"Members": []interface{}{"1", "2", "3"}}
+	Paging{Top: 2, Skip: 2}.PageMembers(resource, entriesURI)
+	if members := resource["Members"].([]interface{}); len(members) != 1 || members[0] != "3" {
+		t.Errorf("PageMembers() members = %v", members)
+	}
+	if _, ok := resource["Members@odata.nextLink"]; ok {
+		t.Errorf("PageMembers() of the last page = %v, want no next link", resource)
+	}
+}
@@ -50,6 +50,9 @@ func SetCommonHeaders(w http.ResponseWriter) {
 	}
 }
 
+// requestKeys are the optional context values which carry the HTTP request across the services
+var requestKeys = []string{RequestURI, RequestMethod, SessionAggregates}
+
 // GetContextData is used to fetch data from metadata and add it to context
 func GetContextData(ctx context.Context) context.Context {
 	md, _ := metadata.FromIncomingContext(ctx)
@@ -62,6 +65,11 @@ func GetContextData(ctx context.Context) context.Context {
 		ctx = context.WithValue(ctx, ThreadID, md[ThreadID][0])
 		ctx = context.WithValue(ctx, ThreadName, md[ThreadName][0])
 	}
+	for _, key := range requestKeys {
+		if len(md[key]) > 0 {
+			ctx = context.WithValue(ctx, key, md[key][0])
+		}
+	}
 
 	return ctx
 }
@@ -77,6 +85,11 @@ func CreateMetadata(ctx context.Context) context.Context {
 			ThreadID:      ctx.Value(ThreadID).(string),
 			ThreadName:    ctx.Value(ThreadName).(string),
 		})
+		for _, key := range requestKeys {
+			if value, _ := ctx.Value(key).(string); value != "" {
+				md.Set(key, value)
+			}
+		}
 		ctx = metadata.NewOutgoingContext(ctx, md)
 	}
 
@@ -105,6 +118,11 @@ func CreateNewRequestContext(ctx context.Context) context.Context {
 	reqCtx = context.WithValue(reqCtx, ActionName, actionName)
 	reqCtx = context.WithValue(reqCtx, ThreadID, threadID)
 	reqCtx = context.WithValue(reqCtx, ThreadName, threadName)
+	for _, key := range requestKeys {
+		if value, _ := ctx.Value(key).(string); value != "" {
+			reqCtx = context.WithValue(reqCtx, key, value)
+		}
+	}
 	return reqCtx
 }
 
@@ -0,0 +1,253 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package common ...
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// scopedCollections are the collections whose members are restricted for
+// the sessions restricted to aggregates
+var scopedCollections = []string{
+	"/redfish/v1/Systems/",
+	"/redfish/v1/Chassis/",
+	"/redfish/v1/Managers/",
+	"/redfish/v1/AggregationService/Aggregates/",
+}
+
+// unscopedResources are the resources which cannot be accessed by the sessions
+// restricted to aggregates, as they are not bound to the systems of an aggregate
+var unscopedResources = []string{
+	"/redfish/v1/AggregationService/AggregationSources",
+	"/redfish/v1/AggregationService/ConnectionMethods",
+	"/redfish/v1/AggregationService/Actions",
+}
+
+// resourceScopeTTL is the duration for which a resolved scope is reused. The sessions
+// restricted to the same aggregates share the scope, so the aggregates and their member
+// systems are read from the DB once in the duration instead of for each request
+const resourceScopeTTL = 10 * time.Second
+
+// ResourceScope holds the resources which can be accessed by a session restricted
+// to aggregates. The aggregates, their member systems and the chassis and
+// managers of the member systems are in the scope
+type ResourceScope struct {
+	resources map[string]bool
+}
+
+// dbReader reads the data of the key from the table of the DB
+type dbReader func(dbFlag DbType, table, key string) (string, *errors.Error)
+
+// ReadResourceScopeData is the function pointer for reading the aggregates
+// and their member systems from the DB for resolving the scopes
+var ReadResourceScopeData dbReader = func(dbFlag DbType, table, key string) (string, *errors.Error) {
+	conn, err := GetDBConnection(dbFlag)
+	if err != nil {
+		return "", err
+	}
+	return conn.Read(table, key)
+}
+
+// cachedResourceScope is a resolved scope which is reused until its expiry
+type cachedResourceScope struct {
+	scope  *ResourceScope
+	expiry time.Time
+}
+
+// resourceScopes holds the resolved scopes by the aggregates they are resolved for
+var resourceScopes = struct {
+	sync.Mutex
+	scopes map[string]cachedResourceScope
+}{scopes: make(map[string]cachedResourceScope)}
+
+// GetResourceScope returns the scope of the session from the aggregates in the context.
+// Nil is returned when the session is not restricted to any aggregate
+func GetResourceScope(ctx context.Context) (*ResourceScope, error) {
+	aggregates, _ := ctx.Value(SessionAggregates).(string)
+	if aggregates == "" {
+		return nil, nil
+	}
+	return NewResourceScope(strings.Split(aggregates, ","))
+}
+
+// NewResourceScope resolves the resources of the aggregates passed. The scope resolved
+// for the same aggregates in the last resourceScopeTTL is reused.
+// Nil is returned when no aggregate is passed
+func NewResourceScope(aggregates []string) (*ResourceScope, error) {
+	if len(aggregates) == 0 {
+		return nil, nil
+	}
+	key := strings.Join(aggregates, ",")
+	now := time.Now()
+	resourceScopes.Lock()
+	cached, ok := resourceScopes.scopes[key]
+	resourceScopes.Unlock()
+	if ok && now.Before(cached.expiry) {
+		return cached.scope, nil
+	}
+	scope, err := resolveResourceScope(aggregates, ReadResourceScopeData)
+	if err != nil {
+		return nil, err
+	}
+	resourceScopes.Lock()
+	defer resourceScopes.Unlock()
+	for k, c := range resourceScopes.scopes {
+		if !now.Before(c.expiry) {
+			delete(resourceScopes.scopes, k)
+		}
+	}
+	resourceScopes.scopes[key] = cachedResourceScope{scope: scope, expiry: now.Add(resourceScopeTTL)}
+	return scope, nil
+}
+
+func resolveResourceScope(aggregates []string, read dbReader) (*ResourceScope, error) {
+	if len(aggregates) == 0 {
+		return nil, nil
+	}
+	scope := &ResourceScope{resources: make(map[string]bool)}
+	for _, aggregateURI := range aggregates {
+		data, err := read(OnDisk, "Aggregate", aggregateURI)
+		if err != nil {
+			// a deleted aggregate adds no resource to the scope
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return nil, err
+		}
+		var aggregate struct {
+			Elements []link `json:"Elements"`
+		}
+		if err := json.Unmarshal([]byte(data), &aggregate); err != nil {
+			return nil, err
+		}
+		scope.resources[aggregateURI] = true
+		for _, element := range aggregate.Elements {
+			scope.resources[element.OdataID] = true
+			if err := scope.addSystemLinks(element.OdataID, read); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return scope, nil
+}
+
+// link is a reference to a resource
+type link struct {
+	OdataID string `json:"@odata.id"`
+}
+
+// addSystemLinks adds the chassis and the managers of the system to the scope
+func (s *ResourceScope) addSystemLinks(systemURI string, read dbReader) error {
+	data, err := read(InMemory, "ComputerSystem", systemURI)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil
+		}
+		return err
+	}
+	var system struct {
+		Links struct {
+			Chassis   []link `json:"Chassis"`
+			ManagedBy []link `json:"ManagedBy"`
+		} `json:"Links"`
+	}
+	if err := json.Unmarshal([]byte(data), &system); err != nil {
+		return err
+	}
+	for _, chassis := range system.Links.Chassis {
+		s.resources[chassis.OdataID] = true
+	}
+	for _, manager := range system.Links.ManagedBy {
+		s.resources[manager.OdataID] = true
+	}
+	return nil
+}
+
+// Allows checks the resource of the URI is in the scope. The URIs of the
+// collections and of the resources which are not restricted by the aggregates
+// are allowed. All the URIs are allowed by a nil scope
+func (s *ResourceScope) Allows(uri string) bool {
+	if s == nil {
+		return true
+	}
+	uri = strings.TrimSuffix(strings.SplitN(uri, "?", 2)[0], "/")
+	for _, resource := range unscopedResources {
+		if uri == resource || strings.HasPrefix(uri, resource+"/") {
+			return false
+		}
+	}
+	for _, collection := range scopedCollections {
+		if strings.HasPrefix(uri, collection) {
+			id := strings.SplitN(strings.TrimPrefix(uri, collection), "/", 2)[0]
+			return s.resources[collection+id]
+		}
+	}
+	return true
+}
+
+// Filter returns the URIs allowed by the scope, keeping their order
+func (s *ResourceScope) Filter(uris []string) []string {
+	if s == nil {
+		return uris
+	}
+	allowed := []string{}
+	for _, uri := range uris {
+		if s.Allows(uri) {
+			allowed = append(allowed, uri)
+		}
+	}
+	return allowed
+}
+
+// AllowsTarget checks the resource of the URI, passed in a request body as the
+// target of an operation, is in the scope. Unlike Allows, the collections and the
+// resources which are not bound to an aggregate are not allowed, as an operation
+// on them reaches the resources out of the scope. All the URIs are allowed by a nil scope
+func (s *ResourceScope) AllowsTarget(uri string) bool {
+	if s == nil {
+		return true
+	}
+	uri = strings.TrimSuffix(strings.SplitN(uri, "?", 2)[0], "/")
+	for _, collection := range scopedCollections {
+		if strings.HasPrefix(uri, collection) {
+			id := strings.SplitN(strings.TrimPrefix(uri, collection), "/", 2)[0]
+			return id != "" && s.resources[collection+id]
+		}
+	}
+	return false
+}
+
+// TargetsOutOfScope returns the target URIs passed in a request body which are
+// not allowed by the scope of the session in the context
+func TargetsOutOfScope(ctx context.Context, targets []string) ([]string, error) {
+	scope, err := GetResourceScope(ctx)
+	if err != nil || scope == nil {
+		return nil, err
+	}
+	outOfScope := []string{}
+	for _, target := range targets {
+		if !scope.AllowsTarget(target) {
+			outOfScope = append(outOfScope, target)
+		}
+	}
+	return outOfScope, nil
+}
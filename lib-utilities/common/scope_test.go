@@ -0,0 +1,143 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+func mockDBReader(data map[string]string) dbReader {
+	return func(dbFlag DbType, table, key string) (string, *errors.Error) {
+		if value, ok := data[table+":"+key]; ok {
+			return value, nil
+		}
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the key ", key, " found")
+	}
+}
+
+func TestResourceScope(t *testing.T) {
+	read := mockDBReader(map[string]string{
+		"Aggregate:/redfish/v1/AggregationService/Aggregates/a1": `{"Elements":[{"@odata.id":"/redfish/v1/Systems/uuid.1"}]}`,
+		"ComputerSystem:/redfish/v1/Systems/uuid.1":              `{"Links":{"Chassis":[{"@odata.id":"/redfish/v1/Chassis/uuid.1"}],"ManagedBy":[{"@odata.id":"/redfish/v1/Managers/uuid.1"}]}}`,
+	})
+	scope, err := resolveResourceScope([]string{"/redfish/v1/AggregationService/Aggregates/a1", "/redfish/v1/AggregationService/Aggregates/deleted"}, read)
+	if err != nil {
+		t.Fatalf("resolveResourceScope() error = %v", err)
+	}
+	tests := []struct {
+		uri  string
+		want bool
+	}{
+		{"/redfish/v1/Systems", true},
+		{"/redfish/v1/Systems/uuid.1", true},
+		{"/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", true},
+		{"/redfish/v1/Systems/uuid.2", false},
+		{"/redfish/v1/Systems/uuid.2/Bios?$select=Id", false},
+		{"/redfish/v1/Chassis/uuid.1/Thermal", true},
+		{"/redfish/v1/Chassis/uuid.2", false},
+		{"/redfish/v1/Managers/uuid.1", true},
+		{"/redfish/v1/Managers/odim", false},
+		{"/redfish/v1/AggregationService/Aggregates/a1/Actions/Aggregate.Reset", true},
+		{"/redfish/v1/AggregationService/Aggregates/a2", false},
+		{"/redfish/v1/AggregationService/AggregationSources", false},
+		{"/redfish/v1/AggregationService/Actions/AggregationService.Reset/", false},
+		{"/redfish/v1/TaskService/Tasks", true},
+	}
+	for _, tt := range tests {
+		if got := scope.Allows(tt.uri); got != tt.want {
+			t.Errorf("Allows(%s) = %v, want %v", tt.uri, got, tt.want)
+		}
+	}
+
+	got := scope.Filter([]string{"/redfish/v1/Systems/uuid.2", "/redfish/v1/Systems/uuid.1"})
+	if want := []string{"/redfish/v1/Systems/uuid.1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter() = %v, want %v", got, want)
+	}
+
+	targets := []struct {
+		uri  string
+		want bool
+	}{
+		{"/redfish/v1/Systems", false},
+		{"/redfish/v1/Systems/", false},
+		{"/redfish/v1/Systems/uuid.1", true},
+		{"/redfish/v1/Systems/uuid.1/Bios", true},
+		{"/redfish/v1/Systems/uuid.2", false},
+		{"/redfish/v1/Managers/uuid.1/", true},
+		{"/redfish/v1/AggregationService/Aggregates/a1", true},
+		{"/redfish/v1/TaskService/Tasks", false},
+		{"/redfish/v1/Fabrics/f1", false},
+	}
+	for _, tt := range targets {
+		if got := scope.AllowsTarget(tt.uri); got != tt.want {
+			t.Errorf("AllowsTarget(%s) = %v, want %v", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestResourceScopeNotRestricted(t *testing.T) {
+	scope, err := GetResourceScope(context.Background())
+	if scope != nil || err != nil {
+		t.Fatalf("GetResourceScope() = %v, %v, want nil scope", scope, err)
+	}
+	if !scope.Allows("/redfish/v1/Systems/uuid.2") {
+		t.Errorf("Allows() expected a nil scope to allow all the URIs")
+	}
+	if !scope.AllowsTarget("/redfish/v1/Systems") {
+		t.Errorf("AllowsTarget() expected a nil scope to allow all the URIs")
+	}
+	uris := []string{"/redfish/v1/Systems/uuid.2"}
+	if got := scope.Filter(uris); !reflect.DeepEqual(got, uris) {
+		t.Errorf("Filter() = %v, want %v", got, uris)
+	}
+}
+
+func TestNewResourceScopeCache(t *testing.T) {
+	reads := 0
+	data := mockDBReader(map[string]string{
+		"Aggregate:/redfish/v1/AggregationService/Aggregates/cached": `{"Elements":[{"@odata.id":"/redfish/v1/Systems/uuid.1"}]}`,
+	})
+	readResourceScopeData := ReadResourceScopeData
+	defer func() { ReadResourceScopeData = readResourceScopeData }()
+	ReadResourceScopeData = func(dbFlag DbType, table, key string) (string, *errors.Error) {
+		reads++
+		return data(dbFlag, table, key)
+	}
+	aggregates := []string{"/redfish/v1/AggregationService/Aggregates/cached"}
+	ctx := context.WithValue(context.Background(), SessionAggregates, aggregates[0])
+
+	scope, err := GetResourceScope(ctx)
+	if err != nil || !scope.Allows("/redfish/v1/Systems/uuid.1") {
+		t.Fatalf("GetResourceScope() = %v, %v", scope, err)
+	}
+	if reads != 2 {
+		t.Errorf("GetResourceScope() read the DB %d times, want 2", reads)
+	}
+	// the scope of the same aggregates is reused until it expires
+	if cached, _ := NewResourceScope(aggregates); cached != scope || reads != 2 {
+		t.Errorf("NewResourceScope() resolved the scope again before its expiry")
+	}
+	resourceScopes.Lock()
+	resourceScopes.scopes[aggregates[0]] = cachedResourceScope{scope: scope, expiry: time.Now()}
+	resourceScopes.Unlock()
+	if resolved, _ := NewResourceScope(aggregates); resolved == scope || reads != 4 {
+		t.Errorf("NewResourceScope() did not resolve the scope again after its expiry")
+	}
+}
@@ -25,6 +25,7 @@ type clModel struct {
 	RegistryAddress      string
 	ServerAddress        string
 	FrameWork            string
+	MetricsAddress       string
 }
 
 // CLArgs is for accessing the data passed as the command line argument
@@ -37,6 +38,7 @@ func CollectCLArgs(wl *WarningList) {
 	flag.StringVar(&CLArgs.RegistryAddress, "registry_address", "", "address of the registry")
 	flag.StringVar(&CLArgs.ServerAddress, "server_address", "", "address for the micro service")
 	flag.StringVar(&CLArgs.FrameWork, "framework", "GRPC", "framework used for micro service communication")
+	flag.StringVar(&CLArgs.MetricsAddress, "metrics_address", "", "address on which the micro service exposes the metrics")
 	flag.Parse()
 	if CLArgs.RegistryAddress == "" {
 		wl.add("No CLI argument found for registry_address")
@@ -25,6 +25,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -44,38 +45,48 @@ var Data configModel
 
 // configModel is for holding all the run time configurations for the services
 type configModel struct {
-	SouthBoundRequestTimeoutInSecs int                      `json:"SouthBoundRequestTimeoutInSecs"` // holds the value of south bound call request time out
-	ServerRediscoveryBatchSize     int                      `json:"ServerRediscoveryBatchSize"`
-	FirmwareVersion                string                   `json:"FirmwareVersion"`
-	RootServiceUUID                string                   `json:"RootServiceUUID"` //static uuid used for root service
-	SearchAndFilterSchemaPath      string                   `json:"SearchAndFilterSchemaPath"`
-	RegistryStorePath              string                   `json:"RegistryStorePath"`
-	LocalhostFQDN                  string                   `json:"LocalhostFQDN"`
-	EnabledServices                []string                 `json:"EnabledServices"`
-	MessageBusConf                 *MessageBusConf          `json:"MessageBusConf"`
-	DBConf                         *DBConf                  `json:"DBConf"`
-	KeyCertConf                    *KeyCertConf             `json:"KeyCertConf"`
-	AuthConf                       *AuthConf                `json:"AuthConf"`
-	APIGatewayConf                 *APIGatewayConf          `json:"APIGatewayConf"`
-	AddComputeSkipResources        *AddComputeSkipResources `json:"AddComputeSkipResources"`
-	URLTranslation                 *URLTranslation          `json:"URLTranslation"`
-	PluginStatusPolling            *PluginStatusPolling     `json:"PluginStatusPolling"`
-	ExecPriorityDelayConf          *ExecPriorityDelayConf   `json:"ExecPriorityDelayConf"`
-	TLSConf                        *TLSConf                 `json:"TLSConf"`
-	TaskQueueConf                  *TaskQueueConf           `json:"TaskQueueConf"`
-	PluginTasksConf                *PluginTasksConf         `json:"PluginTasksConf"`
-	SupportedPluginTypes           []string                 `json:"SupportedPluginTypes"`
-	ConnectionMethodConf           []ConnectionMethodConf   `json:"ConnectionMethodConf"`
-	EventConf                      *EventConf               `json:"EventConf"`
-	ResourceRateLimit              []string                 `json:"ResourceRateLimit"`
-	RequestLimitCountPerSession    int                      `json:"RequestLimitCountPerSession"`
-	SessionLimitCountPerUser       int                      `json:"SessionLimitCountPerUser"`
-	LogLevel                       log.Level                `json:"LogLevel"`
-	LogFormat                      lgr.LogFormat            `json:"LogFormat"`
-	ImageRegistryAddress           string                   `json:"ImageRegistryAddress,omitempty"`
-	KeyExpiryInterval              int                      `json:"KeyExpiryInterval"`
-	EventForwardingWorkerPoolCount int                      `json:"EventForwardingWorkerPoolCount"`
-	EventSaveWorkerPoolCount       int                      `json:"EventSaveWorkerPoolCount"`
+	SouthBoundRequestTimeoutInSecs    int                      `json:"SouthBoundRequestTimeoutInSecs"` // holds the value of south bound call request time out
+	ServerRediscoveryBatchSize        int                      `json:"ServerRediscoveryBatchSize"`
+	CollectionPageSize                int                      `json:"CollectionPageSize"` // maximum number of members returned in a page of a collection
+	FirmwareVersion                   string                   `json:"FirmwareVersion"`
+	RootServiceUUID                   string                   `json:"RootServiceUUID"` //static uuid used for root service
+	SearchAndFilterSchemaPath         string                   `json:"SearchAndFilterSchemaPath"`
+	ResourceFilterSchemaPath          string                   `json:"ResourceFilterSchemaPath"` // searchable properties of the resource collections other than Systems
+	RegistryStorePath                 string                   `json:"RegistryStorePath"`
+	JSONSchemaStorePath               string                   `json:"JSONSchemaStorePath"` // DMTF json schema and CSDL documents served under /redfish/v1/JsonSchemas
+	LocalhostFQDN                     string                   `json:"LocalhostFQDN"`
+	EnabledServices                   []string                 `json:"EnabledServices"`
+	MessageBusConf                    *MessageBusConf          `json:"MessageBusConf"`
+	DBConf                            *DBConf                  `json:"DBConf"`
+	KeyCertConf                       *KeyCertConf             `json:"KeyCertConf"`
+	AuthConf                          *AuthConf                `json:"AuthConf"`
+	APIGatewayConf                    *APIGatewayConf          `json:"APIGatewayConf"`
+	AddComputeSkipResources           *AddComputeSkipResources `json:"AddComputeSkipResources"`
+	URLTranslation                    *URLTranslation          `json:"URLTranslation"`
+	PluginStatusPolling               *PluginStatusPolling     `json:"PluginStatusPolling"`
+	ExecPriorityDelayConf             *ExecPriorityDelayConf   `json:"ExecPriorityDelayConf"`
+	TLSConf                           *TLSConf                 `json:"TLSConf"`
+	TaskQueueConf                     *TaskQueueConf           `json:"TaskQueueConf"`
+	PluginTasksConf                   *PluginTasksConf         `json:"PluginTasksConf"`
+	SupportedPluginTypes              []string                 `json:"SupportedPluginTypes"`
+	ConnectionMethodConf              []ConnectionMethodConf   `json:"ConnectionMethodConf"`
+	EventConf                         *EventConf               `json:"EventConf"`
+	ResourceRateLimit                 []string                 `json:"ResourceRateLimit"`
+	RequestLimitCountPerSession       int                      `json:"RequestLimitCountPerSession"`
+	SessionLimitCountPerUser          int                      `json:"SessionLimitCountPerUser"`
+	LogLevel                          log.Level                `json:"LogLevel"`
+	LogFormat                         lgr.LogFormat            `json:"LogFormat"`
+	ImageRegistryAddress              string                   `json:"ImageRegistryAddress,omitempty"`
+	KeyExpiryInterval                 int                      `json:"KeyExpiryInterval"`
+	EventForwardingWorkerPoolCount    int                      `json:"EventForwardingWorkerPoolCount"`
+	EventSaveWorkerPoolCount          int                      `json:"EventSaveWorkerPoolCount"`
+	ImportSourcesWorkerPoolCount      int                      `json:"ImportSourcesWorkerPoolCount"`      // number of aggregation sources added in parallel by AggregationService.ImportSources
+	CredentialRotationWorkerPoolCount int                      `json:"CredentialRotationWorkerPoolCount"` // number of aggregation sources rotated in parallel by AggregationService.RotateCredentials
+	TracingConf                       *TracingConf             `json:"TracingConf"`
+	AuditLogConf                      *AuditLogConf            `json:"AuditLogConf"`
+	DiscoveryConf                     *DiscoveryConf           `json:"DiscoveryConf"`
+	InventoryResyncConf               *InventoryResyncConf     `json:"InventoryResyncConf"`
+	InventoryHistoryConf              *InventoryHistoryConf    `json:"InventoryHistoryConf"`
 }
 
 // DBConf holds all DB related configurations
@@ -127,6 +138,10 @@ type AuthConf struct {
 	SessionTimeOutInMins            float64        `json:"SessionTimeOutInMins"`
 	ExpiredSessionCleanUpTimeInMins float64        `json:"ExpiredSessionCleanUpTimeInMins"`
 	PasswordRules                   *PasswordRules `json:"PasswordRules"`
+	AccountLockoutThreshold         int            `json:"AccountLockoutThreshold"`         // holds the number of failed logins after which the account is locked, 0 disables the lockout
+	AccountLockoutDuration          int            `json:"AccountLockoutDuration"`          // holds the duration in seconds for which the account stays locked
+	AccountLockoutCounterResetAfter int            `json:"AccountLockoutCounterResetAfter"` // holds the duration in seconds after which the failed logins are reset
+	OEMPrivileges                   []string       `json:"OEMPrivileges"`                   // holds the OEM privileges which can be assigned to the roles
 }
 
 // PasswordRules defines rules for password complexity
@@ -134,6 +149,8 @@ type PasswordRules struct {
 	MinPasswordLength       int    `json:"MinPasswordLength"`       // holds the value  of min password length
 	MaxPasswordLength       int    `json:"MaxPasswordLength"`       // holds the value of max password length
 	AllowedSpecialCharcters string `json:"AllowedSpecialCharcters"` // holds all value of  all sppecial charcters
+	PasswordExpirationDays  int    `json:"PasswordExpirationDays"`  // holds the number of days after which the password expires, 0 disables the expiration
+	PasswordHistoryCount    int    `json:"PasswordHistoryCount"`    // holds the number of recent passwords which can't be reused, 0 disables the history
 }
 
 // APIGatewayConf holds API gateway related configurations
@@ -203,6 +220,51 @@ type EventConf struct {
 	DeliveryRetryIntervalSeconds int `json:"DeliveryRetryIntervalSeconds"` // holds value of retrying events posting in interval
 }
 
+// TracingConf holds the configuration of the export of the distributed traces
+type TracingConf struct {
+	Exporter string `json:"Exporter"` // holds the exporter of the spans, None, Stdout or File
+	FilePath string `json:"FilePath"` // holds the file in which the spans are written by the File exporter
+}
+
+// AuditLogConf holds the configuration of the audit trail persisted in the on-disk DB
+type AuditLogConf struct {
+	RetentionDays        int  `json:"RetentionDays"`        // holds the number of days for which the audit records are kept
+	RecordReadOperations bool `json:"RecordReadOperations"` // holds whether the GET and HEAD requests are recorded in the audit trail
+}
+
+// DiscoveryConf holds the configuration of the network discovery of the Redfish BMCs
+type DiscoveryConf struct {
+	WorkerPoolCount        int                 `json:"WorkerPoolCount"`        // holds the number of addresses probed in parallel
+	ProbeTimeoutInSeconds  int                 `json:"ProbeTimeoutInSeconds"`  // holds the timeout of the probe of an address
+	MaxAddressesPerRequest int                 `json:"MaxAddressesPerRequest"` // holds the maximum number of addresses scanned by a discovery request
+	RestrictedSubnets      []string            `json:"RestrictedSubnets"`      // holds the CIDR ranges not scanned along with the loopback and link-local addresses, such as the service CIDR of the cluster
+	AllowedSubnets         []string            `json:"AllowedSubnets"`         // holds the CIDR ranges scanned even though they are restricted
+	VendorPlugins          map[string]string   `json:"VendorPlugins"`          // holds the plugin ID of the connection method matched for a vendor
+	CredentialProfiles     []CredentialProfile `json:"CredentialProfiles"`     // holds the credentials used to add the discovered BMCs
+}
+
+// CredentialProfile holds the BMC credentials used to add the discovered BMCs as aggregation sources
+type CredentialProfile struct {
+	Name             string `json:"Name"`
+	UserName         string `json:"UserName"`
+	PasswordFilePath string `json:"PasswordFilePath"` // holds the path of the RSA-OAEP encrypted password
+	Password         []byte `json:"-"`
+}
+
+// InventoryResyncConf holds the configuration of the scheduled resynchronization of the BMC inventories
+type InventoryResyncConf struct {
+	IntervalInMinutes           int            `json:"IntervalInMinutes"`           // holds the interval of the resync of all the servers, 0 disables it
+	AggregateIntervalsInMinutes map[string]int `json:"AggregateIntervalsInMinutes"` // holds the interval of the resync of the servers of an aggregate, keyed by aggregate ID
+	TaskUserName                string         `json:"TaskUserName"`                // holds the user owning the tasks of the scheduled resyncs
+}
+
+// InventoryHistoryConf holds the configuration of the revisions of the server inventories kept in the on-disk DB
+type InventoryHistoryConf struct {
+	MaxRevisions               int      `json:"MaxRevisions"`               // holds the maximum number of revisions kept for a BMC
+	RetentionDays              int      `json:"RetentionDays"`              // holds the number of days for which the revisions are kept, the latest revision is always kept
+	HardwareComponentResources []string `json:"HardwareComponentResources"` // holds the resources whose additions and removals raise a hardware component event
+}
+
 // PluginTasksConf stores the information related to plugin tasks
 // and queueing and prioritization of requests to plugin
 type PluginTasksConf struct {
@@ -267,7 +329,18 @@ func ValidateConfiguration() (WarningList, error) {
 	if err = checkPluginTaskConfiguration(); err != nil {
 		return *warningList, err
 	}
-	checkAuthConf(warningList)
+	if err = checkTracingConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkDiscoveryConf(warningList); err != nil {
+		return *warningList, err
+	}
+	if err = checkAuthConf(warningList); err != nil {
+		return *warningList, err
+	}
+	checkAuditLogConf(warningList)
+	checkInventoryResyncConf(warningList)
+	checkInventoryHistoryConf(warningList)
 	checkAddComputeSkipResources(warningList)
 	checkURLTranslation(warningList)
 	checkPluginStatusPolling(warningList)
@@ -293,6 +366,11 @@ func checkMiscellaneousConf(wl *WarningList) error {
 	if _, err := os.Stat(Data.SearchAndFilterSchemaPath); err != nil {
 		return fmt.Errorf("error: value check failed for SearchAndFilterSchemaPath:%s with %v", Data.SearchAndFilterSchemaPath, err)
 	}
+	if Data.ResourceFilterSchemaPath == "" {
+		wl.add("No value set for ResourceFilterSchemaPath, $filter will not be supported for Chassis, Managers, Tasks and EventSubscriptions")
+	} else if _, err := os.Stat(Data.ResourceFilterSchemaPath); err != nil {
+		return fmt.Errorf("error: value check failed for ResourceFilterSchemaPath:%s with %v", Data.ResourceFilterSchemaPath, err)
+	}
 	if _, err := os.Stat(Data.RegistryStorePath); err != nil {
 		return fmt.Errorf("error: value check failed for RegistryStorePath:%s with %v", Data.RegistryStorePath, err)
 	}
@@ -310,6 +388,14 @@ func checkMiscellaneousConf(wl *WarningList) error {
 		wl.add("No value configured for EventSaveWorkerPoolCount, setting default value")
 		Data.EventSaveWorkerPoolCount = DefaultEventSaveWorkerPoolCount
 	}
+	if Data.ImportSourcesWorkerPoolCount <= 0 {
+		wl.add("No value configured for ImportSourcesWorkerPoolCount, setting default value")
+		Data.ImportSourcesWorkerPoolCount = DefaultImportSourcesWorkerPoolCount
+	}
+	if Data.CredentialRotationWorkerPoolCount <= 0 {
+		wl.add("No value configured for CredentialRotationWorkerPoolCount, setting default value")
+		Data.CredentialRotationWorkerPoolCount = DefaultCredentialRotationWorkerPoolCount
+	}
 	return nil
 }
 
@@ -480,19 +566,22 @@ func checkKeyCertConf() error {
 	return nil
 }
 
-func checkAuthConf(wl *WarningList) {
+func checkAuthConf(wl *WarningList) error {
 	if Data.AuthConf == nil {
 		wl.add("No value found for AuthConf, setting default value")
 		Data.AuthConf = &AuthConf{
 			SessionTimeOutInMins:            DefaultSessionTimeOutInMins,
 			ExpiredSessionCleanUpTimeInMins: DefaultExpiredSessionCleanUpTimeInMins,
+			AccountLockoutThreshold:         DefaultAccountLockoutThreshold,
+			AccountLockoutDuration:          DefaultAccountLockoutDuration,
+			AccountLockoutCounterResetAfter: DefaultAccountLockoutCounterResetAfter,
 			PasswordRules: &PasswordRules{
 				MinPasswordLength:       DefaultMinPasswordLength,
 				MaxPasswordLength:       DefaultMaxPasswordLength,
 				AllowedSpecialCharcters: DefaultAllowedSpecialCharcters,
 			},
 		}
-		return
+		return nil
 	}
 	if Data.AuthConf.SessionTimeOutInMins == 0 {
 		wl.add("No value set for SessionTimeOutInMin, setting default value")
@@ -502,7 +591,33 @@ func checkAuthConf(wl *WarningList) {
 		wl.add("No value set for ExpiredSessionCleanUpTimeInMins, setting default value")
 		Data.AuthConf.ExpiredSessionCleanUpTimeInMins = DefaultExpiredSessionCleanUpTimeInMins
 	}
+	if err := checkAccountLockoutConf(wl); err != nil {
+		return err
+	}
 	checkPasswordRulesConf(wl)
+	checkOEMPrivilegesConf(wl)
+	return nil
+}
+
+// checkAccountLockoutConf checks the account lockout configuration, an AccountLockoutThreshold of 0
+// disables the lockout of the accounts
+func checkAccountLockoutConf(wl *WarningList) error {
+	if Data.AuthConf.AccountLockoutThreshold < 0 {
+		return fmt.Errorf("error: invalid value %d set for AccountLockoutThreshold", Data.AuthConf.AccountLockoutThreshold)
+	}
+	if Data.AuthConf.AccountLockoutDuration <= 0 {
+		wl.add("No value set for AccountLockoutDuration, setting default value")
+		Data.AuthConf.AccountLockoutDuration = DefaultAccountLockoutDuration
+	}
+	if Data.AuthConf.AccountLockoutCounterResetAfter <= 0 {
+		wl.add("No value set for AccountLockoutCounterResetAfter, setting default value")
+		Data.AuthConf.AccountLockoutCounterResetAfter = DefaultAccountLockoutCounterResetAfter
+	}
+	if Data.AuthConf.AccountLockoutCounterResetAfter > Data.AuthConf.AccountLockoutDuration {
+		wl.add("AccountLockoutCounterResetAfter is greater than AccountLockoutDuration, setting AccountLockoutDuration value as AccountLockoutCounterResetAfter")
+		Data.AuthConf.AccountLockoutCounterResetAfter = Data.AuthConf.AccountLockoutDuration
+	}
+	return nil
 }
 
 func checkPasswordRulesConf(wl *WarningList) {
@@ -527,6 +642,34 @@ func checkPasswordRulesConf(wl *WarningList) {
 		wl.add("No value set for AllowedSpecialCharcters, setting default value")
 		Data.AuthConf.PasswordRules.AllowedSpecialCharcters = DefaultAllowedSpecialCharcters
 	}
+	if Data.AuthConf.PasswordRules.PasswordExpirationDays < 0 {
+		wl.add("Invalid value set for PasswordExpirationDays, disabling the password expiration")
+		Data.AuthConf.PasswordRules.PasswordExpirationDays = 0
+	}
+	if Data.AuthConf.PasswordRules.PasswordHistoryCount < 0 {
+		wl.add("Invalid value set for PasswordHistoryCount, disabling the password history")
+		Data.AuthConf.PasswordRules.PasswordHistoryCount = 0
+	}
+}
+
+// checkOEMPrivilegesConf removes the empty and duplicate OEM privileges,
+// and the ones which are Redfish privileges
+func checkOEMPrivilegesConf(wl *WarningList) {
+	var oemPrivileges []string
+	existing := make(map[string]bool)
+	for _, privilege := range redfishPrivileges {
+		existing[privilege] = true
+	}
+	for _, privilege := range Data.AuthConf.OEMPrivileges {
+		privilege = strings.TrimSpace(privilege)
+		if privilege == "" || existing[privilege] {
+			wl.add(fmt.Sprintf("Invalid or duplicate value %q set in OEMPrivileges, ignoring it", privilege))
+			continue
+		}
+		existing[privilege] = true
+		oemPrivileges = append(oemPrivileges, privilege)
+	}
+	Data.AuthConf.OEMPrivileges = oemPrivileges
 }
 
 func checkAPIGatewayConf() error {
@@ -720,6 +863,139 @@ func checkEventConf(wl *WarningList) error {
 	return nil
 }
 
+func checkTracingConf(wl *WarningList) error {
+	if Data.TracingConf == nil || Data.TracingConf.Exporter == "" {
+		wl.add("No value found for TracingConf, the trace context will be propagated without exporting the spans")
+		Data.TracingConf = &TracingConf{
+			Exporter: DefaultTraceExporter,
+		}
+		return nil
+	}
+	switch Data.TracingConf.Exporter {
+	case "None", "Stdout":
+	case "File":
+		if Data.TracingConf.FilePath == "" {
+			return fmt.Errorf("error: no value set for TracingConf.FilePath, which is required by the File exporter")
+		}
+	default:
+		return fmt.Errorf("error: invalid value %s for TracingConf.Exporter, supported values are None, Stdout and File", Data.TracingConf.Exporter)
+	}
+	return nil
+}
+
+func checkAuditLogConf(wl *WarningList) {
+	if Data.AuditLogConf == nil {
+		wl.add("No value found for AuditLogConf, setting default value")
+		Data.AuditLogConf = &AuditLogConf{
+			RetentionDays: DefaultAuditLogRetentionDays,
+		}
+		return
+	}
+	if Data.AuditLogConf.RetentionDays <= 0 {
+		wl.add("No value set for AuditLogConf.RetentionDays, setting default value")
+		Data.AuditLogConf.RetentionDays = DefaultAuditLogRetentionDays
+	}
+}
+
+func checkDiscoveryConf(wl *WarningList) error {
+	if Data.DiscoveryConf == nil {
+		wl.add("No value found for DiscoveryConf, setting default value")
+		Data.DiscoveryConf = &DiscoveryConf{}
+	}
+	if Data.DiscoveryConf.WorkerPoolCount <= 0 {
+		wl.add("No value set for DiscoveryConf.WorkerPoolCount, setting default value")
+		Data.DiscoveryConf.WorkerPoolCount = DefaultDiscoveryWorkerPoolCount
+	}
+	if Data.DiscoveryConf.ProbeTimeoutInSeconds <= 0 {
+		wl.add("No value set for DiscoveryConf.ProbeTimeoutInSeconds, setting default value")
+		Data.DiscoveryConf.ProbeTimeoutInSeconds = DefaultDiscoveryProbeTimeoutInSeconds
+	}
+	if Data.DiscoveryConf.MaxAddressesPerRequest <= 0 {
+		wl.add("No value set for DiscoveryConf.MaxAddressesPerRequest, setting default value")
+		Data.DiscoveryConf.MaxAddressesPerRequest = DefaultDiscoveryMaxAddressesPerRequest
+	}
+	if Data.DiscoveryConf.RestrictedSubnets == nil {
+		wl.add("No value set for DiscoveryConf.RestrictedSubnets, setting default value")
+		Data.DiscoveryConf.RestrictedSubnets = []string{DefaultDiscoveryRestrictedSubnet}
+	}
+	for _, subnets := range [][]string{Data.DiscoveryConf.RestrictedSubnets, Data.DiscoveryConf.AllowedSubnets} {
+		for _, subnet := range subnets {
+			if _, _, err := net.ParseCIDR(subnet); err != nil {
+				return fmt.Errorf("error: invalid subnet %s in DiscoveryConf: %v", subnet, err)
+			}
+		}
+	}
+	names := make(map[string]bool)
+	for i, profile := range Data.DiscoveryConf.CredentialProfiles {
+		if profile.Name == "" || profile.UserName == "" || profile.PasswordFilePath == "" {
+			return fmt.Errorf("error: Name, UserName and PasswordFilePath are required for the DiscoveryConf.CredentialProfiles")
+		}
+		if names[profile.Name] {
+			return fmt.Errorf("error: duplicate credential profile %s in DiscoveryConf.CredentialProfiles", profile.Name)
+		}
+		names[profile.Name] = true
+		password, err := decryptRSAOAEPEncryptedPasswords(profile.PasswordFilePath)
+		if err != nil {
+			return fmt.Errorf("error: while decrypting password of the credential profile %s from the passwordFilePath:%s with %v", profile.Name, profile.PasswordFilePath, err)
+		}
+		Data.DiscoveryConf.CredentialProfiles[i].Password = password
+	}
+	return nil
+}
+
+func checkInventoryResyncConf(wl *WarningList) {
+	if Data.InventoryResyncConf == nil {
+		wl.add("No value found for InventoryResyncConf, setting default value")
+		Data.InventoryResyncConf = &InventoryResyncConf{}
+	}
+	if Data.InventoryResyncConf.IntervalInMinutes < 0 {
+		wl.add("Invalid value set for InventoryResyncConf.IntervalInMinutes, disabling the scheduled resync of all the servers")
+		Data.InventoryResyncConf.IntervalInMinutes = 0
+	}
+	for aggregateID, interval := range Data.InventoryResyncConf.AggregateIntervalsInMinutes {
+		if interval <= 0 {
+			wl.add("Invalid value set for InventoryResyncConf.AggregateIntervalsInMinutes of the aggregate " + aggregateID + ", disabling its scheduled resync")
+			delete(Data.InventoryResyncConf.AggregateIntervalsInMinutes, aggregateID)
+		}
+	}
+	if Data.InventoryResyncConf.TaskUserName == "" {
+		wl.add("No value set for InventoryResyncConf.TaskUserName, setting default value")
+		Data.InventoryResyncConf.TaskUserName = DefaultInventoryResyncTaskUserName
+	}
+}
+
+func checkInventoryHistoryConf(wl *WarningList) {
+	if Data.InventoryHistoryConf == nil {
+		wl.add("No value found for InventoryHistoryConf, setting default value")
+		Data.InventoryHistoryConf = &InventoryHistoryConf{}
+	}
+	if Data.InventoryHistoryConf.MaxRevisions <= 0 {
+		wl.add("No value set for InventoryHistoryConf.MaxRevisions, setting default value")
+		Data.InventoryHistoryConf.MaxRevisions = DefaultInventoryHistoryMaxRevisions
+	}
+	if Data.InventoryHistoryConf.RetentionDays <= 0 {
+		wl.add("No value set for InventoryHistoryConf.RetentionDays, setting default value")
+		Data.InventoryHistoryConf.RetentionDays = DefaultInventoryHistoryRetentionDays
+	}
+	if len(Data.InventoryHistoryConf.HardwareComponentResources) == 0 {
+		wl.add("No value set for InventoryHistoryConf.HardwareComponentResources, setting default value")
+		Data.InventoryHistoryConf.HardwareComponentResources = DefaultHardwareComponentResources
+	}
+}
+
+// GetCredentialProfile returns the credential profile of the network discovery with the given name
+func GetCredentialProfile(name string) (CredentialProfile, bool) {
+	if Data.DiscoveryConf == nil {
+		return CredentialProfile{}, false
+	}
+	for _, profile := range Data.DiscoveryConf.CredentialProfiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return CredentialProfile{}, false
+}
+
 func checkResourceRateLimit() error {
 	for _, val := range Data.ResourceRateLimit {
 		resourceLimit := strings.Split(val, ":")
@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -593,6 +594,60 @@ func TestValidateConfigurationGroup3(t *testing.T) {
 	os.Remove(sampleFileForTest)
 }
 
+func TestValidateConfigurationForTracingConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name         string
+		conf         *TracingConf
+		wantExporter string
+		wantErr      bool
+	}{
+		{
+			name:         "Tracing conf not provided, setting to default",
+			conf:         nil,
+			wantExporter: DefaultTraceExporter,
+			wantErr:      false,
+		},
+		{
+			name:         "Stdout exporter",
+			conf:         &TracingConf{Exporter: "Stdout"},
+			wantExporter: "Stdout",
+			wantErr:      false,
+		},
+		{
+			name:         "File exporter",
+			conf:         &TracingConf{Exporter: "File", FilePath: "/var/log/odimra/traces.json"},
+			wantExporter: "File",
+			wantErr:      false,
+		},
+		{
+			name:    "File exporter without file path",
+			conf:    &TracingConf{Exporter: "File"},
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported exporter",
+			conf:    &TracingConf{Exporter: "Jaeger"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.TracingConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TestValidateConfigurationForTracingConf()  = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && Data.TracingConf.Exporter != tt.wantExporter {
+				t.Errorf("TestValidateConfigurationForTracingConf() exporter = %v, want %v", Data.TracingConf.Exporter, tt.wantExporter)
+			}
+		})
+	}
+	Data.TracingConf = nil
+	os.Remove(sampleFileForTest)
+}
+
 func TestValidateConfigurationForEventConf(t *testing.T) {
 	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
 	createFile(t, sampleFileForTest, sampleFileContent)
@@ -627,3 +682,241 @@ func TestValidateConfigurationForEventConf(t *testing.T) {
 	}
 	os.Remove(sampleFileForTest)
 }
+
+func TestCheckAccountLockoutConf(t *testing.T) {
+	tests := []struct {
+		name          string
+		threshold     int
+		wantThreshold int
+		wantErr       bool
+	}{
+		{name: "lockout threshold", threshold: 3, wantThreshold: 3},
+		{name: "lockout disabled", threshold: 0, wantThreshold: 0},
+		{name: "negative lockout threshold", threshold: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Data.AuthConf = &AuthConf{AccountLockoutThreshold: tt.threshold}
+			var wl WarningList
+			err := checkAccountLockoutConf(&wl)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkAccountLockoutConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && Data.AuthConf.AccountLockoutThreshold != tt.wantThreshold {
+				t.Errorf("checkAccountLockoutConf() AccountLockoutThreshold = %v, want %v", Data.AuthConf.AccountLockoutThreshold, tt.wantThreshold)
+			}
+		})
+	}
+}
+
+func TestCheckOEMPrivilegesConf(t *testing.T) {
+	Data.AuthConf = &AuthConf{
+		OEMPrivileges: []string{"ConfigureFirmware", " ExecuteReset ", "", "Login", "ConfigureFirmware"},
+	}
+	var wl WarningList
+	checkOEMPrivilegesConf(&wl)
+	want := []string{"ConfigureFirmware", "ExecuteReset"}
+	if !reflect.DeepEqual(Data.AuthConf.OEMPrivileges, want) {
+		t.Errorf("checkOEMPrivilegesConf() OEMPrivileges = %v, want %v", Data.AuthConf.OEMPrivileges, want)
+	}
+	if len(wl) != 3 {
+		t.Errorf("checkOEMPrivilegesConf() warnings = %v, want 3 warnings", wl)
+	}
+}
+
+func TestValidateConfigurationForAuditLogConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name              string
+		conf              *AuditLogConf
+		wantRetentionDays int
+	}{
+		{
+			name:              "Audit log conf not provided, setting to default",
+			conf:              nil,
+			wantRetentionDays: DefaultAuditLogRetentionDays,
+		},
+		{
+			name:              "Retention days not provided, setting to default",
+			conf:              &AuditLogConf{RecordReadOperations: true},
+			wantRetentionDays: DefaultAuditLogRetentionDays,
+		},
+		{
+			name:              "Retention days provided",
+			conf:              &AuditLogConf{RetentionDays: 7},
+			wantRetentionDays: 7,
+		},
+	}
+	for _, tt := range tests {
+		Data.AuditLogConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateConfiguration(); err != nil {
+				t.Errorf("TestValidateConfigurationForAuditLogConf() error = %v", err)
+			}
+			if Data.AuditLogConf.RetentionDays != tt.wantRetentionDays {
+				t.Errorf("TestValidateConfigurationForAuditLogConf() retention days = %v, want %v", Data.AuditLogConf.RetentionDays, tt.wantRetentionDays)
+			}
+		})
+	}
+	Data.AuditLogConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForDiscoveryConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name                string
+		conf                *DiscoveryConf
+		wantWorkerPoolCount int
+		wantErr             bool
+	}{
+		{
+			name:                "Discovery conf not provided, setting to default",
+			conf:                nil,
+			wantWorkerPoolCount: DefaultDiscoveryWorkerPoolCount,
+		},
+		{
+			name:                "Worker pool count provided",
+			conf:                &DiscoveryConf{WorkerPoolCount: 8},
+			wantWorkerPoolCount: 8,
+		},
+		{
+			name:    "Credential profile without password",
+			conf:    &DiscoveryConf{CredentialProfiles: []CredentialProfile{{Name: "default", UserName: "admin"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Credential profile with invalid password file",
+			conf:    &DiscoveryConf{CredentialProfiles: []CredentialProfile{{Name: "default", UserName: "admin", PasswordFilePath: "/tmp/invalid"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid restricted subnet",
+			conf:    &DiscoveryConf{RestrictedSubnets: []string{"10.233.0.0"}},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid allowed subnet",
+			conf:    &DiscoveryConf{AllowedSubnets: []string{"127.0.0.1/33"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		Data.DiscoveryConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateConfiguration()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TestValidateConfigurationForDiscoveryConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if Data.DiscoveryConf.WorkerPoolCount != tt.wantWorkerPoolCount {
+				t.Errorf("TestValidateConfigurationForDiscoveryConf() worker pool count = %v, want %v", Data.DiscoveryConf.WorkerPoolCount, tt.wantWorkerPoolCount)
+			}
+			if Data.DiscoveryConf.ProbeTimeoutInSeconds != DefaultDiscoveryProbeTimeoutInSeconds ||
+				Data.DiscoveryConf.MaxAddressesPerRequest != DefaultDiscoveryMaxAddressesPerRequest ||
+				len(Data.DiscoveryConf.RestrictedSubnets) != 1 || Data.DiscoveryConf.RestrictedSubnets[0] != DefaultDiscoveryRestrictedSubnet {
+				t.Errorf("TestValidateConfigurationForDiscoveryConf() = %+v, want default values", Data.DiscoveryConf)
+			}
+		})
+	}
+	Data.DiscoveryConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForInventoryResyncConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name string
+		conf *InventoryResyncConf
+		want InventoryResyncConf
+	}{
+		{
+			name: "Inventory resync conf not provided, setting to default",
+			conf: nil,
+			want: InventoryResyncConf{TaskUserName: DefaultInventoryResyncTaskUserName},
+		},
+		{
+			name: "Negative interval disables the global resync",
+			conf: &InventoryResyncConf{IntervalInMinutes: -5, TaskUserName: "operator"},
+			want: InventoryResyncConf{TaskUserName: "operator"},
+		},
+		{
+			name: "Invalid aggregate interval is removed",
+			conf: &InventoryResyncConf{IntervalInMinutes: 60, AggregateIntervalsInMinutes: map[string]int{"agg1": 15, "agg2": 0}},
+			want: InventoryResyncConf{IntervalInMinutes: 60, AggregateIntervalsInMinutes: map[string]int{"agg1": 15}, TaskUserName: DefaultInventoryResyncTaskUserName},
+		},
+	}
+	for _, tt := range tests {
+		Data.InventoryResyncConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateConfiguration(); err != nil {
+				t.Fatalf("TestValidateConfigurationForInventoryResyncConf() error = %v", err)
+			}
+			got := Data.InventoryResyncConf
+			if got.IntervalInMinutes != tt.want.IntervalInMinutes || got.TaskUserName != tt.want.TaskUserName ||
+				len(got.AggregateIntervalsInMinutes) != len(tt.want.AggregateIntervalsInMinutes) {
+				t.Errorf("TestValidateConfigurationForInventoryResyncConf() = %+v, want %+v", *got, tt.want)
+			}
+			for aggregateID, interval := range tt.want.AggregateIntervalsInMinutes {
+				if got.AggregateIntervalsInMinutes[aggregateID] != interval {
+					t.Errorf("TestValidateConfigurationForInventoryResyncConf() interval of %s = %v, want %v", aggregateID, got.AggregateIntervalsInMinutes[aggregateID], interval)
+				}
+			}
+		})
+	}
+	Data.InventoryResyncConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestValidateConfigurationForInventoryHistoryConf(t *testing.T) {
+	sampleFileForTest := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, sampleFileForTest, sampleFileContent)
+	tests := []struct {
+		name string
+		conf *InventoryHistoryConf
+		want InventoryHistoryConf
+	}{
+		{
+			name: "Inventory history conf not provided, setting to default",
+			conf: nil,
+			want: InventoryHistoryConf{
+				MaxRevisions:               DefaultInventoryHistoryMaxRevisions,
+				RetentionDays:              DefaultInventoryHistoryRetentionDays,
+				HardwareComponentResources: DefaultHardwareComponentResources,
+			},
+		},
+		{
+			name: "Inventory history conf provided",
+			conf: &InventoryHistoryConf{MaxRevisions: 5, RetentionDays: 7, HardwareComponentResources: []string{"Memory"}},
+			want: InventoryHistoryConf{MaxRevisions: 5, RetentionDays: 7, HardwareComponentResources: []string{"Memory"}},
+		},
+	}
+	for _, tt := range tests {
+		Data.InventoryHistoryConf = tt.conf
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateConfiguration(); err != nil {
+				t.Fatalf("TestValidateConfigurationForInventoryHistoryConf() error = %v", err)
+			}
+			if !reflect.DeepEqual(*Data.InventoryHistoryConf, tt.want) {
+				t.Errorf("TestValidateConfigurationForInventoryHistoryConf() = %+v, want %+v", *Data.InventoryHistoryConf, tt.want)
+			}
+		})
+	}
+	Data.InventoryHistoryConf = nil
+	os.Remove(sampleFileForTest)
+}
+
+func TestGetCredentialProfile(t *testing.T) {
+	SetUpMockConfig(t)
+	if profile, ok := GetCredentialProfile("default"); !ok || profile.UserName != "admin" {
+		t.Errorf("GetCredentialProfile() = %v, %v", profile, ok)
+	}
+	if _, ok := GetCredentialProfile("unknown"); ok {
+		t.Errorf("GetCredentialProfile() found an unknown profile")
+	}
+}
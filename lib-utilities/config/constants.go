@@ -19,6 +19,9 @@ package config
 
 import "crypto/tls"
 
+// redfishPrivileges are the privileges defined by Redfish, which cannot be configured as OEM privileges
+var redfishPrivileges = []string{"Login", "ConfigureManager", "ConfigureUsers", "ConfigureSelf", "ConfigureComponents"}
+
 // Host defines if the application is Server or client
 type Host int8
 
@@ -98,11 +101,35 @@ const (
 	DefaultEventForwardingWorkerPoolCount = 1000
 	//DefaultEventSaveWorkerPoolCount - default EventSaveWorkerPoolCount value
 	DefaultEventSaveWorkerPoolCount = 10
+	// DefaultImportSourcesWorkerPoolCount - default ImportSourcesWorkerPoolCount value
+	DefaultImportSourcesWorkerPoolCount = 10
+	// DefaultCredentialRotationWorkerPoolCount - default CredentialRotationWorkerPoolCount value
+	DefaultCredentialRotationWorkerPoolCount = 10
+	// DefaultTraceExporter - default TracingConf.Exporter value
+	DefaultTraceExporter = "None"
+	// DefaultAuditLogRetentionDays - default AuditLogConf.RetentionDays value
+	DefaultAuditLogRetentionDays = 30
+	// DefaultDiscoveryWorkerPoolCount - default DiscoveryConf.WorkerPoolCount value
+	DefaultDiscoveryWorkerPoolCount = 64
+	// DefaultDiscoveryProbeTimeoutInSeconds - default DiscoveryConf.ProbeTimeoutInSeconds value
+	DefaultDiscoveryProbeTimeoutInSeconds = 5
+	// DefaultDiscoveryMaxAddressesPerRequest - default DiscoveryConf.MaxAddressesPerRequest value
+	DefaultDiscoveryMaxAddressesPerRequest = 4096
+	// DefaultDiscoveryRestrictedSubnet - default DiscoveryConf.RestrictedSubnets value, the service CIDR of the kubespray clusters
+	DefaultDiscoveryRestrictedSubnet = "10.233.0.0/18"
+	// DefaultInventoryResyncTaskUserName - default InventoryResyncConf.TaskUserName value
+	DefaultInventoryResyncTaskUserName = "admin"
+	// DefaultInventoryHistoryMaxRevisions - default InventoryHistoryConf.MaxRevisions value
+	DefaultInventoryHistoryMaxRevisions = 10
+	// DefaultInventoryHistoryRetentionDays - default InventoryHistoryConf.RetentionDays value
+	DefaultInventoryHistoryRetentionDays = 90
 )
 
 var (
 	// DefaultSkipListUnderSystem - holds the default list of resources which needs to be ignored for storing in DB under system resource
 	DefaultSkipListUnderSystem = []string{"Chassis", "LogServices", "Managers"}
+	// DefaultHardwareComponentResources - default InventoryHistoryConf.HardwareComponentResources value
+	DefaultHardwareComponentResources = []string{"Memory", "Processors", "NetworkAdapters", "Drives", "PCIeDevices", "PowerSupplies"}
 	// DefaultSkipListUnderManager - holds the default list of resources which needs to be ignored for storing in DB under manager resource
 	DefaultSkipListUnderManager = []string{"Chassis", "LogServices", "Systems"}
 	// DefaultSkipListUnderChassis - holds the default list of resources which needs to be ignored for storing in DB under chassis resource
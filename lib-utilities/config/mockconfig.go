@@ -233,6 +233,8 @@ func SetUpMockConfig(t *testing.T) error {
 	}
 	Data.EventForwardingWorkerPoolCount = 1
 	Data.EventSaveWorkerPoolCount = 1
+	Data.ImportSourcesWorkerPoolCount = 2
+	Data.CredentialRotationWorkerPoolCount = 2
 	Data.RegistryStorePath = basePath + "/lib-utilities/etc/"
 	Data.LocalhostFQDN = "odim.test.com"
 	Data.EnabledServices = []string{"SessionService", "AccountService", "EventService"}
@@ -262,10 +264,14 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.AuthConf = &AuthConf{
 		SessionTimeOutInMins:            30,
 		ExpiredSessionCleanUpTimeInMins: 15,
+		AccountLockoutThreshold:         5,
+		AccountLockoutDuration:          30,
+		AccountLockoutCounterResetAfter: 30,
 		PasswordRules: &PasswordRules{
 			MinPasswordLength:       12,
 			MaxPasswordLength:       16,
 			AllowedSpecialCharcters: "~!@#$%^&*-+_|(){}:;<>,.?/",
+			PasswordHistoryCount:    3,
 		},
 	}
 	Data.APIGatewayConf = &APIGatewayConf{
@@ -344,6 +350,34 @@ func SetUpMockConfig(t *testing.T) error {
 		DeliveryRetryAttempts:        1,
 		DeliveryRetryIntervalSeconds: 1,
 	}
+	Data.AuditLogConf = &AuditLogConf{
+		RetentionDays: 30,
+	}
+	Data.DiscoveryConf = &DiscoveryConf{
+		WorkerPoolCount:        4,
+		ProbeTimeoutInSeconds:  2,
+		MaxAddressesPerRequest: 256,
+		RestrictedSubnets:      []string{DefaultDiscoveryRestrictedSubnet},
+		VendorPlugins: map[string]string{
+			"HPE": "ILO",
+		},
+		CredentialProfiles: []CredentialProfile{
+			{
+				Name:     "default",
+				UserName: "admin",
+				Password: []byte("password"),
+			},
+		},
+	}
+	Data.InventoryResyncConf = &InventoryResyncConf{
+		AggregateIntervalsInMinutes: map[string]int{},
+		TaskUserName:                "admin",
+	}
+	Data.InventoryHistoryConf = &InventoryHistoryConf{
+		MaxRevisions:               10,
+		RetentionDays:              90,
+		HardwareComponentResources: []string{"Memory", "Processors", "NetworkAdapters", "Drives"},
+	}
 	Data.TaskQueueConf = &TaskQueueConf{
 		QueueSize:        1000,
 		DBCommitInterval: 1000,
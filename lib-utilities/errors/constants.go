@@ -74,6 +74,8 @@ const (
 	TransactionPartiallyFailed
 	// TimeoutError indicates that the operation is canceled as timeout error occurred.
 	TimeoutError
+	// PasswordChangeRequired indicates the password of the user must be changed before access is granted
+	PasswordChangeRequired
 )
 
 // constants defined for matching partial strings in error returned
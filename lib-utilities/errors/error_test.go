@@ -437,6 +437,19 @@ func TestPackError(t *testing.T) {
 			want1: http.StatusUnauthorized,
 			want2: response.NoValidSession,
 		},
+		{
+			name: "4. Postive case",
+			args: args{
+				errno:        PasswordChangeRequired,
+				errorMessage: errorMessage,
+			},
+			want: &Error{
+				errNum: PasswordChangeRequired,
+				errMsg: errorMessage,
+			},
+			want1: http.StatusForbidden,
+			want2: response.PasswordChangeRequired,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
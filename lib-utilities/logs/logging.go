@@ -136,14 +136,13 @@ func (l *Logging) auditLogEntry(ctx iris.Context, reqBody, fields map[string]int
 }
 
 // MaskRequestBody function
-// masking the request body, making password as null
+// masking the request body, making the passwords at any depth as null
+// and removing the CSV manifests, which contain passwords
 func MaskRequestBody(reqBody map[string]interface{}) string {
 	var jsonStr []byte
 	var err error
 	if len(reqBody) > 0 {
-		if reqBody["Password"] != nil {
-			reqBody["Password"] = "null"
-		}
+		maskSecrets(reqBody)
 		jsonStr, err = json.Marshal(reqBody)
 		if err != nil {
 			Log.Error("while marshalling request body", err.Error())
@@ -154,6 +153,30 @@ func MaskRequestBody(reqBody map[string]interface{}) string {
 	return reqStr
 }
 
+// maskSecrets masks the properties of the request body whose name contains password,
+// in the nested objects and arrays as well
+func maskSecrets(data interface{}) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, property := range value {
+			switch {
+			case key == "CSVManifest":
+				delete(value, key)
+			case strings.Contains(strings.ToLower(key), "password"):
+				if property != nil {
+					value[key] = "null"
+				}
+			default:
+				maskSecrets(property)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			maskSecrets(item)
+		}
+	}
+}
+
 // getResponseStatus function
 // setting operation status flag based on the response code
 
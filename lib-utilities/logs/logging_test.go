@@ -0,0 +1,44 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package logs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMaskRequestBody(t *testing.T) {
+	var reqBody map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"UserName": "admin",
+		"Password": "secret1",
+		"LDAP": {"Authentication": {"Username": "cn=admin", "Password": "secret2"}},
+		"Sources": [{"HostName": "10.0.0.1", "Password": "secret3"}],
+		"AggregationSources": [{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/uuid.1", "Password": "secret4"}],
+		"CSVManifest": "HostName,UserName,Password\n10.0.0.2,admin,secret5\n"
+	}`), &reqBody)
+	got := MaskRequestBody(reqBody)
+	for _, secret := range []string{"secret1", "secret2", "secret3", "secret4", "secret5", "CSVManifest"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("MaskRequestBody() = %s, contains %s", got, secret)
+		}
+	}
+	for _, value := range []string{"admin", "cn=admin", "10.0.0.1", "/redfish/v1/AggregationService/AggregationSources/uuid.1"} {
+		if !strings.Contains(got, value) {
+			t.Errorf("MaskRequestBody() = %s, doesn't contain %s", got, value)
+		}
+	}
+}
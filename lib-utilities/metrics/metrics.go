@@ -0,0 +1,447 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package metrics exposes the operational metrics of the ODIM services
+// in the Prometheus text exposition format
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// Path is the URI on which the metrics are exposed
+	Path = "/metrics"
+	// contentType is the content type of the Prometheus text exposition format
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+	// labelSeparator joins the label values of a series into its key
+	labelSeparator = "\xff"
+)
+
+// DefaultBuckets are the histogram buckets in seconds used for the request latencies
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// family is a metric family, which writes all of its series in the text format
+type family interface {
+	metricName() string
+	write(w io.Writer)
+}
+
+// Registry holds the metric families exposed by a service
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]family
+}
+
+// DefaultRegistry is the registry in which the metrics of this package are registered
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// register adds the metric family to the registry, it panics
+// if a family with the same name is already registered
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exist := r.families[f.metricName()]; exist {
+		panic("metrics: " + f.metricName() + " is already registered")
+	}
+	r.families[f.metricName()] = f
+}
+
+// Write writes all the metric families, sorted by name, in the text exposition format
+func (r *Registry) Write(w io.Writer) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	families := make([]family, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		families = append(families, r.families[name])
+	}
+	r.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	bw.Flush()
+}
+
+// Handler returns the http handler which serves the metrics of the registry
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		r.Write(w)
+	})
+}
+
+// Handler returns the http handler which serves the metrics of the DefaultRegistry
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+// ListenAndServe serves the metrics of the DefaultRegistry on the Path of the address.
+// It is used by the gRPC services, which expose the metrics on a side port
+func ListenAndServe(address string) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+	return server.ListenAndServe()
+}
+
+// desc holds the description shared by all the metric types
+type desc struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+}
+
+func (d *desc) metricName() string {
+	return d.name
+}
+
+// writeHeader writes the HELP and TYPE lines of the family
+func (d *desc) writeHeader(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.metricType)
+}
+
+// labels formats the label pairs of a series, extra holds an additional
+// name and value pair, such as the le label of the histogram buckets
+func (d *desc) labels(labelValues []string, extra ...string) string {
+	if len(d.labelNames) == 0 && len(extra) == 0 {
+		return ""
+	}
+	var pairs []string
+	for i, name := range d.labelNames {
+		pairs = append(pairs, name+`="`+escapeLabelValue(labelValues[i])+`"`)
+	}
+	if len(extra) == 2 {
+		pairs = append(pairs, extra[0]+`="`+escapeLabelValue(extra[1])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// key returns the key of the series, it panics if the number of label values is wrong
+func (d *desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, labelSeparator)
+}
+
+// series is a single time series of a counter or a gauge
+type series struct {
+	labelValues []string
+	value       float64
+}
+
+// valueVec holds the series of a counter or a gauge family
+type valueVec struct {
+	desc
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+func newValueVec(name, help, metricType string, labelNames []string) *valueVec {
+	return &valueVec{
+		desc:   desc{name: name, help: help, metricType: metricType, labelNames: labelNames},
+		series: make(map[string]*series),
+	}
+}
+
+// update applies fn on the value of the series identified by the label values
+func (v *valueVec) update(fn func(float64) float64, labelValues []string) {
+	key := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, exist := v.series[key]
+	if !exist {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		v.series[key] = s
+	}
+	s.value = fn(s.value)
+}
+
+// value returns the value of the series identified by the label values
+func (v *valueVec) value(labelValues ...string) float64 {
+	key := v.key(labelValues)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if s, exist := v.series[key]; exist {
+		return s.value
+	}
+	return 0
+}
+
+func (v *valueVec) write(w io.Writer) {
+	v.mu.Lock()
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		s := v.series[key]
+		lines = append(lines, v.name+v.labels(s.labelValues)+" "+formatFloat(s.value)+"\n")
+	}
+	v.mu.Unlock()
+	v.writeHeader(w)
+	for _, line := range lines {
+		io.WriteString(w, line)
+	}
+}
+
+// CounterVec is a counter partitioned by label values
+type CounterVec struct {
+	*valueVec
+}
+
+// NewCounterVec creates a counter and registers it in the DefaultRegistry
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{newValueVec(name, help, "counter", labelNames)}
+	DefaultRegistry.register(c)
+	return c
+}
+
+// Inc increments the counter of the label values by one
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds the delta, which must not be negative, to the counter of the label values
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic("metrics: counter " + c.name + " can not decrease")
+	}
+	c.update(func(value float64) float64 { return value + delta }, labelValues)
+}
+
+// Value returns the current value of the counter of the label values
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	return c.value(labelValues...)
+}
+
+// GaugeVec is a gauge partitioned by label values
+type GaugeVec struct {
+	*valueVec
+}
+
+// NewGaugeVec creates a gauge and registers it in the DefaultRegistry
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	g := &GaugeVec{newValueVec(name, help, "gauge", labelNames)}
+	DefaultRegistry.register(g)
+	return g
+}
+
+// Set sets the gauge of the label values
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.update(func(float64) float64 { return value }, labelValues)
+}
+
+// Add adds the delta, which can be negative, to the gauge of the label values
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.update(func(value float64) float64 { return value + delta }, labelValues)
+}
+
+// Value returns the current value of the gauge of the label values
+func (g *GaugeVec) Value(labelValues ...string) float64 {
+	return g.value(labelValues...)
+}
+
+// Delete removes the series of the label values
+func (g *GaugeVec) Delete(labelValues ...string) {
+	key := g.key(labelValues)
+	g.mu.Lock()
+	delete(g.series, key)
+	g.mu.Unlock()
+}
+
+// FuncVec is a gauge or a counter family whose series are collected when the metrics are scraped
+type FuncVec struct {
+	desc
+	collect func(emit func(value float64, labelValues ...string))
+}
+
+// NewGaugeFunc creates a gauge collected on every scrape and registers it in the DefaultRegistry.
+// collect calls emit once for each series of the gauge
+func NewGaugeFunc(name, help string, labelNames []string, collect func(emit func(value float64, labelValues ...string))) *FuncVec {
+	return newFuncVec(name, help, "gauge", labelNames, collect)
+}
+
+// NewCounterFunc creates a counter collected on every scrape and registers it in the DefaultRegistry.
+// collect calls emit once for each series of the counter
+func NewCounterFunc(name, help string, labelNames []string, collect func(emit func(value float64, labelValues ...string))) *FuncVec {
+	return newFuncVec(name, help, "counter", labelNames, collect)
+}
+
+func newFuncVec(name, help, metricType string, labelNames []string, collect func(emit func(value float64, labelValues ...string))) *FuncVec {
+	f := &FuncVec{
+		desc:    desc{name: name, help: help, metricType: metricType, labelNames: labelNames},
+		collect: collect,
+	}
+	DefaultRegistry.register(f)
+	return f
+}
+
+func (f *FuncVec) write(w io.Writer) {
+	f.writeHeader(w)
+	f.collect(func(value float64, labelValues ...string) {
+		// key panics when the number of label values is wrong
+		f.key(labelValues)
+		io.WriteString(w, f.name+f.labels(labelValues)+" "+formatFloat(value)+"\n")
+	})
+}
+
+// histogramSeries holds the observations of a single series of a histogram
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// HistogramVec is a histogram partitioned by label values
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+// NewHistogramVec creates a histogram with the upper bounds of the buckets
+// and registers it in the DefaultRegistry
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, metricType: "histogram", labelNames: labelNames},
+		buckets: sorted,
+		series:  make(map[string]*histogramSeries),
+	}
+	DefaultRegistry.register(h)
+	return h
+}
+
+// Observe adds the value to the histogram of the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, exist := h.series[key]
+	if !exist {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+// Count returns the number of observations of the label values
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, exist := h.series[key]; exist {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var lines []string
+	for _, key := range keys {
+		s := h.series[key]
+		for i, upperBound := range h.buckets {
+			lines = append(lines, h.name+"_bucket"+h.labels(s.labelValues, "le", formatFloat(upperBound))+" "+strconv.FormatUint(s.counts[i], 10)+"\n")
+		}
+		lines = append(lines,
+			h.name+"_bucket"+h.labels(s.labelValues, "le", "+Inf")+" "+strconv.FormatUint(s.count, 10)+"\n",
+			h.name+"_sum"+h.labels(s.labelValues)+" "+formatFloat(s.sum)+"\n",
+			h.name+"_count"+h.labels(s.labelValues)+" "+strconv.FormatUint(s.count, 10)+"\n",
+		)
+	}
+	h.mu.Unlock()
+	h.writeHeader(w)
+	for _, line := range lines {
+		io.WriteString(w, line)
+	}
+}
+
+// formatFloat formats the sample value as expected by the text exposition format
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelEscaper.Replace(value)
+}
@@ -0,0 +1,192 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func scrape(t *testing.T) string {
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("failed to scrape the metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if got := resp.Header.Get("Content-Type"); got != contentType {
+		t.Errorf("expected content type %q, got %q", contentType, got)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	return string(body)
+}
+
+func assertContains(t *testing.T, body string, lines ...string) {
+	for _, line := range lines {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("expected line %q in the metrics:\n%s", line, body)
+		}
+	}
+}
+
+func TestCounterVec(t *testing.T) {
+	counter := NewCounterVec("test_counter_total", "Test counter", "method")
+	counter.Inc("GET")
+	counter.Add(2, "GET")
+	counter.Inc(`PO"ST`)
+	if got := counter.Value("GET"); got != 3 {
+		t.Errorf("expected 3, got %v", got)
+	}
+	assertContains(t, scrape(t),
+		"# HELP test_counter_total Test counter",
+		"# TYPE test_counter_total counter",
+		`test_counter_total{method="GET"} 3`,
+		`test_counter_total{method="PO\"ST"} 1`,
+	)
+}
+
+func TestGaugeVec(t *testing.T) {
+	gauge := NewGaugeVec("test_gauge", "Test\ngauge")
+	gauge.Set(5)
+	gauge.Add(-1.5)
+	assertContains(t, scrape(t),
+		`# HELP test_gauge Test\ngauge`,
+		"# TYPE test_gauge gauge",
+		"test_gauge 3.5",
+	)
+
+	labelled := NewGaugeVec("test_labelled_gauge", "Test gauge", "plugin")
+	labelled.Set(1, "GRF")
+	labelled.Delete("GRF")
+	if body := scrape(t); strings.Contains(body, `test_labelled_gauge{plugin="GRF"}`) {
+		t.Errorf("deleted series is still exposed:\n%s", body)
+	}
+}
+
+func TestHistogramVec(t *testing.T) {
+	histogram := NewHistogramVec("test_duration_seconds", "Test histogram", []float64{1, 0.1}, "route")
+	histogram.Observe(0.05, "/redfish/v1")
+	histogram.Observe(0.5, "/redfish/v1")
+	histogram.Observe(5, "/redfish/v1")
+	if got := histogram.Count("/redfish/v1"); got != 3 {
+		t.Errorf("expected 3 observations, got %v", got)
+	}
+	assertContains(t, scrape(t),
+		"# TYPE test_duration_seconds histogram",
+		`test_duration_seconds_bucket{route="/redfish/v1",le="0.1"} 1`,
+		`test_duration_seconds_bucket{route="/redfish/v1",le="1"} 2`,
+		`test_duration_seconds_bucket{route="/redfish/v1",le="+Inf"} 3`,
+		`test_duration_seconds_sum{route="/redfish/v1"} 5.55`,
+		`test_duration_seconds_count{route="/redfish/v1"} 3`,
+	)
+}
+
+func TestWrongLabelValues(t *testing.T) {
+	counter := NewCounterVec("test_wrong_labels_total", "Test counter", "method")
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on wrong number of label values")
+		}
+	}()
+	counter.Inc("GET", "200")
+}
+
+func TestDuplicateRegistration(t *testing.T) {
+	NewGaugeVec("test_duplicate", "Test gauge")
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate registration")
+		}
+	}()
+	NewGaugeVec("test_duplicate", "Test gauge")
+}
+
+func TestRedisPoolMetrics(t *testing.T) {
+	defer func(fn func(persistencemgr.DbType) map[string]poolStats) { poolStatsFunc = fn }(poolStatsFunc)
+	poolStatsFunc = func(dbFlag persistencemgr.DbType) map[string]poolStats {
+		if dbFlag != persistencemgr.InMemory {
+			return nil
+		}
+		return map[string]poolStats{
+			"read": {Hits: 10, Misses: 2, Timeouts: 1, TotalConns: 4, IdleConns: 3, StaleConns: 5},
+		}
+	}
+	assertContains(t, scrape(t),
+		`odim_redis_pool_connections{db="InMemory",pool="read",state="total"} 4`,
+		`odim_redis_pool_connections{db="InMemory",pool="read",state="idle"} 3`,
+		`odim_redis_pool_requests_total{db="InMemory",pool="read",result="hit"} 10`,
+		`odim_redis_pool_requests_total{db="InMemory",pool="read",result="miss"} 2`,
+		`odim_redis_pool_requests_total{db="InMemory",pool="read",result="timeout"} 1`,
+		`odim_redis_pool_stale_connections_total{db="InMemory",pool="read"} 5`,
+	)
+}
+
+func TestObserveHTTPRequest(t *testing.T) {
+	ObserveHTTPRequest("/redfish/v1/Systems/{id}", http.MethodGet, http.StatusNotFound, 20*time.Millisecond)
+	if got := HTTPRequests.Value("/redfish/v1/Systems/{id}", http.MethodGet, "404"); got != 1 {
+		t.Errorf("expected 1 request, got %v", got)
+	}
+	if got := HTTPRequestDuration.Count("/redfish/v1/Systems/{id}", http.MethodGet, "404"); got != 1 {
+		t.Errorf("expected 1 observation, got %v", got)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/systems.Systems/GetSystemResource"}
+	if _, err := UnaryServerInterceptor(context.Background(), nil, info, handler); status.Code(err) != codes.NotFound {
+		t.Errorf("expected the error of the handler, got %v", err)
+	}
+	if got := GRPCServerHandlingDuration.Count(info.FullMethod, codes.NotFound.String()); got != 1 {
+		t.Errorf("expected 1 observation, got %v", got)
+	}
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	if err := UnaryClientInterceptor(context.Background(), info.FullMethod, nil, nil, nil, invoker); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if got := GRPCClientHandlingDuration.Count(info.FullMethod, codes.OK.String()); got != 1 {
+		t.Errorf("expected 1 observation, got %v", got)
+	}
+}
+
+func TestHandlerMethodNotAllowed(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status code %d, got %d", http.StatusMethodNotAllowed, recorder.Code)
+	}
+	if got := recorder.Header().Get("Allow"); got != http.MethodGet {
+		t.Errorf("expected Allow header %q, got %q", http.MethodGet, got)
+	}
+}
@@ -0,0 +1,134 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metrics shared by all the services
+var (
+	// HTTPRequests counts the requests handled by the API gateway
+	HTTPRequests = NewCounterVec("odim_http_requests_total",
+		"Number of HTTP requests handled, partitioned by route, method and status code",
+		"route", "method", "status")
+	// HTTPRequestDuration holds the latencies of the requests handled by the API gateway
+	HTTPRequestDuration = NewHistogramVec("odim_http_request_duration_seconds",
+		"Latency of the HTTP requests, partitioned by route, method and status code",
+		DefaultBuckets, "route", "method", "status")
+	// GRPCServerHandlingDuration holds the latencies of the RPCs served by the service
+	GRPCServerHandlingDuration = NewHistogramVec("odim_grpc_server_handling_seconds",
+		"Latency of the gRPC calls served, partitioned by RPC and status code",
+		DefaultBuckets, "method", "code")
+	// GRPCClientHandlingDuration holds the latencies of the RPCs made by the service
+	GRPCClientHandlingDuration = NewHistogramVec("odim_grpc_client_handling_seconds",
+		"Latency of the gRPC calls made to the other services, partitioned by RPC and status code",
+		DefaultBuckets, "method", "code")
+)
+
+// redis connection pool metrics, collected from persistencemgr on every scrape
+var (
+	_ = NewGaugeFunc("odim_redis_pool_connections",
+		"Number of connections in the redis connection pools, partitioned by db, pool and state",
+		[]string{"db", "pool", "state"},
+		func(emit func(float64, ...string)) {
+			forEachPool(func(db, pool string, stats poolStats) {
+				emit(float64(stats.TotalConns), db, pool, "total")
+				emit(float64(stats.IdleConns), db, pool, "idle")
+			})
+		})
+	_ = NewCounterFunc("odim_redis_pool_requests_total",
+		"Number of connections requested from the redis connection pools, partitioned by db, pool and result",
+		[]string{"db", "pool", "result"},
+		func(emit func(float64, ...string)) {
+			forEachPool(func(db, pool string, stats poolStats) {
+				emit(float64(stats.Hits), db, pool, "hit")
+				emit(float64(stats.Misses), db, pool, "miss")
+				emit(float64(stats.Timeouts), db, pool, "timeout")
+			})
+		})
+	_ = NewCounterFunc("odim_redis_pool_stale_connections_total",
+		"Number of stale connections removed from the redis connection pools, partitioned by db and pool",
+		[]string{"db", "pool"},
+		func(emit func(float64, ...string)) {
+			forEachPool(func(db, pool string, stats poolStats) {
+				emit(float64(stats.StaleConns), db, pool)
+			})
+		})
+)
+
+// poolStats holds the statistics of a redis connection pool
+type poolStats struct {
+	Hits, Misses, Timeouts, TotalConns, IdleConns, StaleConns uint32
+}
+
+// poolStatsFunc returns the statistics of the connection pools of the db, keyed by the pool name.
+// It is a variable so that it can be replaced in the unit tests
+var poolStatsFunc = func(dbFlag persistencemgr.DbType) map[string]poolStats {
+	stats := make(map[string]poolStats)
+	for pool, s := range persistencemgr.PoolStats(dbFlag) {
+		stats[pool] = poolStats{
+			Hits:       s.Hits,
+			Misses:     s.Misses,
+			Timeouts:   s.Timeouts,
+			TotalConns: s.TotalConns,
+			IdleConns:  s.IdleConns,
+			StaleConns: s.StaleConns,
+		}
+	}
+	return stats
+}
+
+// forEachPool calls fn with the statistics of every connection pool of the InMemory and OnDisk DBs
+func forEachPool(fn func(db, pool string, stats poolStats)) {
+	for _, db := range []struct {
+		name   string
+		dbFlag persistencemgr.DbType
+	}{{"InMemory", persistencemgr.InMemory}, {"OnDisk", persistencemgr.OnDisk}} {
+		for pool, stats := range poolStatsFunc(db.dbFlag) {
+			fn(db.name, pool, stats)
+		}
+	}
+}
+
+// ObserveHTTPRequest records a request handled by the API gateway. route is the
+// route template, such as /redfish/v1/Systems/{id}, to keep the number of series bounded
+func ObserveHTTPRequest(route, method string, statusCode int, duration time.Duration) {
+	statusValue := strconv.Itoa(statusCode)
+	HTTPRequests.Inc(route, method, statusValue)
+	HTTPRequestDuration.Observe(duration.Seconds(), route, method, statusValue)
+}
+
+// UnaryServerInterceptor records the latency of every RPC served by the gRPC server
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	GRPCServerHandlingDuration.Observe(time.Since(start).Seconds(), info.FullMethod, status.Code(err).String())
+	return resp, err
+}
+
+// UnaryClientInterceptor records the latency of every RPC made by the gRPC client
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	GRPCClientHandlingDuration.Observe(time.Since(start).Seconds(), method, status.Code(err).String())
+	return err
+}
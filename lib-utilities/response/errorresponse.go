@@ -190,6 +190,22 @@ func (a *Args) CreateGenericErrorResponse() CommonError {
 					MessageArgs: errArg.MessageArgs,
 					Resolution:  "Provide a valid resource identifier and resubmit the request.",
 				})
+		case PasswordChangeRequired:
+			// the URI of the account is passed when known, the authorization of
+			// the other services does not know the account of the session
+			message := "The password provided for this account must be changed before access is granted. PATCH the Password property for this account to complete this process."
+			if len(errArg.MessageArgs) == 1 {
+				message = fmt.Sprintf("The password provided for this account must be changed before access is granted. PATCH the Password property for this account located at the target URI '%v' to complete this process.", errArg.MessageArgs[0])
+			}
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:   ErrorMessageOdataType,
+					MessageID:   errArg.StatusMessage,
+					Message:     message,
+					Severity:    "Critical",
+					MessageArgs: errArg.MessageArgs,
+					Resolution:  "Change the password for this account using a PATCH to the Password property at the URI provided.",
+				})
 		case NoValidSession:
 			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
 				Msg{
@@ -281,6 +297,15 @@ func (a *Args) CreateGenericErrorResponse() CommonError {
 					Severity:   "Warning",
 					Resolution: "Remove the query parameters and resubmit the request if the operation failed.",
 				})
+		case PreconditionFailed:
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:  ErrorMessageOdataType,
+					MessageID:  errArg.StatusMessage,
+					Message:    fmt.Sprintf("The ETag supplied did not match the ETag required to change this resource. %v", errArg.ErrorMessage),
+					Severity:   "Critical",
+					Resolution: "Try the operation again using the appropriate ETag.",
+				})
 		case ActionParameterNotSupported:
 			validateMessageArgs(errArg.MessageArgs, []string{"string", "string"}, actionParameterNotSupportedArgCount)
 			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
@@ -35,6 +35,8 @@ const (
 	Failure = BaseVersion + "Failed"
 	// InsufficientPrivilege defines the status message at the time of Insufficient Privileges
 	InsufficientPrivilege = BaseVersion + "InsufficientPrivilege"
+	// PasswordChangeRequired defines the status message when the password of the account must be changed
+	PasswordChangeRequired = BaseVersion + "PasswordChangeRequired"
 	// InternalError defines the status message at the time of Internal Error
 	InternalError = BaseVersion + "InternalError"
 	// PropertyMissing defines the status message at the time of Property Missing
@@ -63,6 +65,8 @@ const (
 	QueryCombinationInvalid = BaseVersion + "QueryCombinationInvalid"
 	// QueryNotSupported defines the status message at the time of not supported query
 	QueryNotSupported = BaseVersion + "QueryNotSupported"
+	// PreconditionFailed indicates that the ETag supplied did not match the ETag required to change the resource
+	PreconditionFailed = BaseVersion + "PreconditionFailed"
 	// ResourceRemoved is the message for successful removal of resource
 	ResourceRemoved = "ResourceEvent.1.2.1.ResourceRemoved"
 	// ResourceCreated is the message for successful creation of resource
@@ -37,6 +37,11 @@ func (r *Response) CreateGenericResponse(statusMessage string) {
 		r.Message = "The resource has been removed successfully."
 	case ResourceCreated:
 		r.Message = "The resource has been created successfully."
+	case PasswordChangeRequired:
+		r.NumberOfArgs = len(r.MessageArgs)
+		r.Severity = "Critical"
+		r.Message = fmt.Sprintf("The password provided for this account must be changed before access is granted. PATCH the Password property for this account located at the target URI '%v' to complete this process.", r.MessageArgs[0])
+		r.Resolution = "Change the password for this account using a PATCH to the Password property at the URI provided."
 	case TaskStarted:
 		r.NumberOfArgs = len(r.MessageArgs)
 		r.Message = fmt.Sprintf("The task with id %v has started.", r.MessageArgs[0])
@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
@@ -29,12 +31,35 @@ import (
 
 const (
 	clientConnectionErrMsg string = "Failed to create client connection: %v"
+
+	// sessionAggregatesTTL is the duration for which the aggregates of a session are reused.
+	// The aggregates of a session are set when it is created, they are read again only for
+	// removing the sessions which are deleted or expired from the cache
+	sessionAggregatesTTL = time.Minute
+	// maxCachedSessions is the maximum number of sessions whose aggregates are cached
+	maxCachedSessions = 10000
 )
 
+// cachedAggregates are the aggregates of a session which are reused until their expiry
+type cachedAggregates struct {
+	aggregates []string
+	expiry     time.Time
+}
+
+// sessionAggregates holds the aggregates of the sessions by the session token
+var sessionAggregates = struct {
+	sync.Mutex
+	sessions  map[string]cachedAggregates
+	pruneTime time.Time
+}{sessions: make(map[string]cachedAggregates)}
+
 // IsAuthorized is used to authorize the services using svc-account-session.
 // As parameters session token, privileges and oem privileges are passed.
 // A RPC call is made with these parameters to the Account-Session service
 // to check whether the session is valid and have all the privileges which are passed to it.
+// When the context carries the HTTP request, its URI and method are passed as well,
+// so that the privileges are resolved from the privilege registry. The privileges
+// passed are used when the registry does not define the privileges of the request.
 func IsAuthorized(ctx context.Context, sessionToken string, privileges, oemPrivileges []string) (errResponse.RPC, error) {
 	conn, err := ODIMService.Client(AccountSession)
 	if err != nil {
@@ -45,12 +70,18 @@ func IsAuthorized(ctx context.Context, sessionToken string, privileges, oemPrivi
 	asService := authproto.NewAuthorizationClient(conn)
 	ctxt := common.CreateNewRequestContext(ctx)
 	ctxt = common.CreateMetadata(ctxt)
+	requestURI, _ := ctx.Value(common.RequestURI).(string)
+	requestMethod, _ := ctx.Value(common.RequestMethod).(string)
+	requestProperties, _ := ctx.Value(common.RequestProperties).([]string)
 	response, err := asService.IsAuthorized(
 		ctxt,
 		&authproto.AuthRequest{
-			SessionToken:  sessionToken,
-			Privileges:    privileges,
-			Oemprivileges: oemPrivileges,
+			SessionToken:      sessionToken,
+			Privileges:        privileges,
+			Oemprivileges:     oemPrivileges,
+			RequestURI:        requestURI,
+			RequestMethod:     requestMethod,
+			RequestProperties: requestProperties,
 		},
 	)
 	if err != nil && response == nil {
@@ -108,6 +139,59 @@ func GetSessionUserRoleID(ctx context.Context, sessionToken string) (string, err
 	return response.RoleID, err
 }
 
+// GetSessionUserAggregates will get the aggregates the user of the session token is
+// restricted to by rpc call to account-session service. No aggregates are returned
+// when the user is not restricted. The aggregates got in the last sessionAggregatesTTL
+// for the session token are reused without the rpc call
+func GetSessionUserAggregates(ctx context.Context, sessionToken string) ([]string, error) {
+	now := time.Now()
+	sessionAggregates.Lock()
+	cached, ok := sessionAggregates.sessions[sessionToken]
+	sessionAggregates.Unlock()
+	if ok && now.Before(cached.expiry) {
+		return cached.aggregates, nil
+	}
+	aggregates, err := getSessionUserAggregates(ctx, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	sessionAggregates.Lock()
+	defer sessionAggregates.Unlock()
+	if !now.Before(sessionAggregates.pruneTime) {
+		for token, c := range sessionAggregates.sessions {
+			if !now.Before(c.expiry) {
+				delete(sessionAggregates.sessions, token)
+			}
+		}
+		sessionAggregates.pruneTime = now.Add(sessionAggregatesTTL)
+	}
+	if len(sessionAggregates.sessions) < maxCachedSessions {
+		sessionAggregates.sessions[sessionToken] = cachedAggregates{aggregates: aggregates, expiry: now.Add(sessionAggregatesTTL)}
+	}
+	return aggregates, nil
+}
+
+func getSessionUserAggregates(ctx context.Context, sessionToken string) ([]string, error) {
+	conn, err := ODIMService.Client(AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf(clientConnectionErrMsg, err)
+	}
+	defer conn.Close()
+	asService := sessionproto.NewSessionClient(conn)
+	ctxt := common.CreateNewRequestContext(ctx)
+	ctxt = common.CreateMetadata(ctxt)
+	response, err := asService.GetSessionUserAggregates(
+		ctxt,
+		&sessionproto.SessionRequest{
+			SessionToken: sessionToken,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("something went wrong with rpc call: " + err.Error())
+	}
+	return response.Aggregates, nil
+}
+
 // GeneralError will create the error response
 // This function can be used only if the expected response have only
 // one extended info object. Error code for the response will be GeneralError
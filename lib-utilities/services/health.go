@@ -0,0 +1,114 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package services
+
+import (
+	"context"
+	"sync"
+
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Names of the dependencies which can be checked through the grpc.health.v1.Health
+// service of the micro services. An empty name reports the health of the micro service itself
+const (
+	// HealthDB is the name of the check on the InMemory and OnDisk databases
+	HealthDB = "odim.db"
+	// HealthMessageBus is the name of the check on the message bus
+	HealthMessageBus = "odim.messagebus"
+	// HealthPlugins is the name of the check on the plugins added to ODIM
+	HealthPlugins = "odim.plugins"
+)
+
+// ServiceRegistryNames maps the names used in EnabledServices of the
+// odim config to the names of the micro services in the service registry
+var ServiceRegistryNames = map[string]string{
+	"AccountService":     AccountSession,
+	"SessionService":     AccountSession,
+	"EventService":       Events,
+	"Systems":            Systems,
+	"Chassis":            Systems,
+	"TaskService":        Tasks,
+	"AggregationService": Aggregator,
+	"Fabrics":            Fabrics,
+	"Managers":           Managers,
+	"UpdateService":      Update,
+	"TelemetryService":   Telemetry,
+	"CompositionService": CompositionService,
+	"LicenseService":     Licenses,
+}
+
+// HealthCheck is the function which checks a dependency of the micro service.
+// A non nil error marks the dependency as not serving
+type HealthCheck func() error
+
+// healthChecks holds the checks registered by the micro service
+var healthChecks = struct {
+	sync.RWMutex
+	checks map[string]HealthCheck
+}{checks: map[string]HealthCheck{}}
+
+// RegisterHealthCheck adds the check of a dependency to the grpc.health.v1.Health
+// service of the micro service. The check is run each time its name is queried
+func RegisterHealthCheck(name string, check HealthCheck) {
+	healthChecks.Lock()
+	defer healthChecks.Unlock()
+	healthChecks.checks[name] = check
+}
+
+// healthServer implements the grpc.health.v1.Health service for the micro services
+type healthServer struct{}
+
+// Check reports SERVING for the micro service itself when it is queried without
+// a service name, else it runs the registered check of the queried dependency
+func (h healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.Service == "" {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+	}
+	healthChecks.RLock()
+	check, exist := healthChecks.checks[req.Service]
+	healthChecks.RUnlock()
+	if !exist {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.Service)
+	}
+	if err := check(); err != nil {
+		l.LogWithFields(ctx).Warn("Health check of " + req.Service + " failed: " + err.Error())
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+// Watch is not supported, the health of the dependencies is only checked on demand
+func (h healthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "watching the health is not supported")
+}
+
+// CheckHealth queries the grpc.health.v1.Health service of the micro service
+// registered with serviceName for the health of the dependency named healthService
+func CheckHealth(ctx context.Context, serviceName, healthService string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	conn, err := ODIMService.Client(serviceName)
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+	defer conn.Close()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: healthService})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+	return resp.Status, nil
+}
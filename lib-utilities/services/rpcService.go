@@ -27,11 +27,16 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	uuid "github.com/satori/go.uuid"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type serviceType int
@@ -72,6 +77,7 @@ func InitializeService(serviceName string, errChan chan<- error) error {
 		}
 
 		ODIMService.intiateSignalHandler(errChan)
+		serveMetrics()
 
 	default:
 		return fmt.Errorf("unknown framework type")
@@ -85,6 +91,7 @@ func InitializeClient(serviceName string) error {
 	if err != nil {
 		return fmt.Errorf("While trying to initiate ODIMService model, got: %v", err)
 	}
+	serveMetrics()
 	return nil
 }
 
@@ -114,6 +121,7 @@ func (s *odimService) Client(clientName string) (*grpc.ClientConn, error) {
 	return grpc.Dial(
 		clientAddress,
 		grpc.WithTransportCredentials(s.clientTransportCreds),
+		grpc.WithChainUnaryInterceptor(metrics.UnaryClientInterceptor, tracing.UnaryClientInterceptor),
 	)
 }
 
@@ -153,10 +161,39 @@ func (s *odimService) Init(serviceName string) error {
 	}
 	ODIMService.server = grpc.NewServer(
 		grpc.Creds(s.serverTransportCreds),
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor, tracing.UnaryServerInterceptor),
 	)
+	RegisterHealthCheck(HealthDB, common.CheckDBConnection)
+	healthpb.RegisterHealthServer(ODIMService.server, healthServer{})
+	if err = initTracing(serviceName); err != nil {
+		return fmt.Errorf("While trying to initialize the tracing, got: %v", err)
+	}
 	return nil
 }
 
+// initTracing sets up the export of the spans of the micro service as configured in TracingConf
+func initTracing(serviceName string) error {
+	if config.Data.TracingConf == nil {
+		return tracing.Init(serviceName, tracing.ExporterNone, "")
+	}
+	return tracing.Init(serviceName, config.Data.TracingConf.Exporter, config.Data.TracingConf.FilePath)
+}
+
+// serveMetrics exposes the metrics of the micro service on the side port passed with
+// the metrics_address argument. The metrics are not served on the public port of the
+// API gateway, so the side port is the only place where they are exposed.
+// The micro service keeps running if the metrics can not be served
+func serveMetrics() {
+	if config.CLArgs.MetricsAddress == "" {
+		return
+	}
+	go func() {
+		if err := metrics.ListenAndServe(config.CLArgs.MetricsAddress); err != nil {
+			l.Log.Error("While trying to serve the metrics on " + config.CLArgs.MetricsAddress + ", got: " + err.Error())
+		}
+	}()
+}
+
 func (s *odimService) getServiceAddress(serviceName string) (string, error) {
 	cli, err := clientv3.New(clientv3.Config{
 		Endpoints:   []string{s.registryAddress},
@@ -318,51 +355,12 @@ func GetEnabledServiceList() map[string]bool {
 	defer cli.Close()
 	kv := clientv3.NewKV(cli)
 	for _, microService := range config.Data.EnabledServices {
-		switch microService {
-		case "AccountService", "SessionService":
-			resp, err := kv.Get(context.TODO(), AccountSession, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "EventService":
-			resp, err := kv.Get(context.TODO(), Events, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "Systems", "Chassis":
-			resp, err := kv.Get(context.TODO(), Systems, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "TaskService":
-			resp, err := kv.Get(context.TODO(), Tasks, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "AggregationService":
-			resp, err := kv.Get(context.TODO(), Aggregator, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "Fabrics":
-			resp, err := kv.Get(context.TODO(), Fabrics, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "Managers":
-			resp, err := kv.Get(context.TODO(), Managers, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "UpdateService":
-			resp, err := kv.Get(context.TODO(), Update, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "TelemetryService":
-			resp, err := kv.Get(context.TODO(), Telemetry, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "CompositionService":
-			resp, err := kv.Get(context.TODO(), CompositionService, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
-
-		case "LicenseService":
-			resp, err := kv.Get(context.TODO(), Licenses, clientv3.WithPrefix())
-			addServicesToMap(microService, resp, data, err)
+		registryName, exist := ServiceRegistryNames[microService]
+		if !exist {
+			continue
 		}
+		resp, err := kv.Get(context.TODO(), registryName, clientv3.WithPrefix())
+		addServicesToMap(microService, resp, data, err)
 	}
 	return data
 }
@@ -0,0 +1,203 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// the exporters which can be selected in the configuration
+const (
+	// ExporterNone propagates the trace context without exporting the spans
+	ExporterNone = "None"
+	// ExporterStdout writes the spans of the service to the standard output
+	ExporterStdout = "Stdout"
+	// ExporterFile writes the spans of the service to a file
+	ExporterFile = "File"
+)
+
+// Exporter exports the ended spans of the service
+type Exporter interface {
+	Export(span SpanData)
+}
+
+var (
+	exporterMutex sync.RWMutex
+	exporter      Exporter
+)
+
+// Init sets up the exporter of the service. The spans are written in the OTLP JSON
+// format, one ExportTraceServiceRequest per line, so that they can be loaded
+// by an OpenTelemetry collector without an external collector running alongside
+func Init(serviceName, exporterType, filePath string) error {
+	switch exporterType {
+	case "", ExporterNone:
+		SetExporter(nil)
+	case ExporterStdout:
+		SetExporter(NewOTLPJSONExporter(serviceName, os.Stdout))
+	case ExporterFile:
+		if filePath == "" {
+			return fmt.Errorf("no file path set for the %s trace exporter", ExporterFile)
+		}
+		file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+		if err != nil {
+			return fmt.Errorf("while trying to open the trace file %s, got: %v", filePath, err)
+		}
+		SetExporter(NewOTLPJSONExporter(serviceName, file))
+	default:
+		return fmt.Errorf("unsupported trace exporter %q", exporterType)
+	}
+	return nil
+}
+
+// SetExporter replaces the exporter of the service, nil disables the export of the spans
+func SetExporter(e Exporter) {
+	exporterMutex.Lock()
+	exporter = e
+	exporterMutex.Unlock()
+}
+
+func getExporter() Exporter {
+	exporterMutex.RLock()
+	defer exporterMutex.RUnlock()
+	return exporter
+}
+
+// OTLPJSONExporter writes every span as an OTLP ExportTraceServiceRequest on a single line
+type OTLPJSONExporter struct {
+	mu          sync.Mutex
+	serviceName string
+	w           io.Writer
+}
+
+// NewOTLPJSONExporter returns an exporter which writes the spans of the service to w
+func NewOTLPJSONExporter(serviceName string, w io.Writer) *OTLPJSONExporter {
+	return &OTLPJSONExporter{serviceName: serviceName, w: w}
+}
+
+// Export writes the span to the writer of the exporter
+func (e *OTLPJSONExporter) Export(span SpanData) {
+	data, err := json.Marshal(e.request(span))
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	e.w.Write(append(data, '\n'))
+	e.mu.Unlock()
+}
+
+// the OTLP JSON model, trimmed to the fields this package produces
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		TraceState        string          `json:"traceState,omitempty"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              SpanKind        `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            otlpStatus      `json:"status"`
+	}
+	otlpStatus struct {
+		Code    StatusCode `json:"code,omitempty"`
+		Message string     `json:"message,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string                 `json:"key"`
+		Value map[string]interface{} `json:"value"`
+	}
+)
+
+func (e *OTLPJSONExporter) request(span SpanData) otlpRequest {
+	s := otlpSpan{
+		TraceID:           span.SpanContext.TraceID.String(),
+		SpanID:            span.SpanContext.SpanID.String(),
+		TraceState:        span.SpanContext.TraceState,
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+		Attributes:        otlpAttributes(span.Attributes),
+		Status:            otlpStatus{Code: span.StatusCode, Message: span.StatusMessage},
+	}
+	if span.ParentSpanID.IsValid() {
+		s.ParentSpanID = span.ParentSpanID.String()
+	}
+	return otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes(map[string]interface{}{"service.name": e.serviceName}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/ODIM-Project/ODIM/lib-utilities/tracing"},
+				Spans: []otlpSpan{s},
+			}},
+		}},
+	}
+}
+
+// otlpAttributes converts the attributes to OTLP key values, sorted by key
+func otlpAttributes(attributes map[string]interface{}) []otlpAttribute {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]otlpAttribute, 0, len(keys))
+	for _, key := range keys {
+		var value map[string]interface{}
+		switch v := attributes[key].(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int32:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]interface{}{"doubleValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		result = append(result, otlpAttribute{Key: key, Value: value})
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor continues the trace of the caller in a server span for every RPC served
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = Extract(ctx, MetadataCarrier(md))
+	}
+	ctx, span := StartSpan(ctx, info.FullMethod, SpanKindServer)
+	defer span.End()
+	setRPCAttributes(span, info.FullMethod)
+	resp, err := handler(ctx, req)
+	setRPCStatus(span, err)
+	return resp, err
+}
+
+// UnaryClientInterceptor traces every RPC made in a client span and
+// sends the trace context in the metadata of the call
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := StartSpan(ctx, method, SpanKindClient)
+	defer span.End()
+	setRPCAttributes(span, method)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	Inject(ctx, MetadataCarrier(md))
+	err := invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	setRPCStatus(span, err)
+	return err
+}
+
+func setRPCAttributes(span *Span, fullMethod string) {
+	span.SetAttribute("rpc.system", "grpc")
+	span.SetAttribute("rpc.method", fullMethod)
+}
+
+func setRPCStatus(span *Span, err error) {
+	code := status.Code(err)
+	span.SetAttribute("rpc.grpc.status_code", int(code))
+	span.SetError(err)
+}
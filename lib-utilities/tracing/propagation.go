@@ -0,0 +1,93 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Carrier holds the headers in which the trace context is propagated
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// HeaderCarrier carries the trace context in the headers of an HTTP request
+type HeaderCarrier http.Header
+
+// Get returns the value of the header
+func (h HeaderCarrier) Get(key string) string {
+	return http.Header(h).Get(key)
+}
+
+// Set sets the value of the header
+func (h HeaderCarrier) Set(key, value string) {
+	http.Header(h).Set(key, value)
+}
+
+// MetadataCarrier carries the trace context in the metadata of a gRPC call
+type MetadataCarrier metadata.MD
+
+// Get returns the first value of the metadata key
+func (m MetadataCarrier) Get(key string) string {
+	if values := metadata.MD(m).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set sets the value of the metadata key
+func (m MetadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+// MapCarrier carries the trace context in the headers of a message bus message
+type MapCarrier map[string]string
+
+// Get returns the value of the key
+func (m MapCarrier) Get(key string) string {
+	return m[key]
+}
+
+// Set sets the value of the key
+func (m MapCarrier) Set(key, value string) {
+	m[key] = value
+}
+
+// Inject sets the trace context of the context in the carrier
+func Inject(ctx context.Context, carrier Carrier) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	carrier.Set(TraceParentHeader, sc.TraceParent())
+	if sc.TraceState != "" {
+		carrier.Set(TraceStateHeader, sc.TraceState)
+	}
+}
+
+// Extract returns a context with the trace context of the carrier, the context
+// is returned unchanged if the carrier has no valid trace context
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	sc, err := ParseTraceParent(carrier.Get(TraceParentHeader))
+	if err != nil {
+		return ctx
+	}
+	sc.TraceState = carrier.Get(TraceStateHeader)
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
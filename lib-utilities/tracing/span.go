@@ -0,0 +1,198 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SpanKind describes the relationship of the span with its parent and children,
+// the values are the ones of the OTLP protocol
+type SpanKind int
+
+// the kinds of spans
+const (
+	SpanKindInternal SpanKind = iota + 1
+	SpanKindServer
+	SpanKindClient
+	SpanKindProducer
+	SpanKindConsumer
+)
+
+// StatusCode is the status of the operation traced by a span,
+// the values are the ones of the OTLP protocol
+type StatusCode int
+
+// the status codes of a span
+const (
+	StatusUnset StatusCode = iota
+	StatusOK
+	StatusError
+)
+
+// Span traces a single operation of the service
+type Span struct {
+	mu            sync.Mutex
+	name          string
+	kind          SpanKind
+	spanContext   SpanContext
+	parentSpanID  SpanID
+	startTime     time.Time
+	endTime       time.Time
+	attributes    map[string]interface{}
+	statusCode    StatusCode
+	statusMessage string
+	ended         bool
+}
+
+// SpanData is the snapshot of an ended span, which is passed to the exporter
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	SpanContext   SpanContext
+	ParentSpanID  SpanID
+	StartTime     time.Time
+	EndTime       time.Time
+	Attributes    map[string]interface{}
+	StatusCode    StatusCode
+	StatusMessage string
+}
+
+type spanKey struct{}
+
+type remoteSpanContextKey struct{}
+
+// StartSpan starts a span as a child of the span in the context, or of the remote
+// span context extracted from an incoming request. The span must be ended with End
+func StartSpan(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+	span := &Span{
+		name:       name,
+		kind:       kind,
+		startTime:  time.Now(),
+		attributes: make(map[string]interface{}),
+	}
+	if parent.IsValid() {
+		span.spanContext = SpanContext{
+			TraceID:    parent.TraceID,
+			Sampled:    parent.Sampled,
+			TraceState: parent.TraceState,
+		}
+		span.parentSpanID = parent.SpanID
+	} else {
+		span.spanContext = SpanContext{
+			TraceID: newTraceID(),
+			Sampled: getExporter() != nil,
+		}
+	}
+	span.spanContext.SpanID = newSpanID()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanFromContext returns the span in the context, or nil if there is no span in it
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SpanContextFromContext returns the span context of the span in the context,
+// or the remote span context if no span was started yet
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.SpanContext()
+	}
+	sc, _ := ctx.Value(remoteSpanContextKey{}).(SpanContext)
+	return sc
+}
+
+// ContextWithRemoteSpanContext returns a context which holds the span context
+// received from another service, the spans started with it are its children
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	sc.Remote = true
+	return context.WithValue(ctx, remoteSpanContextKey{}, sc)
+}
+
+// TraceParent returns the traceparent header value of the span in the context,
+// or an empty string if the context is not traced
+func TraceParent(ctx context.Context) string {
+	return SpanContextFromContext(ctx).TraceParent()
+}
+
+// SpanContext returns the span context of the span
+func (s *Span) SpanContext() SpanContext {
+	return s.spanContext
+}
+
+// SetName changes the name of the span, when the operation
+// is known better after the span was started
+func (s *Span) SetName(name string) {
+	s.mu.Lock()
+	s.name = name
+	s.mu.Unlock()
+}
+
+// SetAttribute sets an attribute of the span. The value can be a string, a bool, an integer or a float
+func (s *Span) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	s.attributes[key] = value
+	s.mu.Unlock()
+}
+
+// SetStatus sets the status of the operation traced by the span
+func (s *Span) SetStatus(code StatusCode, message string) {
+	s.mu.Lock()
+	s.statusCode = code
+	s.statusMessage = message
+	s.mu.Unlock()
+}
+
+// SetError marks the operation traced by the span as failed with the error
+func (s *Span) SetError(err error) {
+	if err != nil {
+		s.SetStatus(StatusError, err.Error())
+	}
+}
+
+// End ends the span and exports it, if the trace is sampled. Only the first call has an effect
+func (s *Span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.endTime = time.Now()
+	data := SpanData{
+		Name:          s.name,
+		Kind:          s.kind,
+		SpanContext:   s.spanContext,
+		ParentSpanID:  s.parentSpanID,
+		StartTime:     s.startTime,
+		EndTime:       s.endTime,
+		Attributes:    make(map[string]interface{}, len(s.attributes)),
+		StatusCode:    s.statusCode,
+		StatusMessage: s.statusMessage,
+	}
+	for key, value := range s.attributes {
+		data.Attributes[key] = value
+	}
+	s.mu.Unlock()
+
+	if exporter := getExporter(); exporter != nil && data.SpanContext.Sampled {
+		exporter.Export(data)
+	}
+}
@@ -0,0 +1,155 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package tracing propagates the W3C trace context across the API gateway,
+// the micro services, the message bus and the plugins, and exports the spans
+// of the service in the OTLP JSON format
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// TraceParentHeader is the header which carries the trace context, as defined by W3C
+	TraceParentHeader = "traceparent"
+	// TraceStateHeader is the header which carries the vendor specific trace data, as defined by W3C
+	TraceStateHeader = "tracestate"
+	// traceParentVersion is the version of the traceparent header this package produces
+	traceParentVersion = "00"
+	// sampledFlag is the trace flag which marks the trace as sampled
+	sampledFlag = 0x01
+)
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+// IsValid returns true if the trace ID is not all zeros
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid returns true if the span ID is not all zeros
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext is the part of a span which is propagated to the other services
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Sampled    bool
+	TraceState string
+	// Remote is true when the span context was extracted from an incoming request or message
+	Remote bool
+}
+
+// IsValid returns true if the span context has a trace ID and a span ID
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// TraceParent returns the traceparent header value of the span context,
+// or an empty string if the span context is not valid
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	var flags byte
+	if sc.Sampled {
+		flags |= sampledFlag
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceParent parses the value of a traceparent header
+func ParseTraceParent(value string) (SpanContext, error) {
+	var sc SpanContext
+	value = strings.TrimSpace(value)
+	// version-traceid-parentid-flags, where the future versions may append more fields
+	if len(value) < 55 || (len(value) > 55 && value[55] != '-') {
+		return sc, fmt.Errorf("invalid traceparent %q: unexpected length", value)
+	}
+	parts := strings.Split(value[:55], "-")
+	if len(parts) != 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return sc, fmt.Errorf("invalid traceparent %q: unexpected format", value)
+	}
+	version, err := decodeHex(parts[0])
+	if err != nil || version[0] == 0xff {
+		return sc, fmt.Errorf("invalid traceparent %q: unsupported version", value)
+	}
+	if version[0] == 0 && len(value) != 55 {
+		return sc, fmt.Errorf("invalid traceparent %q: unexpected length", value)
+	}
+	traceID, err := decodeHex(parts[1])
+	if err != nil {
+		return sc, fmt.Errorf("invalid traceparent %q: %v", value, err)
+	}
+	spanID, err := decodeHex(parts[2])
+	if err != nil {
+		return sc, fmt.Errorf("invalid traceparent %q: %v", value, err)
+	}
+	flags, err := decodeHex(parts[3])
+	if err != nil {
+		return sc, fmt.Errorf("invalid traceparent %q: %v", value, err)
+	}
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags[0]&sampledFlag == sampledFlag
+	sc.Remote = true
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("invalid traceparent %q: trace ID and parent ID must not be zero", value)
+	}
+	return sc, nil
+}
+
+// decodeHex decodes the lower case hex string, which is the only encoding allowed in a traceparent
+func decodeHex(value string) ([]byte, error) {
+	if strings.ToLower(value) != value {
+		return nil, fmt.Errorf("%q is not lower case hex", value)
+	}
+	return hex.DecodeString(value)
+}
+
+// newTraceID returns a random trace ID
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+// newSpanID returns a random span ID
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,243 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const sampleTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type exporterMock struct {
+	spans []SpanData
+}
+
+func (e *exporterMock) Export(span SpanData) {
+	e.spans = append(e.spans, span)
+}
+
+func mockExporter(t *testing.T) *exporterMock {
+	e := &exporterMock{}
+	SetExporter(e)
+	t.Cleanup(func() { SetExporter(nil) })
+	return e
+}
+
+func TestParseTraceParent(t *testing.T) {
+	sc, err := ParseTraceParent(sampleTraceParent)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" || !sc.Sampled || !sc.Remote {
+		t.Errorf("unexpected span context %+v", sc)
+	}
+	if got := sc.TraceParent(); got != sampleTraceParent {
+		t.Errorf("expected %s, got %s", sampleTraceParent, got)
+	}
+	// future versions can append fields
+	if _, err := ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-abcd"); err != nil {
+		t.Errorf("expected no error for a future version, got %v", err)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-abcd",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceParent(invalid); err == nil {
+			t.Errorf("expected error for traceparent %q", invalid)
+		}
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	exporter := mockExporter(t)
+	ctx := Extract(context.Background(), HeaderCarrier(http.Header{"Traceparent": []string{sampleTraceParent}}))
+	ctx, parent := StartSpan(ctx, "parent", SpanKindServer)
+	_, child := StartSpan(ctx, "child", SpanKindClient)
+	child.SetAttribute("http.method", http.MethodGet)
+	child.SetError(errors.New("plugin not reachable"))
+	child.End()
+	child.End()
+	parent.End()
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %d", len(exporter.spans))
+	}
+	childData, parentData := exporter.spans[0], exporter.spans[1]
+	if parentData.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || parentData.ParentSpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("parent span does not continue the remote trace: %+v", parentData)
+	}
+	if childData.SpanContext.TraceID != parentData.SpanContext.TraceID || childData.ParentSpanID != parentData.SpanContext.SpanID {
+		t.Errorf("child span is not a child of the parent span: %+v", childData)
+	}
+	if childData.StatusCode != StatusError || childData.StatusMessage != "plugin not reachable" || childData.Attributes["http.method"] != http.MethodGet {
+		t.Errorf("unexpected child span %+v", childData)
+	}
+}
+
+func TestStartSpanNotSampled(t *testing.T) {
+	exporter := mockExporter(t)
+	ctx := Extract(context.Background(), MapCarrier{TraceParentHeader: strings.TrimSuffix(sampleTraceParent, "01") + "00"})
+	_, span := StartSpan(ctx, "span", SpanKindConsumer)
+	span.End()
+	if len(exporter.spans) != 0 {
+		t.Errorf("expected no exported span, got %d", len(exporter.spans))
+	}
+
+	SetExporter(nil)
+	ctx, span = StartSpan(context.Background(), "root", SpanKindInternal)
+	if span.SpanContext().Sampled {
+		t.Error("expected the root span not to be sampled without an exporter")
+	}
+	if TraceParent(ctx) == "" {
+		t.Error("expected the trace context to be propagated without an exporter")
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	ctx := Extract(context.Background(), MapCarrier{TraceParentHeader: sampleTraceParent, TraceStateHeader: "odim=1"})
+	ctx, span := StartSpan(ctx, "span", SpanKindProducer)
+	header := http.Header{}
+	Inject(ctx, HeaderCarrier(header))
+	sc, err := ParseTraceParent(header.Get(TraceParentHeader))
+	if err != nil {
+		t.Fatalf("expected a valid traceparent, got %v", err)
+	}
+	if sc.TraceID != span.SpanContext().TraceID || sc.SpanID != span.SpanContext().SpanID {
+		t.Errorf("injected %s, expected the span context of the span", header.Get(TraceParentHeader))
+	}
+	if got := header.Get(TraceStateHeader); got != "odim=1" {
+		t.Errorf("expected tracestate odim=1, got %q", got)
+	}
+
+	empty := http.Header{}
+	Inject(context.Background(), HeaderCarrier(empty))
+	if len(empty) != 0 {
+		t.Errorf("expected no header for an untraced context, got %v", empty)
+	}
+	if ctx := Extract(context.Background(), MapCarrier{TraceParentHeader: "invalid"}); SpanContextFromContext(ctx).IsValid() {
+		t.Error("expected no span context for an invalid traceparent")
+	}
+}
+
+func TestGRPCInterceptors(t *testing.T) {
+	exporter := mockExporter(t)
+	var serverTraceParent string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		serverTraceParent = TraceParent(ctx)
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if len(md.Get("transactionid")) == 0 {
+			t.Error("expected the existing metadata to be kept")
+		}
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := UnaryServerInterceptor(metadata.NewIncomingContext(ctx, md), req, info, handler)
+		return err
+	}
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("transactionid", "1"))
+	err := UnaryClientInterceptor(ctx, "/systems.Systems/GetSystemResource", nil, nil, nil, invoker)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected the error of the handler, got %v", err)
+	}
+
+	if len(exporter.spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %d", len(exporter.spans))
+	}
+	server, client := exporter.spans[0], exporter.spans[1]
+	if server.Kind != SpanKindServer || client.Kind != SpanKindClient {
+		t.Errorf("unexpected span kinds %v and %v", server.Kind, client.Kind)
+	}
+	if server.ParentSpanID != client.SpanContext.SpanID || server.SpanContext.TraceID != client.SpanContext.TraceID {
+		t.Error("expected the server span to be a child of the client span")
+	}
+	if serverTraceParent != server.SpanContext.TraceParent() {
+		t.Error("expected the handler to run within the server span")
+	}
+	if server.Attributes["rpc.grpc.status_code"] != int(codes.NotFound) || server.StatusCode != StatusError {
+		t.Errorf("unexpected server span status %+v", server)
+	}
+}
+
+func TestOTLPJSONExporter(t *testing.T) {
+	var buf bytes.Buffer
+	SetExporter(NewOTLPJSONExporter("svc.systems", &buf))
+	defer SetExporter(nil)
+	_, span := StartSpan(context.Background(), "GET /redfish/v1/Systems", SpanKindServer)
+	span.SetAttribute("http.status_code", http.StatusOK)
+	span.End()
+
+	var request otlpRequest
+	if err := json.Unmarshal(buf.Bytes(), &request); err != nil {
+		t.Fatalf("expected an OTLP JSON line, got %s: %v", buf.String(), err)
+	}
+	if len(request.ResourceSpans) != 1 || len(request.ResourceSpans[0].ScopeSpans) != 1 || len(request.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("unexpected request %s", buf.String())
+	}
+	resource := request.ResourceSpans[0].Resource.Attributes
+	if len(resource) != 1 || resource[0].Key != "service.name" || resource[0].Value["stringValue"] != "svc.systems" {
+		t.Errorf("unexpected resource attributes %+v", resource)
+	}
+	s := request.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if s.TraceID != span.SpanContext().TraceID.String() || s.ParentSpanID != "" || s.Kind != SpanKindServer {
+		t.Errorf("unexpected span %+v", s)
+	}
+	if len(s.Attributes) != 1 || s.Attributes[0].Value["intValue"] != "200" {
+		t.Errorf("unexpected span attributes %+v", s.Attributes)
+	}
+}
+
+func TestInit(t *testing.T) {
+	defer SetExporter(nil)
+	if err := Init("svc.systems", "Jaeger", ""); err == nil {
+		t.Error("expected error for an unsupported exporter")
+	}
+	if err := Init("svc.systems", ExporterFile, ""); err == nil {
+		t.Error("expected error for a file exporter without a file path")
+	}
+	filePath := filepath.Join(t.TempDir(), "traces.json")
+	if err := Init("svc.systems", ExporterFile, filePath); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	_, span := StartSpan(context.Background(), "span", SpanKindInternal)
+	span.End()
+	data, err := os.ReadFile(filePath)
+	if err != nil || !strings.Contains(string(data), span.SpanContext().SpanID.String()) {
+		t.Errorf("expected the span in the trace file, got %s, %v", data, err)
+	}
+	if err := Init("svc.systems", ExporterNone, ""); err != nil || getExporter() != nil {
+		t.Errorf("expected no exporter, got %v", err)
+	}
+}
@@ -235,6 +235,12 @@ func routers() *iris.Application {
 		managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", dphandler.GetResource)
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", dphandler.GetResource)
 
+		// AccountService routers, used for the rotation of the credentials of the BMC
+		accountService := pluginRoutes.Party("/AccountService", dpmiddleware.BasicAuth)
+		accountService.Get("/Accounts", dphandler.GetResource)
+		accountService.Get("/Accounts/{id}", dphandler.GetResource)
+		accountService.Patch("/Accounts/{id}", dphandler.ChangeSettings)
+
 		//Registries routers
 		registries := pluginRoutes.Party("/Registries", dpmiddleware.BasicAuth)
 		registries.Get("", dphandler.GetResource)
@@ -231,6 +231,12 @@ func routers() *iris.Application {
 		managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", lphandler.GetResource)
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", lphandler.GetResource)
 
+		// AccountService routers, used for the rotation of the credentials of the BMC
+		accountService := pluginRoutes.Party("/AccountService", lpmiddleware.BasicAuth)
+		accountService.Get("/Accounts", lphandler.GetResource)
+		accountService.Get("/Accounts/{id}", lphandler.GetResource)
+		accountService.Patch("/Accounts/{id}", lphandler.ChangeSettings)
+
 		//Registries routers
 		registries := pluginRoutes.Party("/Registries", lpmiddleware.BasicAuth)
 		registries.Get("", lphandler.GetResource)
@@ -237,6 +237,12 @@ func routers() *iris.Application {
 		managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", rfphandler.GetResource)
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", rfphandler.GetResource)
 
+		// AccountService routers, used for the rotation of the credentials of the BMC
+		accountService := pluginRoutes.Party("/AccountService", rfpmiddleware.BasicAuth)
+		accountService.Get("/Accounts", rfphandler.GetResource)
+		accountService.Get("/Accounts/{id}", rfphandler.GetResource)
+		accountService.Patch("/Accounts/{id}", rfphandler.ChangeSettings)
+
 		//Registries routers
 		registries := pluginRoutes.Party("/Registries", rfpmiddleware.BasicAuth)
 		registries.Get("", rfphandler.GetResource)
@@ -0,0 +1,428 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package account ...
+package account
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+	"github.com/ODIM-Project/ODIM/svc-account-session/oauth2"
+)
+
+// externalAccountProviderTypes are the account provider types of the external account providers
+var externalAccountProviderTypes = map[string]string{
+	asmodel.LDAPProvider:            "LDAPService",
+	asmodel.ActiveDirectoryProvider: "ActiveDirectoryService",
+	asmodel.OAuth2Provider:          "OAuth2",
+}
+
+// EncryptPassword encrypts the password of the external account providers
+// with the public key of ODIM before storing it
+var EncryptPassword = common.EncryptWithPublicKey
+
+// UpdateAccountService defines the configuration of the external account providers,
+// LDAP and ActiveDirectory, against which the users of the sessions are authenticated
+// when they are not present as local accounts, and OAuth2, which issues the bearer
+// tokens accepted in place of the sessions, and the authentication of the HTTPS clients
+// by the client certificate given in MultiFactorAuth.
+//
+// The properties passed are applied on the provider already configured, the password
+// used for binding to the directory is stored encrypted and never displayed.
+// A user with ConfigureUsers privilege only can update the AccountService.
+//
+// Output is the RPC response, which contains the status code, status message, headers and body.
+func (e *ExternalInterface) UpdateAccountService(ctx context.Context, req *accountproto.UpdateAccountServiceRequest, session *asmodel.Session) response.RPC {
+	errorLogPrefix := "failed to update the account service: "
+	if !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := errorLogPrefix + "User " + session.UserName + " does not have the privilege to update the account service"
+		resp := common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+
+	if isEmptyRequest(req.RequestBody) {
+		errMsg := errorLogPrefix + "empty request can not be processed"
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"request body"}, nil)
+	}
+
+	var accountService asmodel.AccountService
+	var rawProviders map[string]json.RawMessage
+	if err := json.Unmarshal(req.RequestBody, &accountService); err != nil {
+		errMsg := errorLogPrefix + "unable to parse the update account service request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	json.Unmarshal(req.RequestBody, &rawProviders)
+
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, accountService)
+	if err != nil {
+		errMsg := errorLogPrefix + "Request parameters validation failed: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	requestProviders := map[string]*asmodel.ExternalAccountProvider{
+		asmodel.LDAPProvider:            accountService.LDAP,
+		asmodel.ActiveDirectoryProvider: accountService.ActiveDirectory,
+		asmodel.OAuth2Provider:          accountService.OAuth2,
+	}
+	for _, key := range []string{asmodel.LDAPProvider, asmodel.ActiveDirectoryProvider, asmodel.OAuth2Provider} {
+		requestProvider := requestProviders[key]
+		if requestProvider == nil {
+			continue
+		}
+		provider, resp, err := e.mergeExternalAccountProvider(ctx, key, requestProvider, rawProviders[key], errorLogPrefix)
+		if err != nil {
+			return resp
+		}
+		if resp, err := e.validateExternalAccountProvider(ctx, key, provider, errorLogPrefix); err != nil {
+			return resp
+		}
+		l.LogWithFields(ctx).Infof("Saving the %s external account provider", key)
+		if serr := e.SaveExternalAccountProvider(key, provider); serr != nil {
+			errorMessage := errorLogPrefix + "Unable to save the " + key + " external account provider: " + serr.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		}
+	}
+	if accountService.MultiFactorAuth != nil && accountService.MultiFactorAuth.ClientCertificate != nil {
+		if resp, err := e.updateClientCertificate(ctx, accountService.MultiFactorAuth.ClientCertificate, errorLogPrefix); err != nil {
+			return resp
+		}
+	}
+	return GetAccountService(ctx)
+}
+
+// updateClientCertificate applies the settings of the client certificate authentication of the request
+// on the settings stored. The common name of the subject is mapped to the user name by default
+func (e *ExternalInterface) updateClientCertificate(ctx context.Context, requestClientCertificate *asmodel.ClientCertificate,
+	errorLogPrefix string) (response.RPC, error) {
+	clientCertificate, gerr := e.GetClientCertificate()
+	if gerr != nil && gerr.ErrNo() != errors.DBKeyNotFound {
+		errorMessage := errorLogPrefix + "Unable to get the client certificate settings: " + gerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	if requestClientCertificate.Enabled != nil {
+		clientCertificate.Enabled = requestClientCertificate.Enabled
+	}
+	if requestClientCertificate.CertificateMappingAttribute != "" {
+		clientCertificate.CertificateMappingAttribute = requestClientCertificate.CertificateMappingAttribute
+	}
+	if clientCertificate.CertificateMappingAttribute == "" {
+		clientCertificate.CertificateMappingAttribute = asmodel.CommonNameMapping
+	}
+	mapping := clientCertificate.CertificateMappingAttribute
+	if mapping != asmodel.CommonNameMapping && mapping != asmodel.UserPrincipalNameMapping {
+		errorMessage := errorLogPrefix + "Invalid CertificateMappingAttribute " + mapping + ", only " +
+			asmodel.CommonNameMapping + " and " + asmodel.UserPrincipalNameMapping + " are supported"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage,
+			[]interface{}{mapping, "CertificateMappingAttribute"}, nil), fmt.Errorf(errorMessage)
+	}
+	l.LogWithFields(ctx).Info("Saving the client certificate settings")
+	if serr := e.SaveClientCertificate(clientCertificate); serr != nil {
+		errorMessage := errorLogPrefix + "Unable to save the client certificate settings: " + serr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	return response.RPC{}, nil
+}
+
+// mergeExternalAccountProvider applies the properties of the request on the provider stored
+func (e *ExternalInterface) mergeExternalAccountProvider(ctx context.Context, key string, requestProvider *asmodel.ExternalAccountProvider,
+	rawProvider json.RawMessage, errorLogPrefix string) (asmodel.ExternalAccountProvider, response.RPC, error) {
+	provider, gerr := e.GetExternalAccountProvider(key)
+	if gerr != nil && gerr.ErrNo() != errors.DBKeyNotFound {
+		errorMessage := errorLogPrefix + "Unable to get the " + key + " external account provider: " + gerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return provider, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	if gerr != nil {
+		provider = asmodel.ExternalAccountProvider{}
+	}
+	if err := json.Unmarshal(rawProvider, &provider); err != nil {
+		errorMessage := errorLogPrefix + "unable to parse the " + key + " external account provider: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return provider, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+
+	providerType := externalAccountProviderTypes[key]
+	if provider.AccountProviderType == "" {
+		provider.AccountProviderType = providerType
+	} else if provider.AccountProviderType != providerType {
+		errorMessage := errorLogPrefix + "Invalid AccountProviderType " + provider.AccountProviderType + " for " + key
+		l.LogWithFields(ctx).Error(errorMessage)
+		return provider, common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage,
+			[]interface{}{provider.AccountProviderType, "AccountProviderType"}, nil), fmt.Errorf(errorMessage)
+	}
+
+	if requestProvider.Authentication != nil && requestProvider.Authentication.Password != "" {
+		encryptedPassword, err := EncryptPassword([]byte(requestProvider.Authentication.Password))
+		if err != nil {
+			errorMessage := errorLogPrefix + "Unable to encrypt the password of the " + key + " external account provider: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return provider, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+		}
+		provider.Authentication.Password = base64.StdEncoding.EncodeToString(encryptedPassword)
+	}
+	return provider, response.RPC{}, nil
+}
+
+// validateExternalAccountProvider validates the properties of the provider. The service
+// addresses and the search base are required for enabling the directories, the issuer,
+// the audience and the mode are required for enabling OAuth2
+func (e *ExternalInterface) validateExternalAccountProvider(ctx context.Context, key string, provider asmodel.ExternalAccountProvider,
+	errorLogPrefix string) (response.RPC, error) {
+	schemes, schemesDescription := map[string]bool{"ldap": true, "ldaps": true}, "ldap and ldaps"
+	if key == asmodel.OAuth2Provider {
+		schemes, schemesDescription = map[string]bool{"https": true}, "https"
+	}
+	for _, address := range provider.ServiceAddresses {
+		u, err := url.Parse(address)
+		if err != nil || !schemes[u.Scheme] || u.Host == "" {
+			errorMessage := errorLogPrefix + "Invalid service address " + address + " of " + key + ", only " + schemesDescription + " URIs are supported"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+				[]interface{}{address, "ServiceAddresses"}, nil), fmt.Errorf(errorMessage)
+		}
+	}
+
+	for _, mapping := range provider.RemoteRoleMapping {
+		if mapping.RemoteGroup == "" || mapping.LocalRole == "" {
+			errorMessage := errorLogPrefix + "RemoteGroup and LocalRole are required for the RemoteRoleMapping of " + key
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage,
+				[]interface{}{"RemoteRoleMapping"}, nil), fmt.Errorf(errorMessage)
+		}
+		if mapping.LocalRole == common.RoleAdmin || mapping.LocalRole == common.RoleMonitor || mapping.LocalRole == common.RoleClient {
+			continue
+		}
+		if _, err := e.GetRoleDetailsByID(mapping.LocalRole); err != nil {
+			errorMessage := errorLogPrefix + "Invalid LocalRole " + mapping.LocalRole + " present: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			if err.ErrNo() == errors.DBKeyNotFound {
+				return common.GeneralError(http.StatusBadRequest, response.ResourceNotFound, errorMessage,
+					[]interface{}{"Role", mapping.LocalRole}, nil), fmt.Errorf(errorMessage)
+			}
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+		}
+	}
+
+	if provider.Oem != nil && provider.Oem.RootCACertificate != "" {
+		if !isValidCertificate(provider.Oem.RootCACertificate) {
+			errorMessage := errorLogPrefix + "Invalid RootCACertificate of " + key + ", a PEM encoded certificate is expected"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+				[]interface{}{"RootCACertificate", "RootCACertificate"}, nil), fmt.Errorf(errorMessage)
+		}
+	}
+
+	if resp, err := validateOAuth2Service(ctx, provider.OAuth2Service, errorLogPrefix); err != nil {
+		return resp, err
+	}
+
+	if !provider.IsEnabled() {
+		return response.RPC{}, nil
+	}
+	var missingProperties []string
+	if key == asmodel.OAuth2Provider {
+		missingProperties = getMissingOAuth2Properties(provider.OAuth2Service)
+	} else {
+		if len(provider.ServiceAddresses) == 0 {
+			missingProperties = append(missingProperties, key+"/ServiceAddresses")
+		}
+		if provider.LDAPService == nil || provider.LDAPService.SearchSettings == nil ||
+			len(provider.LDAPService.SearchSettings.BaseDistinguishedNames) == 0 {
+			missingProperties = append(missingProperties, key+"/LDAPService/SearchSettings/BaseDistinguishedNames")
+		}
+	}
+	if len(missingProperties) > 0 {
+		errorMessage := errorLogPrefix + "Properties required for enabling " + key + " are missing: " + strings.Join(missingProperties, ", ")
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage,
+			[]interface{}{strings.Join(missingProperties, ", ")}, nil), fmt.Errorf(errorMessage)
+	}
+	return response.RPC{}, nil
+}
+
+// validateOAuth2Service validates the mode and the signing keys of the OAuth2 service
+// and checks the issuer and the audience are present
+func validateOAuth2Service(ctx context.Context, service *asmodel.OAuth2Service, errorLogPrefix string) (response.RPC, error) {
+	if service == nil {
+		return response.RPC{}, nil
+	}
+	if service.Mode != "" && service.Mode != oauth2.ModeDiscovery && service.Mode != oauth2.ModeOffline {
+		errorMessage := errorLogPrefix + "Invalid Mode " + service.Mode + " of the OAuth2 service"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage,
+			[]interface{}{service.Mode, "Mode"}, nil), fmt.Errorf(errorMessage)
+	}
+	if service.OAuthServiceSigningKeys != "" {
+		if err := oauth2.ValidateSigningKeys(service.OAuthServiceSigningKeys); err != nil {
+			errorMessage := errorLogPrefix + "Invalid OAuthServiceSigningKeys of the OAuth2 service: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+				[]interface{}{"OAuthServiceSigningKeys", "OAuthServiceSigningKeys"}, nil), fmt.Errorf(errorMessage)
+		}
+	}
+	// the tokens are always validated against the issuer and the audience,
+	// so they are required whenever the OAuth2 service is configured
+	var missingProperties []string
+	if service.Issuer == "" {
+		missingProperties = append(missingProperties, "OAuth2/OAuth2Service/Issuer")
+	}
+	if len(service.Audience) == 0 {
+		missingProperties = append(missingProperties, "OAuth2/OAuth2Service/Audience")
+	}
+	if len(missingProperties) > 0 {
+		errorMessage := errorLogPrefix + "Properties required for the OAuth2 service are missing: " + strings.Join(missingProperties, ", ")
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage,
+			[]interface{}{strings.Join(missingProperties, ", ")}, nil), fmt.Errorf(errorMessage)
+	}
+	return response.RPC{}, nil
+}
+
+// getMissingOAuth2Properties returns the properties required for enabling OAuth2,
+// the signing keys are required in the Offline mode. The issuer and the audience
+// are validated with the OAuth2 service
+func getMissingOAuth2Properties(service *asmodel.OAuth2Service) []string {
+	if service == nil {
+		return []string{"OAuth2/OAuth2Service"}
+	}
+	var missingProperties []string
+	if service.Mode == "" {
+		missingProperties = append(missingProperties, "OAuth2/OAuth2Service/Mode")
+	}
+	if service.Mode == oauth2.ModeOffline && service.OAuthServiceSigningKeys == "" {
+		missingProperties = append(missingProperties, "OAuth2/OAuth2Service/OAuthServiceSigningKeys")
+	}
+	return missingProperties
+}
+
+func isValidCertificate(certificate string) bool {
+	block, _ := pem.Decode([]byte(certificate))
+	if block == nil {
+		return false
+	}
+	_, err := x509.ParseCertificate(block.Bytes)
+	return err == nil
+}
+
+// getExternalAccountProvider returns the external account provider of the key for display,
+// nil is returned when the provider is not configured
+func getExternalAccountProvider(key string) (*asresponse.ExternalAccountProvider, *errors.Error) {
+	provider, err := GetExternalAccountProviderFunc(key)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resp := asresponse.ExternalAccountProvider{
+		AccountProviderType: provider.AccountProviderType,
+		ServiceEnabled:      provider.IsEnabled(),
+		ServiceAddresses:    provider.ServiceAddresses,
+		RemoteRoleMapping:   []asresponse.RoleMapping{},
+	}
+	if resp.ServiceAddresses == nil {
+		resp.ServiceAddresses = []string{}
+	}
+	if provider.Authentication != nil {
+		resp.Authentication = &asresponse.Authentication{
+			AuthenticationType: provider.Authentication.AuthenticationType,
+			Username:           provider.Authentication.Username,
+		}
+	}
+	if provider.LDAPService != nil && provider.LDAPService.SearchSettings != nil {
+		settings := provider.LDAPService.SearchSettings
+		resp.LDAPService = &asresponse.LDAPService{
+			SearchSettings: &asresponse.LDAPSearchSettings{
+				BaseDistinguishedNames: settings.BaseDistinguishedNames,
+				UsernameAttribute:      settings.UsernameAttribute,
+				GroupsAttribute:        settings.GroupsAttribute,
+				GroupNameAttribute:     settings.GroupNameAttribute,
+			},
+		}
+	}
+	if provider.OAuth2Service != nil {
+		resp.OAuth2Service = &asresponse.OAuth2Service{
+			Issuer:                  provider.OAuth2Service.Issuer,
+			Audience:                provider.OAuth2Service.Audience,
+			Mode:                    provider.OAuth2Service.Mode,
+			OAuthServiceSigningKeys: provider.OAuth2Service.OAuthServiceSigningKeys,
+		}
+		if resp.OAuth2Service.Audience == nil {
+			resp.OAuth2Service.Audience = []string{}
+		}
+	}
+	for _, mapping := range provider.RemoteRoleMapping {
+		resp.RemoteRoleMapping = append(resp.RemoteRoleMapping, asresponse.RoleMapping{
+			LocalRole:   mapping.LocalRole,
+			RemoteGroup: mapping.RemoteGroup,
+		})
+	}
+	if provider.Oem != nil {
+		resp.Oem = &asresponse.ExternalAccountProviderOem{
+			StartTLS:          provider.Oem.StartTLS != nil && *provider.Oem.StartTLS,
+			RootCACertificate: provider.Oem.RootCACertificate,
+			UsernameClaim:     provider.Oem.UsernameClaim,
+			GroupsClaim:       provider.Oem.GroupsClaim,
+		}
+	}
+	return &resp, nil
+}
+
+// getMultiFactorAuth returns the settings of the client certificate authentication for display,
+// the authentication is disabled and the common name is mapped when it is not configured.
+// The clients without a certificate are always served with the other authentication methods
+func getMultiFactorAuth() (*asresponse.MultiFactorAuth, *errors.Error) {
+	clientCertificate, err := GetClientCertificateFunc()
+	if err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return nil, err
+	}
+	resp := asresponse.ClientCertificate{
+		Enabled:                         clientCertificate.IsEnabled(),
+		CertificateMappingAttribute:     clientCertificate.CertificateMappingAttribute,
+		RespondToUnauthenticatedClients: true,
+	}
+	if resp.CertificateMappingAttribute == "" {
+		resp.CertificateMappingAttribute = asmodel.CommonNameMapping
+	}
+	return &asresponse.MultiFactorAuth{ClientCertificate: &resp}, nil
+}
@@ -0,0 +1,140 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+)
+
+func TestUpdateAccountService(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func(get func(string) (asmodel.ExternalAccountProvider, *errors.Error), getClientCertificate func() (asmodel.ClientCertificate, *errors.Error),
+		encrypt func([]byte) ([]byte, error)) {
+		GetExternalAccountProviderFunc = get
+		GetClientCertificateFunc = getClientCertificate
+		EncryptPassword = encrypt
+		mockExternalAccountProviders = map[string]asmodel.ExternalAccountProvider{}
+		mockClientCertificate = nil
+	}(GetExternalAccountProviderFunc, GetClientCertificateFunc, EncryptPassword)
+	GetExternalAccountProviderFunc = mockGetExternalAccountProvider
+	GetClientCertificateFunc = mockGetClientCertificate
+	EncryptPassword = func(password []byte) ([]byte, error) {
+		return append([]byte("encrypted:"), password...), nil
+	}
+	acc := getMockExternalInterface()
+	ctx := mockContext()
+	adminSession := &asmodel.Session{
+		UserName:   "admin",
+		Privileges: map[string]bool{common.PrivilegeConfigureUsers: true},
+	}
+	operatorSession := &asmodel.Session{
+		UserName:   "operator",
+		Privileges: map[string]bool{common.PrivilegeConfigureSelf: true},
+	}
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	publicKey, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	signingKeys := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}))
+
+	tests := []struct {
+		name    string
+		body    string
+		session *asmodel.Session
+		want    int32
+	}{
+		{"without ConfigureUsers privilege", `{"LDAP":{"ServiceEnabled":false}}`, operatorSession, http.StatusForbidden},
+		{"empty request", `{}`, adminSession, http.StatusBadRequest},
+		{"invalid property", `{"LDAP":{"serviceEnabled":true}}`, adminSession, http.StatusBadRequest},
+		{"invalid service address", `{"LDAP":{"ServiceAddresses":["http://ldap.example.com"]}}`, adminSession, http.StatusBadRequest},
+		{"invalid account provider type", `{"LDAP":{"AccountProviderType":"ActiveDirectoryService"}}`, adminSession, http.StatusBadRequest},
+		{"invalid local role", `{"LDAP":{"RemoteRoleMapping":[{"LocalRole":"xyz","RemoteGroup":"admins"}]}}`, adminSession, http.StatusBadRequest},
+		{"invalid root CA certificate", `{"LDAP":{"Oem":{"RootCACertificate":"xyz"}}}`, adminSession, http.StatusBadRequest},
+		{"enabling without the search base", `{"LDAP":{"ServiceEnabled":true,"ServiceAddresses":["ldaps://ldap.example.com"]}}`, adminSession, http.StatusBadRequest},
+		{
+			name: "configuring LDAP",
+			body: `{"LDAP":{"ServiceAddresses":["ldap://ldap.example.com"],
+				"Authentication":{"Username":"cn=odim,dc=example,dc=com","Password":"S3rvice"},
+				"LDAPService":{"SearchSettings":{"BaseDistinguishedNames":["ou=people,dc=example,dc=com"]}},
+				"RemoteRoleMapping":[{"LocalRole":"Administrator","RemoteGroup":"admins"}],
+				"Oem":{"StartTLS":true}}}`,
+			session: adminSession,
+			want:    http.StatusOK,
+		},
+		{"enabling LDAP", `{"LDAP":{"ServiceEnabled":true}}`, adminSession, http.StatusOK},
+		{"invalid OAuth2 service address", `{"OAuth2":{"ServiceAddresses":["ldaps://idp.example.com"]}}`, adminSession, http.StatusBadRequest},
+		{"invalid OAuth2 mode", `{"OAuth2":{"OAuth2Service":{"Mode":"Online"}}}`, adminSession, http.StatusBadRequest},
+		{"invalid OAuth2 signing keys", `{"OAuth2":{"OAuth2Service":{"OAuthServiceSigningKeys":"eHl6"}}}`, adminSession, http.StatusBadRequest},
+		{"OAuth2 service without the audience", `{"OAuth2":{"OAuth2Service":{"Issuer":"https://idp.example.com","Mode":"Discovery"}}}`, adminSession, http.StatusBadRequest},
+		{"enabling OAuth2 without the signing keys", `{"OAuth2":{"ServiceEnabled":true,
+			"OAuth2Service":{"Issuer":"https://idp.example.com","Audience":["odim"],"Mode":"Offline"}}}`, adminSession, http.StatusBadRequest},
+		{
+			name: "configuring OAuth2",
+			body: `{"OAuth2":{"ServiceEnabled":true,
+				"OAuth2Service":{"Issuer":"https://idp.example.com","Audience":["odim"],"Mode":"Offline","OAuthServiceSigningKeys":"` + signingKeys + `"},
+				"RemoteRoleMapping":[{"LocalRole":"Operator","RemoteGroup":"operators"}],
+				"Oem":{"UsernameClaim":"preferred_username"}}}`,
+			session: adminSession,
+			want:    http.StatusOK,
+		},
+		{"invalid certificate mapping attribute", `{"MultiFactorAuth":{"ClientCertificate":{"CertificateMappingAttribute":"Whole"}}}`, adminSession, http.StatusBadRequest},
+		{"enabling client certificate", `{"MultiFactorAuth":{"ClientCertificate":{"Enabled":true}}}`, adminSession, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &accountproto.UpdateAccountServiceRequest{RequestBody: []byte(tt.body)}
+			got := acc.UpdateAccountService(ctx, req, tt.session)
+			if got.StatusCode != tt.want {
+				t.Errorf("UpdateAccountService() status = %v, want %v, body %v", got.StatusCode, tt.want, got.Body)
+			}
+		})
+	}
+
+	provider := mockExternalAccountProviders[asmodel.LDAPProvider]
+	if !provider.IsEnabled() || provider.AccountProviderType != "LDAPService" || provider.Authentication.Username != "cn=odim,dc=example,dc=com" {
+		t.Errorf("UpdateAccountService() saved the provider %+v", provider)
+	}
+	if provider.Authentication.Password != "ZW5jcnlwdGVkOlMzcnZpY2U=" {
+		t.Errorf("UpdateAccountService() saved the password %v, want it encrypted", provider.Authentication.Password)
+	}
+
+	resp := GetAccountService(ctx)
+	body := resp.Body.(asresponse.AccountService)
+	if body.LDAP == nil || body.LDAP.Authentication.Password != nil || !body.LDAP.ServiceEnabled || !body.LDAP.Oem.StartTLS {
+		t.Errorf("GetAccountService() LDAP = %+v", body.LDAP)
+	}
+	if body.OAuth2 == nil || !body.OAuth2.ServiceEnabled || body.OAuth2.AccountProviderType != "OAuth2" ||
+		body.OAuth2.OAuth2Service.Issuer != "https://idp.example.com" || body.OAuth2.Oem.UsernameClaim != "preferred_username" {
+		t.Errorf("GetAccountService() OAuth2 = %+v", body.OAuth2)
+	}
+	if clientCertificate := body.MultiFactorAuth.ClientCertificate; !clientCertificate.Enabled || clientCertificate.CertificateMappingAttribute != "CommonName" {
+		t.Errorf("GetAccountService() ClientCertificate = %+v", clientCertificate)
+	}
+	if body.ActiveDirectory != nil {
+		t.Errorf("GetAccountService() ActiveDirectory = %+v, want nil", body.ActiveDirectory)
+	}
+}
@@ -0,0 +1,313 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package account ...
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+	uuid "github.com/satori/go.uuid"
+)
+
+// CreateAPIKey creates a long-lived API key for the account, which is accepted in place of
+// the sessions in the X-API-Key header. The key expires at the ExpirationDate of the request and
+// has the privileges of the role of the account, restricted to the Privileges of the request
+// when they are given. The token of the key is only returned in the response, the hash of its
+// secret is stored.
+//
+// A user with ConfigureUsers privilege can create the keys of any account, and a user with
+// ConfigureSelf privilege the keys of its own account. An API key can not create API keys.
+func (e *ExternalInterface) CreateAPIKey(ctx context.Context, req *accountproto.APIKeyRequest, session *asmodel.Session) response.RPC {
+	errorLogPrefix := "failed to create the API key of the account " + req.AccountID + ": "
+	if resp, ok := checkAPIKeyPrivilege(ctx, session, req.AccountID, errorLogPrefix); !ok {
+		return resp
+	}
+	if auth.IsAPIKey(session.Token) {
+		errorMessage := errorLogPrefix + "API key can not be used for creating API keys"
+		resp := common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+	user, resp, ok := e.getAPIKeyAccount(ctx, req.AccountID, errorLogPrefix)
+	if !ok {
+		return resp
+	}
+
+	var keyRequest asmodel.APIKeyRequest
+	if err := json.Unmarshal(req.RequestBody, &keyRequest); err != nil {
+		errorMessage := errorLogPrefix + "unable to parse the create API key request: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+	}
+	// Validating the request JSON properties for case sensitive
+	invalidProperties, err := common.RequestParamsCaseValidator(req.RequestBody, keyRequest)
+	if err != nil {
+		errorMessage := errorLogPrefix + "Request parameters validation failed: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	} else if invalidProperties != "" {
+		errorMessage := errorLogPrefix + "One or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, nil)
+	}
+
+	if keyRequest.ExpirationDate == "" {
+		errorMessage := errorLogPrefix + "ExpirationDate is required for creating an API key"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"ExpirationDate"}, nil)
+	}
+	expirationTime, perr := time.Parse(time.RFC3339, keyRequest.ExpirationDate)
+	if perr != nil || !expirationTime.After(time.Now()) {
+		errorMessage := errorLogPrefix + "ExpirationDate " + keyRequest.ExpirationDate + " must be a future date and time in RFC 3339 format"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage,
+			[]interface{}{keyRequest.ExpirationDate, "ExpirationDate"}, nil)
+	}
+
+	role, gerr := e.GetRoleDetailsByID(user.RoleID)
+	if gerr != nil {
+		errorMessage := errorLogPrefix + "Unable to get the role of the account: " + gerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	for _, privilege := range keyRequest.Privileges {
+		if !isRolePrivilege(role, privilege) {
+			errorMessage := errorLogPrefix + "Privilege " + privilege + " is not assigned to the role " + role.ID + " of the account"
+			l.LogWithFields(ctx).Error(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage,
+				[]interface{}{privilege, "Privileges"}, nil)
+		}
+	}
+
+	key := asmodel.APIKey{
+		ID:             uuid.NewV4().String(),
+		Name:           keyRequest.Name,
+		UserName:       user.UserName,
+		Privileges:     keyRequest.Privileges,
+		CreatedTime:    time.Now(),
+		ExpirationTime: expirationTime,
+	}
+	token, hashedSecret, terr := auth.NewAPIKeyToken(key.ID)
+	if terr != nil {
+		errorMessage := errorLogPrefix + "Unable to generate the API key: " + terr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	key.HashedSecret = hashedSecret
+	l.LogWithFields(ctx).Infof("Creating the API key %s of the account %s", key.ID, user.UserName)
+	if cerr := e.SaveAPIKey(key); cerr != nil {
+		errorMessage := errorLogPrefix + "Unable to save the API key: " + cerr.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	resp.StatusCode = http.StatusCreated
+	resp.StatusMessage = response.Created
+	body := getAPIKeyResponse(key, asmodel.APIKeyUsage{}, response.Created)
+	body.Key = token
+	resp.Header = map[string]string{
+		"Location": body.OdataID,
+	}
+	resp.Body = body
+	return resp
+}
+
+// GetAllAPIKeys lists the API keys of the account
+func (e *ExternalInterface) GetAllAPIKeys(ctx context.Context, req *accountproto.APIKeyRequest, session *asmodel.Session) response.RPC {
+	errorLogPrefix := "failed to fetch the API keys of the account " + req.AccountID + ": "
+	if resp, ok := checkAPIKeyPrivilege(ctx, session, req.AccountID, errorLogPrefix); !ok {
+		return resp
+	}
+	if _, resp, ok := e.getAPIKeyAccount(ctx, req.AccountID, errorLogPrefix); !ok {
+		return resp
+	}
+	keys, err := e.GetUserAPIKeys(req.AccountID)
+	if err != nil {
+		errorMessage := errorLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	collectionURI := apiKeysURI(req.AccountID)
+	members := []asresponse.ListMember{}
+	for _, key := range keys {
+		members = append(members, asresponse.ListMember{OdataID: collectionURI + "/" + key.ID})
+	}
+	commonResponse := response.Response{
+		OdataType:    "#OdimAPIKeyCollection.OdimAPIKeyCollection",
+		OdataID:      collectionURI,
+		OdataContext: "/redfish/v1/$metadata#OdimAPIKeyCollection.OdimAPIKeyCollection",
+		Name:         "API Keys",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse = mapEmptyValuesResponseFields(commonResponse)
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: asresponse.List{
+			Response:     commonResponse,
+			MembersCount: len(members),
+			Members:      members,
+		},
+	}
+}
+
+// GetAPIKey retrieves the API key of the account, along with its usage
+func (e *ExternalInterface) GetAPIKey(ctx context.Context, req *accountproto.APIKeyRequest, session *asmodel.Session) response.RPC {
+	errorLogPrefix := "failed to fetch the API key " + req.KeyID + " of the account " + req.AccountID + ": "
+	if resp, ok := checkAPIKeyPrivilege(ctx, session, req.AccountID, errorLogPrefix); !ok {
+		return resp
+	}
+	key, resp, ok := e.getAccountAPIKey(ctx, req.AccountID, req.KeyID, errorLogPrefix)
+	if !ok {
+		return resp
+	}
+	usage, err := e.GetAPIKeyUsage(key.ID)
+	if err != nil {
+		errorMessage := errorLogPrefix + "Unable to get the usage of the API key: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	body := getAPIKeyResponse(key, usage, response.Success)
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          body,
+	}
+}
+
+// DeleteAPIKey revokes the API key of the account
+func (e *ExternalInterface) DeleteAPIKey(ctx context.Context, req *accountproto.APIKeyRequest, session *asmodel.Session) response.RPC {
+	errorLogPrefix := "failed to delete the API key " + req.KeyID + " of the account " + req.AccountID + ": "
+	if resp, ok := checkAPIKeyPrivilege(ctx, session, req.AccountID, errorLogPrefix); !ok {
+		return resp
+	}
+	key, resp, ok := e.getAccountAPIKey(ctx, req.AccountID, req.KeyID, errorLogPrefix)
+	if !ok {
+		return resp
+	}
+	l.LogWithFields(ctx).Infof("Revoking the API key %s of the account %s", key.ID, key.UserName)
+	if err := e.DeleteAPIKeyDetails(key.ID); err != nil {
+		errorMessage := errorLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	return response.RPC{
+		StatusCode:    http.StatusNoContent,
+		StatusMessage: response.ResourceRemoved,
+	}
+}
+
+// checkAPIKeyPrivilege checks the user of the session is allowed to manage the API keys of the account
+func checkAPIKeyPrivilege(ctx context.Context, session *asmodel.Session, accountID, errorLogPrefix string) (response.RPC, bool) {
+	if session.Privileges[common.PrivilegeConfigureUsers] ||
+		(session.Privileges[common.PrivilegeConfigureSelf] && session.UserName == accountID) {
+		return response.RPC{}, true
+	}
+	errorMessage := errorLogPrefix + "User " + session.UserName + " does not have the privilege to manage the API keys of the account"
+	resp := common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+	auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+	return resp, false
+}
+
+// getAPIKeyAccount retrieves the account of the API keys
+func (e *ExternalInterface) getAPIKeyAccount(ctx context.Context, accountID, errorLogPrefix string) (asmodel.User, response.RPC, bool) {
+	user, err := e.GetUserDetails(accountID)
+	if err != nil {
+		errorMessage := errorLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return user, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Account", accountID}, nil), false
+		}
+		return user, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), false
+	}
+	return user, response.RPC{}, true
+}
+
+// getAccountAPIKey retrieves the API key of the ID, which must belong to the account
+func (e *ExternalInterface) getAccountAPIKey(ctx context.Context, accountID, keyID, errorLogPrefix string) (asmodel.APIKey, response.RPC, bool) {
+	key, err := e.GetAPIKeyDetails(keyID)
+	if err == nil && key.UserName != accountID {
+		err = errors.PackError(errors.DBKeyNotFound, "error: API key "+keyID+" does not belong to the account "+accountID)
+	}
+	if err != nil {
+		errorMessage := errorLogPrefix + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return key, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"APIKey", keyID}, nil), false
+		}
+		return key, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), false
+	}
+	return key, response.RPC{}, true
+}
+
+func isRolePrivilege(role asmodel.Role, privilege string) bool {
+	for _, rolePrivilege := range append(role.AssignedPrivileges, role.OEMPrivileges...) {
+		if rolePrivilege == privilege {
+			return true
+		}
+	}
+	return false
+}
+
+func apiKeysURI(accountID string) string {
+	return "/redfish/v1/AccountService/Accounts/" + accountID + "/APIKeys"
+}
+
+// getAPIKeyResponse returns the API key for display, the hash of the secret is never displayed
+func getAPIKeyResponse(key asmodel.APIKey, usage asmodel.APIKeyUsage, statusMessage string) asresponse.APIKey {
+	commonResponse := response.Response{
+		OdataType:    common.APIKeyType,
+		OdataID:      apiKeysURI(key.UserName) + "/" + key.ID,
+		OdataContext: "/redfish/v1/$metadata#OdimAPIKey.OdimAPIKey",
+		ID:           key.ID,
+		Name:         key.Name,
+	}
+	if commonResponse.Name == "" {
+		commonResponse.Name = "API Key"
+	}
+	commonResponse.CreateGenericResponse(statusMessage)
+	commonResponse = mapEmptyValuesResponseFields(commonResponse)
+	resp := asresponse.APIKey{
+		Response:       commonResponse,
+		UserName:       key.UserName,
+		Privileges:     key.Privileges,
+		CreatedTime:    key.CreatedTime.Format(time.RFC3339),
+		ExpirationDate: key.ExpirationTime.Format(time.RFC3339),
+		UsageCount:     usage.UsageCount,
+		Links: asresponse.APIKeyLinks{
+			ManagerAccount: dmtf.Link{Oid: "/redfish/v1/AccountService/Accounts/" + key.UserName},
+		},
+	}
+	if resp.Privileges == nil {
+		resp.Privileges = []string{}
+	}
+	if !usage.LastUsedTime.IsZero() {
+		resp.LastUsedTime = usage.LastUsedTime.Format(time.RFC3339)
+	}
+	return resp
+}
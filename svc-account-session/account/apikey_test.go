@@ -0,0 +1,157 @@
+// (C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+package account
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	defer func() {
+		mockAPIKeys = map[string]asmodel.APIKey{}
+	}()
+	ctx := mockContext()
+	adminSession := &asmodel.Session{
+		UserName:   "admin",
+		Token:      "token",
+		Privileges: map[string]bool{common.PrivilegeConfigureUsers: true},
+	}
+	selfSession := &asmodel.Session{
+		UserName:   "testUser1",
+		Token:      "token",
+		Privileges: map[string]bool{common.PrivilegeConfigureSelf: true},
+	}
+	otherSession := &asmodel.Session{
+		UserName:   "testUser2",
+		Token:      "token",
+		Privileges: map[string]bool{common.PrivilegeConfigureSelf: true},
+	}
+	apiKeySession := &asmodel.Session{
+		UserName:   "admin",
+		Token:      "odimak_key_secret",
+		Privileges: map[string]bool{common.PrivilegeConfigureUsers: true},
+	}
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	validBody := []byte(`{"Name":"backup","ExpirationDate":"` + expiration + `"}`)
+	tests := []struct {
+		name       string
+		session    *asmodel.Session
+		accountID  string
+		body       []byte
+		wantStatus int32
+	}{
+		{"create key as administrator", adminSession, "testUser1", validBody, http.StatusCreated},
+		{"create key of own account", selfSession, "testUser1", validBody, http.StatusCreated},
+		{"create key of another account", otherSession, "testUser1", validBody, http.StatusForbidden},
+		{"create key with an API key", apiKeySession, "testUser1", validBody, http.StatusForbidden},
+		{"create key of missing account", adminSession, "xyz", validBody, http.StatusNotFound},
+		{"malformed request", adminSession, "testUser1", []byte(`{"Name":`), http.StatusBadRequest},
+		{"unknown property", adminSession, "testUser1", []byte(`{"name":"backup","ExpirationDate":"` + expiration + `"}`), http.StatusBadRequest},
+		{"missing expiration", adminSession, "testUser1", []byte(`{"Name":"backup"}`), http.StatusBadRequest},
+		{"past expiration", adminSession, "testUser1", []byte(`{"ExpirationDate":"2020-01-01T00:00:00Z"}`), http.StatusBadRequest},
+		{"privilege not in role", adminSession, "testUser1", []byte(`{"ExpirationDate":"` + expiration + `","Privileges":["ConfigureUsers"]}`), http.StatusBadRequest},
+	}
+	e := getMockExternalInterface()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &accountproto.APIKeyRequest{AccountID: tt.accountID, RequestBody: tt.body}
+			got := e.CreateAPIKey(ctx, req, tt.session)
+			if got.StatusCode != tt.wantStatus {
+				t.Fatalf("CreateAPIKey() status = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+			if got.StatusCode != http.StatusCreated {
+				return
+			}
+			body := got.Body.(asresponse.APIKey)
+			if !auth.IsAPIKey(body.Key) || !strings.Contains(body.Key, body.ID) {
+				t.Errorf("CreateAPIKey() returned invalid key %v", body.Key)
+			}
+			if got.Header["Location"] != "/redfish/v1/AccountService/Accounts/testUser1/APIKeys/"+body.ID {
+				t.Errorf("CreateAPIKey() returned invalid location %v", got.Header["Location"])
+			}
+			saved := mockAPIKeys[body.ID]
+			if saved.HashedSecret == "" || strings.Contains(body.Key, saved.HashedSecret) {
+				t.Errorf("CreateAPIKey() should save the hash of the secret")
+			}
+		})
+	}
+}
+
+func TestGetAndDeleteAPIKey(t *testing.T) {
+	config.SetUpMockConfig(t)
+	common.SetUpMockConfig()
+	mockAPIKeys = map[string]asmodel.APIKey{
+		"key1": {ID: "key1", UserName: "testUser1", ExpirationTime: time.Now().Add(time.Hour)},
+		"key2": {ID: "key2", UserName: "testUser2", ExpirationTime: time.Now().Add(time.Hour)},
+	}
+	defer func() {
+		mockAPIKeys = map[string]asmodel.APIKey{}
+	}()
+	ctx := mockContext()
+	session := &asmodel.Session{
+		UserName:   "testUser1",
+		Privileges: map[string]bool{common.PrivilegeConfigureSelf: true},
+	}
+	e := getMockExternalInterface()
+
+	got := e.GetAllAPIKeys(ctx, &accountproto.APIKeyRequest{AccountID: "testUser1"}, session)
+	if got.StatusCode != http.StatusOK {
+		t.Fatalf("GetAllAPIKeys() status = %v", got.StatusCode)
+	}
+	list := got.Body.(asresponse.List)
+	if list.MembersCount != 1 || list.Members[0].OdataID != "/redfish/v1/AccountService/Accounts/testUser1/APIKeys/key1" {
+		t.Errorf("GetAllAPIKeys() returned unexpected members %v", list.Members)
+	}
+
+	got = e.GetAPIKey(ctx, &accountproto.APIKeyRequest{AccountID: "testUser1", KeyID: "key1"}, session)
+	if got.StatusCode != http.StatusOK {
+		t.Fatalf("GetAPIKey() status = %v", got.StatusCode)
+	}
+	if key := got.Body.(asresponse.APIKey); key.UsageCount != 3 || key.Key != "" {
+		t.Errorf("GetAPIKey() returned unexpected key %v", key)
+	}
+	got = e.GetAPIKey(ctx, &accountproto.APIKeyRequest{AccountID: "testUser1", KeyID: "key2"}, session)
+	if got.StatusCode != http.StatusNotFound {
+		t.Errorf("GetAPIKey() of another account status = %v", got.StatusCode)
+	}
+	got = e.GetAPIKey(ctx, &accountproto.APIKeyRequest{AccountID: "testUser2", KeyID: "key2"}, session)
+	if got.StatusCode != http.StatusForbidden {
+		t.Errorf("GetAPIKey() without privilege status = %v", got.StatusCode)
+	}
+
+	got = e.DeleteAPIKey(ctx, &accountproto.APIKeyRequest{AccountID: "testUser1", KeyID: "key1"}, session)
+	if got.StatusCode != http.StatusNoContent {
+		t.Fatalf("DeleteAPIKey() status = %v", got.StatusCode)
+	}
+	if _, ok := mockAPIKeys["key1"]; ok {
+		t.Errorf("DeleteAPIKey() should delete the key")
+	}
+	got = e.DeleteAPIKey(ctx, &accountproto.APIKeyRequest{AccountID: "testUser1", KeyID: "key1"}, session)
+	if got.StatusCode != http.StatusNotFound {
+		t.Errorf("DeleteAPIKey() of deleted key status = %v", got.StatusCode)
+	}
+}
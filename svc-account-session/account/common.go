@@ -15,11 +15,21 @@
 package account
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
 const (
@@ -29,23 +39,55 @@ const (
 var (
 	// ConfigFilePath holds the value of odim config file path
 	ConfigFilePath string
+	// GetExternalAccountProviderFunc retrieves the external account provider for displaying the AccountService
+	GetExternalAccountProviderFunc = asmodel.GetExternalAccountProvider
+	// GetClientCertificateFunc retrieves the settings of the client certificate authentication for displaying the AccountService
+	GetClientCertificateFunc = asmodel.GetClientCertificate
+	// IsAccountLockedFunc checks the account is locked for displaying the account
+	IsAccountLockedFunc = auth.IsAccountLocked
 )
 
 // ExternalInterface holds all the external connections account package functions uses
 type ExternalInterface struct {
-	CreateUser         func(asmodel.User) *errors.Error
-	GetUserDetails     func(string) (asmodel.User, *errors.Error)
-	GetRoleDetailsByID func(string) (asmodel.Role, *errors.Error)
-	UpdateUserDetails  func(asmodel.User, asmodel.User) *errors.Error
+	CreateUser           func(asmodel.User) *errors.Error
+	GetUserDetails       func(string) (asmodel.User, *errors.Error)
+	GetRoleDetailsByID   func(string) (asmodel.Role, *errors.Error)
+	UpdateUserDetails    func(asmodel.User, asmodel.User) *errors.Error
+	CheckAggregateExists func(string) *errors.Error
+	DeleteLoginAttempts  func(string) *errors.Error
+
+	GetExternalAccountProvider  func(string) (asmodel.ExternalAccountProvider, *errors.Error)
+	SaveExternalAccountProvider func(string, asmodel.ExternalAccountProvider) *errors.Error
+	GetClientCertificate        func() (asmodel.ClientCertificate, *errors.Error)
+	SaveClientCertificate       func(asmodel.ClientCertificate) *errors.Error
+
+	SaveAPIKey          func(asmodel.APIKey) *errors.Error
+	GetAPIKeyDetails    func(string) (asmodel.APIKey, *errors.Error)
+	GetUserAPIKeys      func(string) ([]asmodel.APIKey, *errors.Error)
+	DeleteAPIKeyDetails func(string) *errors.Error
+	GetAPIKeyUsage      func(string) (asmodel.APIKeyUsage, *errors.Error)
 }
 
 // GetExternalInterface retrieves all the external connections account package functions uses
 func GetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		CreateUser:         asmodel.CreateUser,
-		GetUserDetails:     asmodel.GetUserDetails,
-		GetRoleDetailsByID: asmodel.GetRoleDetailsByID,
-		UpdateUserDetails:  asmodel.UpdateUserDetails,
+		CreateUser:           asmodel.CreateUser,
+		GetUserDetails:       asmodel.GetUserDetails,
+		GetRoleDetailsByID:   asmodel.GetRoleDetailsByID,
+		UpdateUserDetails:    asmodel.UpdateUserDetails,
+		CheckAggregateExists: asmodel.CheckAggregateExists,
+		DeleteLoginAttempts:  asmodel.DeleteLoginAttempts,
+
+		GetExternalAccountProvider:  asmodel.GetExternalAccountProvider,
+		SaveExternalAccountProvider: asmodel.SaveExternalAccountProvider,
+		GetClientCertificate:        asmodel.GetClientCertificate,
+		SaveClientCertificate:       asmodel.SaveClientCertificate,
+
+		SaveAPIKey:          asmodel.CreateAPIKey,
+		GetAPIKeyDetails:    asmodel.GetAPIKey,
+		GetUserAPIKeys:      asmodel.GetAllAPIKeys,
+		DeleteAPIKeyDetails: asmodel.DeleteAPIKey,
+		GetAPIKeyUsage:      asmodel.GetAPIKeyUsage,
 	}
 }
 
@@ -53,6 +95,7 @@ func GetExternalInterface() *ExternalInterface {
 func TrackConfigFileChanges(errChan chan error) {
 	eventChan := make(chan interface{})
 	format := config.Data.LogFormat
+	oemPrivileges := config.Data.AuthConf.OEMPrivileges
 	go common.TrackConfigFileChanges(ConfigFilePath, eventChan, errChan)
 	for {
 		select {
@@ -67,8 +110,91 @@ func TrackConfigFileChanges(errChan chan error) {
 				format = config.Data.LogFormat
 				l.Log.Info("Log format is updated, new log format is ", config.Data.LogFormat)
 			}
+			if !reflect.DeepEqual(oemPrivileges, config.Data.AuthConf.OEMPrivileges) {
+				if err := PersistOEMPrivileges(); err != nil {
+					l.Log.Error(err)
+				} else {
+					oemPrivileges = config.Data.AuthConf.OEMPrivileges
+					l.Log.Info("OEM privileges are updated, new OEM privileges are ", oemPrivileges)
+				}
+			}
 		case err := <-errChan:
 			l.Log.Error(err)
 		}
 	}
 }
+
+// PersistOEMPrivileges saves the OEM privileges configured in AuthConf to the DB,
+// from where they are read for validating the OEM privileges of the roles
+func PersistOEMPrivileges() *errors.Error {
+	oemPrivileges := asmodel.OEMPrivileges{List: config.Data.AuthConf.OEMPrivileges}
+	if oemPrivileges.List == nil {
+		oemPrivileges.List = []string{}
+	}
+	return oemPrivileges.Save()
+}
+
+// getAggregates returns the URIs of the aggregates of the account OEM properties.
+// Nil is returned when the aggregates are not passed, so that the aggregates
+// of an account are not modified. A user restricted to aggregates can only give
+// a subset of its own aggregates, and can't lift the restriction with an empty list
+func (e *ExternalInterface) getAggregates(ctx context.Context, oem *asmodel.AccountOem, session *asmodel.Session, errorLogPrefix string) ([]string, response.RPC, error) {
+	if oem == nil || oem.Aggregates == nil {
+		return nil, response.RPC{}, nil
+	}
+	if len(oem.Aggregates) == 0 && len(session.Aggregates) > 0 {
+		errorMessage := errorLogPrefix + "User restricted to aggregates can not remove the aggregates of an account"
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, http.StatusForbidden)
+		return nil, common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	aggregates := make([]string, 0, len(oem.Aggregates))
+	for _, aggregate := range oem.Aggregates {
+		if len(session.Aggregates) > 0 && !isAggregateInScope(aggregate.OdataID, session.Aggregates) {
+			errorMessage := errorLogPrefix + "User is not restricted to the aggregate " + aggregate.OdataID
+			auth.CustomAuthLog(ctx, session.Token, errorMessage, http.StatusForbidden)
+			return nil, common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+		}
+		if err := e.CheckAggregateExists(aggregate.OdataID); err != nil {
+			errorMessage := errorLogPrefix + "Invalid aggregate present: " + err.Error()
+			l.LogWithFields(ctx).Error(errorMessage)
+			if err.ErrNo() == errors.DBKeyNotFound {
+				return nil, common.GeneralError(http.StatusBadRequest, response.ResourceNotFound, errorMessage, []interface{}{"Aggregate", aggregate.OdataID}, nil), fmt.Errorf(errorMessage)
+			}
+			return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+		}
+		aggregates = append(aggregates, aggregate.OdataID)
+	}
+	return aggregates, response.RPC{}, nil
+}
+
+// isAggregateInScope checks if the aggregate is one of the aggregates of the scope
+func isAggregateInScope(aggregate string, scope []string) bool {
+	for _, scopeAggregate := range scope {
+		if strings.TrimSuffix(scopeAggregate, "/") == strings.TrimSuffix(aggregate, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// getPasswordExpiration returns the time the password of the user expires, formatted
+// for displaying the account. The time is empty when the password doesn't expire
+func getPasswordExpiration(user asmodel.User) string {
+	expiration, expires := user.PasswordExpiration(config.Data.AuthConf.PasswordRules.PasswordExpirationDays)
+	if !expires {
+		return ""
+	}
+	return expiration.Format(time.RFC3339)
+}
+
+// getAccountOEM returns the OEM properties of an account restricted to the aggregates
+func getAccountOEM(aggregates []string) *asresponse.OEM {
+	if len(aggregates) == 0 {
+		return nil
+	}
+	oem := asresponse.OEM{}
+	for _, aggregate := range aggregates {
+		oem.Aggregates = append(oem.Aggregates, asresponse.Aggregate{OdataID: aggregate})
+	}
+	return &oem
+}
@@ -43,11 +43,105 @@ func TestGetExternalInterface(t *testing.T) {
 
 func getMockExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		CreateUser:         mockCreateUser,
-		GetUserDetails:     mockGetUserDetails,
-		GetRoleDetailsByID: mockGetRoleDetailsByID,
-		UpdateUserDetails:  mockUpdateUserDetails,
+		CreateUser:           mockCreateUser,
+		GetUserDetails:       mockGetUserDetails,
+		GetRoleDetailsByID:   mockGetRoleDetailsByID,
+		UpdateUserDetails:    mockUpdateUserDetails,
+		CheckAggregateExists: mockCheckAggregateExists,
+		DeleteLoginAttempts:  mockDeleteLoginAttempts,
+
+		GetExternalAccountProvider:  mockGetExternalAccountProvider,
+		SaveExternalAccountProvider: mockSaveExternalAccountProvider,
+		GetClientCertificate:        mockGetClientCertificate,
+		SaveClientCertificate:       mockSaveClientCertificate,
+
+		SaveAPIKey:          mockSaveAPIKey,
+		GetAPIKeyDetails:    mockGetAPIKeyDetails,
+		GetUserAPIKeys:      mockGetUserAPIKeys,
+		DeleteAPIKeyDetails: mockDeleteAPIKeyDetails,
+		GetAPIKeyUsage:      mockGetAPIKeyUsage,
+	}
+}
+
+// mockExternalAccountProviders holds the external account providers saved by the mock
+var mockExternalAccountProviders = map[string]asmodel.ExternalAccountProvider{}
+
+func mockGetExternalAccountProvider(key string) (asmodel.ExternalAccountProvider, *errors.Error) {
+	provider, ok := mockExternalAccountProviders[key]
+	if !ok {
+		return provider, errors.PackError(errors.DBKeyNotFound, "error: data with key "+key+" not found")
+	}
+	return provider, nil
+}
+
+func mockSaveExternalAccountProvider(key string, provider asmodel.ExternalAccountProvider) *errors.Error {
+	mockExternalAccountProviders[key] = provider
+	return nil
+}
+
+// mockClientCertificate holds the settings of the client certificate authentication saved by the mock
+var mockClientCertificate *asmodel.ClientCertificate
+
+func mockGetClientCertificate() (asmodel.ClientCertificate, *errors.Error) {
+	if mockClientCertificate == nil {
+		return asmodel.ClientCertificate{}, errors.PackError(errors.DBKeyNotFound, "error: data with key ClientCertificate not found")
 	}
+	return *mockClientCertificate, nil
+}
+
+func mockSaveClientCertificate(clientCertificate asmodel.ClientCertificate) *errors.Error {
+	mockClientCertificate = &clientCertificate
+	return nil
+}
+
+// mockAPIKeys holds the API keys saved by the mock
+var mockAPIKeys = map[string]asmodel.APIKey{}
+
+func mockSaveAPIKey(key asmodel.APIKey) *errors.Error {
+	mockAPIKeys[key.ID] = key
+	return nil
+}
+
+func mockGetAPIKeyDetails(keyID string) (asmodel.APIKey, *errors.Error) {
+	key, ok := mockAPIKeys[keyID]
+	if !ok {
+		return key, errors.PackError(errors.DBKeyNotFound, "error: data with key "+keyID+" not found")
+	}
+	return key, nil
+}
+
+func mockGetUserAPIKeys(userName string) ([]asmodel.APIKey, *errors.Error) {
+	var keys []asmodel.APIKey
+	for _, key := range mockAPIKeys {
+		if key.UserName == userName {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func mockDeleteAPIKeyDetails(keyID string) *errors.Error {
+	delete(mockAPIKeys, keyID)
+	return nil
+}
+
+func mockGetAPIKeyUsage(keyID string) (asmodel.APIKeyUsage, *errors.Error) {
+	return asmodel.APIKeyUsage{ID: keyID, UsageCount: 3}, nil
+}
+
+// mockUnlockedAccounts holds the accounts unlocked by the mock
+var mockUnlockedAccounts = map[string]bool{}
+
+func mockDeleteLoginAttempts(userName string) *errors.Error {
+	mockUnlockedAccounts[userName] = true
+	return nil
+}
+
+func mockCheckAggregateExists(aggregateURI string) *errors.Error {
+	if aggregateURI != "/redfish/v1/AggregationService/Aggregates/aggregate1" {
+		return errors.PackError(errors.DBKeyNotFound, "error: data with key "+aggregateURI+" not found")
+	}
+	return nil
 }
 
 func mockCreateUser(user asmodel.User) *errors.Error {
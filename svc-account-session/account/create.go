@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/sha3"
 
@@ -113,6 +114,15 @@ func (e *ExternalInterface) Create(ctx context.Context, req *accountproto.Create
 		l.LogWithFields(ctx).Error(errorMessage)
 		return common.GeneralError(http.StatusBadRequest, response.ResourceNotFound, errorMessage, []interface{}{"Role", user.RoleID}, nil), fmt.Errorf(errorMessage)
 	}
+	aggregates, resp, err := e.getAggregates(ctx, createAccount.Oem, session, errorLogPrefix)
+	if err != nil {
+		return resp, err
+	}
+	// an account created by a user restricted to aggregates is restricted to the same aggregates
+	if aggregates == nil && len(session.Aggregates) > 0 {
+		aggregates = session.Aggregates
+	}
+	user.Aggregates = aggregates
 	if err := validatePassword(user.UserName, user.Password); err != nil {
 		errorMessage := err.Error()
 		resp.StatusCode = http.StatusBadRequest
@@ -128,6 +138,10 @@ func (e *ExternalInterface) Create(ctx context.Context, req *accountproto.Create
 	hashSum := hash.Sum(nil)
 	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
 	user.Password = hashedPassword
+	user.PasswordChangedTime = time.Now()
+	if createAccount.PasswordChangeRequired != nil {
+		user.PasswordChangeRequired = *createAccount.PasswordChangeRequired
+	}
 	user.AccountTypes = []string{"Redfish"}
 	if cerr := e.CreateUser(user); cerr != nil {
 		errorMessage := errorLogPrefix + cerr.Error()
@@ -153,15 +167,18 @@ func (e *ExternalInterface) Create(ctx context.Context, req *accountproto.Create
 
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		PasswordExpiration:     getPasswordExpiration(user),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID,
 			},
 		},
+		OEM: getAccountOEM(user.Aggregates),
 	}
 
 	return resp, nil
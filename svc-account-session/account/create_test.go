@@ -88,6 +88,22 @@ func TestCreate(t *testing.T) {
 		Password: "Password@123",
 		RoleID:   "Administrator",
 	})
+	reqBodyAggregateAcc, _ := json.Marshal(asmodel.Account{
+		UserName: "testUser",
+		Password: "Password@123",
+		RoleID:   "Administrator",
+		Oem: &asmodel.AccountOem{
+			Aggregates: []asmodel.Link{{OdataID: "/redfish/v1/AggregationService/Aggregates/aggregate1"}},
+		},
+	})
+	reqBodyInvalidAggregate, _ := json.Marshal(asmodel.Account{
+		UserName: "testUser5",
+		Password: "Password@123",
+		RoleID:   "Administrator",
+		Oem: &asmodel.AccountOem{
+			Aggregates: []asmodel.Link{{OdataID: "/redfish/v1/AggregationService/Aggregates/invalid"}},
+		},
+	})
 	reqBodyInvalidRole, _ := json.Marshal(asmodel.Account{
 		UserName: "testUser1",
 		Password: "Password@123",
@@ -191,6 +207,57 @@ func TestCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "successful account creation restricted to an aggregate",
+			args: args{
+				req: &accountproto.CreateAccountRequest{
+					RequestBody: reqBodyAggregateAcc,
+				},
+				session: &asmodel.Session{
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureUsers: true,
+					},
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusCreated,
+				StatusMessage: response.Created,
+				Header: map[string]string{
+					"Link":     "</redfish/v1/AccountService/Accounts/testUser/>; rel=describedby",
+					"Location": "/redfish/v1/AccountService/Accounts/testUser",
+				},
+				Body: asresponse.Account{
+					Response:     successResponse,
+					UserName:     "testUser",
+					RoleID:       "Administrator",
+					AccountTypes: []string{"Redfish"},
+					Links: asresponse.Links{
+						Role: asresponse.Role{
+							OdataID: "/redfish/v1/AccountService/Roles/Administrator",
+						},
+					},
+					OEM: &asresponse.OEM{
+						Aggregates: []asresponse.Aggregate{{OdataID: "/redfish/v1/AggregationService/Aggregates/aggregate1"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "request body with invalid aggregate",
+			args: args{
+				req: &accountproto.CreateAccountRequest{
+					RequestBody: reqBodyInvalidAggregate,
+				},
+				session: &asmodel.Session{
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureUsers: true,
+					},
+				},
+			},
+			want:    common.GeneralError(http.StatusBadRequest, response.ResourceNotFound, "failed to create account for the user testUser5: Invalid aggregate present: error: data with key /redfish/v1/AggregationService/Aggregates/invalid not found", []interface{}{"Aggregate", "/redfish/v1/AggregationService/Aggregates/invalid"}, nil),
+			wantErr: true,
+		},
 		{
 			name: "request body with invalid role",
 			args: args{
@@ -413,3 +480,67 @@ func TestCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAggregateScope(t *testing.T) {
+	config.SetUpMockConfig(t)
+	acc := getMockExternalInterface()
+	common.SetUpMockConfig()
+	ctx := mockContext()
+	aggregate1 := "/redfish/v1/AggregationService/Aggregates/aggregate1"
+	requestBody := func(aggregates []asmodel.Link) []byte {
+		account := asmodel.Account{UserName: "testUser", Password: "Password@123", RoleID: "Administrator"}
+		if aggregates != nil {
+			account.Oem = &asmodel.AccountOem{Aggregates: aggregates}
+		}
+		body, _ := json.Marshal(account)
+		return body
+	}
+	scopedSession := &asmodel.Session{
+		Privileges: map[string]bool{common.PrivilegeConfigureUsers: true},
+		Aggregates: []string{aggregate1},
+	}
+	tests := []struct {
+		name           string
+		body           []byte
+		wantStatus     int32
+		wantAggregates []asresponse.Aggregate
+	}{
+		{
+			name:           "aggregate in the scope",
+			body:           requestBody([]asmodel.Link{{OdataID: aggregate1}}),
+			wantStatus:     http.StatusCreated,
+			wantAggregates: []asresponse.Aggregate{{OdataID: aggregate1}},
+		},
+		{
+			name:           "account without aggregates is restricted to the scope",
+			body:           requestBody(nil),
+			wantStatus:     http.StatusCreated,
+			wantAggregates: []asresponse.Aggregate{{OdataID: aggregate1}},
+		},
+		{
+			name:       "aggregate out of the scope",
+			body:       requestBody([]asmodel.Link{{OdataID: "/redfish/v1/AggregationService/Aggregates/aggregate2"}}),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "empty aggregates",
+			body:       requestBody([]asmodel.Link{}),
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := acc.Create(ctx, &accountproto.CreateAccountRequest{RequestBody: tt.body}, scopedSession)
+			if got.StatusCode != tt.wantStatus {
+				t.Fatalf("Create() status = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+			if tt.wantAggregates == nil {
+				return
+			}
+			account, _ := got.Body.(asresponse.Account)
+			if account.OEM == nil || !reflect.DeepEqual(account.OEM.Aggregates, tt.wantAggregates) {
+				t.Errorf("Create() OEM = %v, want aggregates %v", account.OEM, tt.wantAggregates)
+			}
+		})
+	}
+}
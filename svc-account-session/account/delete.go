@@ -88,6 +88,11 @@ func Delete(ctx context.Context, session *asmodel.Session, accountID string) res
 		l.LogWithFields(ctx).Error(errorMessage)
 		return resp
 	}
+	// the API keys of the account are revoked, for them not to be valid
+	// for an account created later with the same name
+	if derr := asmodel.DeleteAllAPIKeys(accountID); derr != nil {
+		l.LogWithFields(ctx).Error(errorLogPrefix + "Unable to revoke the API keys of the account: " + derr.Error())
+	}
 
 	resp.StatusCode = http.StatusNoContent
 	resp.StatusMessage = response.AccountRemoved
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/http"
 
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -146,6 +147,13 @@ func GetAccount(ctx context.Context, session *asmodel.Session, accountID string)
 		l.LogWithFields(ctx).Error(errorMessage)
 		return resp
 	}
+	locked, err := IsAccountLockedFunc(accountID)
+	if err != nil {
+		errorMessage := errLogPrefix + "Unable to get the lockout state of the account: " + err.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		l.LogWithFields(ctx).Error(errorMessage)
+		return resp
+	}
 
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
@@ -157,15 +165,19 @@ func GetAccount(ctx context.Context, session *asmodel.Session, accountID string)
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	commonResponse = mapEmptyValuesResponseFields(commonResponse)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		Locked:                 locked,
+		PasswordChangeRequired: user.IsPasswordChangeRequired(config.Data.AuthConf.PasswordRules.PasswordExpirationDays),
+		PasswordExpiration:     getPasswordExpiration(user),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID,
 			},
 		},
+		OEM: getAccountOEM(user.Aggregates),
 	}
 
 	return resp
@@ -204,6 +216,32 @@ func GetAccountService(ctx context.Context) response.RPC {
 		"Link": "	</redfish/v1/SchemaStore/en/AccountService.json>; rel=describedby",
 	}
 
+	ldap, err := getExternalAccountProvider(asmodel.LDAPProvider)
+	if err != nil {
+		errorMessage := "failed to fetch the LDAP external account provider: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	activeDirectory, err := getExternalAccountProvider(asmodel.ActiveDirectoryProvider)
+	if err != nil {
+		errorMessage := "failed to fetch the ActiveDirectory external account provider: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	oauth2, err := getExternalAccountProvider(asmodel.OAuth2Provider)
+	if err != nil {
+		errorMessage := "failed to fetch the OAuth2 external account provider: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	multiFactorAuth, err := getMultiFactorAuth()
+	if err != nil {
+		errorMessage := "failed to fetch the client certificate settings: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	commonResponse = mapEmptyValuesResponseFields(commonResponse)
 	resp.Body = asresponse.AccountService{
@@ -213,14 +251,26 @@ func GetAccountService(ctx context.Context) response.RPC {
 			State:  serviceState,
 			Health: "OK",
 		},
-		ServiceEnabled:    isServiceEnabled,
-		MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+		ServiceEnabled:                    isServiceEnabled,
+		MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+		PasswordExpirationDays:            config.Data.AuthConf.PasswordRules.PasswordExpirationDays,
+		AccountLockoutThreshold:           config.Data.AuthConf.AccountLockoutThreshold,
+		AccountLockoutDuration:            config.Data.AuthConf.AccountLockoutDuration,
+		AccountLockoutCounterResetAfter:   config.Data.AuthConf.AccountLockoutCounterResetAfter,
+		AccountLockoutCounterResetEnabled: true,
 		Accounts: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Accounts",
 		},
 		Roles: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Roles",
 		},
+		PrivilegeMap: &dmtf.Link{
+			Oid: "/redfish/v1/AccountService/PrivilegeMap",
+		},
+		LDAP:            ldap,
+		ActiveDirectory: activeDirectory,
+		OAuth2:          oauth2,
+		MultiFactorAuth: multiFactorAuth,
 	}
 
 	return resp
@@ -19,8 +19,10 @@ import (
 	"reflect"
 	"testing"
 
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
@@ -256,6 +258,18 @@ func TestGetAccount(t *testing.T) {
 func TestGetAccountService(t *testing.T) {
 	successResponse := createMockResponseObject(common.AccountServiceType, "/redfish/v1/AccountService", "/redfish/v1/$metadata#AccountService.AccountService", "AccountService")
 	common.SetUpMockConfig()
+	defer func(get func(string) (asmodel.ExternalAccountProvider, *errors.Error), getClientCertificate func() (asmodel.ClientCertificate, *errors.Error)) {
+		GetExternalAccountProviderFunc = get
+		GetClientCertificateFunc = getClientCertificate
+	}(GetExternalAccountProviderFunc, GetClientCertificateFunc)
+	GetExternalAccountProviderFunc = mockGetExternalAccountProvider
+	GetClientCertificateFunc = mockGetClientCertificate
+	multiFactorAuth := &asresponse.MultiFactorAuth{
+		ClientCertificate: &asresponse.ClientCertificate{
+			CertificateMappingAttribute:     "CommonName",
+			RespondToUnauthenticatedClients: true,
+		},
+	}
 	tests := []struct {
 		name string
 		want response.RPC
@@ -274,14 +288,22 @@ func TestGetAccountService(t *testing.T) {
 						State:  "Enabled",
 						Health: "OK",
 					},
-					ServiceEnabled:    true,
-					MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					ServiceEnabled:                    true,
+					MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					AccountLockoutThreshold:           config.Data.AuthConf.AccountLockoutThreshold,
+					AccountLockoutDuration:            config.Data.AuthConf.AccountLockoutDuration,
+					AccountLockoutCounterResetAfter:   config.Data.AuthConf.AccountLockoutCounterResetAfter,
+					AccountLockoutCounterResetEnabled: true,
 					Accounts: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Accounts",
 					},
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					PrivilegeMap: &dmtf.Link{
+						Oid: "/redfish/v1/AccountService/PrivilegeMap",
+					},
+					MultiFactorAuth: multiFactorAuth,
 				},
 			},
 		},
@@ -299,14 +321,22 @@ func TestGetAccountService(t *testing.T) {
 						State:  "Disabled",
 						Health: "OK",
 					},
-					ServiceEnabled:    false,
-					MinPasswordLength: config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					ServiceEnabled:                    false,
+					MinPasswordLength:                 config.Data.AuthConf.PasswordRules.MinPasswordLength,
+					AccountLockoutThreshold:           config.Data.AuthConf.AccountLockoutThreshold,
+					AccountLockoutDuration:            config.Data.AuthConf.AccountLockoutDuration,
+					AccountLockoutCounterResetAfter:   config.Data.AuthConf.AccountLockoutCounterResetAfter,
+					AccountLockoutCounterResetEnabled: true,
 					Accounts: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Accounts",
 					},
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					PrivilegeMap: &dmtf.Link{
+						Oid: "/redfish/v1/AccountService/PrivilegeMap",
+					},
+					MultiFactorAuth: multiFactorAuth,
 				},
 			},
 		},
@@ -24,8 +24,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
@@ -129,6 +131,17 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 
 	}
 
+	// the account is locked only after consecutive failed logins, so it can only be unlocked
+	if updateAccount.Locked != nil && *updateAccount.Locked {
+		errorMessage := errorLogPrefix + "Locked can only be set to false for unlocking the account"
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{"true", "Locked"}, nil)
+	}
+
+	if requestUser.Aggregates, resp, err = e.getAggregates(ctx, updateAccount.Oem, session, errorLogPrefix); err != nil {
+		return resp
+	}
+
 	l.LogWithFields(ctx).Infof("Fetching details of user %s from the database", id)
 	user, gerr := e.GetUserDetails(id)
 	if gerr != nil {
@@ -172,6 +185,38 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 		}
 	}
 
+	// the aggregates restrict the resources an account can access, so
+	// as the role, only a user with ConfigureUsers privilege can update them
+	if requestUser.Aggregates != nil && !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := errorLogPrefix + "User does not have the privilege of updating the aggregates of any account, including his own account"
+		resp.StatusCode = http.StatusForbidden
+		resp.StatusMessage = response.InsufficientPrivilege
+		args := GetResponseArgs(resp.StatusMessage, errorMessage, []interface{}{})
+		resp.Body = args.CreateGenericErrorResponse()
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+
+	if updateAccount.Locked != nil && !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := errorLogPrefix + "User does not have the privilege of unlocking any account, including his own account"
+		resp.StatusCode = http.StatusForbidden
+		resp.StatusMessage = response.InsufficientPrivilege
+		args := GetResponseArgs(resp.StatusMessage, errorMessage, []interface{}{})
+		resp.Body = args.CreateGenericErrorResponse()
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+
+	if updateAccount.PasswordChangeRequired != nil && !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := errorLogPrefix + "User does not have the privilege of requiring the password change of any account, including his own account"
+		resp.StatusCode = http.StatusForbidden
+		resp.StatusMessage = response.InsufficientPrivilege
+		args := GetResponseArgs(resp.StatusMessage, errorMessage, []interface{}{})
+		resp.Body = args.CreateGenericErrorResponse()
+		auth.CustomAuthLog(ctx, session.Token, errorMessage, resp.StatusCode)
+		return resp
+	}
+
 	if requestUser.Password != "" {
 		// Password modification not allowed, if user doesn't have ConfigureSelf or ConfigureUsers privilege
 		if !session.Privileges[common.PrivilegeConfigureSelf] && !session.Privileges[common.PrivilegeConfigureUsers] {
@@ -198,7 +243,34 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 		hash.Write([]byte(requestUser.Password))
 		hashSum := hash.Sum(nil)
 		hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
+		if isRecentPassword(user, hashedPassword) {
+			errorMessage := fmt.Sprintf("error: invalid password, password is one of the last %d passwords of the user",
+				config.Data.AuthConf.PasswordRules.PasswordHistoryCount)
+			resp.StatusCode = http.StatusBadRequest
+			resp.StatusMessage = response.PropertyValueFormatError
+			args := GetResponseArgs(resp.StatusMessage, errorMessage, []interface{}{requestUser.Password, "Password"})
+			resp.Body = args.CreateGenericErrorResponse()
+			l.LogWithFields(ctx).Error(errorMessage)
+			return resp
+		}
 		requestUser.Password = hashedPassword
+		requestUser.PasswordChangedTime = time.Now()
+		requestUser.PasswordHistory = getPasswordHistory(user)
+		// changing the password fulfills the requirement of changing it
+		user.PasswordChangeRequired = false
+	}
+	if updateAccount.PasswordChangeRequired != nil {
+		user.PasswordChangeRequired = *updateAccount.PasswordChangeRequired
+	}
+
+	if updateAccount.Locked != nil {
+		l.LogWithFields(ctx).Infof("Unlocking the account %s", id)
+		if derr := e.DeleteLoginAttempts(id); derr != nil {
+			errorMessage := errorLogPrefix + "Unable to unlock the account: " + derr.Error()
+			resp.CreateInternalErrorResponse(errorMessage)
+			l.LogWithFields(ctx).Error(errorMessage)
+			return resp
+		}
 	}
 
 	l.LogWithFields(ctx).Infof("Updating the account %s", id)
@@ -219,22 +291,70 @@ func (e *ExternalInterface) Update(ctx context.Context, req *accountproto.Update
 	if requestUser.RoleID != "" {
 		user.RoleID = requestUser.RoleID
 	}
+	if requestUser.Aggregates != nil {
+		user.Aggregates = requestUser.Aggregates
+	}
+	if requestUser.Password != "" {
+		user.PasswordChangedTime = requestUser.PasswordChangedTime
+	}
+	locked, lerr := IsAccountLockedFunc(id)
+	if lerr != nil {
+		l.LogWithFields(ctx).Error(errorLogPrefix + "Unable to get the lockout state of the account: " + lerr.Error())
+	}
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		Locked:                 locked,
+		PasswordChangeRequired: user.IsPasswordChangeRequired(config.Data.AuthConf.PasswordRules.PasswordExpirationDays),
+		PasswordExpiration:     getPasswordExpiration(user),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID,
 			},
 		},
+		OEM: getAccountOEM(user.Aggregates),
 	}
 
 	return resp
 }
 
+// isRecentPassword checks the hashed password is one of the recent passwords of the
+// user, including the current one, which can't be reused as per the password history
+func isRecentPassword(user asmodel.User, hashedPassword string) bool {
+	historyCount := config.Data.AuthConf.PasswordRules.PasswordHistoryCount
+	if historyCount <= 0 {
+		return false
+	}
+	recentPasswords := append([]string{user.Password}, user.PasswordHistory...)
+	if len(recentPasswords) > historyCount {
+		recentPasswords = recentPasswords[:historyCount]
+	}
+	for _, password := range recentPasswords {
+		if password == hashedPassword {
+			return true
+		}
+	}
+	return false
+}
+
+// getPasswordHistory returns the previous passwords of the user to be stored, when the current
+// password is changed. The current password is added to the history, which is trimmed to the
+// passwords checked for the reuse
+func getPasswordHistory(user asmodel.User) []string {
+	historyCount := config.Data.AuthConf.PasswordRules.PasswordHistoryCount - 1
+	if historyCount <= 0 {
+		return nil
+	}
+	history := append([]string{user.Password}, user.PasswordHistory...)
+	if len(history) > historyCount {
+		history = history[:historyCount]
+	}
+	return history
+}
+
 func isEmptyRequest(requestBody []byte) bool {
 	var updateRequest map[string]interface{}
 	json.Unmarshal(requestBody, &updateRequest)
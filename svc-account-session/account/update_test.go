@@ -14,6 +14,7 @@
 package account
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"reflect"
@@ -21,10 +22,13 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asresponse"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+	"golang.org/x/crypto/sha3"
 )
 
 func TestUpdate(t *testing.T) {
@@ -82,6 +86,13 @@ func TestUpdate(t *testing.T) {
 		RoleID: "Administrator",
 	})
 
+	reqBodyAggregates, _ := json.Marshal(asmodel.Account{
+		Oem: &asmodel.AccountOem{
+			Aggregates: []asmodel.Link{{OdataID: "/redfish/v1/AggregationService/Aggregates/aggregate1"}},
+		},
+	})
+	errArgs6 := GetResponseArgs(response.InsufficientPrivilege, "failed to update the account testUser3: User does not have the privilege of updating the aggregates of any account, including his own account", []interface{}{})
+
 	emptyPayload, _ := json.Marshal(map[string]interface{}{})
 
 	tests := []struct {
@@ -459,6 +470,63 @@ func TestUpdate(t *testing.T) {
 				Body:          errArgs1.CreateGenericErrorResponse(),
 			},
 		},
+		{
+			name: "successful updation of account aggregates as admin",
+			args: args{
+				req: &accountproto.UpdateAccountRequest{
+					RequestBody: reqBodyAggregates,
+					AccountID:   "testUser1",
+				},
+				session: &asmodel.Session{
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureUsers: true,
+					},
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.AccountModified,
+				Header: map[string]string{
+					"Link":     "</redfish/v1/AccountService/Accounts/testUser1/>; rel=describedby",
+					"Location": "/redfish/v1/AccountService/Accounts/testUser1",
+				},
+				Body: asresponse.Account{
+					Response: successResponse,
+					UserName: "testUser1",
+					RoleID:   common.RoleAdmin,
+					Links: asresponse.Links{
+						Role: asresponse.Role{
+							OdataID: "/redfish/v1/AccountService/Roles/" + common.RoleAdmin,
+						},
+					},
+					OEM: &asresponse.OEM{
+						Aggregates: []asresponse.Aggregate{{OdataID: "/redfish/v1/AggregationService/Aggregates/aggregate1"}},
+					},
+				},
+			},
+		},
+		{
+			name: "update own account aggregates without ConfigureUsers privilege",
+			args: args{
+				req: &accountproto.UpdateAccountRequest{
+					RequestBody: reqBodyAggregates,
+					AccountID:   "testUser3",
+				},
+				session: &asmodel.Session{
+					ID:       "testUser3",
+					UserName: "testUser3",
+					RoleID:   "PrivilegeLogin",
+					Privileges: map[string]bool{
+						common.PrivilegeConfigureSelf: true,
+					},
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusForbidden,
+				StatusMessage: response.InsufficientPrivilege,
+				Body:          errArgs6.CreateGenericErrorResponse(),
+			},
+		},
 		{
 			name: "update account without payload",
 			args: args{
@@ -500,3 +568,235 @@ func createMockUpdateResponseObject(odataType, odataID, odataContext, ID string)
 	successResponse.CreateGenericResponse(response.AccountModified)
 	return successResponse
 }
+
+func TestUpdateLocked(t *testing.T) {
+	config.SetUpMockConfig(t)
+	acc := getMockExternalInterface()
+	ctx := mockContext()
+	defer func() {
+		IsAccountLockedFunc = auth.IsAccountLocked
+	}()
+	IsAccountLockedFunc = func(userName string) (bool, *errors.Error) {
+		return false, nil
+	}
+
+	reqBodyUnlock, _ := json.Marshal(map[string]interface{}{"Locked": false})
+	reqBodyLock, _ := json.Marshal(map[string]interface{}{"Locked": true})
+
+	errArgLock := GetResponseArgs(response.PropertyValueNotInList, "failed to update the account testUser1: Locked can only be set to false for unlocking the account", []interface{}{"true", "Locked"})
+	errArgPrivilege := GetResponseArgs(response.InsufficientPrivilege, "failed to update the account operatorUser: User does not have the privilege of unlocking any account, including his own account", []interface{}{})
+
+	tests := []struct {
+		name         string
+		req          *accountproto.UpdateAccountRequest
+		session      *asmodel.Session
+		wantStatus   int32
+		wantBody     interface{}
+		wantUnlocked bool
+	}{
+		{
+			name:       "unlock account as admin",
+			req:        &accountproto.UpdateAccountRequest{RequestBody: reqBodyUnlock, AccountID: "testUser1"},
+			session:    &asmodel.Session{Privileges: map[string]bool{common.PrivilegeConfigureUsers: true}},
+			wantStatus: http.StatusOK,
+			wantBody: asresponse.Account{
+				Response: createMockUpdateResponseObject(common.ManagerAccountType, "/redfish/v1/AccountService/Accounts/testUser1", "/redfish/v1/$metadata#ManagerAccount.ManagerAccount", "testUser1"),
+				UserName: "testUser1",
+				RoleID:   common.RoleAdmin,
+				Links: asresponse.Links{
+					Role: asresponse.Role{
+						OdataID: "/redfish/v1/AccountService/Roles/" + common.RoleAdmin,
+					},
+				},
+			},
+			wantUnlocked: true,
+		},
+		{
+			name:       "lock account is not allowed",
+			req:        &accountproto.UpdateAccountRequest{RequestBody: reqBodyLock, AccountID: "testUser1"},
+			session:    &asmodel.Session{Privileges: map[string]bool{common.PrivilegeConfigureUsers: true}},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   errArgLock.CreateGenericErrorResponse(),
+		},
+		{
+			name:       "unlock own account without ConfigureUsers privilege",
+			req:        &accountproto.UpdateAccountRequest{RequestBody: reqBodyUnlock, AccountID: "operatorUser"},
+			session:    &asmodel.Session{UserName: "operatorUser", Privileges: map[string]bool{common.PrivilegeConfigureSelf: true}},
+			wantStatus: http.StatusForbidden,
+			wantBody:   errArgPrivilege.CreateGenericErrorResponse(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUnlockedAccounts = map[string]bool{}
+			got := acc.Update(ctx, tt.req, tt.session)
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("Update() status = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(got.Body, tt.wantBody) {
+				t.Errorf("Update() body = %v, want %v", got.Body, tt.wantBody)
+			}
+			if mockUnlockedAccounts[tt.req.AccountID] != tt.wantUnlocked {
+				t.Errorf("Update() unlocked = %v, want %v", mockUnlockedAccounts[tt.req.AccountID], tt.wantUnlocked)
+			}
+		})
+	}
+}
+
+func TestUpdatePasswordRules(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	defer func() {
+		IsAccountLockedFunc = auth.IsAccountLocked
+	}()
+	IsAccountLockedFunc = func(userName string) (bool, *errors.Error) {
+		return false, nil
+	}
+	hashPassword := func(password string) string {
+		hash := sha3.New512()
+		hash.Write([]byte(password))
+		return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+	}
+	// the history count of the mock config is 3, the current and the last 2 passwords can't be reused
+	user := asmodel.User{
+		UserName:        "testUser1",
+		Password:        hashPassword("Current@12345"),
+		RoleID:          common.RoleAdmin,
+		PasswordHistory: []string{hashPassword("Previous@1234"), hashPassword("Previous@5678"), hashPassword("Oldest@123456")},
+	}
+	var updatedUser, updatedData asmodel.User
+	acc := getMockExternalInterface()
+	acc.GetUserDetails = func(userName string) (asmodel.User, *errors.Error) {
+		return user, nil
+	}
+	acc.UpdateUserDetails = func(user, newData asmodel.User) *errors.Error {
+		updatedUser, updatedData = user, newData
+		return nil
+	}
+	adminSession := &asmodel.Session{UserName: "testUser1", Privileges: map[string]bool{common.PrivilegeConfigureUsers: true}}
+	selfSession := &asmodel.Session{UserName: "testUser1", Privileges: map[string]bool{common.PrivilegeConfigureSelf: true}}
+
+	tests := []struct {
+		name                       string
+		body                       map[string]interface{}
+		session                    *asmodel.Session
+		changeRequired             bool
+		wantStatus                 int32
+		wantHistory                []string
+		wantChangeRequired         bool
+		wantResponseChangeRequired bool
+	}{
+		{
+			name:       "reuse of the current password",
+			body:       map[string]interface{}{"Password": "Current@12345"},
+			session:    selfSession,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "reuse of a recent password",
+			body:       map[string]interface{}{"Password": "Previous@5678"},
+			session:    selfSession,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:               "reuse of a password older than the history",
+			body:               map[string]interface{}{"Password": "Oldest@123456"},
+			session:            selfSession,
+			changeRequired:     true,
+			wantStatus:         http.StatusOK,
+			wantHistory:        []string{hashPassword("Current@12345"), hashPassword("Previous@1234")},
+			wantChangeRequired: false,
+		},
+		{
+			name:       "require password change without ConfigureUsers privilege",
+			body:       map[string]interface{}{"PasswordChangeRequired": true},
+			session:    selfSession,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:                       "require password change as admin",
+			body:                       map[string]interface{}{"PasswordChangeRequired": true},
+			session:                    adminSession,
+			wantStatus:                 http.StatusOK,
+			wantChangeRequired:         true,
+			wantResponseChangeRequired: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user.PasswordChangeRequired = tt.changeRequired
+			updatedUser, updatedData = asmodel.User{}, asmodel.User{}
+			reqBody, _ := json.Marshal(tt.body)
+			got := acc.Update(ctx, &accountproto.UpdateAccountRequest{RequestBody: reqBody, AccountID: "testUser1"}, tt.session)
+			if got.StatusCode != tt.wantStatus {
+				t.Fatalf("Update() status = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+			if got.StatusCode != http.StatusOK {
+				return
+			}
+			if !reflect.DeepEqual(updatedData.PasswordHistory, tt.wantHistory) {
+				t.Errorf("Update() password history = %v, want %v", updatedData.PasswordHistory, tt.wantHistory)
+			}
+			if updatedUser.PasswordChangeRequired != tt.wantChangeRequired {
+				t.Errorf("Update() PasswordChangeRequired = %v, want %v", updatedUser.PasswordChangeRequired, tt.wantChangeRequired)
+			}
+			if body := got.Body.(asresponse.Account); body.PasswordChangeRequired != tt.wantResponseChangeRequired {
+				t.Errorf("Update() response PasswordChangeRequired = %v, want %v", body.PasswordChangeRequired, tt.wantResponseChangeRequired)
+			}
+		})
+	}
+}
+
+func TestUpdateAggregateScope(t *testing.T) {
+	config.SetUpMockConfig(t)
+	acc := getMockExternalInterface()
+	ctx := mockContext()
+	aggregate1 := "/redfish/v1/AggregationService/Aggregates/aggregate1"
+	requestBody := func(aggregates []asmodel.Link) []byte {
+		body, _ := json.Marshal(asmodel.Account{Oem: &asmodel.AccountOem{Aggregates: aggregates}})
+		return body
+	}
+	scopedSession := &asmodel.Session{
+		Privileges: map[string]bool{common.PrivilegeConfigureUsers: true},
+		Aggregates: []string{aggregate1},
+	}
+	tests := []struct {
+		name       string
+		body       []byte
+		session    *asmodel.Session
+		wantStatus int32
+	}{
+		{
+			name:       "aggregate in the scope",
+			body:       requestBody([]asmodel.Link{{OdataID: aggregate1}}),
+			session:    scopedSession,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "aggregate out of the scope",
+			body:       requestBody([]asmodel.Link{{OdataID: "/redfish/v1/AggregationService/Aggregates/aggregate2"}}),
+			session:    scopedSession,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "empty aggregates by a user restricted to aggregates",
+			body:       requestBody([]asmodel.Link{}),
+			session:    scopedSession,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "empty aggregates by an unrestricted user",
+			body:       requestBody([]asmodel.Link{}),
+			session:    &asmodel.Session{Privileges: map[string]bool{common.PrivilegeConfigureUsers: true}},
+			wantStatus: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := acc.Update(ctx, &accountproto.UpdateAccountRequest{RequestBody: tt.body, AccountID: "testUser1"}, tt.session)
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("Update() status = %v, want %v", got.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmessagebus publishes the events of the account service to the message bus
+package asmessagebus
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+	uuid "github.com/satori/go.uuid"
+)
+
+// accountsCollection is the source of the events of the accounts
+const accountsCollection = "AccountsCollection"
+
+// Publish takes the URI of the account, the message ID, event type, message, message args
+// and severity of the event and publishes the event to the message bus
+func Publish(ctx context.Context, accountURI, messageID, eventType, message, severity string, messageArgs []string) {
+	topicName := config.Data.MessageBusConf.OdimControlMessageQueue
+	k, err := dc.Communicator(config.Data.MessageBusConf.MessageBusType, config.Data.MessageBusConf.MessageBusConfigFilePath, topicName)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to connect to " + config.Data.MessageBusConf.MessageBusType + " " + err.Error())
+		return
+	}
+
+	var eventID = uuid.NewV4().String()
+	var event = common.Event{
+		EventID:        eventID,
+		MessageID:      messageID,
+		EventTimestamp: time.Now().Format(time.RFC3339),
+		EventType:      eventType,
+		Message:        message,
+		MessageArgs:    messageArgs,
+		OriginOfCondition: &common.Link{
+			Oid: accountURI,
+		},
+		Severity: severity,
+	}
+	var messageData = common.MessageData{
+		Name:      "Account Event",
+		Context:   "/redfish/v1/$metadata#Event.Event",
+		OdataType: common.EventType,
+		Events:    []common.Event{event},
+	}
+	data, _ := json.Marshal(messageData)
+	ctx, span := tracing.StartSpan(ctx, topicName+" publish", tracing.SpanKindProducer)
+	defer span.End()
+	var mbevent = common.Events{
+		IP:          accountsCollection,
+		Request:     data,
+		TraceParent: tracing.TraceParent(ctx),
+	}
+
+	if err := k.Distribute(mbevent); err != nil {
+		span.SetError(err)
+		l.LogWithFields(ctx).Error("Account:" + accountURI + ", EventID:" + eventID + ", MessageID:" + messageID + " : unable to publish the event to message bus: " + err.Error())
+		return
+	}
+}
@@ -17,6 +17,7 @@ package asmodel
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -24,9 +25,22 @@ import (
 
 // Account is the model for creating/updating an Account
 type Account struct {
-	UserName string `json:"UserName"`
-	Password string `json:"Password"`
-	RoleID   string `json:"RoleId"`
+	UserName               string      `json:"UserName"`
+	Password               string      `json:"Password"`
+	RoleID                 string      `json:"RoleId"`
+	Locked                 *bool       `json:"Locked,omitempty"`
+	PasswordChangeRequired *bool       `json:"PasswordChangeRequired,omitempty"`
+	Oem                    *AccountOem `json:"Oem,omitempty"`
+}
+
+// AccountOem is the model for the OEM properties of an Account
+type AccountOem struct {
+	Aggregates []Link `json:"Aggregates"`
+}
+
+// Link is the model for a reference to a resource
+type Link struct {
+	OdataID string `json:"@odata.id"`
 }
 
 // User is the model for User Account
@@ -35,6 +49,34 @@ type User struct {
 	Password     string   `json:"Password"`
 	RoleID       string   `json:"RoleId"`
 	AccountTypes []string `json:"AccountTypes"`
+	// Aggregates restrict the user to the aggregates and their members,
+	// the user is not restricted when no aggregate is present
+	Aggregates []string `json:"Aggregates,omitempty"`
+	// PasswordChangedTime is the time the password was set, it is set at the first
+	// login of the accounts created without it, till then the password doesn't expire
+	PasswordChangedTime time.Time `json:"PasswordChangedTime,omitempty"`
+	// PasswordHistory holds the previous passwords of the user, the most recent first
+	PasswordHistory        []string `json:"PasswordHistory,omitempty"`
+	PasswordChangeRequired bool     `json:"PasswordChangeRequired,omitempty"`
+}
+
+// PasswordExpiration returns the time the password expires after the given number of days.
+// The password doesn't expire when the days are 0 or the time the password was set is not known
+func (u *User) PasswordExpiration(expirationDays int) (time.Time, bool) {
+	if expirationDays <= 0 || u.PasswordChangedTime.IsZero() {
+		return time.Time{}, false
+	}
+	return u.PasswordChangedTime.AddDate(0, 0, expirationDays), true
+}
+
+// IsPasswordChangeRequired checks the password of the user is required
+// to be changed or the password is expired
+func (u *User) IsPasswordChangeRequired(expirationDays int) bool {
+	if u.PasswordChangeRequired {
+		return true
+	}
+	expiration, expires := u.PasswordExpiration(expirationDays)
+	return expires && time.Now().After(expiration)
 }
 
 var (
@@ -126,12 +168,29 @@ func UpdateUserDetails(user, newData User) *errors.Error {
 
 	if newData.Password != "" {
 		user.Password = newData.Password
+		user.PasswordChangedTime = newData.PasswordChangedTime
+		user.PasswordHistory = newData.PasswordHistory
 	}
 	if newData.RoleID != "" {
 		user.RoleID = newData.RoleID
 	}
+	if newData.Aggregates != nil {
+		user.Aggregates = newData.Aggregates
+	}
 	if _, err = conn.Update(table, user.UserName, user); err != nil {
 		return err
 	}
 	return nil
 }
+
+// CheckAggregateExists checks the aggregate of the URI is present in the db
+func CheckAggregateExists(aggregateURI string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if _, err = conn.Read("Aggregate", aggregateURI); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get aggregate: ", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmodel ...
+package asmodel
+
+import (
+	"encoding/json"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// LDAPProvider is the key of the LDAP external account provider
+	LDAPProvider = "LDAP"
+	// ActiveDirectoryProvider is the key of the Active Directory external account provider
+	ActiveDirectoryProvider = "ActiveDirectory"
+	// OAuth2Provider is the key of the OAuth2 external account provider
+	OAuth2Provider = "OAuth2"
+
+	// CommonNameMapping maps the common name of the subject of the client certificate to the user name
+	CommonNameMapping = "CommonName"
+	// UserPrincipalNameMapping maps the user principal name, or the email address when the
+	// user principal name is not present, of the subject alternative name to the user name
+	UserPrincipalNameMapping = "UserPrincipalName"
+
+	externalAccountProviderTable = "ExternalAccountProvider"
+	multiFactorAuthTable         = "MultiFactorAuth"
+	clientCertificateKey         = "ClientCertificate"
+)
+
+// AccountService is the model for updating the AccountService
+type AccountService struct {
+	LDAP            *ExternalAccountProvider `json:"LDAP"`
+	ActiveDirectory *ExternalAccountProvider `json:"ActiveDirectory"`
+	OAuth2          *ExternalAccountProvider `json:"OAuth2"`
+	MultiFactorAuth *MultiFactorAuth         `json:"MultiFactorAuth"`
+}
+
+// MultiFactorAuth is the model for the additional methods of authenticating the users
+type MultiFactorAuth struct {
+	ClientCertificate *ClientCertificate `json:"ClientCertificate"`
+}
+
+// ClientCertificate is the model for the settings of authenticating the HTTPS clients by the
+// X.509 client certificate issued by the root CA of ODIM. The attribute of the certificate
+// given by CertificateMappingAttribute is mapped to the user name of a local account
+type ClientCertificate struct {
+	Enabled                     *bool  `json:"Enabled"`
+	CertificateMappingAttribute string `json:"CertificateMappingAttribute"`
+}
+
+// ExternalAccountProvider is the model for an external directory
+// against which the users of the sessions are authenticated
+type ExternalAccountProvider struct {
+	AccountProviderType string                      `json:"AccountProviderType"`
+	ServiceEnabled      *bool                       `json:"ServiceEnabled"`
+	ServiceAddresses    []string                    `json:"ServiceAddresses"`
+	Authentication      *Authentication             `json:"Authentication"`
+	LDAPService         *LDAPService                `json:"LDAPService"`
+	OAuth2Service       *OAuth2Service              `json:"OAuth2Service"`
+	RemoteRoleMapping   []RoleMapping               `json:"RemoteRoleMapping"`
+	Oem                 *ExternalAccountProviderOem `json:"Oem"`
+}
+
+// Authentication is the model for the credentials used for binding to the directory.
+// The password is stored encrypted with the public key of ODIM
+type Authentication struct {
+	AuthenticationType string `json:"AuthenticationType"`
+	Username           string `json:"Username"`
+	Password           string `json:"Password"`
+}
+
+// LDAPService is the model for the settings of the directory
+type LDAPService struct {
+	SearchSettings *LDAPSearchSettings `json:"SearchSettings"`
+}
+
+// LDAPSearchSettings is the model for the settings for searching the users in the directory
+type LDAPSearchSettings struct {
+	BaseDistinguishedNames []string `json:"BaseDistinguishedNames"`
+	UsernameAttribute      string   `json:"UsernameAttribute"`
+	GroupsAttribute        string   `json:"GroupsAttribute"`
+	GroupNameAttribute     string   `json:"GroupNameAttribute"`
+}
+
+// OAuth2Service is the model for the settings for validating the bearer tokens
+// issued by the OAuth2 service. The signing keys are the base64 encoded JSON web
+// key set or PEM encoded public keys of the issuer, used in the Offline mode
+type OAuth2Service struct {
+	Issuer                  string   `json:"Issuer"`
+	Audience                []string `json:"Audience"`
+	Mode                    string   `json:"Mode"`
+	OAuthServiceSigningKeys string   `json:"OAuthServiceSigningKeys"`
+}
+
+// RoleMapping is the model for mapping a group of the directory, or a group
+// claimed by the bearer token, to a role
+type RoleMapping struct {
+	LocalRole   string `json:"LocalRole"`
+	RemoteGroup string `json:"RemoteGroup"`
+}
+
+// ExternalAccountProviderOem is the model for the OEM properties of an external account provider
+type ExternalAccountProviderOem struct {
+	StartTLS          *bool  `json:"StartTLS"`
+	RootCACertificate string `json:"RootCACertificate"`
+	UsernameClaim     string `json:"UsernameClaim"`
+	GroupsClaim       string `json:"GroupsClaim"`
+}
+
+// IsEnabled checks the provider is enabled for authenticating the users
+func (p *ExternalAccountProvider) IsEnabled() bool {
+	return p != nil && p.ServiceEnabled != nil && *p.ServiceEnabled
+}
+
+// IsEnabled checks the authentication by the client certificate is enabled
+func (c *ClientCertificate) IsEnabled() bool {
+	return c != nil && c.Enabled != nil && *c.Enabled
+}
+
+// GetExternalAccountProvider retrieves the external account provider of the key from the db
+func GetExternalAccountProvider(key string) (ExternalAccountProvider, *errors.Error) {
+	var provider ExternalAccountProvider
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return provider, err
+	}
+	data, err := conn.Read(externalAccountProviderTable, key)
+	if err != nil {
+		return provider, errors.PackError(err.ErrNo(), "error while trying to get external account provider: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &provider); jerr != nil {
+		return provider, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return provider, nil
+}
+
+// SaveExternalAccountProvider inserts or replaces the external account provider of the key in the db
+func SaveExternalAccountProvider(key string, provider ExternalAccountProvider) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Upsert(externalAccountProviderTable, key, provider); err != nil {
+		return errors.PackError(err.ErrNo(), "error saving external account provider: ", err.Error())
+	}
+	return nil
+}
+
+// GetClientCertificate retrieves the settings of the authentication by the client certificate from the db
+func GetClientCertificate() (ClientCertificate, *errors.Error) {
+	var clientCertificate ClientCertificate
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return clientCertificate, err
+	}
+	data, err := conn.Read(multiFactorAuthTable, clientCertificateKey)
+	if err != nil {
+		return clientCertificate, errors.PackError(err.ErrNo(), "error while trying to get client certificate settings: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &clientCertificate); jerr != nil {
+		return clientCertificate, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return clientCertificate, nil
+}
+
+// SaveClientCertificate inserts or replaces the settings of the authentication by the client certificate in the db
+func SaveClientCertificate(clientCertificate ClientCertificate) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Upsert(multiFactorAuthTable, clientCertificateKey, clientCertificate); err != nil {
+		return errors.PackError(err.ErrNo(), "error saving client certificate settings: ", err.Error())
+	}
+	return nil
+}
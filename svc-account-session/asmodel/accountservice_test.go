@@ -0,0 +1,52 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package asmodel
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalAccountProvider(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+	_, err := GetExternalAccountProvider(LDAPProvider)
+	assert.NotNil(t, err, "There should be an error")
+	assert.Equal(t, errors.DBKeyNotFound, err.ErrNo(), "Error should be DBKeyNotFound")
+
+	enabled := true
+	provider := ExternalAccountProvider{
+		AccountProviderType: "LDAPService",
+		ServiceEnabled:      &enabled,
+		ServiceAddresses:    []string{"ldaps://ldap.example.com"},
+		RemoteRoleMapping:   []RoleMapping{{LocalRole: common.RoleAdmin, RemoteGroup: "admins"}},
+	}
+	err = SaveExternalAccountProvider(LDAPProvider, provider)
+	assert.Nil(t, err, "There should be no error")
+	got, err := GetExternalAccountProvider(LDAPProvider)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, provider, got, "Provider should be same as saved")
+	assert.True(t, got.IsEnabled(), "Provider should be enabled")
+}
@@ -0,0 +1,208 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmodel ...
+package asmodel
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	apiKeyTable = "APIKey"
+	// apiKeyUsageCountTable holds the usage count of the keys, incremented by the service instances
+	apiKeyUsageCountTable = "APIKeyUsageCount"
+	// apiKeyLastUsedTable holds the time at which the keys are last used
+	apiKeyLastUsedTable = "APIKeyLastUsed"
+)
+
+// APIKey is the model for a long-lived key of an account, accepted in place of the sessions.
+// Only the hash of the secret of the key is stored. The key has the privileges of the role
+// of the account, restricted to Privileges when they are given
+type APIKey struct {
+	ID             string    `json:"ID"`
+	Name           string    `json:"Name"`
+	UserName       string    `json:"UserName"`
+	HashedSecret   string    `json:"HashedSecret"`
+	Privileges     []string  `json:"Privileges"`
+	CreatedTime    time.Time `json:"CreatedTime"`
+	ExpirationTime time.Time `json:"ExpirationTime"`
+}
+
+// APIKeyRequest is the model for the request of creating an API key
+type APIKeyRequest struct {
+	Name           string   `json:"Name"`
+	ExpirationDate string   `json:"ExpirationDate"`
+	Privileges     []string `json:"Privileges"`
+}
+
+// APIKeyUsage is the model for tracking the usage of a key, stored in the in-memory db like the sessions
+type APIKeyUsage struct {
+	ID           string    `json:"ID"`
+	UsageCount   int64     `json:"UsageCount"`
+	LastUsedTime time.Time `json:"LastUsedTime"`
+}
+
+// IsExpired checks the expiration time of the key is elapsed
+func (k *APIKey) IsExpired() bool {
+	return !time.Now().Before(k.ExpirationTime)
+}
+
+// CreateAPIKey stores the new key in the db
+func CreateAPIKey(key APIKey) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Create(apiKeyTable, key.ID, key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to create the API key: ", err.Error())
+	}
+	return nil
+}
+
+// GetAPIKey retrieves the key of the ID from the db
+func GetAPIKey(keyID string) (APIKey, *errors.Error) {
+	var key APIKey
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return key, err
+	}
+	data, err := conn.Read(apiKeyTable, keyID)
+	if err != nil {
+		return key, errors.PackError(err.ErrNo(), "error while trying to get the API key: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &key); jerr != nil {
+		return key, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return key, nil
+}
+
+// GetAllAPIKeys retrieves the keys of the user from the db
+func GetAllAPIKeys(userName string) ([]APIKey, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	keyIDs, err := conn.GetAllDetails(apiKeyTable)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the API keys: ", err.Error())
+	}
+	var keys []APIKey
+	for _, keyID := range keyIDs {
+		key, err := GetAPIKey(keyID)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if key.UserName == userName {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// DeleteAPIKey removes the key and its usage from the db, which revokes the key
+func DeleteAPIKey(keyID string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete(apiKeyTable, keyID); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete the API key: ", err.Error())
+	}
+	inMemoryConn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = inMemoryConn.DeleteMultipleKeys([]string{apiKeyUsageCountTable + ":" + keyID, apiKeyLastUsedTable + ":" + keyID}); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete the API key usage: ", err.Error())
+	}
+	return nil
+}
+
+// DeleteAllAPIKeys removes all the keys of the user from the db
+func DeleteAllAPIKeys(userName string) *errors.Error {
+	keys, err := GetAllAPIKeys(userName)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = DeleteAPIKey(key.ID); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAPIKeyUsage retrieves the usage of the key from the in-memory db,
+// the usage is empty when the key is not used
+func GetAPIKeyUsage(keyID string) (APIKeyUsage, *errors.Error) {
+	usage := APIKeyUsage{ID: keyID}
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return usage, err
+	}
+	data, err := conn.Read(apiKeyUsageCountTable, keyID)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return usage, nil
+		}
+		return usage, errors.PackError(err.ErrNo(), "error while trying to get the API key usage: ", err.Error())
+	}
+	if usage.UsageCount, err = parseUsageCount(data); err != nil {
+		return usage, err
+	}
+	data, err = conn.Read(apiKeyLastUsedTable, keyID)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return usage, nil
+		}
+		return usage, errors.PackError(err.ErrNo(), "error while trying to get the last used time of the API key: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &usage.LastUsedTime); jerr != nil {
+		return usage, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return usage, nil
+}
+
+func parseUsageCount(data string) (int64, *errors.Error) {
+	usageCount, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return 0, errors.PackError(errors.UndefinedErrorType, err)
+	}
+	return usageCount, nil
+}
+
+// IncrementAPIKeyUsage increments the usage count and sets the last used time of the key in the in-memory db.
+// The count is incremented atomically, so that it is shared by the service instances
+func IncrementAPIKeyUsage(keyID string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if _, err = conn.Incr(apiKeyUsageCountTable, keyID); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to count the API key usage: ", err.Error())
+	}
+	if err = conn.Upsert(apiKeyLastUsedTable, keyID, time.Now()); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to save the last used time of the API key: ", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package asmodel ...
+package asmodel
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+const (
+	// failedLoginsTable holds the count of the consecutive failed logins of the users,
+	// which expires after the counter reset duration from the last failed login
+	failedLoginsTable = "FailedLogins"
+	// lockedAccountsTable holds the time at which the accounts are locked,
+	// which expires after the lockout duration
+	lockedAccountsTable = "LockedAccounts"
+)
+
+// LoginAttempts is the model for tracking the consecutive failed logins of a user
+type LoginAttempts struct {
+	UserName       string    `json:"UserName"`
+	FailedAttempts int       `json:"FailedAttempts"`
+	LockedTime     time.Time `json:"LockedTime,omitempty"`
+}
+
+// IsLocked checks the account is locked, the lock expires after the lockout duration
+func (a *LoginAttempts) IsLocked(lockoutDuration time.Duration) bool {
+	return !a.LockedTime.IsZero() && time.Since(a.LockedTime) < lockoutDuration
+}
+
+// GetLoginAttempts retrieves the failed logins of the user from the in-memory db,
+// the failed logins are empty when the user has not failed to login
+func GetLoginAttempts(userName string) (LoginAttempts, *errors.Error) {
+	attempts := LoginAttempts{UserName: userName}
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return attempts, err
+	}
+	data, err := conn.Read(failedLoginsTable, userName)
+	if err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return attempts, errors.PackError(err.ErrNo(), "error while trying to get the login attempts: ", err.Error())
+	}
+	if err == nil {
+		if attempts.FailedAttempts, err = parseFailedLogins(data); err != nil {
+			return attempts, err
+		}
+	}
+	data, err = conn.Read(lockedAccountsTable, userName)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return attempts, nil
+		}
+		return attempts, errors.PackError(err.ErrNo(), "error while trying to get the lock of the account: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &attempts.LockedTime); jerr != nil {
+		return attempts, errors.PackError(errors.UndefinedErrorType, jerr)
+	}
+	return attempts, nil
+}
+
+func parseFailedLogins(data string) (int, *errors.Error) {
+	failedLogins, err := strconv.Atoi(data)
+	if err != nil {
+		return 0, errors.PackError(errors.UndefinedErrorType, err)
+	}
+	return failedLogins, nil
+}
+
+// IncrementFailedLogins increments the consecutive failed logins of the user in the in-memory db and
+// returns the count. The count is shared by the service instances, it is removed when the user does not
+// fail to login for counterResetAfter seconds
+func IncrementFailedLogins(userName string, counterResetAfter int) (int, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return 0, err
+	}
+	failedLogins, err := conn.IncrExpire(failedLoginsTable, userName, counterResetAfter)
+	if err != nil {
+		return 0, errors.PackError(err.ErrNo(), "error while trying to count the failed login: ", err.Error())
+	}
+	return failedLogins, nil
+}
+
+// LockAccount locks the account of the user for lockoutDuration seconds and removes the failed logins,
+// which are counted again after the lock is expired. It returns false when the account is already locked
+func LockAccount(userName string, lockoutDuration int) (bool, *errors.Error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	locked, err := conn.SetNX(lockedAccountsTable, userName, time.Now(), lockoutDuration)
+	if err != nil {
+		return false, errors.PackError(err.ErrNo(), "error while trying to lock the account: ", err.Error())
+	}
+	if !locked {
+		return false, nil
+	}
+	if err = conn.Delete(failedLoginsTable, userName); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return true, errors.PackError(err.ErrNo(), "error while trying to delete the login attempts: ", err.Error())
+	}
+	return true, nil
+}
+
+// DeleteLoginAttempts removes the failed logins and the lock of the user from the in-memory db,
+// which unlocks the account of the user
+func DeleteLoginAttempts(userName string) *errors.Error {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return err
+	}
+	if err = conn.DeleteMultipleKeys([]string{failedLoginsTable + ":" + userName, lockedAccountsTable + ":" + userName}); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete the login attempts: ", err.Error())
+	}
+	return nil
+}
@@ -90,3 +90,15 @@ func (p *OEMPrivileges) Create() *errors.Error {
 	}
 	return nil
 }
+
+// Save method is to insert or replace the oemprivileges list in database
+func (p *OEMPrivileges) Save() *errors.Error {
+	conn, err := GetDBConnectionFunc(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Upsert("registry", "oemprivileges", p); err != nil {
+		return errors.PackError(err.ErrNo(), "error saving OEM privileges: ", err.Error())
+	}
+	return nil
+}
@@ -171,3 +171,28 @@ func TestGetPrivilegeRegistryDBError(t *testing.T) {
 	assert.Equalf(t, Privileges{}, priv, "GetPrivilegeRegistry() ")
 	assert.Equalf(t, &errors.Error{}, err, "GetPrivilegeRegistry() ")
 }
+
+func TestSaveOEMPrivileges(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+	}()
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return common.GetDBConnection(dbFlag)
+	}
+	mockData(common.OnDisk, "registry", "oemprivileges", OEMList)
+	updatedList := OEMPrivileges{List: []string{"ConfigureFirmware", "ExecuteReset"}}
+	err := updatedList.Save()
+	assert.Nil(t, err, "There should be no error")
+	oemPriv, err := GetOEMPrivileges()
+	assert.Nil(t, err, "There should be no error")
+	assert.Equalf(t, updatedList, oemPriv, "GetOEMPrivileges() ")
+}
+
+func TestSaveOEMPrivilegesDBError(t *testing.T) {
+	GetDBConnectionFunc = func(dbFlag common.DbType) (*persistencemgr.ConnPool, *errors.Error) {
+		return nil, &errors.Error{}
+	}
+	err := OEMList.Save()
+	assert.Equalf(t, &errors.Error{}, err, " OEM Save() ")
+}
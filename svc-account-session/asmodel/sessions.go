@@ -27,14 +27,19 @@ var sessionStore = common.InMemory
 
 // Session will hold the data assosiated with the session
 type Session struct {
-	ID           string
-	Token        string
-	UserName     string
-	RoleID       string
-	Privileges   map[string]bool
-	Origin       string
-	CreatedTime  time.Time
-	LastUsedTime time.Time
+	ID            string
+	Token         string
+	UserName      string
+	RoleID        string
+	Privileges    map[string]bool
+	OEMPrivileges map[string]bool
+	Aggregates    []string
+	Origin        string
+	CreatedTime   time.Time
+	LastUsedTime  time.Time
+	// PasswordChangeRequired restricts the session to changing the password of
+	// its user, when the session is created with a password required to be changed
+	PasswordChangeRequired bool
 }
 
 // CreateSession will hold input request for creating a session
@@ -23,16 +23,25 @@ import (
 // Account struct is used to ommit password for display purposes
 type Account struct {
 	response.Response
-	UserName     string   `json:"UserName"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
-	Password     *string  `json:"Password"`
-	Links        Links    `json:"Links"`
-	OEM          *OEM     `json:"Oem,omitempty"`
+	UserName               string   `json:"UserName"`
+	RoleID                 string   `json:"RoleId"`
+	AccountTypes           []string `json:"AccountTypes"`
+	Password               *string  `json:"Password"`
+	Locked                 bool     `json:"Locked"`
+	PasswordChangeRequired bool     `json:"PasswordChangeRequired"`
+	PasswordExpiration     string   `json:"PasswordExpiration,omitempty"`
+	Links                  Links    `json:"Links"`
+	OEM                    *OEM     `json:"Oem,omitempty"`
 }
 
 // OEM struct definition
 type OEM struct {
+	Aggregates []Aggregate `json:"Aggregates,omitempty"`
+}
+
+// Aggregate struct definition
+type Aggregate struct {
+	OdataID string `json:"@odata.id"`
 }
 
 // Links struct definition
@@ -45,34 +54,53 @@ type Role struct {
 	OdataID string `json:"@odata.id"`
 }
 
+// APIKey struct definition
+type APIKey struct {
+	response.Response
+	Key            string      `json:"Key,omitempty"`
+	UserName       string      `json:"UserName"`
+	Privileges     []string    `json:"Privileges"`
+	CreatedTime    string      `json:"CreatedTime"`
+	ExpirationDate string      `json:"ExpirationDate"`
+	LastUsedTime   string      `json:"LastUsedTime,omitempty"`
+	UsageCount     int64       `json:"UsageCount"`
+	Links          APIKeyLinks `json:"Links"`
+}
+
+// APIKeyLinks struct definition
+type APIKeyLinks struct {
+	ManagerAccount dmtf.Link `json:"ManagerAccount"`
+}
+
 // AccountService struct definition
 type AccountService struct {
 	response.Response
-	Status                             Status           `json:"Status,omitempty"`
-	ServiceEnabled                     bool             `json:"ServiceEnabled,omitempty"`
-	AuthFailureLoggingThreshold        int              `json:"AuthFailureLoggingThreshold,omitempty"`
-	MinPasswordLength                  int              `json:"MinPasswordLength,omitempty"`
-	AccountLockoutThreshold            int              `json:"AccountLockoutThreshold,omitempty"`
-	AccountLockoutDuration             int              `json:"AccountLockoutDuration,omitempty"`
-	AccountLockoutCounterResetAfter    int              `json:"AccountLockoutCounterResetAfter,omitempty"`
-	Accounts                           Accounts         `json:"Accounts,omitempty"`
-	Roles                              Accounts         `json:"Roles,omitempty"`
-	AccountLockoutCounterResetEnabled  bool             `json:"AccountLockoutCounterResetEnabled,omitempty"`
-	Actions                            *dmtf.OemActions `json:"Actions,omitempty"`
-	ActiveDirectory                    *ActiveDirectory `json:"ActiveDirectory,omitempty"`
-	AdditionalExternalAccountProviders *dmtf.Link       `json:"AdditionalExternalAccountProviders,omitempty"`
-	LDAP                               *LDAP            `json:"LDAP,omitempty"`
-	LocalAccountAuth                   string           `json:"LocalAccountAuth,omitempty"`
-	MaxPasswordLength                  int              `json:"MaxPasswordLength,omitempty"`
-	OAuth2                             *OAuth2          `json:"OAuth2,omitempty"`
-	Oem                                *OEM             `json:"Oem,omitempty"`
-	PasswordExpirationDays             int              `json:"PasswordExpirationDays,omitempty"`
-	PrivilegeMap                       *dmtf.Link       `json:"PrivilegeMap,omitempty"`
-	RestrictedOemPrivileges            []string         `json:"RestrictedOemPrivileges,omitempty"`
-	RestrictedPrivileges               []string         `json:"RestrictedPrivileges,omitempty"`
-	SupportedAccountTypes              []string         `json:"SupportedAccountTypes,omitempty"`
-	SupportedOEMAccountTypes           []string         `json:"SupportedOEMAccountTypes,omitempty"`
-	TACACSplus                         *TACACSplus      `json:"TACACSplus,omitempty"`
+	Status                             Status                   `json:"Status,omitempty"`
+	ServiceEnabled                     bool                     `json:"ServiceEnabled,omitempty"`
+	AuthFailureLoggingThreshold        int                      `json:"AuthFailureLoggingThreshold,omitempty"`
+	MinPasswordLength                  int                      `json:"MinPasswordLength,omitempty"`
+	AccountLockoutThreshold            int                      `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration             int                      `json:"AccountLockoutDuration,omitempty"`
+	AccountLockoutCounterResetAfter    int                      `json:"AccountLockoutCounterResetAfter,omitempty"`
+	Accounts                           Accounts                 `json:"Accounts,omitempty"`
+	Roles                              Accounts                 `json:"Roles,omitempty"`
+	AccountLockoutCounterResetEnabled  bool                     `json:"AccountLockoutCounterResetEnabled,omitempty"`
+	Actions                            *dmtf.OemActions         `json:"Actions,omitempty"`
+	ActiveDirectory                    *ExternalAccountProvider `json:"ActiveDirectory,omitempty"`
+	AdditionalExternalAccountProviders *dmtf.Link               `json:"AdditionalExternalAccountProviders,omitempty"`
+	LDAP                               *ExternalAccountProvider `json:"LDAP,omitempty"`
+	LocalAccountAuth                   string                   `json:"LocalAccountAuth,omitempty"`
+	MaxPasswordLength                  int                      `json:"MaxPasswordLength,omitempty"`
+	MultiFactorAuth                    *MultiFactorAuth         `json:"MultiFactorAuth,omitempty"`
+	OAuth2                             *ExternalAccountProvider `json:"OAuth2,omitempty"`
+	Oem                                *OEM                     `json:"Oem,omitempty"`
+	PasswordExpirationDays             int                      `json:"PasswordExpirationDays,omitempty"`
+	PrivilegeMap                       *dmtf.Link               `json:"PrivilegeMap,omitempty"`
+	RestrictedOemPrivileges            []string                 `json:"RestrictedOemPrivileges,omitempty"`
+	RestrictedPrivileges               []string                 `json:"RestrictedPrivileges,omitempty"`
+	SupportedAccountTypes              []string                 `json:"SupportedAccountTypes,omitempty"`
+	SupportedOEMAccountTypes           []string                 `json:"SupportedOEMAccountTypes,omitempty"`
+	TACACSplus                         *TACACSplus              `json:"TACACSplus,omitempty"`
 }
 
 // Accounts struct definition
@@ -80,16 +108,70 @@ type Accounts struct {
 	OdataID string `json:"@odata.id"`
 }
 
-// OAuth2 struct definition
-type OAuth2 struct {
+// ExternalAccountProvider struct definition
+type ExternalAccountProvider struct {
+	AccountProviderType string                      `json:"AccountProviderType"`
+	ServiceEnabled      bool                        `json:"ServiceEnabled"`
+	ServiceAddresses    []string                    `json:"ServiceAddresses"`
+	Authentication      *Authentication             `json:"Authentication,omitempty"`
+	LDAPService         *LDAPService                `json:"LDAPService,omitempty"`
+	OAuth2Service       *OAuth2Service              `json:"OAuth2Service,omitempty"`
+	RemoteRoleMapping   []RoleMapping               `json:"RemoteRoleMapping"`
+	Oem                 *ExternalAccountProviderOem `json:"Oem,omitempty"`
+}
+
+// Authentication struct definition
+type Authentication struct {
+	AuthenticationType string  `json:"AuthenticationType,omitempty"`
+	Username           string  `json:"Username,omitempty"`
+	Password           *string `json:"Password"`
+}
+
+// LDAPService struct definition
+type LDAPService struct {
+	SearchSettings *LDAPSearchSettings `json:"SearchSettings,omitempty"`
+}
+
+// LDAPSearchSettings struct definition
+type LDAPSearchSettings struct {
+	BaseDistinguishedNames []string `json:"BaseDistinguishedNames"`
+	UsernameAttribute      string   `json:"UsernameAttribute,omitempty"`
+	GroupsAttribute        string   `json:"GroupsAttribute,omitempty"`
+	GroupNameAttribute     string   `json:"GroupNameAttribute,omitempty"`
+}
+
+// OAuth2Service struct definition
+type OAuth2Service struct {
+	Issuer                  string   `json:"Issuer,omitempty"`
+	Audience                []string `json:"Audience"`
+	Mode                    string   `json:"Mode,omitempty"`
+	OAuthServiceSigningKeys string   `json:"OAuthServiceSigningKeys,omitempty"`
+}
+
+// RoleMapping struct definition
+type RoleMapping struct {
+	LocalRole   string `json:"LocalRole"`
+	RemoteGroup string `json:"RemoteGroup"`
+}
+
+// ExternalAccountProviderOem struct definition
+type ExternalAccountProviderOem struct {
+	StartTLS          bool   `json:"StartTLS"`
+	RootCACertificate string `json:"RootCACertificate,omitempty"`
+	UsernameClaim     string `json:"UsernameClaim,omitempty"`
+	GroupsClaim       string `json:"GroupsClaim,omitempty"`
 }
 
-// ActiveDirectory struct definition
-type ActiveDirectory struct {
+// MultiFactorAuth struct definition
+type MultiFactorAuth struct {
+	ClientCertificate *ClientCertificate `json:"ClientCertificate,omitempty"`
 }
 
-// LDAP struct definition
-type LDAP struct {
+// ClientCertificate struct definition
+type ClientCertificate struct {
+	Enabled                         bool   `json:"Enabled"`
+	CertificateMappingAttribute     string `json:"CertificateMappingAttribute"`
+	RespondToUnauthenticatedClients bool   `json:"RespondToUnauthenticatedClients"`
 }
 
 // TACACSplus struct definition
@@ -0,0 +1,162 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"golang.org/x/crypto/sha3"
+)
+
+// apiKeyPrefix is the prefix of the tokens of the API keys, which are made of
+// the prefix, the ID of the key and the secret, separated by underscores
+const apiKeyPrefix = "odimak_"
+
+var (
+	// GetAPIKey retrieves the API key of the token
+	GetAPIKey = asmodel.GetAPIKey
+	// IncrementAPIKeyUsage increments the usage count and sets the last used time of the API key
+	IncrementAPIKeyUsage = asmodel.IncrementAPIKeyUsage
+)
+
+// IsAPIKey checks the token is the token of an API key
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyPrefix)
+}
+
+// NewAPIKeyToken generates the token of the API key of the ID, with a random secret.
+// The token is returned to the user only once, the hash of the secret is stored
+func NewAPIKeyToken(keyID string) (token, hashedSecret string, err error) {
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	encodedSecret := hex.EncodeToString(secret)
+	return apiKeyPrefix + keyID + "_" + encodedSecret, hashAPIKeySecret(encodedSecret), nil
+}
+
+// GetAPIKeyPrivileges returns the privileges and the OEM privileges of the role which are
+// granted to the key, all of them when the key is not restricted. Login is always granted
+func GetAPIKeyPrivileges(key asmodel.APIKey, role asmodel.Role) (map[string]bool, map[string]bool) {
+	allowed := map[string]bool{common.PrivilegeLogin: true}
+	for _, privilege := range key.Privileges {
+		allowed[privilege] = true
+	}
+	privileges, oemPrivileges := make(map[string]bool), make(map[string]bool)
+	for _, privilege := range role.AssignedPrivileges {
+		if len(key.Privileges) == 0 || allowed[privilege] {
+			privileges[privilege] = true
+		}
+	}
+	for _, privilege := range role.OEMPrivileges {
+		if len(key.Privileges) == 0 || allowed[privilege] {
+			oemPrivileges[privilege] = true
+		}
+	}
+	return privileges, oemPrivileges
+}
+
+// RecordAPIKeyUsage increments the usage count and updates the last used time of the API key of the token
+func RecordAPIKeyUsage(token string) *errors.Error {
+	keyID, _, ok := parseAPIKeyToken(token)
+	if !ok {
+		return errors.PackError(errors.InvalidAuthToken, "error: invalid API key")
+	}
+	return IncrementAPIKeyUsage(keyID)
+}
+
+// checkAPIKey validates the token of the API key. The session of the key is not stored, it has the
+// privileges of the role of the account granted to the key, till the key is expired or revoked
+func checkAPIKey(ctx context.Context, token string) (*asmodel.Session, *errors.Error) {
+	keyID, secret, ok := parseAPIKeyToken(token)
+	if !ok {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: invalid API key")
+	}
+	key, err := GetAPIKey(keyID)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, errors.PackError(errors.InvalidAuthToken, "error: API key "+keyID+" is not found")
+		}
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the API key: ", err.Error())
+	}
+	if subtle.ConstantTimeCompare([]byte(key.HashedSecret), []byte(hashAPIKeySecret(secret))) != 1 {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: invalid secret of the API key "+keyID)
+	}
+	if key.IsExpired() {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: API key "+keyID+" is expired")
+	}
+	user, err := GetUserDetails(key.UserName)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, errors.PackError(errors.InvalidAuthToken, "error: account of the API key "+keyID+" is not found")
+		}
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the user details: ", err.Error())
+	}
+	if locked, err := IsAccountLocked(user.UserName); err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the failed logins: ", err.Error())
+	} else if locked {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: account of the API key "+keyID+" is locked")
+	}
+	role, err := GetRoleDetailsByID(user.RoleID)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the role of the API key: ", err.Error())
+	}
+	privileges, oemPrivileges := GetAPIKeyPrivileges(key, role)
+	if !privileges[common.PrivilegeLogin] {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: role of the API key does not have the Login privilege")
+	}
+	// the key of an account which password is required to be changed or is expired
+	// is rejected, till the password is changed
+	if user.IsPasswordChangeRequired(config.Data.AuthConf.PasswordRules.PasswordExpirationDays) {
+		return nil, errors.PackError(errors.PasswordChangeRequired, "error: password of the account of the API key "+keyID+" must be changed")
+	}
+	return &asmodel.Session{
+		ID:            key.ID,
+		Token:         token,
+		UserName:      user.UserName,
+		RoleID:        user.RoleID,
+		Privileges:    privileges,
+		OEMPrivileges: oemPrivileges,
+		Aggregates:    user.Aggregates,
+		CreatedTime:   key.CreatedTime,
+		LastUsedTime:  time.Now(),
+	}, nil
+}
+
+// parseAPIKeyToken returns the ID of the key and the secret of the token
+func parseAPIKeyToken(token string) (string, string, bool) {
+	if !IsAPIKey(token) {
+		return "", "", false
+	}
+	keyID, secret, found := strings.Cut(strings.TrimPrefix(token, apiKeyPrefix), "_")
+	return keyID, secret, found && keyID != "" && secret != ""
+}
+
+func hashAPIKeySecret(secret string) string {
+	hash := sha3.New512()
+	hash.Write([]byte(secret))
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil))
+}
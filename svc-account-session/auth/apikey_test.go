@@ -0,0 +1,133 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+func TestCheckAPIKey(t *testing.T) {
+	config.SetUpMockConfig(t)
+	store, _ := mockLoginAttempts(t)
+	defer func(getAPIKey func(string) (asmodel.APIKey, *errors.Error), getUser func(string) (asmodel.User, *errors.Error),
+		getRole func(string) (asmodel.Role, *errors.Error)) {
+		GetAPIKey = getAPIKey
+		GetUserDetails = getUser
+		GetRoleDetailsByID = getRole
+	}(GetAPIKey, GetUserDetails, GetRoleDetailsByID)
+
+	token, hashedSecret, err := NewAPIKeyToken("key1")
+	if err != nil {
+		t.Fatalf("NewAPIKeyToken() error = %v", err)
+	}
+	expiredToken, expiredHashedSecret, _ := NewAPIKeyToken("key2")
+	otherToken, _, _ := NewAPIKeyToken("key1")
+	changeRequiredToken, changeRequiredHashedSecret, _ := NewAPIKeyToken("key3")
+	expiredPasswordToken, expiredPasswordHashedSecret, _ := NewAPIKeyToken("key4")
+	keys := map[string]asmodel.APIKey{
+		"key1": {ID: "key1", UserName: "user1", HashedSecret: hashedSecret, Privileges: []string{common.PrivilegeConfigureComponents},
+			ExpirationTime: time.Now().Add(time.Hour)},
+		"key2": {ID: "key2", UserName: "user1", HashedSecret: expiredHashedSecret, ExpirationTime: time.Now().Add(-time.Hour)},
+		"key3": {ID: "key3", UserName: "changeRequired", HashedSecret: changeRequiredHashedSecret, ExpirationTime: time.Now().Add(time.Hour)},
+		"key4": {ID: "key4", UserName: "expiredPassword", HashedSecret: expiredPasswordHashedSecret, ExpirationTime: time.Now().Add(time.Hour)},
+	}
+	config.Data.AuthConf.PasswordRules.PasswordExpirationDays = 90
+	users := map[string]asmodel.User{
+		"user1":           {UserName: "user1", RoleID: common.RoleAdmin, PasswordChangedTime: time.Now()},
+		"changeRequired":  {UserName: "changeRequired", RoleID: common.RoleAdmin, PasswordChangedTime: time.Now(), PasswordChangeRequired: true},
+		"expiredPassword": {UserName: "expiredPassword", RoleID: common.RoleAdmin, PasswordChangedTime: time.Now().AddDate(0, 0, -91)},
+	}
+	GetAPIKey = func(keyID string) (asmodel.APIKey, *errors.Error) {
+		key, ok := keys[keyID]
+		if !ok {
+			return key, errors.PackError(errors.DBKeyNotFound, "error: data with key "+keyID+" not found")
+		}
+		return key, nil
+	}
+	GetUserDetails = func(userName string) (asmodel.User, *errors.Error) {
+		return users[userName], nil
+	}
+	GetRoleDetailsByID = func(roleID string) (asmodel.Role, *errors.Error) {
+		return asmodel.Role{
+			ID:                 roleID,
+			AssignedPrivileges: []string{common.PrivilegeLogin, common.PrivilegeConfigureComponents, common.PrivilegeConfigureUsers},
+		}, nil
+	}
+
+	sess, errs := checkAPIKey(context.TODO(), token)
+	if errs != nil {
+		t.Fatalf("checkAPIKey() error = %v", errs)
+	}
+	if sess.UserName != "user1" || !sess.Privileges[common.PrivilegeLogin] || !sess.Privileges[common.PrivilegeConfigureComponents] ||
+		sess.Privileges[common.PrivilegeConfigureUsers] {
+		t.Errorf("checkAPIKey() returned unexpected session %v", sess)
+	}
+
+	for name, invalidToken := range map[string]string{
+		"malformed token": "odimak_key1",
+		"unknown key":     "odimak_key3_secret",
+		"invalid secret":  otherToken,
+		"expired key":     expiredToken,
+	} {
+		if _, errs := checkAPIKey(context.TODO(), invalidToken); errs == nil || errs.ErrNo() != errors.InvalidAuthToken {
+			t.Errorf("checkAPIKey() with %s error = %v, want InvalidAuthToken", name, errs)
+		}
+	}
+
+	for name, changeToken := range map[string]string{
+		"password required to be changed": changeRequiredToken,
+		"expired password":                expiredPasswordToken,
+	} {
+		if _, errs := checkAPIKey(context.TODO(), changeToken); errs == nil || errs.ErrNo() != errors.PasswordChangeRequired {
+			t.Errorf("checkAPIKey() with %s error = %v, want PasswordChangeRequired", name, errs)
+		}
+	}
+
+	store["user1"] = asmodel.LoginAttempts{UserName: "user1", LockedTime: time.Now()}
+	if _, errs := checkAPIKey(context.TODO(), token); errs == nil || errs.ErrNo() != errors.InvalidAuthToken {
+		t.Errorf("checkAPIKey() of locked account error = %v, want InvalidAuthToken", errs)
+	}
+}
+
+func TestRecordAPIKeyUsage(t *testing.T) {
+	defer func(incrementUsage func(string) *errors.Error) {
+		IncrementAPIKeyUsage = incrementUsage
+	}(IncrementAPIKeyUsage)
+	store := map[string]int{}
+	IncrementAPIKeyUsage = func(keyID string) *errors.Error {
+		store[keyID]++
+		return nil
+	}
+
+	token, _, _ := NewAPIKeyToken("key1")
+	for i := 0; i < 2; i++ {
+		if err := RecordAPIKeyUsage(token); err != nil {
+			t.Fatalf("RecordAPIKeyUsage() error = %v", err)
+		}
+	}
+	if store["key1"] != 2 {
+		t.Errorf("RecordAPIKeyUsage() counted %v usages, want 2", store["key1"])
+	}
+	if err := RecordAPIKeyUsage("token"); err == nil {
+		t.Errorf("RecordAPIKeyUsage() of invalid token should fail")
+	}
+}
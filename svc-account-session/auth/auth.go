@@ -26,12 +26,20 @@ import (
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/oauth2"
 )
 
 // Auth functionality will do the following
-//  1. It will check whether the session taken is valid
-//  2. fetch the privileges from DB against session token
+//  1. It will check whether the session taken is valid, the bearer tokens
+//     and the API keys are accepted in place of the session token
+//  2. fetch the privileges and the OEM privileges from DB against session token
 //     and check the service has the previlege
+//  3. the privileges are resolved from the privilege registry when the
+//     request URI and method are passed, else the passed privileges are checked
+//  4. the request URI is checked to be in the scope of the aggregates
+//     the user of the session is restricted to
+//  5. the session created with a password required to be changed is
+//     rejected, till the password is changed
 func Auth(ctx context.Context, req *authproto.AuthRequest) (int32, string) {
 	var threadID int = 1
 	ctxt := context.WithValue(ctx, common.ThreadName, common.CheckAuth)
@@ -42,7 +50,7 @@ func Auth(ctx context.Context, req *authproto.AuthRequest) (int32, string) {
 		CustomAuthLog(ctx, "", "Invalid session token ", http.StatusUnauthorized)
 		return http.StatusUnauthorized, response.NoValidSession
 	}
-	if len(req.Privileges) == 0 {
+	if len(req.Privileges) == 0 && len(req.Oemprivileges) == 0 {
 		CustomAuthLog(ctx, req.SessionToken, "Received empty privileges, unable to proceed ", http.StatusForbidden)
 		return http.StatusUnauthorized, response.NoValidSession
 	}
@@ -56,11 +64,41 @@ func Auth(ctx context.Context, req *authproto.AuthRequest) (int32, string) {
 		}
 		return status, message
 	}
-	session.LastUsedTime = time.Now()
-	// Update Session
-	if err = session.Update(); err != nil {
-		l.LogWithFields(ctx).Error("SessionToken update failed with error: " + err.Error())
-		return err.GetAuthStatusCodeAndMessage()
+	if err = CheckPasswordChangeRequired(ctx, session); err != nil {
+		status, message := err.GetAuthStatusCodeAndMessage()
+		CustomAuthLog(ctx, req.SessionToken, "Password of the user must be changed before further access: "+err.Error(), status)
+		return status, message
+	}
+	// the sessions of the bearer tokens and the API keys are not stored,
+	// the usage of the API keys is tracked in place of the session
+	if IsAPIKey(req.SessionToken) {
+		if err = RecordAPIKeyUsage(req.SessionToken); err != nil {
+			l.LogWithFields(ctx).Error("API key usage update failed with error: " + err.Error())
+			return err.GetAuthStatusCodeAndMessage()
+		}
+	} else if !oauth2.IsJWT(req.SessionToken) {
+		session.LastUsedTime = time.Now()
+		// Update Session
+		if err = session.Update(); err != nil {
+			l.LogWithFields(ctx).Error("SessionToken update failed with error: " + err.Error())
+			return err.GetAuthStatusCodeAndMessage()
+		}
+	}
+
+	if !isInScope(ctx, req.RequestURI, session.Aggregates) {
+		CustomAuthLog(ctx, req.SessionToken, "Requested resource is not in the scope of the aggregates of the user", http.StatusForbidden)
+		return http.StatusForbidden, response.InsufficientPrivilege
+	}
+
+	// the privileges defined in the privilege registry for the request take precedence
+	// over the privileges passed by the service
+	if granted, exist := isGrantedByRegistry(ctx, req.RequestURI, req.RequestMethod, req.RequestProperties, session.Privileges, session.OEMPrivileges); exist {
+		if !granted {
+			CustomAuthLog(ctx, req.SessionToken, "User does not have the privileges required by the privilege registry", http.StatusForbidden)
+			return http.StatusForbidden, response.InsufficientPrivilege
+		}
+		CustomAuthLog(ctx, req.SessionToken, "Authorization is successful", http.StatusOK)
+		return http.StatusOK, response.Success
 	}
 
 	// if the service has all the privileges then return success
@@ -72,12 +110,38 @@ func Auth(ctx context.Context, req *authproto.AuthRequest) (int32, string) {
 		}
 	}
 
-	// TODO: Need to check OEM Privileges
+	// the OEM privileges passed by the service are checked against
+	// the OEM privileges of the role of the session
+	for _, privilege := range req.Oemprivileges {
+		if !session.OEMPrivileges[privilege] {
+			CustomAuthLog(ctx, req.SessionToken, "User does not have sufficient OEM privileges", http.StatusForbidden)
+			return http.StatusForbidden, response.InsufficientPrivilege
+		}
+	}
 
 	CustomAuthLog(ctx, req.SessionToken, "Authorization is successful", http.StatusOK)
 	return http.StatusOK, response.Success
 }
 
+// NewResourceScope is the function pointer for resolving the scope of the aggregates
+var NewResourceScope = common.NewResourceScope
+
+// isInScope checks the resource of the request URI is in the scope of the aggregates
+// the session is restricted to. The scope is not checked when the session is not
+// restricted or the request URI is not passed by the service. The scope of the
+// aggregates is cached, so it is not resolved from the DB for each request
+func isInScope(ctx context.Context, requestURI string, aggregates []string) bool {
+	if requestURI == "" || len(aggregates) == 0 {
+		return true
+	}
+	scope, err := NewResourceScope(aggregates)
+	if err != nil {
+		l.LogWithFields(ctx).Error("failed to resolve the scope of the aggregates of the session: " + err.Error())
+		return false
+	}
+	return scope.Allows(requestURI)
+}
+
 // CustomAuthLog function takes session token, message and response status code
 // Gets the user id and role id for the session token provided
 // logs the messages in custom log format
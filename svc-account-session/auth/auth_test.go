@@ -15,6 +15,7 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
@@ -77,6 +78,12 @@ func TestAuth(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
+	loginToken := "loginToken"
+	err = createSession(loginToken, "loginID", map[string]bool{common.PrivilegeLogin: true}, currentTime, currentTime)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	config.Data.RegistryStorePath = "../../lib-utilities/etc"
 	// positive test case privilege
 	privileges := []string{common.PrivilegeConfigureUsers}
 
@@ -143,6 +150,20 @@ func TestAuth(t *testing.T) {
 			want:  http.StatusForbidden,
 			want1: response.InsufficientPrivilege,
 		},
+		{
+			name: "audit trail with Login privilege",
+			args: args{
+				req: &authproto.AuthRequest{
+					SessionToken:  loginToken,
+					Privileges:    []string{common.PrivilegeLogin, common.PrivilegeConfigureManager},
+					Oemprivileges: oemPrivileges,
+					RequestURI:    "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit/Entries",
+					RequestMethod: http.MethodGet,
+				},
+			},
+			want:  http.StatusForbidden,
+			want1: response.InsufficientPrivilege,
+		},
 		{
 			name: "without privileges",
 			args: args{
@@ -169,3 +190,31 @@ func TestAuth(t *testing.T) {
 	}
 
 }
+
+func TestIsInScope(t *testing.T) {
+	ctx := mockContext()
+	defer func() {
+		NewResourceScope = common.NewResourceScope
+	}()
+	NewResourceScope = func(aggregates []string) (*common.ResourceScope, error) {
+		return nil, fmt.Errorf("DB connection failed")
+	}
+	aggregates := []string{"/redfish/v1/AggregationService/Aggregates/a1"}
+	tests := []struct {
+		name       string
+		uri        string
+		aggregates []string
+		want       bool
+	}{
+		{"session not restricted", "/redfish/v1/Systems/uuid.1", nil, true},
+		{"request URI not passed", "", aggregates, true},
+		{"scope resolution failure", "/redfish/v1/Systems/uuid.1", aggregates, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isInScope(ctx, tt.uri, tt.aggregates); got != tt.want {
+				t.Errorf("isInScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+var (
+	// GetClientCertificate retrieves the settings of the client certificate authentication
+	GetClientCertificate = asmodel.GetClientCertificate
+
+	subjectAltNameOID    = asn1.ObjectIdentifier{2, 5, 29, 17}
+	userPrincipalNameOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+)
+
+// CheckClientCertificate authenticates the HTTPS client by the PEM encoded certificate chain
+// presented by the client, when the client certificate authentication is enabled in the AccountService.
+// The chain is verified against the root CA of ODIM and the attribute of the certificate given by
+// CertificateMappingAttribute is mapped to the user name of a local account which is not locked
+func CheckClientCertificate(ctx context.Context, certificateChain string) (*asmodel.User, *errors.Error) {
+	settings, err := GetClientCertificate()
+	if err != nil && err.ErrNo() != errors.DBKeyNotFound {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the client certificate settings: ", err.Error())
+	}
+	if !settings.IsEnabled() {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking client certificate: client certificate authentication is disabled")
+	}
+	certificate, verr := verifyClientCertificate([]byte(certificateChain))
+	if verr != nil {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking client certificate: ", verr.Error())
+	}
+	userName := getCertificateUserName(certificate, settings.CertificateMappingAttribute)
+	if userName == "" {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking client certificate: no "+
+			settings.CertificateMappingAttribute+" present in the certificate of "+certificate.Subject.String())
+	}
+	l.LogWithFields(ctx).Debugf("Client certificate of %s is mapped to the user %s", certificate.Subject.String(), userName)
+	user, err := GetUserDetails(userName)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while checking client certificate: unable to get the account of "+userName+": ", err.Error())
+	}
+	attempts, err := GetLoginAttempts(userName)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the failed logins: ", err.Error())
+	}
+	if attempts.IsLocked(lockoutDuration()) {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking client certificate: account is locked")
+	}
+	return &user, nil
+}
+
+// verifyClientCertificate verifies the client certificate, the first of the chain, for client
+// authentication against the root CA of ODIM, the rest of the chain are the intermediate certificates
+func verifyClientCertificate(certificateChain []byte) (*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for block, rest := pem.Decode(certificateChain); block != nil; block, rest = pem.Decode(rest) {
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the certificate: %v", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate is present")
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(config.Data.KeyCertConf.RootCACertificate) {
+		return nil, fmt.Errorf("unable to load the root CA certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := certificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify the certificate of %s: %v", certificates[0].Subject.String(), err)
+	}
+	return certificates[0], nil
+}
+
+// getCertificateUserName returns the user name mapped from the certificate. For the UserPrincipalName
+// mapping, the user principal name of the subject alternative name, or the email address when it is not
+// present, is mapped and the user name is the part before the domain
+func getCertificateUserName(certificate *x509.Certificate, mappingAttribute string) string {
+	if mappingAttribute != asmodel.UserPrincipalNameMapping {
+		return certificate.Subject.CommonName
+	}
+	principalName := getUserPrincipalName(certificate)
+	if principalName == "" && len(certificate.EmailAddresses) > 0 {
+		principalName = certificate.EmailAddresses[0]
+	}
+	userName, _, _ := strings.Cut(principalName, "@")
+	return userName
+}
+
+// getUserPrincipalName returns the user principal name present as the other name of the
+// subject alternative name, which the x509 package does not parse
+func getUserPrincipalName(certificate *x509.Certificate) string {
+	for _, extension := range certificate.Extensions {
+		if !extension.Id.Equal(subjectAltNameOID) {
+			continue
+		}
+		var generalNames asn1.RawValue
+		if _, err := asn1.Unmarshal(extension.Value, &generalNames); err != nil {
+			return ""
+		}
+		for rest := generalNames.Bytes; len(rest) > 0; {
+			var generalName asn1.RawValue
+			var err error
+			if rest, err = asn1.Unmarshal(rest, &generalName); err != nil {
+				return ""
+			}
+			// otherName [0] IMPLICIT SEQUENCE { type-id OBJECT IDENTIFIER, value [0] EXPLICIT ANY }
+			if generalName.Class != asn1.ClassContextSpecific || generalName.Tag != 0 {
+				continue
+			}
+			var otherName struct {
+				TypeID asn1.ObjectIdentifier
+				Value  asn1.RawValue
+			}
+			if _, err := asn1.UnmarshalWithParams(generalName.FullBytes, &otherName, "tag:0"); err != nil {
+				continue
+			}
+			var principalName string
+			if otherName.TypeID.Equal(userPrincipalNameOID) {
+				if _, err := asn1.Unmarshal(otherName.Value.Bytes, &principalName); err == nil {
+					return principalName
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// createMockCertificate creates a certificate signed by the parent, a self signed CA when the parent is nil
+func createMockCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("unable to create the certificate: %v", err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	return certificate, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// userPrincipalNameExtension returns the subject alternative name extension with the user principal name
+func userPrincipalNameExtension(t *testing.T, principalName string) pkix.Extension {
+	value, _ := asn1.Marshal(principalName)
+	otherName, err := asn1.Marshal(struct {
+		TypeID asn1.ObjectIdentifier
+		Value  asn1.RawValue
+	}{userPrincipalNameOID, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: value}})
+	if err != nil {
+		t.Fatalf("unable to marshal the user principal name: %v", err)
+	}
+	// the universal SEQUENCE tag of the other name is replaced with the implicit context specific tag 0
+	otherName[0] = 0xa0
+	generalNames, _ := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSequence, IsCompound: true, Bytes: otherName})
+	return pkix.Extension{Id: subjectAltNameOID, Value: generalNames}
+}
+
+func TestCheckClientCertificate(t *testing.T) {
+	config.SetUpMockConfig(t)
+	store, _ := mockLoginAttempts(t)
+	defer func(getClientCertificate func() (asmodel.ClientCertificate, *errors.Error), getUser func(string) (asmodel.User, *errors.Error),
+		rootCA []byte) {
+		GetClientCertificate = getClientCertificate
+		GetUserDetails = getUser
+		config.Data.KeyCertConf.RootCACertificate = rootCA
+	}(GetClientCertificate, GetUserDetails, config.Data.KeyCertConf.RootCACertificate)
+
+	enabled := true
+	settings := asmodel.ClientCertificate{Enabled: &enabled, CertificateMappingAttribute: asmodel.CommonNameMapping}
+	GetClientCertificate = func() (asmodel.ClientCertificate, *errors.Error) {
+		return settings, nil
+	}
+	GetUserDetails = func(userName string) (asmodel.User, *errors.Error) {
+		if userName != "user1" {
+			return asmodel.User{}, errors.PackError(errors.DBKeyNotFound, "error: data with key "+userName+" not found")
+		}
+		return asmodel.User{UserName: userName, RoleID: "Administrator"}, nil
+	}
+
+	ca, caKey, caPEM := createMockCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "ODIMRA CA"}}, nil, nil)
+	_, _, otherCAPEM := createMockCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Other CA"}}, nil, nil)
+	config.Data.KeyCertConf.RootCACertificate = []byte(caPEM)
+	_, _, clientPEM := createMockCertificate(t, &x509.Certificate{
+		Subject:         pkix.Name{CommonName: "user1"},
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{userPrincipalNameExtension(t, "user1@example.com")},
+	}, ca, caKey)
+	_, _, emailPEM := createMockCertificate(t, &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "client"},
+		EmailAddresses: []string{"user1@example.com"},
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	_, _, serverPEM := createMockCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "user1"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	_, _, unknownPEM := createMockCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "user2"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		mapping     string
+		certificate string
+		wantErr     bool
+	}{
+		{"disabled", false, asmodel.CommonNameMapping, clientPEM, true},
+		{"common name", true, asmodel.CommonNameMapping, clientPEM, false},
+		{"user principal name", true, asmodel.UserPrincipalNameMapping, clientPEM, false},
+		{"email address", true, asmodel.UserPrincipalNameMapping, emailPEM, false},
+		{"common name not mapped", true, asmodel.CommonNameMapping, emailPEM, true},
+		{"not issued by the root CA", true, asmodel.CommonNameMapping, otherCAPEM, true},
+		{"not for client authentication", true, asmodel.CommonNameMapping, serverPEM, true},
+		{"unknown user", true, asmodel.CommonNameMapping, unknownPEM, true},
+		{"invalid certificate", true, asmodel.CommonNameMapping, "xyz", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enabled = tt.enabled
+			settings.CertificateMappingAttribute = tt.mapping
+			user, err := CheckClientCertificate(context.Background(), tt.certificate)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckClientCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && user.UserName != "user1" {
+				t.Errorf("CheckClientCertificate() user = %v, want user1", user.UserName)
+			}
+		})
+	}
+
+	store["user1"] = asmodel.LoginAttempts{UserName: "user1", FailedAttempts: 5, LockedTime: time.Now()}
+	settings.CertificateMappingAttribute = asmodel.CommonNameMapping
+	if _, err := CheckClientCertificate(context.Background(), clientPEM); err == nil {
+		t.Errorf("CheckClientCertificate() authenticated a locked account")
+	}
+}
@@ -0,0 +1,105 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	customLogs "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+const accountLockedMessageID = "ResourceEvent.1.3.0.ResourceStateChanged"
+
+var (
+	// GetLoginAttempts retrieves the failed logins of the user
+	GetLoginAttempts = asmodel.GetLoginAttempts
+	// IncrementFailedLogins counts a failed login of the user
+	IncrementFailedLogins = asmodel.IncrementFailedLogins
+	// LockAccount locks the account of the user for the lockout duration
+	LockAccount = asmodel.LockAccount
+	// DeleteLoginAttempts removes the failed logins of the user, which unlocks the account
+	DeleteLoginAttempts = asmodel.DeleteLoginAttempts
+	// PublishEvent publishes the event of the account to the message bus
+	PublishEvent = asmessagebus.Publish
+)
+
+// IsAccountLocked checks the account of the user is locked after
+// consecutive failed logins and the lockout duration is not elapsed
+func IsAccountLocked(userName string) (bool, *errors.Error) {
+	attempts, err := GetLoginAttempts(userName)
+	if err != nil {
+		return false, err
+	}
+	return attempts.IsLocked(lockoutDuration()), nil
+}
+
+// recordFailedLogin increments the consecutive failed logins of the user and locks the account
+// when the failed logins reach the lockout threshold. The failed logins are counted in the in-memory db,
+// so the logins failed on all the service instances are counted. They are counted again when the lock
+// is expired or when the user does not fail to login for the counter reset duration.
+// The failed logins are not counted when the lockout threshold is 0, which disables the lockout
+func recordFailedLogin(ctx context.Context, userName string) {
+	if config.Data.AuthConf.AccountLockoutThreshold == 0 {
+		return
+	}
+	failedLogins, err := IncrementFailedLogins(userName, config.Data.AuthConf.AccountLockoutCounterResetAfter)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to count the failed login of the user " + userName + ": " + err.Error())
+		return
+	}
+	if failedLogins < config.Data.AuthConf.AccountLockoutThreshold {
+		return
+	}
+	// the account is locked once when the logins fail concurrently
+	locked, err := LockAccount(userName, config.Data.AuthConf.AccountLockoutDuration)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Unable to lock the account of the user " + userName + ": " + err.Error())
+	}
+	if !locked {
+		return
+	}
+	message := fmt.Sprintf("Account %s is locked for %d seconds after %d consecutive failed logins", userName,
+		config.Data.AuthConf.AccountLockoutDuration, failedLogins)
+	ctx = context.WithValue(ctx, common.SessionUserID, userName)
+	ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusUnauthorized))
+	customLogs.AuthLog(ctx).Warn(message)
+	accountURI := "/redfish/v1/AccountService/Accounts/" + userName
+	go PublishEvent(ctx, accountURI, accountLockedMessageID, "Alert",
+		"The state of resource `"+accountURI+"` has changed to Locked.", "Warning", []string{accountURI, "Locked"})
+}
+
+// resetFailedLogins removes the failed logins of the user after a successful login
+func resetFailedLogins(ctx context.Context, attempts asmodel.LoginAttempts) {
+	if attempts.FailedAttempts == 0 {
+		return
+	}
+	if err := DeleteLoginAttempts(attempts.UserName); err != nil {
+		l.LogWithFields(ctx).Error("Unable to reset the failed logins of the user " + attempts.UserName + ": " + err.Error())
+	}
+}
+
+func lockoutDuration() time.Duration {
+	return time.Duration(config.Data.AuthConf.AccountLockoutDuration) * time.Second
+}
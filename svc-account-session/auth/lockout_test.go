@@ -0,0 +1,152 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// mockLoginAttempts replaces the in-memory db functions of the failed logins
+// and returns the failed logins saved by the mock and the published events
+func mockLoginAttempts(t *testing.T) (map[string]asmodel.LoginAttempts, chan string) {
+	store := map[string]asmodel.LoginAttempts{}
+	events := make(chan string, 10)
+	getLoginAttempts, incrementFailedLogins, lockAccount := GetLoginAttempts, IncrementFailedLogins, LockAccount
+	deleteLoginAttempts, publishEvent := DeleteLoginAttempts, PublishEvent
+	t.Cleanup(func() {
+		GetLoginAttempts, IncrementFailedLogins, LockAccount = getLoginAttempts, incrementFailedLogins, lockAccount
+		DeleteLoginAttempts, PublishEvent = deleteLoginAttempts, publishEvent
+	})
+	GetLoginAttempts = func(userName string) (asmodel.LoginAttempts, *errors.Error) {
+		attempts, ok := store[userName]
+		if !ok {
+			attempts.UserName = userName
+		}
+		return attempts, nil
+	}
+	IncrementFailedLogins = func(userName string, counterResetAfter int) (int, *errors.Error) {
+		if counterResetAfter != config.Data.AuthConf.AccountLockoutCounterResetAfter {
+			t.Errorf("failed logins are reset after %d seconds, want %d", counterResetAfter, config.Data.AuthConf.AccountLockoutCounterResetAfter)
+		}
+		attempts := store[userName]
+		attempts.UserName = userName
+		attempts.FailedAttempts++
+		store[userName] = attempts
+		return attempts.FailedAttempts, nil
+	}
+	LockAccount = func(userName string, lockoutDuration int) (bool, *errors.Error) {
+		attempts := store[userName]
+		if attempts.IsLocked(time.Duration(lockoutDuration) * time.Second) {
+			return false, nil
+		}
+		attempts.FailedAttempts = 0
+		attempts.LockedTime = time.Now()
+		store[userName] = attempts
+		return true, nil
+	}
+	DeleteLoginAttempts = func(userName string) *errors.Error {
+		delete(store, userName)
+		return nil
+	}
+	PublishEvent = func(ctx context.Context, accountURI, messageID, eventType, message, severity string, messageArgs []string) {
+		events <- accountURI
+	}
+	return store, events
+}
+
+func TestRecordFailedLogin(t *testing.T) {
+	config.SetUpMockConfig(t)
+	store, events := mockLoginAttempts(t)
+	ctx := context.Background()
+	threshold := config.Data.AuthConf.AccountLockoutThreshold
+
+	for i := 1; i < threshold; i++ {
+		recordFailedLogin(ctx, "user1")
+		if locked, _ := IsAccountLocked("user1"); locked {
+			t.Fatalf("account is locked after %d failed logins, want locked after %d", i, threshold)
+		}
+	}
+	recordFailedLogin(ctx, "user1")
+	if locked, _ := IsAccountLocked("user1"); !locked {
+		t.Fatalf("account is not locked after %d failed logins", threshold)
+	}
+	select {
+	case uri := <-events:
+		if uri != "/redfish/v1/AccountService/Accounts/user1" {
+			t.Errorf("event is published for %s", uri)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("event of the locked account is not published")
+	}
+
+	// the lock expires after the lockout duration
+	attempts := store["user1"]
+	attempts.LockedTime = attempts.LockedTime.Add(-lockoutDuration())
+	store["user1"] = attempts
+	if locked, _ := IsAccountLocked("user1"); locked {
+		t.Errorf("account is locked after the lockout duration")
+	}
+	// the failed logins are counted again after the lock is expired
+	recordFailedLogin(ctx, "user1")
+	if got := store["user1"].FailedAttempts; got != 1 {
+		t.Errorf("failed logins after the lock is expired = %d, want 1", got)
+	}
+
+	// the account locked by a concurrent failed login is not locked again
+	attempts = store["user1"]
+	attempts.FailedAttempts = threshold - 1
+	attempts.LockedTime = time.Now()
+	store["user1"] = attempts
+	recordFailedLogin(ctx, "user1")
+	select {
+	case uri := <-events:
+		t.Errorf("event is published for %s, which is already locked", uri)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the failed logins are removed after a successful login
+	resetFailedLogins(ctx, store["user1"])
+	if _, ok := store["user1"]; ok {
+		t.Errorf("failed logins are not removed after a successful login")
+	}
+}
+
+func TestRecordFailedLoginWithLockoutDisabled(t *testing.T) {
+	config.SetUpMockConfig(t)
+	store, events := mockLoginAttempts(t)
+	config.Data.AuthConf.AccountLockoutThreshold = 0
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		recordFailedLogin(ctx, "user1")
+	}
+	if _, ok := store["user1"]; ok {
+		t.Errorf("failed logins are counted when the lockout is disabled")
+	}
+	if locked, _ := IsAccountLocked("user1"); locked {
+		t.Errorf("account is locked when the lockout is disabled")
+	}
+	select {
+	case uri := <-events:
+		t.Errorf("event is published for %s when the lockout is disabled", uri)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
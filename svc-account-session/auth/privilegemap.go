@@ -0,0 +1,89 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+)
+
+// privilegeRegistry holds the privilege registry loaded from the registry store.
+// The registry is loaded again when the file is modified, so that the operators
+// can change the privileges of the operations without restarting the service
+var privilegeRegistry struct {
+	sync.Mutex
+	privilegeMap *dmtf.PrivilegeMap
+	path         string
+	modTime      time.Time
+}
+
+// LoadPrivilegeMap is the function pointer for loading the privilege registry
+var LoadPrivilegeMap = dmtf.LoadPrivilegeMap
+
+// getPrivilegeMap returns the privilege registry, loading it again when the file changed.
+// Nil is returned when no privilege registry could be loaded
+func getPrivilegeMap(ctx context.Context) *dmtf.PrivilegeMap {
+	privilegeRegistry.Lock()
+	defer privilegeRegistry.Unlock()
+	if privilegeRegistry.privilegeMap != nil {
+		info, err := os.Stat(privilegeRegistry.path)
+		if err == nil && info.ModTime().Equal(privilegeRegistry.modTime) {
+			return privilegeRegistry.privilegeMap
+		}
+	}
+	privilegeMap, path, err := LoadPrivilegeMap(config.Data.RegistryStorePath)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while trying to load the privilege registry: " + err.Error())
+		return privilegeRegistry.privilegeMap
+	}
+	privilegeRegistry.privilegeMap = privilegeMap
+	privilegeRegistry.path = path
+	if info, err := os.Stat(path); err == nil {
+		privilegeRegistry.modTime = info.ModTime()
+	}
+	l.LogWithFields(ctx).Info("loaded the privilege registry " + privilegeMap.ID)
+	return privilegeMap
+}
+
+// isGrantedByRegistry checks the privileges and the OEM privileges of the session
+// against the privileges required by the privilege registry for the request. The properties patched by
+// the request are used for the PropertyOverrides of the registry. The returned bool is false
+// when the registry does not define the privileges of the request.
+// ConfigureSelf is not considered granted here, as the ownership of the resource
+// can only be checked by the service of the resource
+func isGrantedByRegistry(ctx context.Context, requestURI, requestMethod string, properties []string, privileges, oemPrivileges map[string]bool) (bool, bool) {
+	if requestURI == "" || requestMethod == "" {
+		return false, false
+	}
+	privilegeMap := getPrivilegeMap(ctx)
+	if privilegeMap == nil {
+		return false, false
+	}
+	required, exist := privilegeMap.RequiredPrivileges(requestURI, requestMethod, properties)
+	if !exist {
+		return false, false
+	}
+	return dmtf.IsGranted(required, func(privilege string) bool {
+		return privilege != common.PrivilegeConfigureSelf && (privileges[privilege] || oemPrivileges[privilege])
+	}), true
+}
@@ -0,0 +1,167 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestIsGrantedByRegistry(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.RegistryStorePath = "../../lib-utilities/etc"
+	ctx := mockContext()
+	login := map[string]bool{common.PrivilegeLogin: true}
+	components := map[string]bool{common.PrivilegeLogin: true, common.PrivilegeConfigureComponents: true}
+	manager := map[string]bool{common.PrivilegeLogin: true, common.PrivilegeConfigureManager: true}
+	tests := []struct {
+		name       string
+		uri        string
+		method     string
+		privileges map[string]bool
+		granted    bool
+		exist      bool
+	}{
+		{"read a system", "/redfish/v1/Systems/uuid.1", http.MethodGet, login, true, true},
+		{"reset a system without ConfigureComponents", "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", http.MethodPost, login, false, true},
+		{"reset a system", "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", http.MethodPost, components, true, true},
+		{"manager interface subordinate override", "/redfish/v1/Managers/uuid.1/EthernetInterfaces/1", http.MethodPatch, components, false, true},
+		{"ConfigureSelf is not granted", "/redfish/v1/SessionService/Sessions/1", http.MethodDelete, map[string]bool{common.PrivilegeConfigureSelf: true}, false, true},
+		{"read the audit trail with Login", "/redfish/v1/Managers/uuid/LogServices/Audit/Entries", http.MethodGet, login, false, true},
+		{"read an audit entry with Login", "/redfish/v1/Managers/uuid/LogServices/Audit/Entries/1", http.MethodGet, login, false, true},
+		{"read the audit trail", "/redfish/v1/Managers/uuid/LogServices/Audit/Entries?$top=10", http.MethodGet, manager, true, true},
+		{"read other log entries with Login", "/redfish/v1/Managers/uuid.1/LogServices/SL/Entries/1", http.MethodGet, login, true, true},
+		{"URI not in the registry", "/ODIM/v1/Status", http.MethodGet, login, false, false},
+		{"no request passed", "", "", login, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted, exist := isGrantedByRegistry(ctx, tt.uri, tt.method, nil, tt.privileges, nil)
+			if granted != tt.granted || exist != tt.exist {
+				t.Errorf("isGrantedByRegistry() = %v, %v, want %v, %v", granted, exist, tt.granted, tt.exist)
+			}
+		})
+	}
+}
+
+func TestIsGrantedByRegistryWithOEMPrivileges(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	defer func() {
+		LoadPrivilegeMap = dmtf.LoadPrivilegeMap
+		privilegeRegistry.privilegeMap = nil
+	}()
+	privilegeRegistry.privilegeMap = nil
+	LoadPrivilegeMap = func(string) (*dmtf.PrivilegeMap, string, error) {
+		return &dmtf.PrivilegeMap{
+			OEMPrivilegesUsed: []string{"ExecuteReset"},
+			Mappings: []dmtf.Mapping{
+				{
+					Entity: "ComputerSystem",
+					OperationMap: dmtf.OperationMap{
+						POST: []dmtf.OperationPrivilege{{Privilege: []string{common.PrivilegeConfigureComponents}}},
+					},
+					ResourceURIOverrides: []dmtf.TargetPrivilegeMap{
+						{
+							Targets: []string{"/redfish/v1/Systems/{ComputerSystemId}/Actions/ComputerSystem.Reset"},
+							OperationMap: dmtf.OperationMap{
+								POST: []dmtf.OperationPrivilege{
+									{Privilege: []string{common.PrivilegeConfigureComponents}},
+									{Privilege: []string{"ExecuteReset"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, "", nil
+	}
+	login := map[string]bool{common.PrivilegeLogin: true}
+	oem := map[string]bool{"ExecuteReset": true}
+	tests := []struct {
+		name          string
+		uri           string
+		oemPrivileges map[string]bool
+		granted       bool
+	}{
+		{"reset with the OEM privilege", "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", oem, true},
+		{"reset without the OEM privilege", "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", nil, false},
+		{"OEM privilege not mapped to the operation", "/redfish/v1/Systems/uuid.1", oem, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted, exist := isGrantedByRegistry(ctx, tt.uri, http.MethodPost, nil, login, tt.oemPrivileges)
+			if granted != tt.granted || !exist {
+				t.Errorf("isGrantedByRegistry() = %v, %v, want %v, true", granted, exist, tt.granted)
+			}
+		})
+	}
+}
+
+func TestIsGrantedByRegistryWithPropertyOverrides(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	defer func() {
+		LoadPrivilegeMap = dmtf.LoadPrivilegeMap
+		privilegeRegistry.privilegeMap = nil
+	}()
+	privilegeRegistry.privilegeMap = nil
+	LoadPrivilegeMap = func(string) (*dmtf.PrivilegeMap, string, error) {
+		return &dmtf.PrivilegeMap{
+			Mappings: []dmtf.Mapping{
+				{
+					Entity: "ComputerSystem",
+					OperationMap: dmtf.OperationMap{
+						PATCH: []dmtf.OperationPrivilege{{Privilege: []string{common.PrivilegeConfigureComponents}}},
+					},
+					PropertyOverrides: []dmtf.TargetPrivilegeMap{
+						{
+							Targets: []string{"Boot"},
+							OperationMap: dmtf.OperationMap{
+								PATCH: []dmtf.OperationPrivilege{{Privilege: []string{common.PrivilegeConfigureManager}}},
+							},
+						},
+					},
+				},
+			},
+		}, "", nil
+	}
+	components := map[string]bool{common.PrivilegeLogin: true, common.PrivilegeConfigureComponents: true}
+	manager := map[string]bool{common.PrivilegeLogin: true, common.PrivilegeConfigureManager: true}
+	tests := []struct {
+		name       string
+		properties []string
+		privileges map[string]bool
+		granted    bool
+	}{
+		{"patch without properties", nil, components, true},
+		{"patch a property without override", []string{"AssetTag"}, components, true},
+		{"patch an overridden property without its privilege", []string{"Boot"}, components, false},
+		{"patch an overridden property", []string{"Boot"}, manager, true},
+		{"patch an overridden property along with others", []string{"AssetTag", "Boot"}, manager, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted, exist := isGrantedByRegistry(ctx, "/redfish/v1/Systems/uuid.1", http.MethodPatch, tt.properties, tt.privileges, nil)
+			if granted != tt.granted || !exist {
+				t.Errorf("isGrantedByRegistry() = %v, %v, want %v, true", granted, exist, tt.granted)
+			}
+		})
+	}
+}
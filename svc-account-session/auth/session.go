@@ -27,6 +27,8 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/ldap"
+	"github.com/ODIM-Project/ODIM/svc-account-session/oauth2"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -35,7 +37,23 @@ var lastExpiredSessionCleanUpTime time.Time
 // Lock defines mutex lock to avoid race conditions
 var Lock sync.Mutex
 
-// CheckSessionCreationCredentials defines the auth at the time of session creation
+var (
+	// GetExternalAccountProvider retrieves the external account provider configured in the AccountService
+	GetExternalAccountProvider = asmodel.GetExternalAccountProvider
+	// AuthenticateExternalAccount authenticates the user against the directory of the external account provider
+	AuthenticateExternalAccount = ldap.Authenticate
+	// ValidateBearerToken validates the bearer token with the OAuth2 external account provider
+	ValidateBearerToken = oauth2.Validate
+	// GetUserDetails retrieves the local account of the user of the bearer token
+	GetUserDetails = asmodel.GetUserDetails
+	// GetRoleDetailsByID retrieves the role of the user of the bearer token
+	GetRoleDetailsByID = asmodel.GetRoleDetailsByID
+	// UpdateUserDetails saves the time the password of the user was set, at its first login
+	UpdateUserDetails = asmodel.UpdateUserDetails
+)
+
+// CheckSessionCreationCredentials defines the auth at the time of session creation.
+// The account of a local user is locked after consecutive failed logins
 func CheckSessionCreationCredentials(ctx context.Context, userName, password string) (*asmodel.User, *errors.Error) {
 	var threadID int = 1
 	ctxt := context.WithValue(ctx, common.ThreadName, common.CheckSessionCreation)
@@ -47,18 +65,71 @@ func CheckSessionCreationCredentials(ctx context.Context, userName, password str
 	}
 	user, err := asmodel.GetUserDetails(userName)
 	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			// the users not present as local accounts are authenticated against the external account providers
+			return checkExternalAccountCredentials(ctx, userName, password, err)
+		}
 		return nil, errors.PackError(err.ErrNo(), "error: Invalid username or password :", err.Error())
 	}
+	// the password is not checked for a locked account, till the lockout duration is elapsed
+	attempts, err := GetLoginAttempts(userName)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the failed logins: ", err.Error())
+	}
+	if attempts.IsLocked(lockoutDuration()) {
+		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking session credentials: account is locked")
+	}
 	hash := sha3.New512()
 	hash.Write([]byte(password))
 	hashSum := hash.Sum(nil)
 	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
 	if user.Password != hashedPassword {
+		recordFailedLogin(ctx, userName)
 		return nil, errors.PackError(errors.UndefinedErrorType, "error while checking session credentials: input password is not matching user password")
 	}
+	resetFailedLogins(ctx, attempts)
+	// the time the password was set is not known for the accounts created without it,
+	// the expiration of their password starts with their first login
+	if user.PasswordChangedTime.IsZero() {
+		user.PasswordChangedTime = time.Now()
+		if err := UpdateUserDetails(user, asmodel.User{}); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to save the time the password of the user " + userName + " was set: " + err.Error())
+		}
+	}
 	return &user, nil
 }
 
+// checkExternalAccountCredentials authenticates the user against the enabled external account
+// providers, LDAP and then ActiveDirectory. The user is given the role mapped to the groups of
+// the user in the directory. The error of the local account is returned when no provider is enabled
+func checkExternalAccountCredentials(ctx context.Context, userName, password string, localErr *errors.Error) (*asmodel.User, *errors.Error) {
+	authErr := errors.PackError(localErr.ErrNo(), "error: Invalid username or password :", localErr.Error())
+	for _, key := range []string{asmodel.LDAPProvider, asmodel.ActiveDirectoryProvider} {
+		provider, err := GetExternalAccountProvider(key)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return nil, errors.PackError(err.ErrNo(), "error while trying to get the external account provider: ", err.Error())
+		}
+		if !provider.IsEnabled() {
+			continue
+		}
+		roleID, aerr := AuthenticateExternalAccount(ctx, provider, userName, password)
+		if aerr != nil {
+			l.LogWithFields(ctx).Infof("Unable to authenticate the user %s with %s: %s", userName, key, aerr.Error())
+			authErr = errors.PackError(errors.UndefinedErrorType, "error while checking session credentials with "+key+": ", aerr.Error())
+			continue
+		}
+		return &asmodel.User{
+			UserName:     userName,
+			RoleID:       roleID,
+			AccountTypes: []string{key},
+		}, nil
+	}
+	return nil, authErr
+}
+
 // CheckSessionTimeOut defines the session validity check
 func CheckSessionTimeOut(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
 	var threadID int = 1
@@ -69,6 +140,12 @@ func CheckSessionTimeOut(ctx context.Context, sessionToken string) (*asmodel.Ses
 	if sessionToken == "" {
 		return nil, errors.PackError(errors.InvalidAuthToken, "error: no session token found in header")
 	}
+	if oauth2.IsJWT(sessionToken) {
+		return checkBearerToken(ctx, sessionToken)
+	}
+	if IsAPIKey(sessionToken) {
+		return checkAPIKey(ctx, sessionToken)
+	}
 	session, err := asmodel.GetSession(sessionToken)
 	if err != nil {
 		return nil, errors.PackError(err.ErrNo(), "error while trying to get session details", ": ", err.Error())
@@ -80,6 +157,76 @@ func CheckSessionTimeOut(ctx context.Context, sessionToken string) (*asmodel.Ses
 	return &session, nil
 }
 
+// CheckPasswordChangeRequired rejects the session created with a password which is required
+// to be changed or is expired, till the user changes the password. The restriction of the
+// session is removed once the password is changed
+func CheckPasswordChangeRequired(ctx context.Context, session *asmodel.Session) *errors.Error {
+	if !session.PasswordChangeRequired {
+		return nil
+	}
+	user, err := GetUserDetails(session.UserName)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get the user details: ", err.Error())
+	}
+	if user.IsPasswordChangeRequired(config.Data.AuthConf.PasswordRules.PasswordExpirationDays) {
+		return errors.PackError(errors.PasswordChangeRequired, "error: password of the user "+session.UserName+" must be changed")
+	}
+	session.PasswordChangeRequired = false
+	return nil
+}
+
+// checkBearerToken validates the bearer token issued by the OAuth2 external account provider.
+// The session of the token is not stored, it is valid as long as the token and has the
+// privileges of the role mapped to the groups claimed by the token. The tokens of the users
+// present as local accounts are not accepted, as the local accounts take precedence
+func checkBearerToken(ctx context.Context, token string) (*asmodel.Session, *errors.Error) {
+	provider, err := GetExternalAccountProvider(asmodel.OAuth2Provider)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, errors.PackError(errors.InvalidAuthToken, "error: OAuth2 external account provider is not configured")
+		}
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the OAuth2 external account provider: ", err.Error())
+	}
+	if !provider.IsEnabled() {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: OAuth2 external account provider is not enabled")
+	}
+	bearer, verr := ValidateBearerToken(ctx, provider, token)
+	if verr != nil {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: invalid bearer token: ", verr.Error())
+	}
+	if _, err := GetUserDetails(bearer.UserName); err == nil {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: bearer token is issued for the local account ", bearer.UserName)
+	} else if err.ErrNo() != errors.DBKeyNotFound {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the user details: ", err.Error())
+	}
+	role, err := GetRoleDetailsByID(bearer.RoleID)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to get the role of the bearer token: ", err.Error())
+	}
+	session := asmodel.Session{
+		Token:         token,
+		UserName:      bearer.UserName,
+		RoleID:        bearer.RoleID,
+		Privileges:    make(map[string]bool),
+		OEMPrivileges: make(map[string]bool),
+		CreatedTime:   bearer.IssuedAt,
+		LastUsedTime:  time.Now(),
+	}
+	for _, privilege := range role.AssignedPrivileges {
+		session.Privileges[privilege] = true
+	}
+	for _, privilege := range role.OEMPrivileges {
+		session.OEMPrivileges[privilege] = true
+	}
+	if !session.Privileges[common.PrivilegeLogin] {
+		return nil, errors.PackError(errors.InvalidAuthToken, "error: role of the bearer token does not have the Login privilege")
+	}
+	if session.CreatedTime.IsZero() {
+		session.CreatedTime = session.LastUsedTime
+	}
+	return &session, nil
+}
+
 // expiredSessionCleanUp is for deleting timed out sessions from the db
 func expiredSessionCleanUp(ctx context.Context) {
 	Lock.Lock()
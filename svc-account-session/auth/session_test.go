@@ -14,7 +14,9 @@
 package auth
 
 import (
+	"context"
 	"encoding/base64"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -23,7 +25,9 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/oauth2"
 )
 
 func createMockUser(username, roleID string) error {
@@ -100,6 +104,11 @@ func TestCheckSessionCreationCredentials(t *testing.T) {
 			}
 			if got != nil {
 				got.Password = ""
+				// the time the password was set is saved at the first login
+				if user, _ := asmodel.GetUserDetails(got.UserName); got.PasswordChangedTime.IsZero() || !user.PasswordChangedTime.Equal(got.PasswordChangedTime) {
+					t.Errorf("CheckSessionCreationCredentials() PasswordChangedTime = %v, saved %v", got.PasswordChangedTime, user.PasswordChangedTime)
+				}
+				got.PasswordChangedTime = time.Time{}
 			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CheckSessionCreationCredentials() = %v, want %v", got, tt.want)
@@ -178,3 +187,154 @@ func TestCheckSessionTimeOut(t *testing.T) {
 		time.Sleep(4 * time.Second)
 	}
 }
+
+func TestCheckExternalAccountCredentials(t *testing.T) {
+	defer func(get func(string) (asmodel.ExternalAccountProvider, *errors.Error),
+		authenticate func(context.Context, asmodel.ExternalAccountProvider, string, string) (string, error)) {
+		GetExternalAccountProvider = get
+		AuthenticateExternalAccount = authenticate
+	}(GetExternalAccountProvider, AuthenticateExternalAccount)
+
+	enabled, disabled := true, false
+	providers := map[string]asmodel.ExternalAccountProvider{
+		asmodel.LDAPProvider:            {ServiceEnabled: &disabled},
+		asmodel.ActiveDirectoryProvider: {ServiceEnabled: &enabled, ServiceAddresses: []string{"ldaps://ad.example.com"}},
+	}
+	GetExternalAccountProvider = func(key string) (asmodel.ExternalAccountProvider, *errors.Error) {
+		if provider, ok := providers[key]; ok {
+			return provider, nil
+		}
+		return asmodel.ExternalAccountProvider{}, errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	AuthenticateExternalAccount = func(ctx context.Context, provider asmodel.ExternalAccountProvider, userName, password string) (string, error) {
+		if provider.ServiceAddresses[0] == "ldaps://ad.example.com" && userName == "alice" && password == "Al1ce" {
+			return common.RoleMonitor, nil
+		}
+		return "", fmt.Errorf("invalid credentials")
+	}
+	localErr := errors.PackError(errors.DBKeyNotFound, "no data with the key alice found")
+
+	got, err := checkExternalAccountCredentials(mockContext(), "alice", "Al1ce", localErr)
+	want := &asmodel.User{UserName: "alice", RoleID: common.RoleMonitor, AccountTypes: []string{asmodel.ActiveDirectoryProvider}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("checkExternalAccountCredentials() = %v, %v, want %v", got, err, want)
+	}
+
+	if _, err := checkExternalAccountCredentials(mockContext(), "alice", "wrong", localErr); err == nil {
+		t.Errorf("checkExternalAccountCredentials() expected an error for invalid credentials")
+	}
+
+	delete(providers, asmodel.ActiveDirectoryProvider)
+	if _, err := checkExternalAccountCredentials(mockContext(), "alice", "Al1ce", localErr); err == nil || err.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("checkExternalAccountCredentials() error = %v, want the error of the local account", err)
+	}
+}
+
+func TestCheckBearerToken(t *testing.T) {
+	defer func(get func(string) (asmodel.ExternalAccountProvider, *errors.Error),
+		validate func(context.Context, asmodel.ExternalAccountProvider, string) (oauth2.Token, error),
+		getUser func(string) (asmodel.User, *errors.Error), getRole func(string) (asmodel.Role, *errors.Error)) {
+		GetExternalAccountProvider = get
+		ValidateBearerToken = validate
+		GetUserDetails = getUser
+		GetRoleDetailsByID = getRole
+	}(GetExternalAccountProvider, ValidateBearerToken, GetUserDetails, GetRoleDetailsByID)
+
+	enabled := true
+	providers := map[string]asmodel.ExternalAccountProvider{
+		asmodel.OAuth2Provider: {ServiceEnabled: &enabled},
+	}
+	GetExternalAccountProvider = func(key string) (asmodel.ExternalAccountProvider, *errors.Error) {
+		if provider, ok := providers[key]; ok {
+			return provider, nil
+		}
+		return asmodel.ExternalAccountProvider{}, errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	ValidateBearerToken = func(ctx context.Context, provider asmodel.ExternalAccountProvider, token string) (oauth2.Token, error) {
+		switch token {
+		case "header.alice.signature":
+			return oauth2.Token{UserName: "alice", RoleID: common.RoleMonitor, IssuedAt: issuedAt}, nil
+		case "header.admin.signature":
+			return oauth2.Token{UserName: "admin", RoleID: common.RoleAdmin, IssuedAt: issuedAt}, nil
+		case "header.bob.signature":
+			return oauth2.Token{UserName: "bob", RoleID: "NoLogin", IssuedAt: issuedAt}, nil
+		}
+		return oauth2.Token{}, fmt.Errorf("invalid token")
+	}
+	GetUserDetails = func(userName string) (asmodel.User, *errors.Error) {
+		if userName == "admin" {
+			return asmodel.User{UserName: "admin"}, nil
+		}
+		return asmodel.User{}, errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	GetRoleDetailsByID = func(roleID string) (asmodel.Role, *errors.Error) {
+		if roleID == common.RoleMonitor {
+			return asmodel.Role{ID: roleID, AssignedPrivileges: []string{common.PrivilegeLogin}}, nil
+		}
+		return asmodel.Role{ID: roleID, AssignedPrivileges: []string{common.PrivilegeConfigureSelf}}, nil
+	}
+
+	got, err := CheckSessionTimeOut(mockContext(), "header.alice.signature")
+	if err != nil {
+		t.Fatalf("CheckSessionTimeOut() error = %v", err)
+	}
+	if got.UserName != "alice" || got.RoleID != common.RoleMonitor || !got.Privileges[common.PrivilegeLogin] ||
+		!got.CreatedTime.Equal(issuedAt) || got.Token != "header.alice.signature" {
+		t.Errorf("CheckSessionTimeOut() = %v, want the session of alice", got)
+	}
+
+	for _, token := range []string{"header.invalid.signature", "header.admin.signature", "header.bob.signature"} {
+		if _, err := CheckSessionTimeOut(mockContext(), token); err == nil || err.ErrNo() != errors.InvalidAuthToken {
+			t.Errorf("CheckSessionTimeOut(%s) error = %v, want an invalid token", token, err)
+		}
+	}
+
+	delete(providers, asmodel.OAuth2Provider)
+	if _, err := CheckSessionTimeOut(mockContext(), "header.alice.signature"); err == nil || err.ErrNo() != errors.InvalidAuthToken {
+		t.Errorf("CheckSessionTimeOut() error = %v, want an invalid token when OAuth2 is not configured", err)
+	}
+}
+
+func TestCheckPasswordChangeRequired(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.AuthConf.PasswordRules.PasswordExpirationDays = 90
+	defer func() {
+		GetUserDetails = asmodel.GetUserDetails
+	}()
+	users := map[string]asmodel.User{
+		"changeRequired": {UserName: "changeRequired", PasswordChangeRequired: true},
+		"expired":        {UserName: "expired", PasswordChangedTime: time.Now().AddDate(0, 0, -91)},
+		"changed":        {UserName: "changed", PasswordChangedTime: time.Now()},
+	}
+	GetUserDetails = func(userName string) (asmodel.User, *errors.Error) {
+		user, ok := users[userName]
+		if !ok {
+			return user, errors.PackError(errors.DBKeyNotFound, "no data with the with key "+userName+" found")
+		}
+		return user, nil
+	}
+	tests := []struct {
+		name    string
+		session asmodel.Session
+		wantErr bool
+	}{
+		{name: "session without restriction", session: asmodel.Session{UserName: "unknown"}},
+		{name: "password change required", session: asmodel.Session{UserName: "changeRequired", PasswordChangeRequired: true}, wantErr: true},
+		{name: "password expired", session: asmodel.Session{UserName: "expired", PasswordChangeRequired: true}, wantErr: true},
+		{name: "password changed", session: asmodel.Session{UserName: "changed", PasswordChangeRequired: true}},
+		{name: "user not found", session: asmodel.Session{UserName: "unknown", PasswordChangeRequired: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := tt.session
+			err := CheckPasswordChangeRequired(context.Background(), &session)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckPasswordChangeRequired() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && session.PasswordChangeRequired {
+				t.Errorf("CheckPasswordChangeRequired() restriction of the session is not removed")
+			}
+		})
+	}
+}
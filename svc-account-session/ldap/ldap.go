@@ -0,0 +1,233 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package ldap authenticates the users against the LDAP and
+// Active Directory external account providers
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+const (
+	dialTimeout = 10 * time.Second
+
+	defaultGroupsAttribute              = "memberOf"
+	defaultUsernameAttribute            = "uid"
+	defaultActiveDirectoryUserAttribute = "sAMAccountName"
+)
+
+// DecryptPassword decrypts the password of the provider stored encrypted
+// with the public key of ODIM
+var DecryptPassword = common.DecryptWithPrivateKey
+
+// Authenticate binds to the directory of the provider as the user and maps the groups
+// of the user to a role with the RemoteRoleMapping of the provider.
+// The first mapping matching a group of the user gives the role of the user.
+// The service addresses are tried in order till a connection is established.
+func Authenticate(ctx context.Context, provider asmodel.ExternalAccountProvider, userName, password string) (string, error) {
+	if userName == "" || password == "" {
+		return "", fmt.Errorf("username or password is empty")
+	}
+	conn, err := connect(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if err := serviceBind(conn, provider.Authentication); err != nil {
+		return "", err
+	}
+
+	entry, err := searchUser(conn, provider, userName)
+	if err != nil {
+		return "", err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		return "", fmt.Errorf("unable to bind as the user %s: %v", userName, err)
+	}
+
+	groups := entry.GetAttributeValues(groupsAttribute(provider))
+	groupNameAttribute := ""
+	if provider.LDAPService != nil && provider.LDAPService.SearchSettings != nil {
+		groupNameAttribute = provider.LDAPService.SearchSettings.GroupNameAttribute
+	}
+	for _, mapping := range provider.RemoteRoleMapping {
+		for _, group := range groups {
+			if isGroupMatching(group, mapping.RemoteGroup, groupNameAttribute) {
+				return mapping.LocalRole, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no role is mapped to the groups of the user %s", userName)
+}
+
+// connect establishes the connection with the first reachable service address of the provider.
+// The connection is upgraded with StartTLS for the ldap scheme when StartTLS is enabled
+func connect(ctx context.Context, provider asmodel.ExternalAccountProvider) (*goldap.Conn, error) {
+	var errs []string
+	for _, address := range provider.ServiceAddresses {
+		tlsConfig, err := getTLSConfig(provider.Oem, address)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := goldap.DialURL(address, goldap.DialWithTLSConfig(tlsConfig),
+			goldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}))
+		if err != nil {
+			l.LogWithFields(ctx).Warnf("unable to connect to the directory %s: %s", address, err.Error())
+			errs = append(errs, err.Error())
+			continue
+		}
+		conn.SetTimeout(dialTimeout)
+		if isStartTLSEnabled(provider.Oem) && strings.HasPrefix(strings.ToLower(address), "ldap://") {
+			if err := conn.StartTLS(tlsConfig); err != nil {
+				conn.Close()
+				l.LogWithFields(ctx).Warnf("unable to start TLS with the directory %s: %s", address, err.Error())
+				errs = append(errs, err.Error())
+				continue
+			}
+		}
+		return conn, nil
+	}
+	return nil, fmt.Errorf("unable to connect to any of the directories: %s", strings.Join(errs, "; "))
+}
+
+// getTLSConfig returns the TLS configuration for the address which
+// verifies the directory with the root CA certificate of the provider
+func getTLSConfig(oem *asmodel.ExternalAccountProviderOem, address string) (*tls.Config, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service address %s: %v", address, err)
+	}
+	tlsConfig := &tls.Config{
+		ServerName: u.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+	if oem != nil && oem.RootCACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(oem.RootCACertificate)) {
+			return nil, fmt.Errorf("invalid root CA certificate of the directory")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+func isStartTLSEnabled(oem *asmodel.ExternalAccountProviderOem) bool {
+	return oem != nil && oem.StartTLS != nil && *oem.StartTLS
+}
+
+// serviceBind binds with the credentials of the provider for searching the user.
+// The search is done anonymously when no credential is configured
+func serviceBind(conn *goldap.Conn, authentication *asmodel.Authentication) error {
+	if authentication == nil || authentication.Username == "" {
+		return nil
+	}
+	password := ""
+	if authentication.Password != "" {
+		encryptedPassword, err := base64.StdEncoding.DecodeString(authentication.Password)
+		if err != nil {
+			return fmt.Errorf("unable to decode the password of the directory: %v", err)
+		}
+		decryptedPassword, err := DecryptPassword(encryptedPassword)
+		if err != nil {
+			return fmt.Errorf("unable to decrypt the password of the directory: %v", err)
+		}
+		password = string(decryptedPassword)
+	}
+	if err := conn.Bind(authentication.Username, password); err != nil {
+		return fmt.Errorf("unable to bind as %s: %v", authentication.Username, err)
+	}
+	return nil
+}
+
+// searchUser searches the user in the base distinguished names of the provider,
+// the user must be present only once in the directory
+func searchUser(conn *goldap.Conn, provider asmodel.ExternalAccountProvider, userName string) (*goldap.Entry, error) {
+	var baseDNs []string
+	usernameAttribute := defaultUsernameAttribute
+	if provider.AccountProviderType == "ActiveDirectoryService" {
+		usernameAttribute = defaultActiveDirectoryUserAttribute
+	}
+	if provider.LDAPService != nil && provider.LDAPService.SearchSettings != nil {
+		settings := provider.LDAPService.SearchSettings
+		baseDNs = settings.BaseDistinguishedNames
+		if settings.UsernameAttribute != "" {
+			usernameAttribute = settings.UsernameAttribute
+		}
+	}
+	filter := fmt.Sprintf("(%s=%s)", usernameAttribute, goldap.EscapeFilter(userName))
+	var entries []*goldap.Entry
+	for _, baseDN := range baseDNs {
+		request := goldap.NewSearchRequest(baseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+			2, int(dialTimeout.Seconds()), false, filter, []string{groupsAttribute(provider)}, nil)
+		result, err := conn.Search(request)
+		if err != nil {
+			if goldap.IsErrorWithCode(err, goldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			return nil, fmt.Errorf("unable to search the user %s in %s: %v", userName, baseDN, err)
+		}
+		entries = append(entries, result.Entries...)
+	}
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("found %d users with the name %s in the directory", len(entries), userName)
+	}
+	return entries[0], nil
+}
+
+func groupsAttribute(provider asmodel.ExternalAccountProvider) string {
+	if provider.LDAPService != nil && provider.LDAPService.SearchSettings != nil &&
+		provider.LDAPService.SearchSettings.GroupsAttribute != "" {
+		return provider.LDAPService.SearchSettings.GroupsAttribute
+	}
+	return defaultGroupsAttribute
+}
+
+// isGroupMatching checks the group of the user is the remote group of a role mapping.
+// The remote group is either the distinguished name of the group or the value of the
+// first attribute of the distinguished name, like the cn of the group. When the group
+// name attribute is configured, only the value of that attribute is matched
+func isGroupMatching(group, remoteGroup, groupNameAttribute string) bool {
+	if strings.EqualFold(group, remoteGroup) {
+		return true
+	}
+	groupDN, err := goldap.ParseDN(group)
+	if err != nil || len(groupDN.RDNs) == 0 || len(groupDN.RDNs[0].Attributes) == 0 {
+		return false
+	}
+	if remoteDN, err := goldap.ParseDN(remoteGroup); err == nil && len(remoteDN.RDNs) > 1 {
+		return groupDN.EqualFold(remoteDN)
+	}
+	name := groupDN.RDNs[0].Attributes[0]
+	if groupNameAttribute != "" && !strings.EqualFold(name.Type, groupNameAttribute) {
+		return false
+	}
+	return strings.EqualFold(name.Value, remoteGroup)
+}
@@ -0,0 +1,370 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package ldap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+const (
+	serviceDN       = "cn=odim,dc=example,dc=com"
+	servicePassword = "S3rvice"
+	startTLSOID     = "1.3.6.1.4.1.1466.20037"
+)
+
+// entry is an entry of the stand-in directory
+type entry struct {
+	password   string
+	attributes map[string][]string
+}
+
+// directory is a stand-in LDAP server which serves the simple bind,
+// search, StartTLS and unbind operations on the entries
+type directory struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	entries   map[string]entry
+}
+
+func newDirectory(t *testing.T, tlsConfig *tls.Config, implicitTLS bool) *directory {
+	var listener net.Listener
+	var err error
+	if implicitTLS {
+		listener, err = tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	} else {
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatalf("unable to start the directory: %v", err)
+	}
+	d := &directory{
+		listener:  listener,
+		tlsConfig: tlsConfig,
+		entries: map[string]entry{
+			serviceDN: {password: servicePassword},
+			"uid=alice,ou=people,dc=example,dc=com": {
+				password: "Al1ce",
+				attributes: map[string][]string{
+					"uid":      {"alice"},
+					"memberOf": {"cn=operators,ou=groups,dc=example,dc=com", "cn=admins,ou=groups,dc=example,dc=com"},
+				},
+			},
+			"uid=bob,ou=people,dc=example,dc=com": {
+				password: "B0b",
+				attributes: map[string][]string{
+					"uid":      {"bob"},
+					"memberOf": {"cn=guests,ou=groups,dc=example,dc=com"},
+				},
+			},
+		},
+	}
+	go d.serve()
+	t.Cleanup(func() { listener.Close() })
+	return d
+}
+
+func (d *directory) address(scheme string) string {
+	return scheme + "://" + d.listener.Addr().String()
+}
+
+func (d *directory) serve() {
+	for {
+		conn, err := d.listener.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func (d *directory) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	boundDN := ""
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			resultCode := goldap.LDAPResultInvalidCredentials
+			if e, ok := d.entries[dn]; ok && e.password == password {
+				resultCode = goldap.LDAPResultSuccess
+				boundDN = dn
+			}
+			conn.Write(result(id, goldap.ApplicationBindResponse, resultCode).Bytes())
+		case goldap.ApplicationSearchRequest:
+			if boundDN != serviceDN {
+				conn.Write(result(id, goldap.ApplicationSearchResultDone, goldap.LDAPResultInsufficientAccessRights).Bytes())
+				continue
+			}
+			baseDN := op.Children[0].Value.(string)
+			filter, _ := goldap.DecompileFilter(op.Children[6])
+			for dn, e := range d.entries {
+				if strings.HasSuffix(dn, ","+baseDN) && matches(e, filter) {
+					conn.Write(searchEntry(id, dn, e).Bytes())
+				}
+			}
+			conn.Write(result(id, goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess).Bytes())
+		case goldap.ApplicationExtendedRequest:
+			if op.Children[0].Data.String() != startTLSOID || d.tlsConfig == nil {
+				conn.Write(result(id, goldap.ApplicationExtendedResponse, goldap.LDAPResultProtocolError).Bytes())
+				continue
+			}
+			conn.Write(result(id, goldap.ApplicationExtendedResponse, goldap.LDAPResultSuccess).Bytes())
+			tlsConn := tls.Server(conn, d.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+		case goldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func matches(e entry, filter string) bool {
+	for attribute, values := range e.attributes {
+		for _, value := range values {
+			if filter == fmt.Sprintf("(%s=%s)", attribute, goldap.EscapeFilter(value)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func result(id int64, tag ber.Tag, resultCode int) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "resultCode"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+	packet.AppendChild(response)
+	return packet
+}
+
+func searchEntry(id int64, dn string, e entry) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "objectName"))
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attributes")
+	for name, values := range e.attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, value := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "value"))
+		}
+		attribute.AppendChild(vals)
+		attributes.AppendChild(attribute)
+	}
+	response.AppendChild(attributes)
+	packet.AppendChild(response)
+	return packet
+}
+
+// selfSignedCertificate returns the TLS configuration of the directory and the PEM encoded certificate
+func selfSignedCertificate(t *testing.T) (*tls.Config, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate the key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "directory"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create the certificate: %v", err)
+	}
+	certificate := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return &tls.Config{Certificates: []tls.Certificate{certificate}}, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func mockProvider(address string, oem *asmodel.ExternalAccountProviderOem) asmodel.ExternalAccountProvider {
+	enabled := true
+	return asmodel.ExternalAccountProvider{
+		AccountProviderType: "LDAPService",
+		ServiceEnabled:      &enabled,
+		ServiceAddresses:    []string{address},
+		Authentication: &asmodel.Authentication{
+			AuthenticationType: "UsernameAndPassword",
+			Username:           serviceDN,
+			Password:           base64.StdEncoding.EncodeToString([]byte(servicePassword)),
+		},
+		LDAPService: &asmodel.LDAPService{
+			SearchSettings: &asmodel.LDAPSearchSettings{
+				BaseDistinguishedNames: []string{"ou=people,dc=example,dc=com"},
+			},
+		},
+		RemoteRoleMapping: []asmodel.RoleMapping{
+			{LocalRole: "Administrator", RemoteGroup: "admins"},
+			{LocalRole: "Operator", RemoteGroup: "cn=operators,ou=groups,dc=example,dc=com"},
+		},
+		Oem: oem,
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	decryptPassword := DecryptPassword
+	defer func() { DecryptPassword = decryptPassword }()
+	DecryptPassword = func(password []byte) ([]byte, error) {
+		return password, nil
+	}
+	tlsConfig, certificate := selfSignedCertificate(t)
+	startTLS := true
+	plain := newDirectory(t, nil, false)
+	ldaps := newDirectory(t, tlsConfig, true)
+	startTLSDirectory := newDirectory(t, tlsConfig, false)
+
+	tests := []struct {
+		name     string
+		provider asmodel.ExternalAccountProvider
+		userName string
+		password string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "first mapped group gives the role",
+			provider: mockProvider(plain.address("ldap"), nil),
+			userName: "alice",
+			password: "Al1ce",
+			want:     "Administrator",
+		},
+		{
+			name:     "invalid password",
+			provider: mockProvider(plain.address("ldap"), nil),
+			userName: "alice",
+			password: "wrong",
+			wantErr:  true,
+		},
+		{
+			name:     "user not present",
+			provider: mockProvider(plain.address("ldap"), nil),
+			userName: "carol",
+			password: "Car0l",
+			wantErr:  true,
+		},
+		{
+			name:     "no group of the user is mapped",
+			provider: mockProvider(plain.address("ldap"), nil),
+			userName: "bob",
+			password: "B0b",
+			wantErr:  true,
+		},
+		{
+			name:     "filter characters of the username are escaped",
+			provider: mockProvider(plain.address("ldap"), nil),
+			userName: "*",
+			password: "Al1ce",
+			wantErr:  true,
+		},
+		{
+			name:     "ldaps with the root CA certificate",
+			provider: mockProvider(ldaps.address("ldaps"), &asmodel.ExternalAccountProviderOem{RootCACertificate: certificate}),
+			userName: "alice",
+			password: "Al1ce",
+			want:     "Administrator",
+		},
+		{
+			name:     "ldaps without the root CA certificate",
+			provider: mockProvider(ldaps.address("ldaps"), nil),
+			userName: "alice",
+			password: "Al1ce",
+			wantErr:  true,
+		},
+		{
+			name:     "StartTLS",
+			provider: mockProvider(startTLSDirectory.address("ldap"), &asmodel.ExternalAccountProviderOem{StartTLS: &startTLS, RootCACertificate: certificate}),
+			userName: "alice",
+			password: "Al1ce",
+			want:     "Administrator",
+		},
+		{
+			name:     "StartTLS not supported by the directory",
+			provider: mockProvider(plain.address("ldap"), &asmodel.ExternalAccountProviderOem{StartTLS: &startTLS, RootCACertificate: certificate}),
+			userName: "alice",
+			password: "Al1ce",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Authenticate(context.TODO(), tt.provider, tt.userName, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsGroupMatching(t *testing.T) {
+	tests := []struct {
+		group              string
+		remoteGroup        string
+		groupNameAttribute string
+		want               bool
+	}{
+		{"cn=admins,ou=groups,dc=example,dc=com", "CN=Admins,OU=Groups,DC=example,DC=com", "", true},
+		{"cn=admins,ou=groups,dc=example,dc=com", "admins", "", true},
+		{"cn=admins,ou=groups,dc=example,dc=com", "admins", "cn", true},
+		{"cn=admins,ou=groups,dc=example,dc=com", "admins", "ou", false},
+		{"cn=admins,ou=groups,dc=example,dc=com", "cn=admins,ou=other,dc=example,dc=com", "", false},
+		{"admins", "admins", "", true},
+	}
+	for _, tt := range tests {
+		if got := isGroupMatching(tt.group, tt.remoteGroup, tt.groupNameAttribute); got != tt.want {
+			t.Errorf("isGroupMatching(%s, %s, %s) = %v, want %v", tt.group, tt.remoteGroup, tt.groupNameAttribute, got, tt.want)
+		}
+	}
+}
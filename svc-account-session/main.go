@@ -67,6 +67,10 @@ func main() {
 		log.Fatal("Error while trying to check DB connection health: " + err.Error())
 	}
 
+	if err := account.PersistOEMPrivileges(); err != nil {
+		log.Fatal("Error while trying to save the OEM privileges: " + err.Error())
+	}
+
 	account.ConfigFilePath = os.Getenv("CONFIG_FILE_PATH")
 	if account.ConfigFilePath == "" {
 		log.Fatal("error: no value get the environment variable CONFIG_FILE_PATH")
@@ -0,0 +1,320 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package oauth2
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	httpTimeout   = 10 * time.Second
+	// keysRefreshInterval is the minimum interval between the downloads of the keys
+	// of an issuer, when a token is signed with a key unknown to ODIM
+	keysRefreshInterval = time.Minute
+)
+
+type signingKey struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type discoveredKeys struct {
+	keys      []signingKey
+	fetchedAt time.Time
+}
+
+// discoveredKeysCache holds the keys downloaded from the issuers
+var discoveredKeysCache = struct {
+	sync.Mutex
+	issuers map[string]discoveredKeys
+}{issuers: make(map[string]discoveredKeys)}
+
+// ValidateSigningKeys checks the signing keys configured for the Offline mode
+// are a base64 encoded JSON web key set or PEM encoded public keys
+func ValidateSigningKeys(encodedKeys string) error {
+	_, err := parseSigningKeys(encodedKeys)
+	return err
+}
+
+// getSigningKeys returns the keys configured for the Offline mode or the keys downloaded from
+// the issuer for the Discovery mode. The keys of the issuer are downloaded again when the key
+// of the token is not known, at most once in the refresh interval
+func getSigningKeys(ctx context.Context, provider asmodel.ExternalAccountProvider, kid string) ([]signingKey, error) {
+	service := provider.OAuth2Service
+	if service.Mode == ModeOffline {
+		return parseSigningKeys(service.OAuthServiceSigningKeys)
+	}
+	discoveredKeysCache.Lock()
+	defer discoveredKeysCache.Unlock()
+	cached, ok := discoveredKeysCache.issuers[service.Issuer]
+	if ok && (hasKey(cached.keys, kid) || time.Since(cached.fetchedAt) < keysRefreshInterval) {
+		return cached.keys, nil
+	}
+	keys, err := discoverSigningKeys(ctx, provider)
+	if err != nil {
+		if ok {
+			l.LogWithFields(ctx).Warnf("unable to refresh the signing keys of %s: %s", service.Issuer, err.Error())
+			return cached.keys, nil
+		}
+		return nil, err
+	}
+	discoveredKeysCache.issuers[service.Issuer] = discoveredKeys{keys: keys, fetchedAt: time.Now()}
+	return keys, nil
+}
+
+func hasKey(keys []signingKey, kid string) bool {
+	for _, key := range keys {
+		if kid == "" || key.kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+// discoverSigningKeys downloads the key set of the issuer from the jwks_uri of the OpenID
+// provider metadata. The service addresses are tried in order and the issuer is used when
+// no service address is configured
+func discoverSigningKeys(ctx context.Context, provider asmodel.ExternalAccountProvider) ([]signingKey, error) {
+	client, err := newHTTPClient(provider.Oem)
+	if err != nil {
+		return nil, err
+	}
+	addresses := provider.ServiceAddresses
+	if len(addresses) == 0 {
+		addresses = []string{provider.OAuth2Service.Issuer}
+	}
+	var errs []string
+	for _, address := range addresses {
+		var metadata struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := getJSON(client, strings.TrimSuffix(address, "/")+discoveryPath, &metadata); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if metadata.JWKSURI == "" {
+			errs = append(errs, "jwks_uri is not present in the metadata of "+address)
+			continue
+		}
+		var keySet jsonWebKeySet
+		if err := getJSON(client, metadata.JWKSURI, &keySet); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		keys, err := parseJSONWebKeySet(keySet)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		l.LogWithFields(ctx).Infof("Downloaded %d signing keys of %s", len(keys), provider.OAuth2Service.Issuer)
+		return keys, nil
+	}
+	return nil, fmt.Errorf("unable to download the signing keys: %s", strings.Join(errs, "; "))
+}
+
+// newHTTPClient returns the client verifying the issuer with the root CA certificate of the provider
+func newHTTPClient(oem *asmodel.ExternalAccountProviderOem) (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if oem != nil && oem.RootCACertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(oem.RootCACertificate)) {
+			return nil, fmt.Errorf("invalid root CA certificate of the OAuth2 service")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &http.Client{
+		Timeout:   httpTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+func getJSON(client *http.Client, uri string, v interface{}) error {
+	resp, err := client.Get(uri)
+	if err != nil {
+		return fmt.Errorf("unable to get %s: %v", uri, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to get %s: status code %d", uri, resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %v", uri, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unable to parse %s: %v", uri, err)
+	}
+	return nil
+}
+
+// parseSigningKeys decodes the base64 encoded JSON web key set or PEM encoded
+// public keys and certificates, like the key files published by the issuer
+func parseSigningKeys(encodedKeys string) ([]signingKey, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKeys))
+	if err != nil {
+		return nil, fmt.Errorf("signing keys are not base64 encoded: %v", err)
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var keySet jsonWebKeySet
+		if err := json.Unmarshal(trimmed, &keySet); err != nil {
+			return nil, fmt.Errorf("invalid JSON web key set: %v", err)
+		}
+		return parseJSONWebKeySet(keySet)
+	}
+	var keys []signingKey
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		key, err := parsePEMBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, signingKey{key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing key is present")
+	}
+	return keys, nil
+}
+
+func parsePEMBlock(block *pem.Block) (crypto.PublicKey, error) {
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, fmt.Errorf("PEM block %s is not a public key", block.Type)
+}
+
+// parseJSONWebKeySet parses the RSA and EC signing keys of the key set,
+// the keys of the other types and uses are ignored
+func parseJSONWebKeySet(keySet jsonWebKeySet) ([]signingKey, error) {
+	var keys []signingKey
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		var key crypto.PublicKey
+		var err error
+		switch jwk.Kty {
+		case "RSA":
+			key, err = parseRSAKey(jwk)
+		case "EC":
+			key, err = parseECKey(jwk)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %v", jwk.Kid, err)
+		}
+		keys = append(keys, signingKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing key is present")
+	}
+	return keys, nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := decodeBigInt(jwk.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := decodeBigInt(jwk.E)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func parseECKey(jwk jsonWebKey) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch jwk.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("curve %s is not supported", jwk.Crv)
+	}
+	x, err := decodeBigInt(jwk.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := decodeBigInt(jwk.Y)
+	if err != nil {
+		return nil, err
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, fmt.Errorf("point is not on the curve %s", jwk.Crv)
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("invalid key parameter %q", value)
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,259 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package oauth2 validates the bearer tokens issued by the OAuth2
+// external account provider and maps the claims of the tokens to roles
+package oauth2
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+const (
+	// ModeDiscovery is the mode in which the signing keys are downloaded from the issuer
+	ModeDiscovery = "Discovery"
+	// ModeOffline is the mode in which the signing keys are configured in the AccountService
+	ModeOffline = "Offline"
+
+	defaultUsernameClaim = "sub"
+	defaultGroupsClaim   = "groups"
+
+	// clockSkew is the tolerance allowed between the clocks of ODIM and the issuer
+	clockSkew = time.Minute
+)
+
+// Token is the bearer token validated with the OAuth2 external account provider
+type Token struct {
+	UserName  string
+	RoleID    string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type claims map[string]interface{}
+
+// now is the function pointer for getting the current time
+var now = time.Now
+
+// IsJWT checks the token is a JSON web token, which is made of three
+// base64url encoded parts. The tokens of the sessions are UUIDs
+func IsJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// Validate verifies the signature of the token with the signing keys of the provider
+// and validates the issuer, the audience and the lifetime of the token. The role of
+// the token is the LocalRole of the first RemoteRoleMapping matching a group claimed
+// by the token.
+func Validate(ctx context.Context, provider asmodel.ExternalAccountProvider, token string) (Token, error) {
+	var result Token
+	service := provider.OAuth2Service
+	if service == nil {
+		return result, fmt.Errorf("OAuth2 service is not configured")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return result, fmt.Errorf("token is not a JSON web token")
+	}
+	var hdr header
+	if err := decodeSegment(parts[0], &hdr); err != nil {
+		return result, fmt.Errorf("invalid header of the token: %v", err)
+	}
+	var tokenClaims claims
+	if err := decodeSegment(parts[1], &tokenClaims); err != nil {
+		return result, fmt.Errorf("invalid claims of the token: %v", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return result, fmt.Errorf("invalid signature of the token: %v", err)
+	}
+
+	keys, err := getSigningKeys(ctx, provider, hdr.Kid)
+	if err != nil {
+		return result, err
+	}
+	if err := verifySignature(hdr, keys, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return result, err
+	}
+
+	if err := validateClaims(service, tokenClaims); err != nil {
+		return result, err
+	}
+	result.ExpiresAt = tokenClaims.time("exp")
+	result.IssuedAt = tokenClaims.time("iat")
+
+	usernameClaim, groupsClaim := defaultUsernameClaim, defaultGroupsClaim
+	if provider.Oem != nil && provider.Oem.UsernameClaim != "" {
+		usernameClaim = provider.Oem.UsernameClaim
+	}
+	if provider.Oem != nil && provider.Oem.GroupsClaim != "" {
+		groupsClaim = provider.Oem.GroupsClaim
+	}
+	result.UserName, _ = tokenClaims[usernameClaim].(string)
+	if result.UserName == "" {
+		return result, fmt.Errorf("token does not have the %s claim", usernameClaim)
+	}
+	groups := tokenClaims.strings(groupsClaim)
+	for _, mapping := range provider.RemoteRoleMapping {
+		for _, group := range groups {
+			if group == mapping.RemoteGroup {
+				result.RoleID = mapping.LocalRole
+				return result, nil
+			}
+		}
+	}
+	return result, fmt.Errorf("no role is mapped to the groups of the user %s", result.UserName)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifySignature verifies the signature with the keys matching the key ID of the token.
+// Only the asymmetric algorithms are accepted, as the keys of the issuer are public
+func verifySignature(hdr header, keys []signingKey, signingInput, signature []byte) error {
+	hash, err := getHash(hdr.Alg)
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+	for _, key := range keys {
+		if (hdr.Kid != "" && key.kid != "" && key.kid != hdr.Kid) || (key.alg != "" && key.alg != hdr.Alg) {
+			continue
+		}
+		switch publicKey := key.key.(type) {
+		case *rsa.PublicKey:
+			if strings.HasPrefix(hdr.Alg, "RS") && rsa.VerifyPKCS1v15(publicKey, hash, digest, signature) == nil {
+				return nil
+			}
+			if strings.HasPrefix(hdr.Alg, "PS") && rsa.VerifyPSS(publicKey, hash, digest, signature, nil) == nil {
+				return nil
+			}
+		case *ecdsa.PublicKey:
+			if strings.HasPrefix(hdr.Alg, "ES") && verifyECDSA(publicKey, digest, signature) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("signature of the token is not valid")
+}
+
+// verifyECDSA verifies the signature made of the r and s values of the key size
+func verifyECDSA(publicKey *ecdsa.PublicKey, digest, signature []byte) bool {
+	size := (publicKey.Curve.Params().BitSize + 7) / 8
+	if len(signature) != 2*size {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:size])
+	s := new(big.Int).SetBytes(signature[size:])
+	return ecdsa.Verify(publicKey, digest, r, s)
+}
+
+func getHash(alg string) (crypto.Hash, error) {
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		return crypto.SHA256, nil
+	case "RS384", "PS384", "ES384":
+		return crypto.SHA384, nil
+	case "RS512", "PS512", "ES512":
+		return crypto.SHA512, nil
+	}
+	return 0, fmt.Errorf("signing algorithm %s of the token is not supported", alg)
+}
+
+// validateClaims validates the token is issued by the issuer for one of the audiences
+// and the token is in its lifetime. The issuer and the audience of the service and
+// the expiry of the token are required
+func validateClaims(service *asmodel.OAuth2Service, tokenClaims claims) error {
+	if service.Issuer == "" || len(service.Audience) == 0 {
+		return fmt.Errorf("issuer and audience of the OAuth2 service are not configured")
+	}
+	if issuer, _ := tokenClaims["iss"].(string); issuer != service.Issuer {
+		return fmt.Errorf("token is not issued by %s", service.Issuer)
+	}
+	if !isAudienceMatching(service.Audience, tokenClaims.strings("aud")) {
+		return fmt.Errorf("token is not issued for the audience %s", strings.Join(service.Audience, ", "))
+	}
+	currentTime := now()
+	expiresAt := tokenClaims.time("exp")
+	if expiresAt.IsZero() {
+		return fmt.Errorf("token does not have the exp claim")
+	}
+	if currentTime.After(expiresAt.Add(clockSkew)) {
+		return fmt.Errorf("token is expired")
+	}
+	if notBefore := tokenClaims.time("nbf"); !notBefore.IsZero() && currentTime.Before(notBefore.Add(-clockSkew)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	return nil
+}
+
+func isAudienceMatching(audience, tokenAudience []string) bool {
+	for _, aud := range tokenAudience {
+		for _, expected := range audience {
+			if aud == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// time returns the time of the numeric date claim, zero time is returned when not present
+func (c claims) time(name string) time.Time {
+	value, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(value), 0)
+}
+
+// strings returns the values of the claim, which is either a string or an array of strings
+func (c claims) strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
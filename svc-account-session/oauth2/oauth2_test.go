@@ -0,0 +1,325 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package oauth2
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+const testIssuer = "https://idp.example.com"
+
+func signToken(t *testing.T, alg, kid string, key crypto.Signer, tokenClaims map[string]interface{}) string {
+	hdr, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(tokenClaims)
+	signingInput := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatalf("unable to sign the token: %v", err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatalf("unable to sign the token: %v", err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeKeySet(rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) []byte {
+	keySet, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA", "kid": "rsa-key", "use": "sig", "alg": "RS256",
+				"n": base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC", "kid": "ec-key", "crv": "P-256",
+				"x": base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+				"y": base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+			{"kty": "RSA", "kid": "encryption-key", "use": "enc"},
+		},
+	})
+	return keySet
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":    testIssuer,
+		"aud":    []string{"odim", "portal"},
+		"sub":    "alice",
+		"email":  "alice@example.com",
+		"groups": []string{"operators", "odim-admins"},
+		"iat":    time.Now().Unix(),
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	publicKey, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})
+
+	provider := asmodel.ExternalAccountProvider{
+		AccountProviderType: "OAuth2",
+		OAuth2Service: &asmodel.OAuth2Service{
+			Issuer:                  testIssuer,
+			Audience:                []string{"odim"},
+			Mode:                    ModeOffline,
+			OAuthServiceSigningKeys: base64.StdEncoding.EncodeToString(encodeKeySet(rsaKey, ecKey)),
+		},
+		RemoteRoleMapping: []asmodel.RoleMapping{
+			{RemoteGroup: "odim-admins", LocalRole: "Administrator"},
+			{RemoteGroup: "operators", LocalRole: "Operator"},
+		},
+	}
+	pemProvider := provider
+	pemService := *provider.OAuth2Service
+	pemService.OAuthServiceSigningKeys = base64.StdEncoding.EncodeToString(pemKey)
+	pemProvider.OAuth2Service = &pemService
+	noAudienceProvider := provider
+	noAudienceService := *provider.OAuth2Service
+	noAudienceService.Audience = nil
+	noAudienceProvider.OAuth2Service = &noAudienceService
+	noIssuerProvider := provider
+	noIssuerService := *provider.OAuth2Service
+	noIssuerService.Issuer = ""
+	noIssuerProvider.OAuth2Service = &noIssuerService
+	emailProvider := provider
+	emailProvider.Oem = &asmodel.ExternalAccountProviderOem{UsernameClaim: "email", GroupsClaim: "roles"}
+
+	withClaim := func(name string, value interface{}) map[string]interface{} {
+		tokenClaims := validClaims()
+		if value == nil {
+			delete(tokenClaims, name)
+		} else {
+			tokenClaims[name] = value
+		}
+		return tokenClaims
+	}
+	tests := []struct {
+		name     string
+		provider asmodel.ExternalAccountProvider
+		token    string
+		want     Token
+		wantErr  bool
+	}{
+		{
+			name:     "RSA signed token",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, validClaims()),
+			want:     Token{UserName: "alice", RoleID: "Administrator"},
+		},
+		{
+			name:     "EC signed token",
+			provider: provider,
+			token:    signToken(t, "ES256", "ec-key", ecKey, withClaim("groups", "operators")),
+			want:     Token{UserName: "alice", RoleID: "Operator"},
+		},
+		{
+			name:     "PEM encoded key",
+			provider: pemProvider,
+			token:    signToken(t, "RS256", "", rsaKey, validClaims()),
+			want:     Token{UserName: "alice", RoleID: "Administrator"},
+		},
+		{
+			name:     "claims configured in Oem",
+			provider: emailProvider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("roles", []string{"operators"})),
+			want:     Token{UserName: "alice@example.com", RoleID: "Operator"},
+		},
+		{
+			name:     "token signed with unknown key",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", otherKey, validClaims()),
+			wantErr:  true,
+		},
+		{
+			name:     "algorithm not matching the key",
+			provider: provider,
+			token:    signToken(t, "ES256", "rsa-key", ecKey, validClaims()),
+			wantErr:  true,
+		},
+		{
+			name:     "unsigned token",
+			provider: provider,
+			token:    "eyJhbGciOiJub25lIn0.e30.",
+			wantErr:  true,
+		},
+		{
+			name:     "token of other issuer",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("iss", "https://other.example.com")),
+			wantErr:  true,
+		},
+		{
+			name:     "token of other audience",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("aud", "other")),
+			wantErr:  true,
+		},
+		{
+			name:     "service without audience",
+			provider: noAudienceProvider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, validClaims()),
+			wantErr:  true,
+		},
+		{
+			name:     "service without issuer and token without issuer",
+			provider: noIssuerProvider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("iss", nil)),
+			wantErr:  true,
+		},
+		{
+			name:     "expired token",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("exp", time.Now().Add(-time.Hour).Unix())),
+			wantErr:  true,
+		},
+		{
+			name:     "token without expiry",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("exp", nil)),
+			wantErr:  true,
+		},
+		{
+			name:     "token not valid yet",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("nbf", time.Now().Add(time.Hour).Unix())),
+			wantErr:  true,
+		},
+		{
+			name:     "no role mapped to the groups",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("groups", []string{"guests"})),
+			wantErr:  true,
+		},
+		{
+			name:     "token without username",
+			provider: provider,
+			token:    signToken(t, "RS256", "rsa-key", rsaKey, withClaim("sub", nil)),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Validate(context.Background(), tt.provider, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.UserName != tt.want.UserName || got.RoleID != tt.want.RoleID) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateDiscovery(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	var requests int
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case discoveryPath:
+			json.NewEncoder(w).Encode(map[string]string{"issuer": testIssuer, "jwks_uri": server.URL + "/keys"})
+		case "/keys":
+			w.Write(encodeKeySet(rsaKey, ecKey))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	rootCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	provider := asmodel.ExternalAccountProvider{
+		AccountProviderType: "OAuth2",
+		ServiceAddresses:    []string{server.URL},
+		OAuth2Service: &asmodel.OAuth2Service{
+			Issuer:   testIssuer,
+			Audience: []string{"odim"},
+			Mode:     ModeDiscovery,
+		},
+		RemoteRoleMapping: []asmodel.RoleMapping{{RemoteGroup: "operators", LocalRole: "Operator"}},
+		Oem:               &asmodel.ExternalAccountProviderOem{RootCACertificate: string(rootCA)},
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := Validate(context.Background(), provider, signToken(t, "RS256", "rsa-key", rsaKey, validClaims()))
+		if err != nil || got.RoleID != "Operator" {
+			t.Fatalf("Validate() = %v, %v, want role Operator", got, err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("signing keys are downloaded %d times, want them cached", requests/2)
+	}
+
+	// the keys are not downloaded again in the refresh interval for an unknown key
+	if _, err := Validate(context.Background(), provider, signToken(t, "RS256", "unknown-key", rsaKey, validClaims())); err == nil {
+		t.Errorf("Validate() with a key ID not known is not failing")
+	}
+	if requests != 2 {
+		t.Errorf("signing keys are downloaded again in the refresh interval")
+	}
+}
+
+func TestValidateSigningKeys(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err := ValidateSigningKeys(base64.StdEncoding.EncodeToString(encodeKeySet(rsaKey, ecKey))); err != nil {
+		t.Errorf("ValidateSigningKeys() of a key set error = %v", err)
+	}
+	for _, keys := range []string{"not base64", base64.StdEncoding.EncodeToString([]byte(`{"keys":[]}`)),
+		base64.StdEncoding.EncodeToString([]byte("not a key"))} {
+		if err := ValidateSigningKeys(keys); err == nil {
+			t.Errorf("ValidateSigningKeys(%q) is not failing", keys)
+		}
+	}
+}
+
+func TestIsJWT(t *testing.T) {
+	if !IsJWT("eyJhbGciOiJSUzI1NiJ9.e30.c2ln") {
+		t.Errorf("IsJWT() of a JSON web token is false")
+	}
+	if IsJWT("2c2aa2d7-5c4c-4e8b-b4b6-4ad1a4f15b1a") {
+		t.Errorf("IsJWT() of a session token is true")
+	}
+}
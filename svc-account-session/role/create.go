@@ -195,6 +195,9 @@ func Create(ctx context.Context, req *roleproto.RoleRequest, session *asmodel.Se
 
 	resp.StatusCode = http.StatusCreated
 	resp.StatusMessage = response.ResourceCreated
+	resp.Header = map[string]string{
+		"Location": "/redfish/v1/AccountService/Roles/" + role.ID,
+	}
 
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	commonResponse.ID = createRoleReq.ID
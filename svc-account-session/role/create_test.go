@@ -136,6 +136,9 @@ func TestCreate(t *testing.T) {
 			want: response.RPC{
 				StatusCode:    http.StatusCreated,
 				StatusMessage: response.ResourceCreated,
+				Header: map[string]string{
+					"Location": "/redfish/v1/AccountService/Roles/testRole",
+				},
 				Body: asresponse.UserRole{
 					IsPredefined:       false,
 					AssignedPrivileges: []string{common.PrivilegeLogin},
@@ -29,6 +29,7 @@ import (
 	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/account"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
@@ -58,7 +59,7 @@ func (a *Account) Create(ctx context.Context, req *accountproto.CreateAccountReq
 	args := account.GetResponseArgs("", "", []interface{}{})
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before creating the account")
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -94,7 +95,7 @@ func (a *Account) GetAllAccounts(ctx context.Context, req *accountproto.AccountR
 	args := account.GetResponseArgs("", "", []interface{}{})
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before fetching all accounts")
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -130,7 +131,7 @@ func (a *Account) GetAccount(ctx context.Context, req *accountproto.GetAccountRe
 	args := account.GetResponseArgs("", "", []interface{}{})
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before fetching the account")
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -165,7 +166,7 @@ func (a *Account) GetAccountServices(ctx context.Context, req *accountproto.Acco
 	var resp accountproto.AccountResponse
 	args := account.GetResponseArgs("", "", []interface{}{})
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before checking the availability of account session")
-	_, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	_, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -200,7 +201,7 @@ func (a *Account) Update(ctx context.Context, req *accountproto.UpdateAccountReq
 	var resp accountproto.AccountResponse
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before updating the account")
 	args := account.GetResponseArgs("", "", []interface{}{})
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, req.AccountID)
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -237,7 +238,7 @@ func (a *Account) Delete(ctx context.Context, req *accountproto.DeleteAccountReq
 	var resp accountproto.AccountResponse
 	args := account.GetResponseArgs("", "", []interface{}{})
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before deleting the account")
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -261,6 +262,116 @@ func (a *Account) Delete(ctx context.Context, req *accountproto.DeleteAccountReq
 	return &resp, nil
 }
 
+// UpdateAccountService defines the operations which handles the RPC request response
+// for the update of the account service of account-session micro service.
+// The functionality retrieves the request and return backs the response to
+// RPC according to the protoc file defined in the util-lib package.
+// The function also checks for the session time out of the token
+// which is present in the request.
+func (a *Account) UpdateAccountService(ctx context.Context, req *accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error) {
+	ctx = getContext(ctx, common.SessionService)
+	var resp accountproto.AccountResponse
+	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before updating the account service")
+	args := account.GetResponseArgs("", "", []interface{}{})
+	sess, errs := checkSession(ctx, req.SessionToken, "")
+	if errs != nil {
+		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
+		return &resp, nil
+	}
+
+	err := UpdateLastUsedTimeFunc(ctx, req.SessionToken)
+	if err != nil {
+		resp = mapErrorResponse(ctx, resp, args, err)
+		return &resp, nil
+	}
+
+	acc := account.GetExternalInterface()
+
+	data := acc.UpdateAccountService(ctx, req, sess)
+	errorMessage := "error while trying to marshal the response body of the update account service API: "
+	resp, err = mapAccountResponse(resp, data, errorMessage)
+	if err != nil {
+		l.LogWithFields(ctx).Error(resp.StatusMessage)
+		return &resp, nil
+	}
+	l.LogWithFields(ctx).Debugf("outgoing response of request to update the account service: %s", string(resp.Body))
+
+	return &resp, nil
+}
+
+// CreateAPIKey defines the operations which handles the RPC request response
+// for the create API key of the account
+func (a *Account) CreateAPIKey(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req, "creating the API key", account.GetExternalInterface().CreateAPIKey)
+}
+
+// GetAllAPIKeys defines the operations which handles the RPC request response
+// for the list API keys of the account
+func (a *Account) GetAllAPIKeys(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req, "listing the API keys", account.GetExternalInterface().GetAllAPIKeys)
+}
+
+// GetAPIKey defines the operations which handles the RPC request response
+// for the view API key of the account
+func (a *Account) GetAPIKey(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req, "fetching the API key", account.GetExternalInterface().GetAPIKey)
+}
+
+// DeleteAPIKey defines the operations which handles the RPC request response
+// for the revoke API key of the account
+func (a *Account) DeleteAPIKey(ctx context.Context, req *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	return handleAPIKeyRequest(ctx, req, "deleting the API key", account.GetExternalInterface().DeleteAPIKey)
+}
+
+// handleAPIKeyRequest validates the session of the API key request and passes it to the handler
+func handleAPIKeyRequest(ctx context.Context, req *accountproto.APIKeyRequest, operation string,
+	handler func(context.Context, *accountproto.APIKeyRequest, *asmodel.Session) response.RPC) (*accountproto.AccountResponse, error) {
+	ctx = getContext(ctx, common.SessionService)
+	var resp accountproto.AccountResponse
+	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before " + operation)
+	args := account.GetResponseArgs("", "", []interface{}{})
+	// the session required to change the password of its user can't manage the API keys,
+	// even of its own account, since the keys would not be restricted by the password change
+	sess, errs := checkSession(ctx, req.SessionToken, "")
+	if errs != nil {
+		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
+		return &resp, nil
+	}
+
+	err := UpdateLastUsedTimeFunc(ctx, req.SessionToken)
+	if err != nil {
+		resp = mapErrorResponse(ctx, resp, args, err)
+		return &resp, nil
+	}
+
+	data := handler(ctx, req, sess)
+	errorMessage := "error while trying to marshal the response body of " + operation + ": "
+	resp, err = mapAccountResponse(resp, data, errorMessage)
+	if err != nil {
+		l.LogWithFields(ctx).Error(resp.StatusMessage)
+		return &resp, nil
+	}
+	l.LogWithFields(ctx).Debugf("outgoing response of request for %s: %s", operation, string(resp.Body))
+
+	return &resp, nil
+}
+
+// checkSession validates the session token and rejects the session which is required to change
+// the password of its user, unless the request is to update the account of the user
+func checkSession(ctx context.Context, sessionToken, accountID string) (*asmodel.Session, *errors.Error) {
+	sess, errs := CheckSessionTimeOutFunc(ctx, sessionToken)
+	if errs != nil {
+		return nil, errs
+	}
+	if sess == nil || sess.UserName == accountID {
+		return sess, nil
+	}
+	if errs = CheckPasswordChangeRequiredFunc(ctx, sess); errs != nil {
+		return nil, errs
+	}
+	return sess, nil
+}
+
 func validateSessionTimeoutError(ctx context.Context, sessionToken string, errs *errors.Error) (body []byte, statusCode int32, statusMessage string) {
 	errorMessage := "error while authorizing session token: " + errs.Error()
 	statusCode, statusMessage = errs.GetAuthStatusCodeAndMessage()
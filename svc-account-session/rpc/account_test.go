@@ -15,6 +15,7 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
 	e "errors"
 	"fmt"
 	"net/http"
@@ -516,6 +517,135 @@ func TestAccount_Update(t *testing.T) {
 	}
 }
 
+func TestAccount_UpdateAccountService(t *testing.T) {
+	common.SetUpMockConfig()
+	tests := []struct {
+		name                    string
+		CheckSessionTimeOutFunc func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error)
+		UpdateLastUsedTimeFunc  func(ctx context.Context, token string) error
+		want                    int32
+	}{
+		{
+			name: "Session Timeout Error for 401(not valid session)",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return nil, errors.PackError(errors.InvalidAuthToken, "error: invalid token ", sessionToken)
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return nil },
+			want:                   http.StatusUnauthorized,
+		},
+		{
+			name: "UpdateLastUsedTime error",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{}, nil
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return e.New("fakeError") },
+			want:                   http.StatusInternalServerError,
+		},
+		{
+			name: "without ConfigureUsers privilege",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{UserName: "operator", Privileges: map[string]bool{common.PrivilegeLogin: true}}, nil
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return nil },
+			want:                   http.StatusForbidden,
+		},
+	}
+	MarshalFunc = json.Marshal
+	for _, tt := range tests {
+		CheckSessionTimeOutFunc = tt.CheckSessionTimeOutFunc
+		UpdateLastUsedTimeFunc = tt.UpdateLastUsedTimeFunc
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Account{}
+			req := &accountproto.UpdateAccountServiceRequest{RequestBody: []byte(`{"LDAP":{"ServiceEnabled":true}}`)}
+			got, err := a.UpdateAccountService(context.TODO(), req)
+			if err != nil {
+				t.Errorf("UpdateAccountService() error = %v", err)
+				return
+			}
+			if got.StatusCode != tt.want {
+				t.Errorf("UpdateAccountService() status = %v, want %v", got.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccount_APIKeys(t *testing.T) {
+	common.SetUpMockConfig()
+	tests := []struct {
+		name                    string
+		CheckSessionTimeOutFunc func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error)
+		UpdateLastUsedTimeFunc  func(ctx context.Context, token string) error
+		want                    int32
+	}{
+		{
+			name: "Session Timeout Error for 401(not valid session)",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return nil, errors.PackError(errors.InvalidAuthToken, "error: invalid token ", sessionToken)
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return nil },
+			want:                   http.StatusUnauthorized,
+		},
+		{
+			name: "UpdateLastUsedTime error",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{}, nil
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return e.New("fakeError") },
+			want:                   http.StatusInternalServerError,
+		},
+		{
+			name: "without privilege to manage the API keys",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{UserName: "operator", Privileges: map[string]bool{common.PrivilegeConfigureSelf: true}}, nil
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return nil },
+			want:                   http.StatusForbidden,
+		},
+		{
+			name: "session of the account required to change the password",
+			CheckSessionTimeOutFunc: func(ctx context.Context, sessionToken string) (*asmodel.Session, *errors.Error) {
+				return &asmodel.Session{UserName: "admin", PasswordChangeRequired: true, Privileges: map[string]bool{common.PrivilegeConfigureUsers: true}}, nil
+			},
+			UpdateLastUsedTimeFunc: func(ctx context.Context, token string) error { return nil },
+			want:                   http.StatusForbidden,
+		},
+	}
+	defer func(checkPasswordChangeRequired func(context.Context, *asmodel.Session) *errors.Error) {
+		CheckPasswordChangeRequiredFunc = checkPasswordChangeRequired
+	}(CheckPasswordChangeRequiredFunc)
+	CheckPasswordChangeRequiredFunc = func(ctx context.Context, session *asmodel.Session) *errors.Error {
+		if session.PasswordChangeRequired {
+			return errors.PackError(errors.PasswordChangeRequired, "error: password of the user "+session.UserName+" must be changed")
+		}
+		return nil
+	}
+	MarshalFunc = json.Marshal
+	a := &Account{}
+	handlers := map[string]func(context.Context, *accountproto.APIKeyRequest) (*accountproto.AccountResponse, error){
+		"CreateAPIKey":  a.CreateAPIKey,
+		"GetAllAPIKeys": a.GetAllAPIKeys,
+		"GetAPIKey":     a.GetAPIKey,
+		"DeleteAPIKey":  a.DeleteAPIKey,
+	}
+	for _, tt := range tests {
+		CheckSessionTimeOutFunc = tt.CheckSessionTimeOutFunc
+		UpdateLastUsedTimeFunc = tt.UpdateLastUsedTimeFunc
+		for handlerName, handler := range handlers {
+			t.Run(handlerName+" "+tt.name, func(t *testing.T) {
+				req := &accountproto.APIKeyRequest{AccountID: "admin", KeyID: "key1", RequestBody: []byte(`{}`)}
+				got, err := handler(context.TODO(), req)
+				if err != nil {
+					t.Errorf("%s() error = %v", handlerName, err)
+					return
+				}
+				if got.StatusCode != tt.want {
+					t.Errorf("%s() status = %v, want %v", handlerName, got.StatusCode, tt.want)
+				}
+			})
+		}
+	}
+}
+
 func TestAccount_Delete(t *testing.T) {
 	type args struct {
 		ctx context.Context
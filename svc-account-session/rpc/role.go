@@ -37,13 +37,14 @@ type Role struct {
 
 // helper functions
 var (
-	CheckSessionTimeOutFunc = auth.CheckSessionTimeOut
-	UpdateLastUsedTimeFunc  = session.UpdateLastUsedTime
-	CreateFunc              = role.Create
-	GetRoleFunc             = role.GetRole
-	GetAllRolesFunc         = role.GetAllRoles
-	DeleteFunc              = role.Delete
-	UpdateFunc              = role.Update
+	CheckSessionTimeOutFunc         = auth.CheckSessionTimeOut
+	CheckPasswordChangeRequiredFunc = auth.CheckPasswordChangeRequired
+	UpdateLastUsedTimeFunc          = session.UpdateLastUsedTime
+	CreateFunc                      = role.Create
+	GetRoleFunc                     = role.GetRole
+	GetAllRolesFunc                 = role.GetAllRoles
+	DeleteFunc                      = role.Delete
+	UpdateFunc                      = role.Update
 )
 
 // CreateRole defines the operations which handles the RPC request response
@@ -59,7 +60,7 @@ func (r *Role) CreateRole(ctx context.Context, req *roleproto.RoleRequest) (*rol
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before creating the role")
 	// Validating the session
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -98,7 +99,7 @@ func (r *Role) GetRole(ctx context.Context, req *roleproto.GetRoleRequest) (*rol
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before fetching the role details")
 	// Validating the session
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -139,7 +140,7 @@ func (r *Role) GetAllRoles(ctx context.Context, req *roleproto.GetRoleRequest) (
 	args := account.GetResponseArgs("", "", []interface{}{})
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before fetching all roles")
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
@@ -179,7 +180,7 @@ func (r *Role) UpdateRole(ctx context.Context, req *roleproto.UpdateRoleRequest)
 
 	l.LogWithFields(ctx).Info("Validating session and updating the last used time of the session before updating the role")
 	// Validating the session
-	sess, errs := CheckSessionTimeOutFunc(ctx, req.SessionToken)
+	sess, errs := checkSession(ctx, req.SessionToken, "")
 	if errs != nil {
 		resp.Body, resp.StatusCode, resp.StatusMessage = validateSessionTimeoutError(ctx, req.SessionToken, errs)
 		return &resp, nil
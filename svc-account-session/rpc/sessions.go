@@ -42,6 +42,8 @@ var (
 	GetSessionUserNameFunc   = session.GetSessionUserName
 	GetSessionUserRoleIDFunc = session.GetSessionUserRoleID
 	MarshalFunc              = json.Marshal
+
+	GetSessionUserAggregatesFunc = session.GetSessionUserAggregates
 )
 
 // CreateSession is a rpc call to create session
@@ -123,6 +125,13 @@ func (s *Session) GetSessionUserRoleID(ctx context.Context, req *sessionproto.Se
 	return resp, err
 }
 
+// GetSessionUserAggregates is a rpc call to get the aggregates
+// the session user is restricted to
+func (s *Session) GetSessionUserAggregates(ctx context.Context, req *sessionproto.SessionRequest) (*sessionproto.SessionUserAggregates, error) {
+	ctx = getContext(ctx, common.SessionService)
+	return GetSessionUserAggregatesFunc(ctx, req)
+}
+
 // GetAllActiveSessions is a rpc call to get all active sessions
 // This method will accepts the sessionrequest which has session id and session token
 // and it will call GetAllActiveSessions from the session package
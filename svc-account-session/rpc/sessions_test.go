@@ -346,6 +346,22 @@ func TestSession_GetSessionUserRoleID(t *testing.T) {
 	}
 }
 
+func TestSession_GetSessionUserAggregates(t *testing.T) {
+	ctx := mockContext()
+	want := &sessionproto.SessionUserAggregates{Aggregates: []string{"/redfish/v1/AggregationService/Aggregates/a1"}}
+	GetSessionUserAggregatesFunc = func(ctx context.Context, req *sessionproto.SessionRequest) (*sessionproto.SessionUserAggregates, error) {
+		return want, nil
+	}
+	s := &Session{}
+	got, err := s.GetSessionUserAggregates(ctx, &sessionproto.SessionRequest{SessionToken: "token"})
+	if err != nil {
+		t.Fatalf("GetSessionUserAggregates() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetSessionUserAggregates() got = %v, want %v", got, want)
+	}
+}
+
 func Test_getCommonResponse(t *testing.T) {
 	type args struct {
 		statusMessage string
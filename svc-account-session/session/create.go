@@ -41,6 +41,8 @@ import (
 // and check whether the credentials are correct also it will
 // check privileges. and then add the session details in DB
 // respond RPC response and error if there is.
+// The session of the HTTPS client presenting a client certificate is created
+// for the user mapped from the certificate, in place of the credentials.
 func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateRequest) (response.RPC, string) {
 	commonResponse := response.Response{
 		OdataType: common.SessionServiceType,
@@ -48,7 +50,11 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 		ID:        "Sessions",
 		Name:      "Session Service",
 	}
-	var resp response.RPC
+
+	// the HTTPS clients authenticated by the client certificate have no request body
+	if req.ClientCertificate != "" {
+		return createCertificateSession(ctx, req.ClientCertificate, commonResponse)
+	}
 
 	// parsing the CreateSession
 	var createSession asmodel.CreateSession
@@ -77,19 +83,41 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 	user, err := auth.CheckSessionCreationCredentials(ctx, createSession.UserName, createSession.Password)
 	if err != nil {
 		errMsg := errLogPrefix + "Unable to authorize session creation credentials: " + err.Error()
-		l.LogWithFields(ctx).Error(errMsg)
-		if err.ErrNo() == errors.DBConnFailed {
-			msgArgs := []interface{}{fmt.Sprintf("%v:%v", config.Data.DBConf.OnDiskHost, config.Data.DBConf.OnDiskPort)}
-			resp = common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, msgArgs, nil)
-		} else {
-			resp = common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil)
-			ctx = context.WithValue(ctx, common.SessionUserID, createSession.UserName)
-			ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusUnauthorized))
-			customLogs.AuthLog(ctx).Error("Invalid username or password")
-		}
-		return resp, ""
+		return authFailureResponse(ctx, errMsg, createSession.UserName, "Invalid username or password", err), ""
 	}
+	return createUserSession(ctx, user, errLogPrefix, commonResponse)
+}
 
+// createCertificateSession creates the session of the user mapped from
+// the client certificate presented by the HTTPS client
+func createCertificateSession(ctx context.Context, certificate string, commonResponse response.Response) (response.RPC, string) {
+	errLogPrefix := "failed to create session for client certificate: "
+	l.LogWithFields(ctx).Info("Validating the client certificate to create new session")
+	user, err := auth.CheckClientCertificate(ctx, certificate)
+	if err != nil {
+		errMsg := errLogPrefix + "Unable to authorize the client certificate: " + err.Error()
+		return authFailureResponse(ctx, errMsg, "", "Invalid client certificate", err), ""
+	}
+	errLogPrefix = fmt.Sprintf("failed to create session for user %s: ", user.UserName)
+	return createUserSession(ctx, user, errLogPrefix, commonResponse)
+}
+
+// authFailureResponse logs the failed authentication and returns the error response
+func authFailureResponse(ctx context.Context, errMsg, userName, authLogMsg string, err *errors.Error) response.RPC {
+	l.LogWithFields(ctx).Error(errMsg)
+	if err.ErrNo() == errors.DBConnFailed {
+		msgArgs := []interface{}{fmt.Sprintf("%v:%v", config.Data.DBConf.OnDiskHost, config.Data.DBConf.OnDiskPort)}
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errMsg, msgArgs, nil)
+	}
+	ctx = context.WithValue(ctx, common.SessionUserID, userName)
+	ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusUnauthorized))
+	customLogs.AuthLog(ctx).Error(authLogMsg)
+	return common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil)
+}
+
+// createUserSession checks the privileges of the authenticated user and adds the session details in DB
+func createUserSession(ctx context.Context, user *asmodel.User, errLogPrefix string, commonResponse response.Response) (response.RPC, string) {
+	var resp response.RPC
 	role, err := asmodel.GetRoleDetailsByID(user.RoleID)
 	if err != nil {
 		errorMessage := errLogPrefix + "Unable to get role privileges for session creation: " + err.Error()
@@ -101,27 +129,37 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 	for _, privilege := range role.AssignedPrivileges {
 		rolePrivilege[privilege] = true
 	}
+	oemPrivilege := make(map[string]bool)
+	for _, privilege := range role.OEMPrivileges {
+		oemPrivilege[privilege] = true
+	}
 	//User requires Login privelege to create a session
 	if _, exist := rolePrivilege[common.PrivilegeLogin]; !exist {
 		errorMessage := errLogPrefix + "User doesn't have required privilege to create a session"
-		ctx = context.WithValue(ctx, common.SessionUserID, createSession.UserName)
+		ctx = context.WithValue(ctx, common.SessionUserID, user.UserName)
 		ctx = context.WithValue(ctx, common.SessionRoleID, role.ID)
 		ctx = context.WithValue(ctx, common.StatusCode, int32(http.StatusForbidden))
 		customLogs.AuthLog(ctx).Error(errorMessage)
 		return common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil), ""
 	}
 
+	// the session created with a password required to be changed or an expired
+	// password is only allowed to change the password of the user
+	passwordChangeRequired := user.IsPasswordChangeRequired(config.Data.AuthConf.PasswordRules.PasswordExpirationDays)
 	currentTime := time.Now()
 	sess := asmodel.Session{
-		ID:           uuid.NewV4().String(),
-		Token:        uuid.NewV4().String(),
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		Privileges:   rolePrivilege,
-		CreatedTime:  currentTime,
-		LastUsedTime: currentTime,
-	}
-	l.LogWithFields(ctx).Infof("Creating session for the user %s", createSession.UserName)
+		ID:                     uuid.NewV4().String(),
+		Token:                  uuid.NewV4().String(),
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		Privileges:             rolePrivilege,
+		OEMPrivileges:          oemPrivilege,
+		Aggregates:             user.Aggregates,
+		CreatedTime:            currentTime,
+		LastUsedTime:           currentTime,
+		PasswordChangeRequired: passwordChangeRequired,
+	}
+	l.LogWithFields(ctx).Infof("Creating session for the user %s", user.UserName)
 	auth.Lock.Lock()
 	defer auth.Lock.Unlock()
 	if err = sess.Persist(); err != nil {
@@ -149,10 +187,16 @@ func CreateNewSession(ctx context.Context, req *sessionproto.SessionCreateReques
 
 	commonResponse.ID = sess.ID
 	commonResponse.OdataID = "/redfish/v1/SessionService/Sessions/" + commonResponse.ID
-	commonResponse.CreateGenericResponse(resp.StatusMessage)
+	if passwordChangeRequired {
+		l.LogWithFields(ctx).Infof("Password of the user %s must be changed before further access", user.UserName)
+		commonResponse.MessageArgs = []string{"/redfish/v1/AccountService/Accounts/" + user.UserName}
+		commonResponse.CreateGenericResponse(response.PasswordChangeRequired)
+	} else {
+		commonResponse.CreateGenericResponse(resp.StatusMessage)
+	}
 	resp.Body = asresponse.Session{
 		Response: commonResponse,
-		UserName: createSession.UserName,
+		UserName: user.UserName,
 	}
 
 	return resp, commonResponse.ID
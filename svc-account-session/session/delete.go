@@ -37,7 +37,7 @@ func DeleteSession(ctx context.Context, req *sessionproto.SessionRequest) respon
 	errorLogPrefix := "failed to delete session : "
 	args := account.GetResponseArgs("", "", []interface{}{})
 	l.LogWithFields(ctx).Info("Validating the request to delete the session")
-	currentSession, serr := asmodel.GetSession(req.SessionToken)
+	currentSession, serr := auth.CheckSessionTimeOut(ctx, req.SessionToken)
 	if serr != nil {
 		errorMessage := errorLogPrefix + serr.Error()
 		l.LogWithFields(ctx).Error(errorMessage)
@@ -58,7 +58,7 @@ func DeleteSession(ctx context.Context, req *sessionproto.SessionRequest) respon
 			continue
 		}
 		if session.ID == req.SessionId {
-			hasprivilege := checkPrivilege(req.SessionToken, session, &currentSession)
+			hasprivilege := checkPrivilege(req.SessionToken, session, currentSession)
 			if hasprivilege {
 				if req.SessionToken != session.Token {
 					err := UpdateLastUsedTime(ctx, req.SessionToken)
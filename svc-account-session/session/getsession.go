@@ -71,6 +71,21 @@ func GetSessionUserRoleID(ctx context.Context, req *sessionproto.SessionRequest)
 	return &resp, nil
 }
 
+// GetSessionUserAggregates is a RPC handle to get the aggregates the session user is
+// restricted to from the session Token. No aggregates are returned for an invalid
+// session, as the session is validated again while authorizing the request
+func GetSessionUserAggregates(ctx context.Context, req *sessionproto.SessionRequest) (*sessionproto.SessionUserAggregates, error) {
+	var resp sessionproto.SessionUserAggregates
+	currentSession, err := auth.CheckSessionTimeOut(ctx, req.SessionToken)
+	if err != nil {
+		l.LogWithFields(ctx).Debugf("unable to get the aggregates of the session: %s", err.Error())
+		return &resp, nil
+	}
+	resp.Aggregates = currentSession.Aggregates
+	l.LogWithFields(ctx).Debugf("outgoing response of request to get session aggregates: %v", currentSession.Aggregates)
+	return &resp, nil
+}
+
 // GetSession is a method to get session
 // it will accepts the SessionCreateRequest which will have sessionid and sessiontoken
 // and it will check privileges to get session and then get the session against the sessionID
@@ -89,6 +104,10 @@ func GetSession(ctx context.Context, req *sessionproto.SessionRequest) response.
 	l.LogWithFields(ctx).Info("Validating the request to fetch the session")
 	// Validating the session
 	currentSession, err := auth.CheckSessionTimeOut(ctx, req.SessionToken)
+	if err == nil {
+		// the session required to change the password of its user can't access the sessions
+		err = auth.CheckPasswordChangeRequired(ctx, currentSession)
+	}
 	if err != nil {
 		errorMessage := errLogPrefix + "Unable to authorize session token: " + err.Error()
 		resp.StatusCode, resp.StatusMessage = err.GetAuthStatusCodeAndMessage()
@@ -195,6 +214,10 @@ func GetAllActiveSessions(ctx context.Context, req *sessionproto.SessionRequest)
 	l.LogWithFields(ctx).Info("fetching all active sessions")
 	// Validating the session
 	currentSession, gerr := auth.CheckSessionTimeOut(ctx, req.SessionToken)
+	if gerr == nil {
+		// the session required to change the password of its user can't access the sessions
+		gerr = auth.CheckPasswordChangeRequired(ctx, currentSession)
+	}
 	if gerr != nil {
 		errorMessage := errorLogPrefix + "Unable to authorize session token: " + gerr.Error()
 		resp.StatusCode, resp.StatusMessage = gerr.GetAuthStatusCodeAndMessage()
@@ -21,13 +21,26 @@ import (
 	"time"
 
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
+	"github.com/ODIM-Project/ODIM/svc-account-session/oauth2"
 )
 
 // UpdateLastUsedTime is supposed to be used whenever there is a session usage.
 // The function is for updating the last used time of a session, so that
 // the active sessions won't time out and expire. As the input of the function
 // we are passing the session token. As return, function give backs the error, if any.
+// The sessions of the bearer tokens are not stored, so nothing is updated for them.
+// The usage count and the last used time of the API keys are updated in place of the session.
 func UpdateLastUsedTime(ctx context.Context, token string) error {
+	if oauth2.IsJWT(token) {
+		return nil
+	}
+	if auth.IsAPIKey(token) {
+		if err := auth.RecordAPIKeyUsage(token); err != nil {
+			return fmt.Errorf("error while trying to update the usage of the API key: %v", err)
+		}
+		return nil
+	}
 	session, err := asmodel.GetSession(token)
 	if err != nil {
 		return fmt.Errorf("error while trying to get the session details with the token %v: %v", token, err)
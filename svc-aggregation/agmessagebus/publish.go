@@ -24,9 +24,19 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	uuid "github.com/satori/go.uuid"
 )
 
+const (
+	// HardwareComponentAdded is the event published when a hardware component is added to a server
+	HardwareComponentAdded = "HardwareComponentAdded"
+	// HardwareComponentRemoved is the event published when a hardware component is removed from a server
+	HardwareComponentRemoved = "HardwareComponentRemoved"
+	// hardwareComponentMessageRegistry is the prefix of the message IDs of the hardware component events
+	hardwareComponentMessageRegistry = "OdimInventoryEvent.1.0.0."
+)
+
 // MQBusCommunicator holds the communicator interface function
 type MQBusCommunicator struct {
 	Communicator func(string, string, string) (dc.MQBus, error)
@@ -50,16 +60,29 @@ func Publish(ctx context.Context, systemID, eventType, collectionType string, MQ
 	}
 
 	var message string
+	messageID := "ResourceEvent.1.2.0." + eventType
 	switch eventType {
 	case "ResourceAdded":
 		message = "The resource has been created successfully."
 	case "ResourceRemoved":
 		message = "The resource has been removed successfully."
+	case "ResourceUpdated":
+		message = "The resource has been updated successfully."
+	// the hardware component events have dedicated message IDs, so that they are subscribed apart
+	// from the ResourceAdded and ResourceRemoved events which are published for the same resources
+	case HardwareComponentAdded:
+		message = "The hardware component has been added to the server."
+		messageID = hardwareComponentMessageRegistry + eventType
+		eventType = "Other"
+	case HardwareComponentRemoved:
+		message = "The hardware component has been removed from the server."
+		messageID = hardwareComponentMessageRegistry + eventType
+		eventType = "Other"
 	}
 
 	var event = common.Event{
 		EventID:        uuid.NewV4().String(),
-		MessageID:      "ResourceEvent.1.2.0." + eventType,
+		MessageID:      messageID,
 		EventTimestamp: time.Now().Format(time.RFC3339),
 		EventType:      eventType,
 		Message:        message,
@@ -76,12 +99,16 @@ func Publish(ctx context.Context, systemID, eventType, collectionType string, MQ
 		Events:    events,
 	}
 	data, _ := json.Marshal(messageData)
+	ctx, span := tracing.StartSpan(ctx, topicName+" publish", tracing.SpanKindProducer)
+	defer span.End()
 	var mbevent = common.Events{
-		IP:      collectionType,
-		Request: data,
+		IP:          collectionType,
+		Request:     data,
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	if err := k.Distribute(mbevent); err != nil {
+		span.SetError(err)
 		l.LogWithFields(ctx).Error("Unable Publish events to kafka" + err.Error())
 		return err
 	}
@@ -2,6 +2,7 @@ package agmessagebus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -27,11 +28,13 @@ func mockContext() context.Context {
 var (
 	communicateerror bool
 	distributeerror  bool
+	distributedEvent interface{}
 )
 
 func resetMockVariable() {
 	communicateerror = false
 	distributeerror = false
+	distributedEvent = nil
 
 }
 
@@ -44,6 +47,7 @@ func (m *MockMQBus) Distribute(event interface{}) error {
 		return fmt.Errorf("Error while posting the message to message bus")
 
 	}
+	distributedEvent = event
 	return nil
 }
 
@@ -100,6 +104,20 @@ func TestPublish(t *testing.T) {
 			distributedErr:  false,
 			eventType:       "ResourceRemoved",
 		},
+		{
+			name:            "Positive Case Resource Updated",
+			wantErr:         false,
+			communicatorErr: false,
+			distributedErr:  false,
+			eventType:       "ResourceUpdated",
+		},
+		{
+			name:            "Positive Case Hardware Component Removed",
+			wantErr:         false,
+			communicatorErr: false,
+			distributedErr:  false,
+			eventType:       "HardwareComponentRemoved",
+		},
 		{
 			name:            "Kafka COnnection Failure",
 			wantErr:         true,
@@ -201,3 +219,27 @@ func TestPublishCtrlMsg(t *testing.T) {
 	}
 
 }
+
+func TestPublishHardwareComponentEvents(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer resetMockVariable()
+	tests := []struct {
+		eventType string
+		messageID string
+	}{
+		{eventType: HardwareComponentAdded, messageID: "OdimInventoryEvent.1.0.0.HardwareComponentAdded"},
+		{eventType: HardwareComponentRemoved, messageID: "OdimInventoryEvent.1.0.0.HardwareComponentRemoved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			err := Publish(mockContext(), "/redfish/v1/Systems/1/Memory/1", tt.eventType, "SystemsCollection", MQBusCommunicator{Communicator: MockCommunicator})
+			assert.Nil(t, err, "Error should be Nil for this scenario")
+			mbEvent, ok := distributedEvent.(common.Events)
+			assert.True(t, ok, "the event should be distributed to the message bus")
+			var message common.MessageData
+			assert.Nil(t, json.Unmarshal(mbEvent.Request, &message), "the event should be a message data")
+			assert.Equal(t, tt.messageID, message.Events[0].MessageID, "the message ID should be dedicated to the hardware component events")
+			assert.Equal(t, "Other", message.Events[0].EventType, "the hardware component events should not be resource events")
+		})
+	}
+}
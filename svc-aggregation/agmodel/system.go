@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
@@ -421,6 +423,18 @@ func deletefilteredkeys(key string) error {
 		}
 	}
 
+	// removing the Chassis and Managers of the server from the indexes
+	deviceUUID := strings.SplitN(key[strings.LastIndex(key, "/")+1:], ".", 2)[0]
+	for _, resourceType := range []string{common.ChassisFilterResource, common.ManagersFilterResource} {
+		for _, index := range common.FilterIndexNames(resourceType) {
+			if delErr := conn.Del(index, "/"+deviceUUID+".*"); delErr != nil {
+				if delErr.Error() != "no data with ID found" {
+					return fmt.Errorf("error while deleting data: " + delErr.Error())
+				}
+			}
+		}
+	}
+
 	delErr := conn.Del("UUID", key)
 	if delErr != nil {
 		if delErr.Error() != "no data with ID found" {
@@ -470,6 +484,10 @@ func DeleteSystem(key string) *errors.Error {
 			return errors.PackError(errors.UndefinedErrorType, err)
 		}
 	}
+	// the inventory history is removed along with the server
+	if err = DeleteInventoryHistory(key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete the inventory history: ", err.Error())
+	}
 	return nil
 }
 
@@ -717,6 +735,31 @@ func DeleteSystemOperationInfo(systemURI string) *errors.Error {
 	return nil
 }
 
+// ClaimSystemOperation adds the system operation info to db only when no operation is under progress
+// for the system, which is done atomically so that a single instance of the service claims the system
+/* Inputs:
+1.systemURI: computer system uri for which system operation is maintained
+*/
+// It returns false when another operation is under progress for the system
+func (system *SystemOperation) ClaimSystemOperation(systemURI string) (bool, *errors.Error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	return conn.SetNX("SystemOperation", systemURI, system, 0)
+}
+
+// ClaimInventoryResync claims the scheduled resync of the inventory of the servers of the target URI
+// for the owner instance of the service for expiry seconds, so that a single instance runs the resync
+// in the interval. It returns false when the resync is already claimed by another instance
+func ClaimInventoryResync(targetURI, owner string, expiry int) (bool, *errors.Error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return false, err
+	}
+	return conn.SetNX("InventoryResync", targetURI, owner, expiry)
+}
+
 // AddSystemResetInfo connects to the persistencemgr and Add the system reset info to db
 /* Inputs:
 1.systemURI: computer system uri for which system operation is maintained
@@ -1302,6 +1345,45 @@ func DeleteAggregateHostIndex(uuid string) error {
 	return nil
 }
 
+// SaveFilterIndexes creates the indexes of the searchable properties of the Chassis and Managers
+// in the BMC inventory data, which are used for filtering the members of their collections.
+// With update flag the existing index values of the resources are removed before saving.
+func SaveFilterIndexes(data map[string]interface{}, update bool) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err.Error())
+	}
+	for key, value := range data {
+		table := strings.SplitN(key, ":", 2)
+		if len(table) < 2 || (table[0] != common.ChassisFilterResource && table[0] != common.ManagersFilterResource) {
+			continue
+		}
+		body, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var resource map[string]interface{}
+		if err := json.Unmarshal([]byte(body), &resource); err != nil {
+			return fmt.Errorf("error while trying to unmarshal %v: %v", table[1], err)
+		}
+		if update {
+			for _, index := range common.FilterIndexNames(table[0]) {
+				if delErr := conn.Del(index, table[1]); delErr != nil && delErr.Error() != "no data with ID found" {
+					return fmt.Errorf("error while deleting index of %v: %v", table[1], delErr)
+				}
+			}
+		}
+		form := common.CreateFilterIndexForm(table[0], resource)
+		if len(form) == 0 {
+			continue
+		}
+		if err := conn.CreateIndex(form, table[1]); err != nil {
+			return fmt.Errorf("error while trying to index %v: %v", table[1], err)
+		}
+	}
+	return nil
+}
+
 // SaveBMCInventory function save all bmc inventory data togeter using the transaction model
 func SaveBMCInventory(data map[string]interface{}) error {
 	connPool, err := common.GetDBConnection(common.InMemory)
@@ -1313,3 +1395,243 @@ func SaveBMCInventory(data map[string]interface{}) error {
 	}
 	return nil
 }
+
+// ImportSourcesReport is the per source result of an AggregationService.ImportSources action,
+// the report is identified by the ID of the task of the action
+type ImportSourcesReport struct {
+	ID        string                   `json:"Id"`
+	Created   string                   `json:"Created"`
+	Total     int                      `json:"Total"`
+	Succeeded int                      `json:"Succeeded"`
+	Failed    int                      `json:"Failed"`
+	Rows      []ImportSourcesReportRow `json:"Rows"`
+}
+
+// ImportSourcesReportRow is the result of adding an aggregation source of the manifest
+type ImportSourcesReportRow struct {
+	Row               int    `json:"Row"`
+	HostName          string `json:"HostName"`
+	Result            string `json:"Result"`
+	StatusCode        int32  `json:"StatusCode"`
+	Message           string `json:"Message,omitempty"`
+	AggregationSource string `json:"AggregationSource,omitempty"`
+	SubTask           string `json:"SubTask,omitempty"`
+}
+
+// SaveImportSourcesReport stores the report of an AggregationService.ImportSources action,
+// the report expires along with the task after KeyExpiryInterval
+func SaveImportSourcesReport(report ImportSourcesReport) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.SetExpire("ImportSourcesReport", report.ID, report, config.Data.KeyExpiryInterval); err != nil {
+		return errors.PackError(err.ErrNo(), "error: while trying to save the import sources report: ", err.Error())
+	}
+	return nil
+}
+
+// GetImportSourcesReport fetches the report of an AggregationService.ImportSources action
+func GetImportSourcesReport(id string) (ImportSourcesReport, *errors.Error) {
+	var report ImportSourcesReport
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return report, err
+	}
+	data, err := conn.Read("ImportSourcesReport", id)
+	if err != nil {
+		return report, errors.PackError(err.ErrNo(), "error: while trying to fetch the import sources report: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &report); jerr != nil {
+		return report, errors.PackError(errors.JSONUnmarshalFailed, jerr)
+	}
+	return report, nil
+}
+
+// DiscoveredSource is a Redfish service found by the AggregationService.DiscoverSources action,
+// which is pending the approval of an admin to be added as an aggregation source
+type DiscoveredSource struct {
+	HostName         string   `json:"HostName"`
+	Vendor           string   `json:"Vendor,omitempty"`
+	Product          string   `json:"Product,omitempty"`
+	RedfishVersion   string   `json:"RedfishVersion,omitempty"`
+	ServiceUUID      string   `json:"ServiceUUID,omitempty"`
+	ConnectionMethod *OdataID `json:"ConnectionMethod,omitempty"`
+	DiscoveredTime   string   `json:"DiscoveredTime"`
+	DiscoveryTask    string   `json:"DiscoveryTask"`
+	Message          string   `json:"Message,omitempty"` // holds the error of the last attempt to add the source
+}
+
+// SaveDiscoveredSource saves the discovered source, a source discovered again is updated
+func SaveDiscoveredSource(source DiscoveredSource, discoveredSourceURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Upsert("DiscoveredSource", discoveredSourceURI, source); err != nil {
+		return errors.PackError(err.ErrNo(), "error: while trying to save the discovered source: ", err.Error())
+	}
+	return nil
+}
+
+// GetDiscoveredSource fetches the discovered source for the given discoveredSourceURI
+func GetDiscoveredSource(discoveredSourceURI string) (DiscoveredSource, *errors.Error) {
+	var source DiscoveredSource
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return source, err
+	}
+	data, err := conn.Read("DiscoveredSource", discoveredSourceURI)
+	if err != nil {
+		return source, errors.PackError(err.ErrNo(), "error: while trying to fetch the discovered source: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &source); jerr != nil {
+		return source, errors.PackError(errors.JSONUnmarshalFailed, jerr)
+	}
+	return source, nil
+}
+
+// DeleteDiscoveredSource deletes the discovered source for the given discoveredSourceURI
+func DeleteDiscoveredSource(discoveredSourceURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Delete("DiscoveredSource", discoveredSourceURI); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InventoryRevision is a revision of the inventory of a BMC kept in the on-disk DB,
+// the contents of the resources are stored once per BMC and referenced by their hash
+type InventoryRevision struct {
+	ID        int               `json:"Id"`
+	Created   string            `json:"Created"`
+	Trigger   string            `json:"Trigger"`   // holds the operation which wrote the inventory
+	Resources map[string]string `json:"Resources"` // holds the hash of the content of each resource keyed by Table:URI
+	Added     []string          `json:"Added,omitempty"`
+	Removed   []string          `json:"Removed,omitempty"`
+	Changed   []string          `json:"Changed,omitempty"`
+}
+
+// SaveInventoryRevision saves a revision of the inventory of the BMC
+func SaveInventoryRevision(deviceUUID string, revision InventoryRevision) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Upsert("InventoryRevision", deviceUUID+":"+strconv.Itoa(revision.ID), revision); err != nil {
+		return errors.PackError(err.ErrNo(), "error: while trying to save the inventory revision: ", err.Error())
+	}
+	return nil
+}
+
+// NextInventoryRevisionID atomically increments the ID of the revisions of the inventory of the BMC and
+// returns it, so that concurrent revisions get distinct IDs. The counter is seeded with the ID of the
+// latest stored revision when it is missing
+func NextInventoryRevisionID(deviceUUID string, latestID int) (int, *errors.Error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = conn.SetNX("InventoryRevisionID", deviceUUID, latestID, 0); err != nil {
+		return 0, errors.PackError(err.ErrNo(), "error: while trying to seed the inventory revision ID: ", err.Error())
+	}
+	id, err := conn.Incr("InventoryRevisionID", deviceUUID)
+	if err != nil {
+		return 0, errors.PackError(err.ErrNo(), "error: while trying to increment the inventory revision ID: ", err.Error())
+	}
+	return id, nil
+}
+
+// GetInventoryRevisions fetches the revisions of the inventory of the BMC sorted by ID
+func GetInventoryRevisions(deviceUUID string) ([]InventoryRevision, *errors.Error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := conn.GetAllMatchingDetails("InventoryRevision", deviceUUID+":")
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error: while trying to fetch the inventory revisions: ", err.Error())
+	}
+	revisions := make([]InventoryRevision, 0, len(keys))
+	for _, key := range keys {
+		data, err := conn.Read("InventoryRevision", key)
+		if err != nil {
+			return nil, errors.PackError(err.ErrNo(), "error: while trying to fetch the inventory revision: ", err.Error())
+		}
+		var revision InventoryRevision
+		if jerr := json.Unmarshal([]byte(data), &revision); jerr != nil {
+			return nil, errors.PackError(errors.JSONUnmarshalFailed, jerr)
+		}
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].ID < revisions[j].ID })
+	return revisions, nil
+}
+
+// DeleteInventoryRevision deletes a revision of the inventory of the BMC
+func DeleteInventoryRevision(deviceUUID string, id int) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	return conn.Delete("InventoryRevision", deviceUUID+":"+strconv.Itoa(id))
+}
+
+// SaveInventoryResourceContent saves the content of a resource of the inventory revisions of the BMC
+func SaveInventoryResourceContent(deviceUUID, hash, content string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	if err = conn.Upsert("InventoryResourceContent", deviceUUID+":"+hash, content); err != nil {
+		return errors.PackError(err.ErrNo(), "error: while trying to save the inventory resource content: ", err.Error())
+	}
+	return nil
+}
+
+// GetInventoryResourceContent fetches the content of a resource of the inventory revisions of the BMC
+func GetInventoryResourceContent(deviceUUID, hash string) (string, *errors.Error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return "", err
+	}
+	data, err := conn.Read("InventoryResourceContent", deviceUUID+":"+hash)
+	if err != nil {
+		return "", errors.PackError(err.ErrNo(), "error: while trying to fetch the inventory resource content: ", err.Error())
+	}
+	var content string
+	if jerr := json.Unmarshal([]byte(data), &content); jerr != nil {
+		return "", errors.PackError(errors.JSONUnmarshalFailed, jerr)
+	}
+	return content, nil
+}
+
+// DeleteInventoryResourceContents deletes the contents of resources of the inventory revisions of the BMC
+func DeleteInventoryResourceContents(deviceUUID string, hashes []string) *errors.Error {
+	if len(hashes) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		keys = append(keys, "InventoryResourceContent:"+deviceUUID+":"+hash)
+	}
+	return DeleteMultipleKeys(keys, common.OnDisk)
+}
+
+// DeleteInventoryHistory deletes the revisions of the inventory of the BMC along with the contents of their resources
+func DeleteInventoryHistory(deviceUUID string) *errors.Error {
+	keys := []string{"InventoryRevisionID:" + deviceUUID}
+	for _, table := range []string{"InventoryRevision", "InventoryResourceContent"} {
+		matches, err := GetAllMatchingDetails(table, deviceUUID+":", common.OnDisk)
+		if err != nil {
+			return err
+		}
+		for _, key := range matches {
+			keys = append(keys, table+":"+key)
+		}
+	}
+	return DeleteMultipleKeys(keys, common.OnDisk)
+}
@@ -63,3 +63,137 @@ type AggregateActions struct {
 	AggregateAddElements         Action `json:"#Aggregate.AddElements"`
 	AggregateRemoveElements      Action `json:"#Aggregate.RemoveElements"`
 }
+
+// ImportSourcesResponse defines the response of the AggregationService.ImportSources action
+type ImportSourcesResponse struct {
+	response.Response
+	Total     int             `json:"Total"`
+	Succeeded int             `json:"Succeeded"`
+	Failed    int             `json:"Failed"`
+	Report    agmodel.OdataID `json:"Report"`
+}
+
+// ImportSourcesReportResponse defines the response for the report of an AggregationService.ImportSources action
+type ImportSourcesReportResponse struct {
+	OdataContext      string                           `json:"@odata.context,omitempty"`
+	OdataID           string                           `json:"@odata.id"`
+	ID                string                           `json:"Id"`
+	Name              string                           `json:"Name"`
+	Created           string                           `json:"Created"`
+	Task              agmodel.OdataID                  `json:"Task"`
+	Total             int                              `json:"Total"`
+	Succeeded         int                              `json:"Succeeded"`
+	Failed            int                              `json:"Failed"`
+	Rows              []agmodel.ImportSourcesReportRow `json:"Rows"`
+	AdditionalDataURI string                           `json:"AdditionalDataURI"`
+}
+
+// DiscoverSourcesResponse defines the response of the AggregationService.DiscoverSources action
+type DiscoverSourcesResponse struct {
+	response.Response
+	AddressesScanned  int             `json:"AddressesScanned"`
+	Discovered        int             `json:"Discovered"`
+	AlreadyAggregated int             `json:"AlreadyAggregated"`
+	Added             int             `json:"Added"`
+	DiscoveredSources agmodel.OdataID `json:"DiscoveredSources"`
+}
+
+// InventoryResyncResponse defines the response of a scheduled resync of the inventory of the servers
+type InventoryResyncResponse struct {
+	response.Response
+	ServersResynced  int `json:"ServersResynced"`
+	ServersFailed    int `json:"ServersFailed"`
+	ResourcesChecked int `json:"ResourcesChecked"`
+	ResourcesUpdated int `json:"ResourcesUpdated"`
+	ResourcesAdded   int `json:"ResourcesAdded"`
+	ResourcesRemoved int `json:"ResourcesRemoved"`
+}
+
+// DiscoveredSourceResponse defines the response of a source found by the AggregationService.DiscoverSources action
+type DiscoveredSourceResponse struct {
+	response.Response
+	HostName       string                  `json:"HostName"`
+	Vendor         string                  `json:"Vendor,omitempty"`
+	Product        string                  `json:"Product,omitempty"`
+	RedfishVersion string                  `json:"RedfishVersion,omitempty"`
+	ServiceUUID    string                  `json:"ServiceUUID,omitempty"`
+	DiscoveredTime string                  `json:"DiscoveredTime"`
+	Message        string                  `json:"Message,omitempty"`
+	Links          DiscoveredSourceLinks   `json:"Links"`
+	Actions        DiscoveredSourceActions `json:"Actions"`
+}
+
+// DiscoveredSourceLinks defines the links of a discovered source
+type DiscoveredSourceLinks struct {
+	ConnectionMethod *agmodel.OdataID `json:"ConnectionMethod,omitempty"`
+	DiscoveryTask    agmodel.OdataID  `json:"DiscoveryTask"`
+}
+
+// DiscoveredSourceActions defines the actions of a discovered source
+type DiscoveredSourceActions struct {
+	Approve Action `json:"#DiscoveredSource.Approve"`
+}
+
+// InventoryHistoryResponse defines the response of the Odim.InventoryHistory action of a server
+type InventoryHistoryResponse struct {
+	response.Response
+	Revisions []InventoryRevisionSummary `json:"Revisions"`
+	Diff      *InventoryDiff             `json:"Diff,omitempty"`
+}
+
+// InventoryRevisionSummary defines a revision of the inventory of a server and the resources it changed
+type InventoryRevisionSummary struct {
+	ID      int      `json:"Id"`
+	Created string   `json:"Created"`
+	Trigger string   `json:"Trigger"`
+	Added   []string `json:"Added"`
+	Removed []string `json:"Removed"`
+	Changed []string `json:"Changed"`
+}
+
+// InventoryDiff defines the differences of the inventory of a server between two revisions
+type InventoryDiff struct {
+	FromRevision int                       `json:"FromRevision"`
+	ToRevision   int                       `json:"ToRevision"`
+	Added        []InventoryResourceDiff   `json:"Added"`
+	Removed      []InventoryResourceDiff   `json:"Removed"`
+	Changed      []InventoryResourceChange `json:"Changed"`
+}
+
+// InventoryResourceDiff defines a resource added or removed between two revisions
+type InventoryResourceDiff struct {
+	OdataID  string      `json:"@odata.id"`
+	Resource interface{} `json:"Resource"`
+}
+
+// InventoryResourceChange defines the changes of a resource between two revisions
+type InventoryResourceChange struct {
+	OdataID string            `json:"@odata.id"`
+	Changes []InventoryChange `json:"Changes"`
+}
+
+// InventoryChange defines a change of a property of a resource, the path is a JSON pointer to the property
+type InventoryChange struct {
+	Op            string      `json:"Op"`
+	Path          string      `json:"Path"`
+	Value         interface{} `json:"Value,omitempty"`
+	PreviousValue interface{} `json:"PreviousValue,omitempty"`
+}
+
+// RotateCredentialsResponse defines the response of the AggregationService.RotateCredentials action
+type RotateCredentialsResponse struct {
+	response.Response
+	Total      int                       `json:"Total"`
+	Rotated    int                       `json:"Rotated"`
+	RolledBack int                       `json:"RolledBack"`
+	Failed     int                       `json:"Failed"`
+	Results    []RotateCredentialsResult `json:"Results"`
+}
+
+// RotateCredentialsResult defines the result of the credential rotation of an aggregation source
+type RotateCredentialsResult struct {
+	OdataID string `json:"@odata.id"`
+	SubTask string `json:"SubTask,omitempty"`
+	Result  string `json:"Result"`
+	Message string `json:"Message,omitempty"`
+}
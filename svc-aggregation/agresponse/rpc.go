@@ -40,6 +40,7 @@ type AggregationServiceResponse struct {
 	Aggregates         OdataID   `json:"Aggregates,omitempty"`
 	AggregationSources OdataID   `json:"AggregationSources,omitempty"`
 	ConnectionMethods  OdataID   `json:"ConnectionMethods,omitempty"`
+	DiscoveredSources  OdataID   `json:"DiscoveredSources,omitempty"`
 	ServiceEnabled     bool      `json:"ServiceEnabled,omitempty"`
 	Status             Status    `json:"Status,omitempty"`
 	Oem                *dmtf.Oem `json:"Oem,omitempty"`
@@ -49,6 +50,9 @@ type AggregationServiceResponse struct {
 type Actions struct {
 	Reset               Action `json:"#AggregationService.Reset"`
 	SetDefaultBootOrder Action `json:"#AggregationService.SetDefaultBootOrder"`
+	ImportSources       Action `json:"#AggregationService.ImportSources"`
+	DiscoverSources     Action `json:"#AggregationService.DiscoverSources"`
+	RotateCredentials   Action `json:"#AggregationService.RotateCredentials"`
 }
 
 //Status struct definition
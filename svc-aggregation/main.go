@@ -14,18 +14,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/sirupsen/logrus"
 
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
+	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/logs"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/rpc"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/system"
@@ -76,6 +79,11 @@ func main() {
 	if err := common.CheckDBConnection(); err != nil {
 		log.Fatal("error while trying to check DB connection health: " + err.Error())
 	}
+	if config.Data.ResourceFilterSchemaPath != "" {
+		if err := common.LoadFilterSchemas(config.Data.ResourceFilterSchemaPath); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
 	var connectionMethodInterface = agcommon.DBInterface{
 		GetAllKeysFromTableInterface: agmodel.GetAllKeysFromTable,
 		GetConnectionMethodInterface: agmodel.GetConnectionMethod,
@@ -90,23 +98,37 @@ func main() {
 	if err := services.InitializeService(services.Aggregator, errChan); err != nil {
 		log.Fatal("fatal: error while trying to initialize service: " + err.Error())
 	}
+	services.RegisterHealthCheck(services.HealthMessageBus, func() error {
+		return dc.Ping(config.Data.MessageBusConf.MessageBusType)
+	})
+	services.RegisterHealthCheck(services.HealthPlugins, system.CheckPluginsHealth)
 
 	aggregator := rpc.GetAggregator()
 	aggregatorproto.RegisterAggregatorServer(services.ODIMService.Server(), aggregator)
 
 	// Rediscover the Resources by looking in OnDisk DB, populate the resources in InMemory DB
 	//This happens only if the InMemory DB lost it contents due to DB reboot or host VM reboot.
-	// p := system.ExternalInterface{
-	// 	ContactClient:   pmbhandle.ContactPlugin,
-	// 	Auth:            services.IsAuthorized,
-	// 	PublishEventMB:  agmessagebus.Publish,
-	// 	GetPluginStatus: agcommon.GetPluginStatus,
-	// 	SubscribeToEMB:  services.SubscribeToEMB,
-	// 	DecryptPassword: common.DecryptWithPrivateKey,
-	// 	UpdateTask:      system.UpdateTaskData,
-	// }
-
-	// go p.RediscoverResources()
+	// The inventory of the servers is also resynced with their BMCs as scheduled in InventoryResyncConf
+	p := system.ExternalInterface{
+		ContactClient: pmbhandle.ContactPlugin,
+		Auth:          services.IsAuthorized,
+		PublishEventMB: func(ctx context.Context, systemID, eventType, collectionType string) {
+			agmessagebus.Publish(ctx, systemID, eventType, collectionType, agmessagebus.InitMQSCom())
+		},
+		GetPluginStatus:       agcommon.GetPluginStatus,
+		SubscribeToEMB:        services.SubscribeToEMB,
+		DecryptPassword:       common.DecryptWithPrivateKey,
+		CreateTask:            services.CreateTask,
+		UpdateTask:            system.UpdateTaskData,
+		EventNotification:     agmessagebus.Publish,
+		GetAllMatchingDetails: agmodel.GetAllMatchingDetails,
+		GetResource:           agmodel.GetResource,
+		Delete:                agmodel.Delete,
+		GetInventoryRevisions: agmodel.GetInventoryRevisions,
+	}
+
+	go p.RediscoverResources()
+	go p.ScheduleInventoryResync()
 
 	agcommon.ConfigFilePath = os.Getenv("CONFIG_FILE_PATH")
 	if agcommon.ConfigFilePath == "" {
@@ -89,6 +89,15 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 				Target:     "/redfish/v1/AggregationService/Actions/AggregationService.SetDefaultBootOrder/",
 				ActionInfo: "/redfish/v1/AggregationService/SetDefaultBootOrderActionInfo",
 			},
+			ImportSources: agresponse.Action{
+				Target: "/redfish/v1/AggregationService/Actions/AggregationService.ImportSources/",
+			},
+			DiscoverSources: agresponse.Action{
+				Target: "/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources/",
+			},
+			RotateCredentials: agresponse.Action{
+				Target: "/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials/",
+			},
 		},
 		Aggregates: agresponse.OdataID{
 			OdataID: "/redfish/v1/AggregationService/Aggregates",
@@ -99,6 +108,9 @@ func (a *Aggregator) GetAggregationService(ctx context.Context, req *aggregatorp
 		ConnectionMethods: agresponse.OdataID{
 			OdataID: "/redfish/v1/AggregationService/ConnectionMethods",
 		},
+		DiscoveredSources: agresponse.OdataID{
+			OdataID: "/redfish/v1/AggregationService/DiscoveredSources",
+		},
 		ServiceEnabled: isServiceEnabled,
 		Status: agresponse.Status{
 			State:        serviceState,
@@ -1076,3 +1088,395 @@ func (a *Aggregator) GetSetDefaultBootOrderActionInfo(ctx context.Context, req *
 	l.LogWithFields(ctx).Debugf("final response for get set default boot order action info request: %s", string(resp.Body))
 	return resp, nil
 }
+
+// ImportSources defines the operations which handles the RPC request response
+// for the AggregationService.ImportSources action of aggregation micro service.
+// The manifest is validated before the task of the action is created, and the
+// aggregation sources are added in the subtasks of the task
+func (a *Aggregator) ImportSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	//password in the manifest, hence cannot log it
+	sources, errResp := system.ParseImportSourcesManifest(req.RequestBody)
+	if errResp.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid import sources request")
+		generateResponse(errResp, resp)
+		return resp, nil
+	}
+	var invalidSources []string
+	for i, source := range sources {
+		if err := validateManagerAddress(source.HostName); source.HostName != "" && err != nil {
+			invalidSources = append(invalidSources, fmt.Sprintf("row %d: %v", i+1, err))
+		}
+	}
+	if len(invalidSources) > 0 {
+		errMsg := "error: invalid HostName in the manifest: " + strings.Join(invalidSources, "; ")
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{"<manifest>", "HostName"}, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	var taskID string
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	ctxt := context.WithValue(ctx, common.ThreadName, common.ImportSources)
+	ctxt = context.WithValue(ctxt, common.ThreadID, "1")
+	go a.connector.ImportSources(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for import sources request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetImportSourcesReport defines the operations which handles the RPC request response
+// for getting the report of an AggregationService.ImportSources action, as JSON or as CSV
+func (a *Aggregator) GetImportSourcesReport(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetImportSourcesReport(ctx, req.URL)
+	generateResponse(data, resp)
+	// the CSV report is sent as it is
+	if report, ok := data.Body.(string); ok {
+		resp.Body = []byte(report)
+	}
+	l.LogWithFields(ctx).Debugf("final response for get import sources report request with status code %d", resp.StatusCode)
+	return resp, nil
+}
+
+// DiscoverSources defines the operations which handles the RPC request response
+// for the AggregationService.DiscoverSources action of aggregation micro service.
+// The subnets are validated before the task of the action is created, and the
+// Redfish services are probed in the task
+func (a *Aggregator) DiscoverSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	if _, _, errResp := system.ParseDiscoverSourcesRequest(req.RequestBody); errResp.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid discover sources request")
+		generateResponse(errResp, resp)
+		return resp, nil
+	}
+
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	var taskID string
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	ctxt := context.WithValue(ctx, common.ThreadName, common.DiscoverSources)
+	ctxt = context.WithValue(ctxt, common.ThreadID, "1")
+	go a.connector.DiscoverSources(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for discover sources request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetAllDiscoveredSources defines the operations which handles the RPC request response
+// for getting the sources found by the AggregationService.DiscoverSources action
+func (a *Aggregator) GetAllDiscoveredSources(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetAllDiscoveredSources(ctx)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for get all discovered sources request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// GetDiscoveredSource defines the operations which handles the RPC request response
+// for getting a source found by the AggregationService.DiscoverSources action
+func (a *Aggregator) GetDiscoveredSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetDiscoveredSource(ctx, req.URL)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for get discovered source request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// ApproveDiscoveredSource defines the operations which handles the RPC request response
+// for the DiscoveredSource.Approve action of aggregation micro service.
+// The request is validated before the task of the action is created, and the
+// discovered source is added as an aggregation source in the task
+func (a *Aggregator) ApproveDiscoveredSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	//password in the request, hence cannot log it
+	discoveredSourceURI, aggregationSource, errResp := a.connector.GetApprovedAggregationSource(ctx, req.URL, req.RequestBody)
+	if errResp.StatusCode != 0 {
+		generateResponse(errResp, resp)
+		return resp, nil
+	}
+
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	var taskID string
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	ctxt := context.WithValue(ctx, common.ThreadName, common.ApproveDiscoveredSource)
+	ctxt = context.WithValue(ctxt, common.ThreadID, "1")
+	go a.connector.ApproveDiscoveredSource(ctxt, taskID, discoveredSourceURI, aggregationSource)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for approve discovered source request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// DeleteDiscoveredSource defines the operations which handles the RPC request response
+// for dismissing a source found by the AggregationService.DiscoverSources action
+func (a *Aggregator) DeleteDiscoveredSource(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.DeleteDiscoveredSourceInfo(ctx, req.URL)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for delete discovered source request with status code %d", resp.StatusCode)
+	return resp, nil
+}
+
+// GetInventoryHistory defines the operations which handles the RPC request response
+// for the Odim.InventoryHistory action of a server, it returns the revisions of the
+// inventory of the server and the differences between two of them when they are requested
+func (a *Aggregator) GetInventoryHistory(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeLogin}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	data := a.connector.GetInventoryHistory(ctx, req)
+	generateResponse(data, resp)
+	l.LogWithFields(ctx).Debugf("final response for get inventory history request: %s", string(resp.Body))
+	return resp, nil
+}
+
+// RotateCredentials defines the operations which handles the RPC request response
+// for the AggregationService.RotateCredentials action of aggregation micro service.
+// The request is validated before the task of the action is created, and the
+// credentials of the aggregation sources are rotated in the subtasks of the task
+func (a *Aggregator) RotateCredentials(ctx context.Context, req *aggregatorproto.AggregatorRequest) (
+	*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.GetContextData(ctx)
+	ctx = common.ModifyContext(ctx, common.AggregationService, podName)
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authResp, err := a.connector.Auth(ctx, req.SessionToken, privileges, oemprivileges)
+	resp := &aggregatorproto.AggregatorResponse{}
+	if authResp.StatusCode != http.StatusOK {
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
+		}
+		generateResponse(authResp, resp)
+		return resp, nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(ctx, req.SessionToken)
+	if err != nil {
+		errMsg := "Unable to get session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	//passwords in the request, hence cannot log it
+	if _, errResp := system.ParseRotateCredentialsRequest(req.RequestBody); errResp.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid rotate credentials request")
+		generateResponse(errResp, resp)
+		return resp, nil
+	}
+
+	taskURI, err := a.connector.CreateTask(ctx, sessionUserName)
+	if err != nil {
+		errMsg := "Unable to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		l.LogWithFields(ctx).Error(errMsg)
+		return resp, nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	var taskID string
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	ctxt := context.WithValue(ctx, common.ThreadName, common.RotateCredentials)
+	ctxt = context.WithValue(ctxt, common.ThreadID, "1")
+	go a.connector.RotateCredentials(ctxt, taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Location": "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	l.LogWithFields(ctx).Debugf("final response for rotate credentials request: %s", string(resp.Body))
+	return resp, nil
+}
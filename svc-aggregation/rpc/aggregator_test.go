@@ -1775,3 +1775,342 @@ func TestAggregator_GetConnectionMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregator_ImportSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	validManifest, _ := json.Marshal(map[string]interface{}{
+		"CSVManifest": "HostName,UserName,Password,ConnectionMethod\n10.0.0.1,admin,,/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d3906\n",
+	})
+	invalidHostManifest, _ := json.Marshal(map[string]interface{}{
+		"Sources": []map[string]interface{}{{"HostName": "not a host", "UserName": "admin", "Password": "password"}},
+	})
+	emptyManifest, _ := json.Marshal(map[string]interface{}{"Sources": []interface{}{}})
+	tests := []struct {
+		name string
+		req  *aggregatorproto.AggregatorRequest
+		want int32
+	}{
+		{
+			name: "auth fail",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", RequestBody: validManifest},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "empty manifest",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: emptyManifest},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "invalid host name",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: invalidHostManifest},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "unable to create task",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", RequestBody: validManifest},
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "positive case",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: validManifest},
+			want: http.StatusAccepted,
+		},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.ImportSources(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.ImportSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.ImportSources() status code = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_GetImportSourcesReport(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := []struct {
+		name        string
+		req         *aggregatorproto.AggregatorRequest
+		want        int32
+		contentType string
+	}{
+		{
+			name: "auth fail",
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken",
+				URL: "/redfish/v1/AggregationService/ImportSourcesReports/someTask"},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "report not found",
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken",
+				URL: "/redfish/v1/AggregationService/ImportSourcesReports/otherTask"},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "json report",
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken",
+				URL: "/redfish/v1/AggregationService/ImportSourcesReports/someTask"},
+			want: http.StatusOK,
+		},
+		{
+			name: "csv report",
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken",
+				URL: "/redfish/v1/AggregationService/ImportSourcesReports/someTask/Report.csv"},
+			want:        http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+		},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.GetImportSourcesReport(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.GetImportSourcesReport() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.GetImportSourcesReport() status code = %v, want %v", resp.StatusCode, tt.want)
+			}
+			if tt.contentType != "" && resp.Header["Content-Type"] != tt.contentType {
+				t.Errorf("Aggregator.GetImportSourcesReport() content type = %v, want %v", resp.Header["Content-Type"], tt.contentType)
+			}
+		})
+	}
+}
+
+func TestAggregator_DiscoverSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.DiscoveryConf.AllowedSubnets = []string{"127.0.0.0/8"}
+	tests := []struct {
+		name string
+		req  *aggregatorproto.AggregatorRequest
+		want int32
+	}{
+		{
+			name: "auth fail",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", RequestBody: []byte(`{"Subnets":["127.0.0.1"],"Port":1}`)},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid subnet",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte(`{"Subnets":["127.0.0.0/8"]}`)},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "unable to create task",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", RequestBody: []byte(`{"Subnets":["127.0.0.1"],"Port":1}`)},
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "positive case",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte(`{"Subnets":["127.0.0.1"],"Port":1}`)},
+			want: http.StatusAccepted,
+		},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.DiscoverSources(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.DiscoverSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.DiscoverSources() status code = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_DiscoveredSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	a := &Aggregator{connector: connector}
+	tests := []struct {
+		name string
+		call func(context.Context, *aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+		req  *aggregatorproto.AggregatorRequest
+		want int32
+	}{
+		{
+			name: "get all auth fail",
+			call: a.GetAllDiscoveredSources,
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: "/redfish/v1/AggregationService/DiscoveredSources"},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "get all",
+			call: a.GetAllDiscoveredSources,
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: "/redfish/v1/AggregationService/DiscoveredSources"},
+			want: http.StatusOK,
+		},
+		{
+			name: "get",
+			call: a.GetDiscoveredSource,
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: mockDiscoveredSourceURI},
+			want: http.StatusOK,
+		},
+		{
+			name: "get not found",
+			call: a.GetDiscoveredSource,
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: "/redfish/v1/AggregationService/DiscoveredSources/otherSource"},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "approve auth fail",
+			call: a.ApproveDiscoveredSource,
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: mockDiscoveredSourceURI + "/Actions/DiscoveredSource.Approve",
+				RequestBody: []byte(`{"CredentialProfile":"default"}`)},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "approve invalid request",
+			call: a.ApproveDiscoveredSource,
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: mockDiscoveredSourceURI + "/Actions/DiscoveredSource.Approve",
+				RequestBody: []byte(`{"UserName":"admin"}`)},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "approve not found",
+			call: a.ApproveDiscoveredSource,
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: "/redfish/v1/AggregationService/DiscoveredSources/otherSource/Actions/DiscoveredSource.Approve",
+				RequestBody: []byte(`{"CredentialProfile":"default"}`)},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "approve unable to create task",
+			call: a.ApproveDiscoveredSource,
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", URL: mockDiscoveredSourceURI + "/Actions/DiscoveredSource.Approve",
+				RequestBody: []byte(`{"CredentialProfile":"default"}`)},
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "delete not found",
+			call: a.DeleteDiscoveredSource,
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: "/redfish/v1/AggregationService/DiscoveredSources/otherSource"},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "delete",
+			call: a.DeleteDiscoveredSource,
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: mockDiscoveredSourceURI},
+			want: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.call(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("%s error = %v", tt.name, err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("%s status code = %v, want %v", tt.name, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_GetInventoryHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	a := &Aggregator{connector: connector}
+	tests := []struct {
+		name string
+		req  *aggregatorproto.AggregatorRequest
+		want int32
+	}{
+		{
+			name: "auth fail",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", URL: mockInventorySystemURI + "/Actions/Oem/Odim.InventoryHistory"},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "revisions",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: mockInventorySystemURI + "/Actions/Oem/Odim.InventoryHistory"},
+			want: http.StatusOK,
+		},
+		{
+			name: "diff",
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: mockInventorySystemURI + "/Actions/Oem/Odim.InventoryHistory",
+				RequestBody: []byte(`{"FromRevision":1,"ToRevision":2}`)},
+			want: http.StatusOK,
+		},
+		{
+			name: "revision not retained",
+			req: &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: mockInventorySystemURI + "/Actions/Oem/Odim.InventoryHistory",
+				RequestBody: []byte(`{"FromRevision":1,"ToRevision":5}`)},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "system not found",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", URL: "/redfish/v1/Systems/a8b47c32-ee08-4b30-8b91-d7e6e2ee1a3c.1/Actions/Oem/Odim.InventoryHistory"},
+			want: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, _ := a.GetInventoryHistory(context.Background(), tt.req)
+			if resp.StatusCode != tt.want {
+				t.Errorf("GetInventoryHistory() status = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregator_RotateCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	validRequest, _ := json.Marshal(map[string]interface{}{
+		"AggregationSources": []map[string]interface{}{
+			{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/058c1876-6f24-439a-8968-2af261a5555a.1"},
+		},
+	})
+	conflictRequest, _ := json.Marshal(map[string]interface{}{
+		"Aggregate": map[string]interface{}{"@odata.id": "/redfish/v1/AggregationService/Aggregates/someAggregate"},
+		"AggregationSources": []map[string]interface{}{
+			{"@odata.id": "/redfish/v1/AggregationService/AggregationSources/058c1876-6f24-439a-8968-2af261a5555a.1"},
+		},
+	})
+	tests := []struct {
+		name string
+		req  *aggregatorproto.AggregatorRequest
+		want int32
+	}{
+		{
+			name: "auth fail",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", RequestBody: validRequest},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "malformed request",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte(`{"AggregationSources":`)},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "aggregate and aggregation sources",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: conflictRequest},
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "unable to create task",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", RequestBody: validRequest},
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "positive case",
+			req:  &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: validRequest},
+			want: http.StatusAccepted,
+		},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := a.RotateCredentials(mockContext(), tt.req)
+			if err != nil {
+				t.Fatalf("Aggregator.RotateCredentials() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("Aggregator.RotateCredentials() status code = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -37,35 +37,42 @@ type Aggregator struct {
 func GetAggregator() *Aggregator {
 	return &Aggregator{
 		connector: &system.ExternalInterface{
-			ContactClient:            pmbhandle.ContactPlugin,
-			Auth:                     services.IsAuthorized,
-			GetSessionUserName:       services.GetSessionUserName,
-			CreateTask:               services.CreateTask,
-			CreateChildTask:          services.CreateChildTask,
-			UpdateTask:               system.UpdateTaskData,
-			CreateSubscription:       system.CreateDefaultEventSubscription,
-			PublishEvent:             system.PublishEvent,
-			GetPluginStatus:          agcommon.GetPluginStatus,
-			SubscribeToEMB:           services.SubscribeToEMB,
-			EncryptPassword:          common.EncryptWithPublicKey,
-			DecryptPassword:          common.DecryptWithPrivateKey,
-			DeleteComputeSystem:      agmodel.DeleteComputeSystem,
-			DeleteSystem:             agmodel.DeleteSystem,
-			DeleteEventSubscription:  services.DeleteSubscription,
-			EventNotification:        agmessagebus.Publish,
-			GetAllKeysFromTable:      agmodel.GetAllKeysFromTable,
-			GetConnectionMethod:      agmodel.GetConnectionMethod,
-			UpdateConnectionMethod:   agmodel.UpdateConnectionMethod,
-			GetPluginMgrAddr:         agmodel.GetPluginData,
-			GetAggregationSourceInfo: agmodel.GetAggregationSourceInfo,
-			GenericSave:              agmodel.GenericSave,
-			CheckActiveRequest:       agmodel.CheckActiveRequest,
-			DeleteActiveRequest:      agmodel.DeleteActiveRequest,
-			GetAllMatchingDetails:    agmodel.GetAllMatchingDetails,
-			CheckMetricRequest:       agmodel.CheckMetricRequest,
-			DeleteMetricRequest:      agmodel.DeleteMetricRequest,
-			GetResource:              agmodel.GetResource,
-			Delete:                   agmodel.Delete,
+			ContactClient:               pmbhandle.ContactPlugin,
+			Auth:                        services.IsAuthorized,
+			GetSessionUserName:          services.GetSessionUserName,
+			CreateTask:                  services.CreateTask,
+			CreateChildTask:             services.CreateChildTask,
+			UpdateTask:                  system.UpdateTaskData,
+			CreateSubscription:          system.CreateDefaultEventSubscription,
+			PublishEvent:                system.PublishEvent,
+			GetPluginStatus:             agcommon.GetPluginStatus,
+			SubscribeToEMB:              services.SubscribeToEMB,
+			EncryptPassword:             common.EncryptWithPublicKey,
+			DecryptPassword:             common.DecryptWithPrivateKey,
+			DeleteComputeSystem:         agmodel.DeleteComputeSystem,
+			DeleteSystem:                agmodel.DeleteSystem,
+			DeleteEventSubscription:     services.DeleteSubscription,
+			EventNotification:           agmessagebus.Publish,
+			GetAllKeysFromTable:         agmodel.GetAllKeysFromTable,
+			GetConnectionMethod:         agmodel.GetConnectionMethod,
+			UpdateConnectionMethod:      agmodel.UpdateConnectionMethod,
+			GetPluginMgrAddr:            agmodel.GetPluginData,
+			GetAggregationSourceInfo:    agmodel.GetAggregationSourceInfo,
+			GenericSave:                 agmodel.GenericSave,
+			CheckActiveRequest:          agmodel.CheckActiveRequest,
+			DeleteActiveRequest:         agmodel.DeleteActiveRequest,
+			GetAllMatchingDetails:       agmodel.GetAllMatchingDetails,
+			CheckMetricRequest:          agmodel.CheckMetricRequest,
+			DeleteMetricRequest:         agmodel.DeleteMetricRequest,
+			GetResource:                 agmodel.GetResource,
+			Delete:                      agmodel.Delete,
+			SaveImportSourcesReport:     agmodel.SaveImportSourcesReport,
+			GetImportSourcesReportInfo:  agmodel.GetImportSourcesReport,
+			SaveDiscoveredSource:        agmodel.SaveDiscoveredSource,
+			GetDiscoveredSourceInfo:     agmodel.GetDiscoveredSource,
+			DeleteDiscoveredSource:      agmodel.DeleteDiscoveredSource,
+			GetInventoryRevisions:       agmodel.GetInventoryRevisions,
+			GetInventoryResourceContent: agmodel.GetInventoryResourceContent,
 		},
 	}
 }
@@ -35,29 +35,106 @@ import (
 )
 
 var connector = &system.ExternalInterface{
-	ContactClient:            mockContactClient,
-	Auth:                     mockIsAuthorized,
-	CreateTask:               createTaskForTesting,
-	CreateChildTask:          mockCreateChildTask,
-	UpdateTask:               mockUpdateTask,
-	DecryptPassword:          stubDevicePassword,
-	GetPluginStatus:          GetPluginStatusForTesting,
-	CreateSubscription:       EventFunctionsForTesting,
-	PublishEvent:             PostEventFunctionForTesting,
-	EncryptPassword:          stubDevicePassword,
-	DeleteComputeSystem:      deleteComputeforTest,
-	DeleteSystem:             deleteSystemforTest,
-	DeleteEventSubscription:  mockDeleteSubscription,
-	EventNotification:        mockEventNotification,
-	SubscribeToEMB:           mockSubscribeEMB,
-	GetSessionUserName:       getSessionUserNameForTesting,
-	GetAllKeysFromTable:      mockGetAllKeysFromTable,
-	GetConnectionMethod:      mockGetConnectionMethod,
-	UpdateConnectionMethod:   mockUpdateConnectionMethod,
-	GetAggregationSourceInfo: mockGetAggregationSourceInfo,
-	GenericSave:              mockGenericSave,
-	CheckActiveRequest:       mockCheckActiveRequest,
-	DeleteActiveRequest:      mockDeleteActiveRequest,
+	ContactClient:               mockContactClient,
+	Auth:                        mockIsAuthorized,
+	CreateTask:                  createTaskForTesting,
+	CreateChildTask:             mockCreateChildTask,
+	UpdateTask:                  mockUpdateTask,
+	DecryptPassword:             stubDevicePassword,
+	GetPluginStatus:             GetPluginStatusForTesting,
+	CreateSubscription:          EventFunctionsForTesting,
+	PublishEvent:                PostEventFunctionForTesting,
+	EncryptPassword:             stubDevicePassword,
+	DeleteComputeSystem:         deleteComputeforTest,
+	DeleteSystem:                deleteSystemforTest,
+	DeleteEventSubscription:     mockDeleteSubscription,
+	EventNotification:           mockEventNotification,
+	SubscribeToEMB:              mockSubscribeEMB,
+	GetSessionUserName:          getSessionUserNameForTesting,
+	GetAllKeysFromTable:         mockGetAllKeysFromTable,
+	GetConnectionMethod:         mockGetConnectionMethod,
+	UpdateConnectionMethod:      mockUpdateConnectionMethod,
+	GetAggregationSourceInfo:    mockGetAggregationSourceInfo,
+	GenericSave:                 mockGenericSave,
+	CheckActiveRequest:          mockCheckActiveRequest,
+	DeleteActiveRequest:         mockDeleteActiveRequest,
+	SaveImportSourcesReport:     mockSaveImportSourcesReport,
+	GetImportSourcesReportInfo:  mockGetImportSourcesReport,
+	SaveDiscoveredSource:        mockSaveDiscoveredSource,
+	GetDiscoveredSourceInfo:     mockGetDiscoveredSource,
+	DeleteDiscoveredSource:      mockDeleteDiscoveredSource,
+	GetResource:                 mockGetResource,
+	GetInventoryRevisions:       mockGetInventoryRevisions,
+	GetInventoryResourceContent: mockGetInventoryResourceContent,
+}
+
+const mockInventorySystemURI = "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"
+
+func mockGetResource(ctx context.Context, table, key string) (string, *errors.Error) {
+	if table != "ComputerSystem" || key != mockInventorySystemURI {
+		return "", errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	return `{"@odata.id":"` + mockInventorySystemURI + `"}`, nil
+}
+
+func mockGetInventoryRevisions(deviceUUID string) ([]agmodel.InventoryRevision, *errors.Error) {
+	return []agmodel.InventoryRevision{
+		{ID: 1, Trigger: "AddCompute", Resources: map[string]string{"Memory:/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Memory/1": "hash1"}},
+		{ID: 2, Trigger: "Resync", Resources: map[string]string{"Memory:/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Memory/1": "hash2"},
+			Changed: []string{"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1/Memory/1"}},
+	}, nil
+}
+
+func mockGetInventoryResourceContent(deviceUUID, hash string) (string, *errors.Error) {
+	return `{"Id":"1","CapacityMiB":` + map[string]string{"hash1": "16384", "hash2": "32768"}[hash] + `}`, nil
+}
+
+const mockDiscoveredSourceURI = "/redfish/v1/AggregationService/DiscoveredSources/someSource"
+
+func mockSaveDiscoveredSource(source agmodel.DiscoveredSource, uri string) *errors.Error {
+	return nil
+}
+
+func mockGetDiscoveredSource(uri string) (agmodel.DiscoveredSource, *errors.Error) {
+	if uri != mockDiscoveredSourceURI {
+		return agmodel.DiscoveredSource{}, errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	return agmodel.DiscoveredSource{
+		HostName: "10.0.0.3",
+		Vendor:   "HPE",
+		ConnectionMethod: &agmodel.OdataID{
+			OdataID: "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069",
+		},
+		DiscoveryTask: "someTask",
+	}, nil
+}
+
+func mockDeleteDiscoveredSource(uri string) *errors.Error {
+	if uri != mockDiscoveredSourceURI {
+		return errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	return nil
+}
+
+func mockSaveImportSourcesReport(report agmodel.ImportSourcesReport) *errors.Error {
+	return nil
+}
+
+func mockGetImportSourcesReport(id string) (agmodel.ImportSourcesReport, *errors.Error) {
+	if id != "someTask" {
+		return agmodel.ImportSourcesReport{}, errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	return agmodel.ImportSourcesReport{
+		ID:        id,
+		Total:     2,
+		Succeeded: 1,
+		Failed:    1,
+		Rows: []agmodel.ImportSourcesReportRow{
+			{Row: 1, HostName: "10.0.0.1", Result: "Success", StatusCode: http.StatusCreated,
+				AggregationSource: "/redfish/v1/AggregationService/AggregationSources/ef83e569-7336-492a-aaee-31c02d9db831"},
+			{Row: 2, HostName: "10.0.0.2", Result: "Failure", StatusCode: http.StatusConflict, Message: "Manager address already exist 10.0.0.2"},
+		},
+	}, nil
 }
 
 func mockGetAggregationSourceInfo(ctx context.Context, reqURI string) (agmodel.AggregationSource, *errors.Error) {
@@ -81,6 +158,8 @@ func mockGetAggregationSourceInfo(ctx context.Context, reqURI string) (agmodel.A
 func mockGetAllKeysFromTable(ctx context.Context, table string) ([]string, error) {
 	if table == "ConnectionMethod" {
 		return []string{"/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73"}, nil
+	} else if table == "DiscoveredSource" {
+		return []string{mockDiscoveredSourceURI}, nil
 	}
 	return []string{}, fmt.Errorf("Table not found")
 }
@@ -961,5 +961,6 @@ func getMockExternalInterface() *ExternalInterface {
 		DeleteMetricRequest:     mockDeleteMetricRequest,
 		GetResource:             mockGetResource,
 		Delete:                  mockDelete,
+		GetInventoryRevisions:   agmodel.GetInventoryRevisions,
 	}
 }
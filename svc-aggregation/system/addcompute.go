@@ -294,6 +294,9 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage,
 			nil, nil), "", nil
 	}
+	if err = agmodel.SaveFilterIndexes(h.InventoryData, false); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to save index values of chassis and managers: " + err.Error())
+	}
 	ciphertext, err := e.EncryptPassword([]byte(addResourceRequest.Password))
 	if err != nil {
 		go e.rollbackInMemory(resourceURI)
@@ -309,6 +312,7 @@ func (e *ExternalInterface) addCompute(ctx context.Context, taskID, targetURI, p
 		l.LogWithFields(ctx).Error(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo), "", nil
 	}
+	e.recordInventoryRevision(ctx, saveSystem.DeviceUUID, inventoryTriggerAddCompute)
 	aggSourceIDChassisAndManager := saveSystem.DeviceUUID + "."
 	chassisList, _ := agmodel.GetAllMatchingDetails("Chassis", aggSourceIDChassisAndManager, common.InMemory)
 	managersList, _ := agmodel.GetAllMatchingDetails("Managers", aggSourceIDChassisAndManager, common.InMemory)
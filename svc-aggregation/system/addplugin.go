@@ -300,6 +300,11 @@ func (e *ExternalInterface) addPluginData(ctx context.Context, req AddResourceRe
 
 		return common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"Plugin", "PluginID", plugin.ID}, taskInfo), "", nil
 	}
+	if err := agmodel.SaveFilterIndexes(mapData, false); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to save index values of plugin managers: " + err.Error())
+	}
+	// saving the OEM json schemas published by the plugin
+	savePluginJSONSchemas(ctx, pluginContactRequest)
 
 	l.LogWithFields(ctx).Info("subscribing to EMB for plugin " + plugin.ID)
 	err = e.SubscribeToEMB(ctx, plugin.ID, queueList)
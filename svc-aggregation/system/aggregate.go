@@ -76,7 +76,7 @@ func (e *ExternalInterface) CreateAggregate(ctx context.Context, req *aggregator
 		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Elements"}, nil)
 	}
 
-	statuscode, err := validateElements(createRequest.Elements)
+	statuscode, err := validateElements(ctx, createRequest.Elements)
 	if err != nil {
 		errMsg := "invalid elements for create an aggregate" + err.Error()
 		l.LogWithFields(ctx).Error(errMsg)
@@ -119,12 +119,20 @@ func (e *ExternalInterface) CreateAggregate(ctx context.Context, req *aggregator
 	return resp
 }
 
-// check if the resource is exist in odim
-func validateElements(elements []agmodel.OdataID) (int32, error) {
+// check if the resource is exist in odim, the resources out of the scope
+// of the session are not found, so that the scope can not be extended
+func validateElements(ctx context.Context, elements []agmodel.OdataID) (int32, error) {
 	if checkDuplicateElements(elements) {
 		return http.StatusBadRequest, errors.PackError(errors.UndefinedErrorType, fmt.Errorf("Duplicate elements present"))
 	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
 	for _, element := range elements {
+		if !scope.Allows(element.OdataID) {
+			return http.StatusNotFound, errors.PackError(errors.DBKeyNotFound, "error: ", element.OdataID, " is not in the scope of the session")
+		}
 		if _, err := agmodel.GetComputerSystem(element.OdataID); err != nil {
 			return http.StatusNotFound, err
 		}
@@ -155,6 +163,12 @@ func (e *ExternalInterface) GetAllAggregates(ctx context.Context, req *aggregato
 		errorMessage := err.Error()
 		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage, []interface{}{config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}, nil)
 	}
+	scope, serr := common.GetResourceScope(ctx)
+	if serr != nil {
+		l.LogWithFields(ctx).Error("error while resolving the scope of the session: " + serr.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, serr.Error(), nil, nil)
+	}
+	aggregateKeys = scope.Filter(aggregateKeys)
 	var members = make([]agresponse.ListMember, 0)
 	for i := 0; i < len(aggregateKeys); i++ {
 		members = append(members, agresponse.ListMember{
@@ -277,7 +291,7 @@ func (e *ExternalInterface) AddElementsToAggregate(ctx context.Context, req *agg
 		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Elements"}, nil)
 	}
 
-	statuscode, err := validateElements(addRequest.Elements)
+	statuscode, err := validateElements(ctx, addRequest.Elements)
 	if err != nil {
 		errMsg := "invalid elements for create an aggregate" + err.Error()
 		l.LogWithFields(ctx).Error(errMsg)
@@ -75,36 +75,43 @@ type Device struct {
 
 // ExternalInterface struct holds the function pointers all outboud services
 type ExternalInterface struct {
-	ContactClient            func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
-	Auth                     func(context.Context, string, []string, []string) (response.RPC, error)
-	GetSessionUserName       func(context.Context, string) (string, error)
-	CreateChildTask          func(context.Context, string, string) (string, error)
-	CreateTask               func(context.Context, string) (string, error)
-	UpdateTask               func(context.Context, common.TaskData) error
-	CreateSubscription       func(context.Context, []string)
-	PublishEvent             func(context.Context, []string, string)
-	PublishEventMB           func(context.Context, string, string, string)
-	GetPluginStatus          func(context.Context, agmodel.Plugin) bool
-	SubscribeToEMB           func(context.Context, string, []string) error
-	EncryptPassword          func([]byte) ([]byte, error)
-	DecryptPassword          func([]byte) ([]byte, error)
-	DeleteComputeSystem      func(int, string) *errors.Error
-	DeleteSystem             func(string) *errors.Error
-	DeleteEventSubscription  func(context.Context, string, string) (*eventsproto.EventSubResponse, error)
-	EventNotification        func(context.Context, string, string, string, agmessagebus.MQBusCommunicator) error
-	GetAllKeysFromTable      func(context.Context, string) ([]string, error)
-	GetConnectionMethod      func(context.Context, string) (agmodel.ConnectionMethod, *errors.Error)
-	UpdateConnectionMethod   func(agmodel.ConnectionMethod, string) *errors.Error
-	GetPluginMgrAddr         func(string, agmodel.DBPluginDataRead) (agmodel.Plugin, *errors.Error)
-	GetAggregationSourceInfo func(context.Context, string) (agmodel.AggregationSource, *errors.Error)
-	GenericSave              func([]byte, string, string) error
-	CheckActiveRequest       func(string) (bool, *errors.Error)
-	DeleteActiveRequest      func(string) *errors.Error
-	GetAllMatchingDetails    func(string, string, common.DbType) ([]string, *errors.Error)
-	CheckMetricRequest       func(string) (bool, *errors.Error)
-	DeleteMetricRequest      func(string) *errors.Error
-	GetResource              func(context.Context, string, string) (string, *errors.Error)
-	Delete                   func(string, string, common.DbType) *errors.Error
+	ContactClient               func(context.Context, string, string, string, string, interface{}, map[string]string) (*http.Response, error)
+	Auth                        func(context.Context, string, []string, []string) (response.RPC, error)
+	GetSessionUserName          func(context.Context, string) (string, error)
+	CreateChildTask             func(context.Context, string, string) (string, error)
+	CreateTask                  func(context.Context, string) (string, error)
+	UpdateTask                  func(context.Context, common.TaskData) error
+	CreateSubscription          func(context.Context, []string)
+	PublishEvent                func(context.Context, []string, string)
+	PublishEventMB              func(context.Context, string, string, string)
+	GetPluginStatus             func(context.Context, agmodel.Plugin) bool
+	SubscribeToEMB              func(context.Context, string, []string) error
+	EncryptPassword             func([]byte) ([]byte, error)
+	DecryptPassword             func([]byte) ([]byte, error)
+	DeleteComputeSystem         func(int, string) *errors.Error
+	DeleteSystem                func(string) *errors.Error
+	DeleteEventSubscription     func(context.Context, string, string) (*eventsproto.EventSubResponse, error)
+	EventNotification           func(context.Context, string, string, string, agmessagebus.MQBusCommunicator) error
+	GetAllKeysFromTable         func(context.Context, string) ([]string, error)
+	GetConnectionMethod         func(context.Context, string) (agmodel.ConnectionMethod, *errors.Error)
+	UpdateConnectionMethod      func(agmodel.ConnectionMethod, string) *errors.Error
+	GetPluginMgrAddr            func(string, agmodel.DBPluginDataRead) (agmodel.Plugin, *errors.Error)
+	GetAggregationSourceInfo    func(context.Context, string) (agmodel.AggregationSource, *errors.Error)
+	GenericSave                 func([]byte, string, string) error
+	CheckActiveRequest          func(string) (bool, *errors.Error)
+	DeleteActiveRequest         func(string) *errors.Error
+	GetAllMatchingDetails       func(string, string, common.DbType) ([]string, *errors.Error)
+	CheckMetricRequest          func(string) (bool, *errors.Error)
+	DeleteMetricRequest         func(string) *errors.Error
+	GetResource                 func(context.Context, string, string) (string, *errors.Error)
+	Delete                      func(string, string, common.DbType) *errors.Error
+	SaveImportSourcesReport     func(agmodel.ImportSourcesReport) *errors.Error
+	GetImportSourcesReportInfo  func(string) (agmodel.ImportSourcesReport, *errors.Error)
+	SaveDiscoveredSource        func(agmodel.DiscoveredSource, string) *errors.Error
+	GetDiscoveredSourceInfo     func(string) (agmodel.DiscoveredSource, *errors.Error)
+	DeleteDiscoveredSource      func(string) *errors.Error
+	GetInventoryRevisions       func(string) ([]agmodel.InventoryRevision, *errors.Error)
+	GetInventoryResourceContent func(string, string) (string, *errors.Error)
 }
 
 type responseStatus struct {
@@ -975,25 +982,8 @@ func (h *respHolder) getResourceDetails(ctx context.Context, taskID string, prog
 	}
 	resourceName := getResourceName(req.OID, memberFlag)
 	if memberFlag && strings.Contains(resourceName, "VolumesCollection") {
-		CollectionCapabilities := dmtf.CollectionCapabilities{
-			OdataType: "#CollectionCapabilities.v1_4_0.CollectionCapabilities",
-			Capabilities: []*dmtf.Capabilities{
-				{
-					CapabilitiesObject: &dmtf.Link{
-						Oid: req.OID + "/Capabilities",
-					},
-					Links: dmtf.CapLinks{
-						TargetCollection: &dmtf.Link{
-							Oid: req.OID,
-						},
-					},
-					UseCase: "VolumeCreation",
-				},
-			},
-		}
-		resourceData["@Redfish.CollectionCapabilities"] = CollectionCapabilities
+		addVolumeCollectionCapabilities(req.OID, resourceData)
 		body, _ = json.Marshal(resourceData)
-
 	}
 	//replacing the uuid while saving the data
 	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
@@ -1018,6 +1008,28 @@ func (h *respHolder) getResourceDetails(ctx context.Context, taskID string, prog
 	progress = progress + alottedWork
 	return progress
 }
+
+// addVolumeCollectionCapabilities adds the volume creation capabilities to a volumes collection
+func addVolumeCollectionCapabilities(oid string, resourceData map[string]interface{}) {
+	CollectionCapabilities := dmtf.CollectionCapabilities{
+		OdataType: "#CollectionCapabilities.v1_4_0.CollectionCapabilities",
+		Capabilities: []*dmtf.Capabilities{
+			{
+				CapabilitiesObject: &dmtf.Link{
+					Oid: oid + "/Capabilities",
+				},
+				Links: dmtf.CapLinks{
+					TargetCollection: &dmtf.Link{
+						Oid: oid,
+					},
+				},
+				UseCase: "VolumeCreation",
+			},
+		},
+	}
+	resourceData["@Redfish.CollectionCapabilities"] = CollectionCapabilities
+}
+
 func getResourceName(oDataID string, memberFlag bool) string {
 	str := strings.Split(oDataID, "/")
 	if memberFlag {
@@ -1717,3 +1729,41 @@ func (e *ExternalInterface) monitorPluginTask(ctx context.Context, subTaskChanne
 	}
 	return monitorTaskData.getResponse, nil
 }
+
+// runInWorkerPool runs work for the jobs 0 to count-1, workers jobs at a time. When progress is given, it is called
+// with the number of completed jobs after each job but the last, and the remaining jobs are not started once it
+// returns false. runInWorkerPool returns false when the jobs are stopped by progress
+func runInWorkerPool(workers, count int, work func(int), progress func(int) bool) bool {
+	if workers <= 0 || workers > count {
+		workers = count
+	}
+	jobs := make(chan int)
+	done := make(chan struct{}, count)
+	stop := make(chan struct{})
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				work(i)
+				done <- struct{}{}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < count; i++ {
+			select {
+			case jobs <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	for completed := 1; completed <= count; completed++ {
+		<-done
+		if progress != nil && completed < count && !progress(completed) {
+			close(stop)
+			return false
+		}
+	}
+	return true
+}
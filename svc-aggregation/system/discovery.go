@@ -0,0 +1,715 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// DiscoveredSourcesURI is the URI of the collection of the sources found by the AggregationService.DiscoverSources action
+	DiscoveredSourcesURI = "/redfish/v1/AggregationService/DiscoveredSources"
+	// ApproveDiscoveredSourceAction is the URI suffix of the action which adds a discovered source as an aggregation source
+	ApproveDiscoveredSourceAction = "/Actions/DiscoveredSource.Approve"
+
+	discoverSourcesTargetURI = "/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources"
+	aggregationSourcesURI    = "/redfish/v1/AggregationService/AggregationSources"
+	defaultRedfishPort       = 443
+	defaultDiscoveryPluginID = "GRF"
+	// maxServiceRootSize is the maximum size of a service root read by a probe
+	maxServiceRootSize = 1 << 20
+)
+
+// DiscoverSourcesRequest is the request of the AggregationService.DiscoverSources action.
+// Subnets are the CIDR ranges or addresses to scan, the Redfish services are probed on Port, 443 by default.
+// With AutoAdd, the discovered sources are added as aggregation sources with the credentials of CredentialProfile
+type DiscoverSourcesRequest struct {
+	Subnets           []string `json:"Subnets"`
+	Port              int      `json:"Port,omitempty"`
+	AutoAdd           bool     `json:"AutoAdd,omitempty"`
+	CredentialProfile string   `json:"CredentialProfile,omitempty"`
+}
+
+// ApproveDiscoveredSourceRequest is the request of the DiscoveredSource.Approve action.
+// The credentials are given either with UserName and Password or with CredentialProfile, and the connection
+// method in Links overrides the connection method matched during the discovery
+type ApproveDiscoveredSourceRequest struct {
+	UserName          string `json:"UserName,omitempty"`
+	Password          string `json:"Password,omitempty"`
+	CredentialProfile string `json:"CredentialProfile,omitempty"`
+	Links             *Links `json:"Links,omitempty"`
+}
+
+// serviceRoot is the part of the Redfish service root used to classify a discovered service
+type serviceRoot struct {
+	OdataID        string                 `json:"@odata.id"`
+	RedfishVersion string                 `json:"RedfishVersion"`
+	UUID           string                 `json:"UUID"`
+	Vendor         string                 `json:"Vendor"`
+	Product        string                 `json:"Product"`
+	Oem            map[string]interface{} `json:"Oem"`
+}
+
+// ParseDiscoverSourcesRequest returns the request of the AggregationService.DiscoverSources action
+// and the addresses of its subnets. The returned response is not empty when the request is not valid
+func ParseDiscoverSourcesRequest(body []byte) (DiscoverSourcesRequest, []string, response.RPC) {
+	var req DiscoverSourcesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		errMsg := "unable to parse the discover sources request: " + err.Error()
+		return req, nil, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(body, req)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		return req, nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		return req, nil, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	if len(req.Subnets) == 0 {
+		errMsg := "error: mandatory field Subnets missing in the request"
+		return req, nil, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Subnets"}, nil)
+	}
+	if req.Port < 0 || req.Port > 65535 {
+		errMsg := fmt.Sprintf("error: invalid port %d", req.Port)
+		return req, nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{strconv.Itoa(req.Port), "Port"}, nil)
+	}
+	if req.AutoAdd {
+		if req.CredentialProfile == "" {
+			errMsg := "error: CredentialProfile is required to add the discovered sources"
+			return req, nil, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"CredentialProfile"}, nil)
+		}
+	}
+	if req.CredentialProfile != "" {
+		if _, ok := config.GetCredentialProfile(req.CredentialProfile); !ok {
+			errMsg := "error: unknown credential profile " + req.CredentialProfile
+			return req, nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{req.CredentialProfile, "CredentialProfile"}, nil)
+		}
+	}
+	addresses, err := expandSubnets(req.Subnets, config.Data.DiscoveryConf.MaxAddressesPerRequest)
+	if err != nil {
+		errMsg := "error: invalid subnets: " + err.Error()
+		return req, nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{strings.Join(req.Subnets, ","), "Subnets"}, nil)
+	}
+	return req, addresses, response.RPC{}
+}
+
+// expandSubnets returns the addresses of the subnets, at most limit addresses.
+// The network and broadcast addresses of the IPv4 subnets are not returned,
+// and the subnets with restricted addresses are rejected
+func expandSubnets(subnets []string, limit int) ([]string, error) {
+	var addresses []string
+	added := make(map[string]bool)
+	for _, subnet := range subnets {
+		subnet = strings.TrimSpace(subnet)
+		if !strings.Contains(subnet, "/") {
+			ip := net.ParseIP(subnet)
+			if ip == nil {
+				return nil, fmt.Errorf("%s is neither an IP address nor a CIDR range", subnet)
+			}
+			if ip.To4() != nil {
+				subnet += "/32"
+			} else {
+				subnet += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, err
+		}
+		ones, bits := ipNet.Mask.Size()
+		if bits-ones >= 31 || 1<<(bits-ones) > limit {
+			return nil, fmt.Errorf("the subnet %s has more than %d addresses", subnet, limit)
+		}
+		skipEnds := bits == 32 && bits-ones > 1
+		for ip := ipNet.IP; ipNet.Contains(ip); ip = nextIP(ip) {
+			if skipEnds && (ip.Equal(ipNet.IP) || !ipNet.Contains(nextIP(ip))) {
+				continue
+			}
+			if added[ip.String()] {
+				continue
+			}
+			if isRestrictedAddress(ip) {
+				return nil, fmt.Errorf("the address %s of the subnet %s is restricted", ip, subnet)
+			}
+			if len(addresses) == limit {
+				return nil, fmt.Errorf("the subnets have more than %d addresses", limit)
+			}
+			added[ip.String()] = true
+			addresses = append(addresses, ip.String())
+		}
+	}
+	return addresses, nil
+}
+
+// isRestrictedAddress checks the address must not be scanned. The loopback and link-local addresses
+// and the addresses of DiscoveryConf.RestrictedSubnets, such as the service CIDR of the cluster,
+// are restricted unless they are in DiscoveryConf.AllowedSubnets
+func isRestrictedAddress(ip net.IP) bool {
+	if inSubnets(ip, config.Data.DiscoveryConf.AllowedSubnets) {
+		return false
+	}
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() ||
+		inSubnets(ip, config.Data.DiscoveryConf.RestrictedSubnets)
+}
+
+// inSubnets checks the address is in one of the CIDR ranges
+func inSubnets(ip net.IP, subnets []string) bool {
+	for _, subnet := range subnets {
+		if _, ipNet, err := net.ParseCIDR(subnet); err == nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextIP returns the address following ip
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
+
+// newDiscoveryClient returns the client of the probes. The certificates are not verified since the BMCs
+// usually have self-signed certificates before they are added, and no credentials are sent in a probe.
+// The connections to the restricted addresses are refused
+func newDiscoveryClient() *http.Client {
+	dialer := &net.Dialer{
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isRestrictedAddress(ip) {
+				return fmt.Errorf("the address %s is restricted", host)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: time.Duration(config.Data.DiscoveryConf.ProbeTimeoutInSeconds) * time.Second,
+		Transport: &http.Transport{
+			DialContext:       dialer.DialContext,
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// probeRedfishService returns the service root of the Redfish service at the address,
+// the service root is read without credentials
+func probeRedfishService(ctx context.Context, client *http.Client, address string) (*serviceRoot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+address+"/redfish/v1/", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the service root request returned status code %d", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxServiceRootSize))
+	if err != nil {
+		return nil, err
+	}
+	var root serviceRoot
+	if err := json.Unmarshal(body, &root); err != nil {
+		return nil, fmt.Errorf("the service root is not valid JSON: %v", err)
+	}
+	if root.RedfishVersion == "" || !strings.HasPrefix(root.OdataID, "/redfish/v1") {
+		return nil, fmt.Errorf("the service root is not of a Redfish service")
+	}
+	return &root, nil
+}
+
+// vendor returns the vendor of the Redfish service, from the Vendor property of the service root
+// or else from its only Oem extension
+func (root *serviceRoot) vendor() string {
+	if root.Vendor != "" {
+		return root.Vendor
+	}
+	if len(root.Oem) == 1 {
+		for vendor := range root.Oem {
+			return vendor
+		}
+	}
+	return ""
+}
+
+// matchConnectionMethod returns the connection method of ConnectionMethodConf with the plugin configured
+// for the vendor in DiscoveryConf.VendorPlugins, or with the GRF plugin for the other vendors
+func matchConnectionMethod(vendor string, connectionMethods map[string]agmodel.ConnectionMethod) *agmodel.OdataID {
+	pluginID := defaultDiscoveryPluginID
+	for vendorName, vendorPluginID := range config.Data.DiscoveryConf.VendorPlugins {
+		if strings.EqualFold(vendorName, vendor) {
+			pluginID = vendorPluginID
+			break
+		}
+	}
+	for _, conf := range config.Data.ConnectionMethodConf {
+		// the connection method variant is in the format PluginType:PreferredAuthType:PluginID_FirmwareVersion
+		variant := strings.Split(conf.ConnectionMethodVariant, ":")
+		if conf.ConnectionMethodType != "Redfish" || len(variant) < 3 || variant[0] != "Compute" ||
+			!strings.EqualFold(strings.Split(variant[2], "_")[0], pluginID) {
+			continue
+		}
+		for connectionMethodURI, connectionMethod := range connectionMethods {
+			if connectionMethod.ConnectionMethodVariant == conf.ConnectionMethodVariant {
+				return &agmodel.OdataID{OdataID: connectionMethodURI}
+			}
+		}
+	}
+	return nil
+}
+
+// getConnectionMethods returns the connection methods by URI
+func (e *ExternalInterface) getConnectionMethods(ctx context.Context) (map[string]agmodel.ConnectionMethod, error) {
+	connectionMethodURIs, err := e.GetAllKeysFromTable(ctx, "ConnectionMethod")
+	if err != nil {
+		return nil, err
+	}
+	connectionMethods := make(map[string]agmodel.ConnectionMethod, len(connectionMethodURIs))
+	for _, connectionMethodURI := range connectionMethodURIs {
+		connectionMethod, err := e.GetConnectionMethod(ctx, connectionMethodURI)
+		if err != nil {
+			return nil, err
+		}
+		connectionMethods[connectionMethodURI] = connectionMethod
+	}
+	return connectionMethods, nil
+}
+
+// getAggregatedHosts returns the host names of the aggregation sources
+func (e *ExternalInterface) getAggregatedHosts(ctx context.Context) (map[string]bool, error) {
+	aggregationSourceURIs, err := e.GetAllKeysFromTable(ctx, "AggregationSource")
+	if err != nil {
+		return nil, err
+	}
+	hosts := make(map[string]bool, len(aggregationSourceURIs))
+	for _, aggregationSourceURI := range aggregationSourceURIs {
+		aggregationSource, err := e.GetAggregationSourceInfo(ctx, aggregationSourceURI)
+		if err != nil {
+			return nil, err
+		}
+		hosts[aggregationSource.HostName] = true
+	}
+	return hosts, nil
+}
+
+// discoveredHostName returns the host name of the aggregation source of the address,
+// the port is part of the host name when it is not the default one
+func discoveredHostName(address string, port int) string {
+	if port == defaultRedfishPort {
+		return address
+	}
+	return net.JoinHostPort(address, strconv.Itoa(port))
+}
+
+// discoveredSourceURI returns the URI of the discovered source of the host, the same host is always
+// discovered with the same URI
+func discoveredSourceURI(hostName string) string {
+	return DiscoveredSourcesURI + "/" + uuid.NewV5(uuid.NamespaceURL, hostName).String()
+}
+
+// DiscoverSources probes the Redfish service root on the addresses of the subnets, DiscoveryConf.WorkerPoolCount
+// addresses at a time. The Redfish services which are not aggregation sources are saved as discovered sources,
+// and with AutoAdd they are added through the AddAggregationSource workflow in subtasks of the task
+func (e *ExternalInterface) DiscoverSources(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	taskRequest := string(req.RequestBody)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: discoverSourcesTargetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	discoverRequest, addresses, perr := ParseDiscoverSourcesRequest(req.RequestBody)
+	if perr.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid discover sources request")
+		e.UpdateTask(ctx, fillTaskData(taskID, discoverSourcesTargetURI, taskRequest, perr, common.Exception, common.Critical, 100, http.MethodPost))
+		return perr
+	}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, discoverSourcesTargetURI, taskRequest, resp, common.Running, common.OK, 0, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	connectionMethods, err := e.getConnectionMethods(ctx)
+	if err != nil {
+		errMsg := "unable to get the connection methods: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	aggregatedHosts, err := e.getAggregatedHosts(ctx)
+	if err != nil {
+		errMsg := "unable to get the aggregation sources: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+
+	port := discoverRequest.Port
+	if port == 0 {
+		port = defaultRedfishPort
+	}
+	client := newDiscoveryClient()
+	roots := make([]*serviceRoot, len(addresses))
+	var percentComplete int32
+	completed := runInWorkerPool(config.Data.DiscoveryConf.WorkerPoolCount, len(addresses), func(i int) {
+		root, err := probeRedfishService(ctx, client, net.JoinHostPort(addresses[i], strconv.Itoa(port)))
+		if err != nil {
+			l.LogWithFields(ctx).Debugf("no Redfish service found on %s: %s", addresses[i], err.Error())
+			return
+		}
+		roots[i] = root
+	}, func(completed int) bool {
+		// the task is updated when the percentage changes, not after each of the probes
+		percent := int32(completed * 100 / len(addresses))
+		if percent == percentComplete {
+			return true
+		}
+		percentComplete = percent
+		err := e.UpdateTask(ctx, fillTaskData(taskID, discoverSourcesTargetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		return err == nil || err.Error() != common.Cancelling
+	})
+	if !completed {
+		l.LogWithFields(ctx).Info("discover sources task " + taskID + " is cancelled")
+		e.UpdateTask(ctx, fillTaskData(taskID, discoverSourcesTargetURI, taskRequest, resp, common.Cancelled, common.OK, percentComplete, http.MethodPost))
+		return resp
+	}
+
+	result := agresponse.DiscoverSourcesResponse{
+		AddressesScanned:  len(addresses),
+		DiscoveredSources: agmodel.OdataID{OdataID: DiscoveredSourcesURI},
+	}
+	var discoveredURIs []string
+	var discovered []agmodel.DiscoveredSource
+	for i, root := range roots {
+		if root == nil {
+			continue
+		}
+		hostName := discoveredHostName(addresses[i], port)
+		if aggregatedHosts[hostName] {
+			result.AlreadyAggregated++
+			continue
+		}
+		vendor := root.vendor()
+		source := agmodel.DiscoveredSource{
+			HostName:         hostName,
+			Vendor:           vendor,
+			Product:          root.Product,
+			RedfishVersion:   root.RedfishVersion,
+			ServiceUUID:      root.UUID,
+			ConnectionMethod: matchConnectionMethod(vendor, connectionMethods),
+			DiscoveredTime:   time.Now().UTC().Format(time.RFC3339),
+			DiscoveryTask:    taskID,
+		}
+		sourceURI := discoveredSourceURI(hostName)
+		if err := e.SaveDiscoveredSource(source, sourceURI); err != nil {
+			errMsg := "unable to save the discovered source: " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		}
+		discoveredURIs = append(discoveredURIs, sourceURI)
+		discovered = append(discovered, source)
+	}
+	result.Discovered = len(discovered)
+
+	if discoverRequest.AutoAdd {
+		result.Added = e.addDiscoveredSources(ctx, taskID, sessionUserName, discoverRequest.CredentialProfile, discoveredURIs, discovered)
+	}
+
+	commonResponse := response.Response{
+		OdataType: "#ActionResponse.v1_0_0.ActionResponse",
+		OdataID:   discoverSourcesTargetURI,
+		ID:        taskID,
+		Name:      "Discover Sources",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = fmt.Sprintf("%d Redfish services are discovered on the %d addresses, for more information please check the discovered sources in URI: %s",
+		result.Discovered, result.AddressesScanned, DiscoveredSourcesURI)
+	result.Response = commonResponse
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = result
+	e.UpdateTask(ctx, fillTaskData(taskID, discoverSourcesTargetURI, taskRequest, resp, common.Completed, common.OK, 100, http.MethodPost))
+	l.LogWithFields(ctx).Infof("discover sources task %s completed: %d addresses scanned, %d sources discovered, %d added",
+		taskID, result.AddressesScanned, result.Discovered, result.Added)
+	return resp
+}
+
+// addDiscoveredSources adds the discovered sources which have a connection method with the credentials of the profile,
+// in subtasks of the task, DiscoveryConf.WorkerPoolCount sources at a time. The added sources are removed from the discovered sources, and the error is kept
+// in the others. It returns the number of added sources
+func (e *ExternalInterface) addDiscoveredSources(ctx context.Context, taskID, sessionUserName, credentialProfile string,
+	discoveredURIs []string, discovered []agmodel.DiscoveredSource) int {
+	profile, _ := config.GetCredentialProfile(credentialProfile)
+	var indexes []int
+	for i, source := range discovered {
+		if source.ConnectionMethod != nil {
+			indexes = append(indexes, i)
+		}
+	}
+	rows := make([]agmodel.ImportSourcesReportRow, len(indexes))
+	runInWorkerPool(config.Data.DiscoveryConf.WorkerPoolCount, len(indexes), func(i int) {
+		source := discovered[indexes[i]]
+		rows[i] = e.importSource(ctx, taskID, sessionUserName, i, AggregationSource{
+			HostName: source.HostName,
+			UserName: profile.UserName,
+			Password: string(profile.Password),
+			Links:    &Links{ConnectionMethod: &ConnectionMethod{OdataID: source.ConnectionMethod.OdataID}},
+		}, e.addDiscoveredSource)
+	}, nil)
+
+	var added int
+	for i, row := range rows {
+		sourceURI := discoveredURIs[indexes[i]]
+		if row.Result == importSucceeded {
+			added++
+			if err := e.DeleteDiscoveredSource(sourceURI); err != nil {
+				l.LogWithFields(ctx).Error("unable to remove the added discovered source " + sourceURI + ": " + err.Error())
+			}
+			continue
+		}
+		source := discovered[indexes[i]]
+		source.Message = row.Message
+		if err := e.SaveDiscoveredSource(source, sourceURI); err != nil {
+			l.LogWithFields(ctx).Error("unable to save the discovered source " + sourceURI + ": " + err.Error())
+		}
+	}
+	return added
+}
+
+// addDiscoveredSource adds the aggregation source through the AddAggregationSource workflow in the task,
+// the password is not kept in the task request since it can be of a credential profile
+func (e *ExternalInterface) addDiscoveredSource(ctx context.Context, taskID string, source AggregationSource) response.RPC {
+	maskedSource := source
+	maskedSource.Password = ""
+	reqBody, _ := json.Marshal(maskedSource)
+	var resp response.RPC
+	err := e.UpdateTask(ctx, fillTaskData(taskID, aggregationSourcesURI, string(reqBody), resp, common.Running, common.OK, 0, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: aggregationSourcesURI, UpdateTask: e.UpdateTask, TaskRequest: string(reqBody)}
+	return e.addAggregationSource(ctx, taskID, aggregationSourcesURI, string(reqBody), 0, source, taskInfo)
+}
+
+// GetAllDiscoveredSources returns the collection of the discovered sources
+func (e *ExternalInterface) GetAllDiscoveredSources(ctx context.Context) response.RPC {
+	sourceURIs, err := e.GetAllKeysFromTable(ctx, "DiscoveredSource")
+	if err != nil {
+		errorMessage := err.Error()
+		l.LogWithFields(ctx).Error("unable to get the discovered sources: " + errorMessage)
+		return common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, errorMessage, []interface{}{config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}, nil)
+	}
+	var members = make([]agresponse.ListMember, 0)
+	for _, sourceURI := range sourceURIs {
+		members = append(members, agresponse.ListMember{
+			OdataID: sourceURI,
+		})
+	}
+	commonResponse := response.Response{
+		OdataType:    "#DiscoveredSourceCollection.DiscoveredSourceCollection",
+		OdataID:      DiscoveredSourcesURI,
+		OdataContext: "/redfish/v1/$metadata#DiscoveredSourceCollection.DiscoveredSourceCollection",
+		Name:         "Discovered Sources",
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.List{
+			Response:     commonResponse,
+			MembersCount: len(members),
+			Members:      members,
+		},
+	}
+}
+
+// GetDiscoveredSource returns the discovered source of the URL
+func (e *ExternalInterface) GetDiscoveredSource(ctx context.Context, reqURL string) response.RPC {
+	sourceURI := strings.TrimSuffix(reqURL, "/")
+	source, err := e.GetDiscoveredSourceInfo(sourceURI)
+	if err != nil {
+		return discoveredSourceError(ctx, sourceURI, err)
+	}
+	commonResponse := response.Response{
+		OdataType:    "#DiscoveredSource.v1_0_0.DiscoveredSource",
+		OdataID:      sourceURI,
+		OdataContext: "/redfish/v1/$metadata#DiscoveredSource.DiscoveredSource",
+		ID:           sourceURI[strings.LastIndex(sourceURI, "/")+1:],
+		Name:         "Discovered Source",
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.DiscoveredSourceResponse{
+			Response:       commonResponse,
+			HostName:       source.HostName,
+			Vendor:         source.Vendor,
+			Product:        source.Product,
+			RedfishVersion: source.RedfishVersion,
+			ServiceUUID:    source.ServiceUUID,
+			DiscoveredTime: source.DiscoveredTime,
+			Message:        source.Message,
+			Links: agresponse.DiscoveredSourceLinks{
+				ConnectionMethod: source.ConnectionMethod,
+				DiscoveryTask:    agmodel.OdataID{OdataID: "/redfish/v1/TaskService/Tasks/" + source.DiscoveryTask},
+			},
+			Actions: agresponse.DiscoveredSourceActions{
+				Approve: agresponse.Action{Target: sourceURI + ApproveDiscoveredSourceAction},
+			},
+		},
+	}
+}
+
+// DeleteDiscoveredSourceInfo dismisses the discovered source of the URL
+func (e *ExternalInterface) DeleteDiscoveredSourceInfo(ctx context.Context, reqURL string) response.RPC {
+	sourceURI := strings.TrimSuffix(reqURL, "/")
+	if err := e.DeleteDiscoveredSource(sourceURI); err != nil {
+		return discoveredSourceError(ctx, sourceURI, err)
+	}
+	l.LogWithFields(ctx).Info("discovered source " + sourceURI + " is dismissed")
+	return response.RPC{
+		StatusCode:    http.StatusNoContent,
+		StatusMessage: response.ResourceRemoved,
+	}
+}
+
+// GetApprovedAggregationSource returns the URI of the discovered source approved by the DiscoveredSource.Approve
+// action of the URL, and the aggregation source to add for it. The returned response is not empty when the
+// request is not valid
+func (e *ExternalInterface) GetApprovedAggregationSource(ctx context.Context, reqURL string, body []byte) (string, AggregationSource, response.RPC) {
+	var aggregationSource AggregationSource
+	sourceURI := strings.TrimSuffix(strings.TrimSuffix(reqURL, "/"), ApproveDiscoveredSourceAction)
+	var req ApproveDiscoveredSourceRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		errMsg := "unable to parse the approve request: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return sourceURI, aggregationSource, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(body, req)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return sourceURI, aggregationSource, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		l.LogWithFields(ctx).Error(errMsg)
+		return sourceURI, aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	switch {
+	case req.CredentialProfile != "" && (req.UserName != "" || req.Password != ""):
+		errMsg := "error: the credentials must be given either in UserName and Password or in CredentialProfile"
+		l.LogWithFields(ctx).Error(errMsg)
+		return sourceURI, aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"CredentialProfile", "UserName"}, nil)
+	case req.CredentialProfile != "":
+		profile, ok := config.GetCredentialProfile(req.CredentialProfile)
+		if !ok {
+			errMsg := "error: unknown credential profile " + req.CredentialProfile
+			l.LogWithFields(ctx).Error(errMsg)
+			return sourceURI, aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{req.CredentialProfile, "CredentialProfile"}, nil)
+		}
+		aggregationSource.UserName = profile.UserName
+		aggregationSource.Password = string(profile.Password)
+	case req.UserName == "" || req.Password == "":
+		errMsg := "error: mandatory fields UserName and Password missing in the request"
+		l.LogWithFields(ctx).Error(errMsg)
+		return sourceURI, aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"UserName Password"}, nil)
+	default:
+		aggregationSource.UserName = req.UserName
+		aggregationSource.Password = req.Password
+	}
+
+	source, derr := e.GetDiscoveredSourceInfo(sourceURI)
+	if derr != nil {
+		return sourceURI, aggregationSource, discoveredSourceError(ctx, sourceURI, derr)
+	}
+	aggregationSource.HostName = source.HostName
+	switch {
+	case req.Links != nil && req.Links.ConnectionMethod != nil && req.Links.ConnectionMethod.OdataID != "":
+		aggregationSource.Links = req.Links
+	case source.ConnectionMethod != nil:
+		aggregationSource.Links = &Links{ConnectionMethod: &ConnectionMethod{OdataID: source.ConnectionMethod.OdataID}}
+	default:
+		errMsg := "error: no connection method is matched for the discovered source, ConnectionMethod is required"
+		l.LogWithFields(ctx).Error(errMsg)
+		return sourceURI, aggregationSource, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ConnectionMethod"}, nil)
+	}
+	return sourceURI, aggregationSource, response.RPC{}
+}
+
+// ApproveDiscoveredSource adds the approved discovered source as an aggregation source through
+// the AddAggregationSource workflow in the task, the discovered source is removed once it is added
+func (e *ExternalInterface) ApproveDiscoveredSource(ctx context.Context, taskID, discoveredSourceURI string, aggregationSource AggregationSource) response.RPC {
+	resp := e.addDiscoveredSource(ctx, taskID, aggregationSource)
+	if resp.StatusCode == http.StatusCreated {
+		if err := e.DeleteDiscoveredSource(discoveredSourceURI); err != nil {
+			l.LogWithFields(ctx).Error("unable to remove the added discovered source " + discoveredSourceURI + ": " + err.Error())
+		}
+		return resp
+	}
+	source, err := e.GetDiscoveredSourceInfo(discoveredSourceURI)
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to get the discovered source " + discoveredSourceURI + ": " + err.Error())
+		return resp
+	}
+	source.Message = getErrorMessage(resp)
+	if err := e.SaveDiscoveredSource(source, discoveredSourceURI); err != nil {
+		l.LogWithFields(ctx).Error("unable to save the discovered source " + discoveredSourceURI + ": " + err.Error())
+	}
+	return resp
+}
+
+// discoveredSourceError returns the response of an error while accessing the discovered source
+func discoveredSourceError(ctx context.Context, sourceURI string, err *errors.Error) response.RPC {
+	errMsg := "unable to get the discovered source: " + err.Error()
+	l.LogWithFields(ctx).Error(errMsg)
+	if err.ErrNo() == errors.DBKeyNotFound {
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"DiscoveredSource", sourceURI}, nil)
+	}
+	return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+}
@@ -0,0 +1,441 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+// mockRedfishService returns a mock Redfish service with the service root
+func mockRedfishService(root map[string]interface{}) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redfish/v1/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(root)
+	}))
+}
+
+var mockHPEServiceRoot = map[string]interface{}{
+	"@odata.id":      "/redfish/v1/",
+	"RedfishVersion": "1.6.0",
+	"UUID":           "aa4dc9d6-8ad6-4a4e-b6c6-6e0e4a1e2e8f",
+	"Product":        "ProLiant DL360 Gen10",
+	"Oem":            map[string]interface{}{"Hpe": map[string]interface{}{}},
+}
+
+// mockDiscoveredSources is a mock of the discovered sources table
+type mockDiscoveredSources struct {
+	lock    sync.Mutex
+	sources map[string]agmodel.DiscoveredSource
+}
+
+func (m *mockDiscoveredSources) save(source agmodel.DiscoveredSource, uri string) *errors.Error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sources[uri] = source
+	return nil
+}
+
+func (m *mockDiscoveredSources) get(uri string) (agmodel.DiscoveredSource, *errors.Error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	source, ok := m.sources[uri]
+	if !ok {
+		return source, errors.PackError(errors.DBKeyNotFound, "no data with the key "+uri+" found")
+	}
+	return source, nil
+}
+
+func (m *mockDiscoveredSources) delete(uri string) *errors.Error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.sources[uri]; !ok {
+		return errors.PackError(errors.DBKeyNotFound, "no data with the key "+uri+" found")
+	}
+	delete(m.sources, uri)
+	return nil
+}
+
+func getMockDiscoveryInterface(store *mockDiscoveredSources, aggregatedHost string) *ExternalInterface {
+	p := getMockExternalInterface()
+	p.SaveDiscoveredSource = store.save
+	p.GetDiscoveredSourceInfo = store.get
+	p.DeleteDiscoveredSource = store.delete
+	p.GetAllKeysFromTable = func(ctx context.Context, table string) ([]string, error) {
+		switch table {
+		case "ConnectionMethod":
+			return []string{
+				"/redfish/v1/AggregationService/ConnectionMethods/7ff3bd97-c41c-5de0-937d-85d390691b73",
+				"/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069",
+			}, nil
+		case "AggregationSource":
+			return []string{"/redfish/v1/AggregationService/AggregationSources/36474ba4-a201-46aa-badf-d8104da418e8"}, nil
+		case "DiscoveredSource":
+			store.lock.Lock()
+			defer store.lock.Unlock()
+			var uris []string
+			for uri := range store.sources {
+				uris = append(uris, uri)
+			}
+			return uris, nil
+		}
+		return nil, fmt.Errorf("Table not found")
+	}
+	p.GetAggregationSourceInfo = func(ctx context.Context, reqURI string) (agmodel.AggregationSource, *errors.Error) {
+		return agmodel.AggregationSource{HostName: aggregatedHost}, nil
+	}
+	return p
+}
+
+func TestExpandSubnets(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.DiscoveryConf.AllowedSubnets = []string{"127.0.1.0/24"}
+	tests := []struct {
+		name    string
+		subnets []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "address",
+			subnets: []string{"10.0.0.1"},
+			want:    []string{"10.0.0.1"},
+		},
+		{
+			name:    "subnet without network and broadcast addresses",
+			subnets: []string{"10.0.0.0/30"},
+			want:    []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:    "point to point subnet",
+			subnets: []string{"10.0.0.0/31"},
+			want:    []string{"10.0.0.0", "10.0.0.1"},
+		},
+		{
+			name:    "overlapping subnets",
+			subnets: []string{"10.0.0.0/30", "10.0.0.2", "fd00::1"},
+			want:    []string{"10.0.0.1", "10.0.0.2", "fd00::1"},
+		},
+		{
+			name:    "subnet larger than the limit",
+			subnets: []string{"10.0.0.0/24"},
+			wantErr: true,
+		},
+		{
+			name:    "subnets larger than the limit",
+			subnets: []string{"10.0.0.0/26", "10.0.1.0/26", "10.0.2.0/26"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid address",
+			subnets: []string{"bmc.example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid subnet",
+			subnets: []string{"10.0.0.0/33"},
+			wantErr: true,
+		},
+		{
+			name:    "loopback address",
+			subnets: []string{"127.0.0.1"},
+			wantErr: true,
+		},
+		{
+			name:    "link-local address",
+			subnets: []string{"169.254.169.254"},
+			wantErr: true,
+		},
+		{
+			name:    "IPv6 link-local address",
+			subnets: []string{"fe80::1"},
+			wantErr: true,
+		},
+		{
+			name:    "subnet overlapping the restricted subnets",
+			subnets: []string{"10.233.63.252/30"},
+			wantErr: true,
+		},
+		{
+			name:    "allowed loopback address",
+			subnets: []string{"127.0.1.1"},
+			want:    []string{"127.0.1.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandSubnets(tt.subnets, 128)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandSubnets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("expandSubnets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDiscoverSourcesRequest(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr int32
+	}{
+		{name: "subnets", body: `{"Subnets":["10.0.0.0/30","10.0.1.1"]}`, want: 3},
+		{name: "auto add", body: `{"Subnets":["10.0.0.1"],"Port":8443,"AutoAdd":true,"CredentialProfile":"default"}`, want: 1},
+		{name: "invalid json", body: `{`, wantErr: http.StatusBadRequest},
+		{name: "unknown property", body: `{"subnets":["10.0.0.1"]}`, wantErr: http.StatusBadRequest},
+		{name: "without subnets", body: `{"Subnets":[]}`, wantErr: http.StatusBadRequest},
+		{name: "invalid port", body: `{"Subnets":["10.0.0.1"],"Port":70000}`, wantErr: http.StatusBadRequest},
+		{name: "auto add without profile", body: `{"Subnets":["10.0.0.1"],"AutoAdd":true}`, wantErr: http.StatusBadRequest},
+		{name: "unknown profile", body: `{"Subnets":["10.0.0.1"],"CredentialProfile":"other"}`, wantErr: http.StatusBadRequest},
+		{name: "too many addresses", body: `{"Subnets":["10.0.0.0/16"]}`, wantErr: http.StatusBadRequest},
+		{name: "restricted address", body: `{"Subnets":["169.254.169.254"]}`, wantErr: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, addresses, resp := ParseDiscoverSourcesRequest([]byte(tt.body))
+			if resp.StatusCode != tt.wantErr {
+				t.Fatalf("ParseDiscoverSourcesRequest() status code = %v, want %v", resp.StatusCode, tt.wantErr)
+			}
+			if len(addresses) != tt.want {
+				t.Errorf("ParseDiscoverSourcesRequest() addresses = %v, want %d addresses", addresses, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbeRedfishService(t *testing.T) {
+	config.SetUpMockConfig(t)
+	redfishService := mockRedfishService(mockHPEServiceRoot)
+	defer redfishService.Close()
+	otherService := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html></html>"))
+	}))
+	defer otherService.Close()
+	closedService := httptest.NewTLSServer(http.NotFoundHandler())
+	closedService.Close()
+
+	client := newDiscoveryClient()
+	if _, err := probeRedfishService(context.TODO(), client, redfishService.Listener.Addr().String()); err == nil {
+		t.Errorf("probeRedfishService() of the restricted address %s did not fail", redfishService.URL)
+	}
+	config.Data.DiscoveryConf.AllowedSubnets = []string{"127.0.0.0/8"}
+	root, err := probeRedfishService(context.TODO(), client, redfishService.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("probeRedfishService() error = %v", err)
+	}
+	if root.RedfishVersion != "1.6.0" || root.Product != "ProLiant DL360 Gen10" || root.vendor() != "Hpe" {
+		t.Errorf("probeRedfishService() = %+v", root)
+	}
+	for _, server := range []*httptest.Server{otherService, closedService} {
+		if _, err := probeRedfishService(context.TODO(), client, server.Listener.Addr().String()); err == nil {
+			t.Errorf("probeRedfishService() of %s did not fail", server.URL)
+		}
+	}
+}
+
+func TestMatchConnectionMethod(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.ConnectionMethodConf = []config.ConnectionMethodConf{
+		{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:GRF_v2.0.0"},
+		{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:ILO_v2.0.0"},
+	}
+	connectionMethods := map[string]agmodel.ConnectionMethod{
+		"/redfish/v1/AggregationService/ConnectionMethods/grf": {ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:GRF_v2.0.0"},
+		"/redfish/v1/AggregationService/ConnectionMethods/ilo": {ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:ILO_v2.0.0"},
+	}
+	tests := []struct {
+		vendor string
+		want   string
+	}{
+		{vendor: "HPE", want: "/redfish/v1/AggregationService/ConnectionMethods/ilo"},
+		{vendor: "hpe", want: "/redfish/v1/AggregationService/ConnectionMethods/ilo"},
+		{vendor: "Dell", want: "/redfish/v1/AggregationService/ConnectionMethods/grf"},
+		{vendor: "", want: "/redfish/v1/AggregationService/ConnectionMethods/grf"},
+	}
+	for _, tt := range tests {
+		got := matchConnectionMethod(tt.vendor, connectionMethods)
+		if got == nil || got.OdataID != tt.want {
+			t.Errorf("matchConnectionMethod(%q) = %v, want %v", tt.vendor, got, tt.want)
+		}
+	}
+	config.Data.DiscoveryConf.VendorPlugins = map[string]string{"HPE": "XYZ"}
+	if got := matchConnectionMethod("HPE", connectionMethods); got != nil {
+		t.Errorf("matchConnectionMethod() = %v, want nil", got)
+	}
+}
+
+func TestExternalInterface_DiscoverSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.DiscoveryConf.AllowedSubnets = []string{"127.0.0.0/8"}
+	config.Data.ConnectionMethodConf = []config.ConnectionMethodConf{
+		{ConnectionMethodType: "Redfish", ConnectionMethodVariant: "Compute:BasicAuth:ILO_v2.0.0"},
+	}
+	redfishService := mockRedfishService(mockHPEServiceRoot)
+	defer redfishService.Close()
+	serviceURL, _ := url.Parse(redfishService.URL)
+	port, _ := strconv.Atoi(serviceURL.Port())
+	hostName := net.JoinHostPort("127.0.0.1", serviceURL.Port())
+
+	store := &mockDiscoveredSources{sources: make(map[string]agmodel.DiscoveredSource)}
+	p := getMockDiscoveryInterface(store, "10.0.0.1")
+	body, _ := json.Marshal(DiscoverSourcesRequest{Subnets: []string{"127.0.0.1", "127.0.0.2"}, Port: port})
+	resp := p.DiscoverSources(mockContext(), "someTask", "admin", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: body})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("DiscoverSources() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	result := resp.Body.(agresponse.DiscoverSourcesResponse)
+	if result.AddressesScanned != 2 || result.Discovered != 1 || result.AlreadyAggregated != 0 || result.Added != 0 {
+		t.Errorf("DiscoverSources() = %+v", result)
+	}
+	sourceURI := discoveredSourceURI(hostName)
+	source, ok := store.sources[sourceURI]
+	if !ok {
+		t.Fatalf("DiscoverSources() saved %v, want %v", store.sources, sourceURI)
+	}
+	if source.HostName != hostName || source.Vendor != "Hpe" || source.DiscoveryTask != "someTask" ||
+		source.ConnectionMethod == nil || source.ConnectionMethod.OdataID != "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d39069" {
+		t.Errorf("DiscoverSources() saved source = %+v", source)
+	}
+
+	// the aggregation sources are not discovered again
+	store.sources = make(map[string]agmodel.DiscoveredSource)
+	p = getMockDiscoveryInterface(store, hostName)
+	resp = p.DiscoverSources(mockContext(), "someTask", "admin", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: body})
+	result = resp.Body.(agresponse.DiscoverSourcesResponse)
+	if result.Discovered != 0 || result.AlreadyAggregated != 1 || len(store.sources) != 0 {
+		t.Errorf("DiscoverSources() = %+v, saved %v", result, store.sources)
+	}
+
+	// invalid request
+	resp = p.DiscoverSources(mockContext(), "someTask", "admin", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte(`{"Subnets":[]}`)})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("DiscoverSources() status code = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+}
+
+func TestExternalInterface_DiscoveredSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	sourceURI := discoveredSourceURI("10.0.0.2")
+	store := &mockDiscoveredSources{sources: map[string]agmodel.DiscoveredSource{
+		sourceURI: {HostName: "10.0.0.2", Vendor: "Hpe", DiscoveryTask: "someTask"},
+	}}
+	p := getMockDiscoveryInterface(store, "10.0.0.1")
+
+	resp := p.GetAllDiscoveredSources(mockContext())
+	if resp.StatusCode != http.StatusOK || resp.Body.(agresponse.List).MembersCount != 1 {
+		t.Errorf("GetAllDiscoveredSources() = %+v", resp)
+	}
+
+	resp = p.GetDiscoveredSource(mockContext(), sourceURI)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetDiscoveredSource() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	source := resp.Body.(agresponse.DiscoveredSourceResponse)
+	if source.HostName != "10.0.0.2" || source.Actions.Approve.Target != sourceURI+ApproveDiscoveredSourceAction ||
+		source.Links.DiscoveryTask.OdataID != "/redfish/v1/TaskService/Tasks/someTask" {
+		t.Errorf("GetDiscoveredSource() = %+v", source)
+	}
+
+	approveURL := sourceURI + ApproveDiscoveredSourceAction
+	tests := []struct {
+		name    string
+		url     string
+		body    string
+		want    AggregationSource
+		wantErr int32
+	}{
+		{
+			name: "credentials and connection method",
+			url:  approveURL,
+			body: `{"UserName":"admin","Password":"secret","Links":{"ConnectionMethod":{"@odata.id":"` + testConnectionMethod + `"}}}`,
+			want: AggregationSource{HostName: "10.0.0.2", UserName: "admin", Password: "secret",
+				Links: &Links{ConnectionMethod: &ConnectionMethod{OdataID: testConnectionMethod}}},
+		},
+		{
+			name:    "credential profile without connection method",
+			url:     approveURL,
+			body:    `{"CredentialProfile":"default"}`,
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "credentials and credential profile",
+			url:     approveURL,
+			body:    `{"UserName":"admin","Password":"secret","CredentialProfile":"default"}`,
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "without password",
+			url:     approveURL,
+			body:    `{"UserName":"admin"}`,
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "unknown discovered source",
+			url:     DiscoveredSourcesURI + "/other" + ApproveDiscoveredSourceAction,
+			body:    `{"UserName":"admin","Password":"secret"}`,
+			wantErr: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURI, got, resp := p.GetApprovedAggregationSource(mockContext(), tt.url, []byte(tt.body))
+			if resp.StatusCode != tt.wantErr {
+				t.Fatalf("GetApprovedAggregationSource() status code = %v, want %v", resp.StatusCode, tt.wantErr)
+			}
+			if tt.wantErr != 0 {
+				return
+			}
+			gotData, _ := json.Marshal(got)
+			wantData, _ := json.Marshal(tt.want)
+			if gotURI != sourceURI || string(gotData) != string(wantData) {
+				t.Errorf("GetApprovedAggregationSource() = %v, %s, want %v, %s", gotURI, gotData, sourceURI, wantData)
+			}
+		})
+	}
+
+	resp = p.DeleteDiscoveredSourceInfo(mockContext(), sourceURI)
+	if resp.StatusCode != http.StatusNoContent || len(store.sources) != 0 {
+		t.Errorf("DeleteDiscoveredSourceInfo() status code = %v, sources = %v", resp.StatusCode, store.sources)
+	}
+	resp = p.GetDiscoveredSource(mockContext(), sourceURI)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetDiscoveredSource() status code = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+}
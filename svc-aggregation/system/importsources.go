@@ -0,0 +1,361 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	// ImportSourcesReportsURI is the URI under which the reports of the AggregationService.ImportSources actions are served
+	ImportSourcesReportsURI = "/redfish/v1/AggregationService/ImportSourcesReports"
+	// ImportSourcesReportCSV is the name of the CSV representation of an import sources report
+	ImportSourcesReportCSV = "Report.csv"
+
+	importSourcesTargetURI = "/redfish/v1/AggregationService/Actions/AggregationService.ImportSources"
+	importSucceeded        = "Success"
+	importFailed           = "Failure"
+)
+
+// csvManifestColumns are the columns of a CSV manifest of the AggregationService.ImportSources action
+var csvManifestColumns = []string{"HostName", "UserName", "Password", "ConnectionMethod"}
+
+// ImportSourcesRequest is the request of the AggregationService.ImportSources action.
+// The manifest is either the list of the aggregation sources in Sources, in the format of
+// the AggregationSources POST request, or a CSV in CSVManifest with the header
+// HostName,UserName,Password,ConnectionMethod where ConnectionMethod is the @odata.id of the connection method
+type ImportSourcesRequest struct {
+	Sources     []AggregationSource `json:"Sources,omitempty"`
+	CSVManifest string              `json:"CSVManifest,omitempty"`
+}
+
+// ParseImportSourcesManifest returns the aggregation sources of the manifest in the ImportSources request.
+// The returned response is not empty when the request is not valid
+func ParseImportSourcesManifest(body []byte) ([]AggregationSource, response.RPC) {
+	var req ImportSourcesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		errMsg := "unable to parse the import sources request: " + err.Error()
+		return nil, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(body, req)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		return nil, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	switch {
+	case len(req.Sources) > 0 && req.CSVManifest != "":
+		errMsg := "error: the manifest must be given either in Sources or in CSVManifest"
+		return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"CSVManifest", "Sources"}, nil)
+	case req.CSVManifest != "":
+		sources, err := parseCSVManifest(req.CSVManifest)
+		if err != nil {
+			errMsg := "error: invalid CSV manifest: " + err.Error()
+			return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{"<CSV>", "CSVManifest"}, nil)
+		}
+		return sources, response.RPC{}
+	case len(req.Sources) > 0:
+		return req.Sources, response.RPC{}
+	}
+	errMsg := "error: the request has no aggregation source to import"
+	return nil, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Sources"}, nil)
+}
+
+// parseCSVManifest reads the aggregation sources of a CSV manifest, the columns can be in any order
+func parseCSVManifest(manifest string) ([]AggregationSource, error) {
+	reader := csv.NewReader(strings.NewReader(manifest))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("the manifest must have a header and at least one aggregation source")
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range csvManifestColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the header has no %s column", name)
+		}
+	}
+	if len(columns) != len(csvManifestColumns) {
+		return nil, fmt.Errorf("the header must have only the columns %s", strings.Join(csvManifestColumns, ","))
+	}
+	var sources []AggregationSource
+	for _, record := range records[1:] {
+		source := AggregationSource{
+			HostName: strings.TrimSpace(record[columns["HostName"]]),
+			UserName: record[columns["UserName"]],
+			Password: record[columns["Password"]],
+		}
+		if connectionMethod := strings.TrimSpace(record[columns["ConnectionMethod"]]); connectionMethod != "" {
+			source.Links = &Links{ConnectionMethod: &ConnectionMethod{OdataID: connectionMethod}}
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// ImportSources adds the aggregation sources of the manifest through the AddAggregationSource workflow,
+// ImportSourcesWorkerPoolCount sources at a time. Each source is added in a subtask of the task of the action,
+// and the per source result is saved in a report which is served under ImportSourcesReportsURI
+func (e *ExternalInterface) ImportSources(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	sources, perr := ParseImportSourcesManifest(req.RequestBody)
+	// the passwords in the manifest are not kept in the task
+	taskRequest := maskImportSourcesRequest(sources)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: importSourcesTargetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	if perr.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid import sources request")
+		e.UpdateTask(ctx, fillTaskData(taskID, importSourcesTargetURI, taskRequest, perr, common.Exception, common.Critical, 100, http.MethodPost))
+		return perr
+	}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, importSourcesTargetURI, taskRequest, resp, common.Running, common.OK, 0, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	rows := make([]agmodel.ImportSourcesReportRow, len(sources))
+	var percentComplete int32
+	addSource := func(ctx context.Context, subTaskID string, source AggregationSource) response.RPC {
+		body, _ := json.Marshal(source)
+		return e.AddAggregationSource(ctx, subTaskID, sessionUserName, &aggregatorproto.AggregatorRequest{
+			SessionToken: req.SessionToken,
+			RequestBody:  body,
+		})
+	}
+	completed := runInWorkerPool(config.Data.ImportSourcesWorkerPoolCount, len(sources), func(i int) {
+		rows[i] = e.importSource(ctx, taskID, sessionUserName, i, sources[i], addSource)
+	}, func(completed int) bool {
+		percentComplete = int32(completed * 100 / len(sources))
+		err := e.UpdateTask(ctx, fillTaskData(taskID, importSourcesTargetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		return err == nil || err.Error() != common.Cancelling
+	})
+	if !completed {
+		// the sources which are being added are completed, the remaining ones are not started
+		l.LogWithFields(ctx).Info("import sources task " + taskID + " is cancelled")
+		e.UpdateTask(ctx, fillTaskData(taskID, importSourcesTargetURI, taskRequest, resp, common.Cancelled, common.OK, percentComplete, http.MethodPost))
+		return resp
+	}
+
+	report := agmodel.ImportSourcesReport{
+		ID:      taskID,
+		Created: time.Now().UTC().Format(time.RFC3339),
+		Total:   len(rows),
+		Rows:    rows,
+	}
+	for _, row := range rows {
+		if row.Result == importSucceeded {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+	}
+	if serr := e.SaveImportSourcesReport(report); serr != nil {
+		errMsg := "error while saving the import sources report: " + serr.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+
+	taskStatus := common.OK
+	if report.Failed > 0 {
+		taskStatus = common.Warning
+	}
+	commonResponse := response.Response{
+		OdataType: "#ActionResponse.v1_0_0.ActionResponse",
+		OdataID:   importSourcesTargetURI,
+		ID:        taskID,
+		Name:      "Import Sources",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = fmt.Sprintf("%d of the %d aggregation sources are added, for more information please check the report or SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+		report.Succeeded, report.Total, taskID)
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = agresponse.ImportSourcesResponse{
+		Response:  commonResponse,
+		Total:     report.Total,
+		Succeeded: report.Succeeded,
+		Failed:    report.Failed,
+		Report:    agmodel.OdataID{OdataID: ImportSourcesReportsURI + "/" + taskID},
+	}
+	e.UpdateTask(ctx, fillTaskData(taskID, importSourcesTargetURI, taskRequest, resp, common.Completed, taskStatus, 100, http.MethodPost))
+	l.LogWithFields(ctx).Infof("import sources task %s completed: %d added, %d failed", taskID, report.Succeeded, report.Failed)
+	return resp
+}
+
+// importSource adds an aggregation source of the manifest with add in a subtask of the task
+func (e *ExternalInterface) importSource(ctx context.Context, taskID, sessionUserName string, index int, source AggregationSource,
+	add func(context.Context, string, AggregationSource) response.RPC) agmodel.ImportSourcesReportRow {
+	row := agmodel.ImportSourcesReportRow{
+		Row:      index + 1,
+		HostName: source.HostName,
+	}
+	subTaskURI, err := e.CreateChildTask(ctx, sessionUserName, taskID)
+	if err != nil {
+		row.Result = importFailed
+		row.StatusCode = http.StatusInternalServerError
+		row.Message = "unable to create the subtask: " + err.Error()
+		l.LogWithFields(ctx).Error(row.Message)
+		return row
+	}
+	subTaskURI = strings.TrimSuffix(subTaskURI, "/")
+	subTaskID := subTaskURI[strings.LastIndex(subTaskURI, "/")+1:]
+	row.SubTask = subTaskURI
+
+	var resp response.RPC
+	if missing := strings.TrimSpace(validateImportSource(source)); missing != "" {
+		errMsg := "error: mandatory field " + missing + " missing in the manifest"
+		l.LogWithFields(ctx).Error(errMsg)
+		taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: importSourcesTargetURI, UpdateTask: e.UpdateTask}
+		resp = common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{missing}, taskInfo)
+	} else {
+		resp = add(ctx, subTaskID, source)
+	}
+	row.StatusCode = resp.StatusCode
+	if resp.StatusCode == http.StatusCreated {
+		row.Result = importSucceeded
+		row.AggregationSource = resp.Header["Location"]
+		return row
+	}
+	row.Result = importFailed
+	row.Message = getErrorMessage(resp)
+	return row
+}
+
+// validateImportSource returns the mandatory fields which are missing in the aggregation source
+func validateImportSource(source AggregationSource) string {
+	var missing string
+	if source.HostName == "" {
+		missing += "HostName "
+	}
+	if source.UserName == "" {
+		missing += "UserName "
+	}
+	if source.Password == "" {
+		missing += "Password "
+	}
+	if source.Links == nil || source.Links.ConnectionMethod == nil || source.Links.ConnectionMethod.OdataID == "" {
+		missing += "ConnectionMethod "
+	}
+	return missing
+}
+
+// getErrorMessage returns the message of the error response
+func getErrorMessage(resp response.RPC) string {
+	errResp, ok := resp.Body.(response.CommonError)
+	if !ok {
+		return fmt.Sprintf("request failed with status code %d", resp.StatusCode)
+	}
+	if len(errResp.Error.MessageExtendedInfo) > 0 {
+		return errResp.Error.MessageExtendedInfo[0].Message
+	}
+	return errResp.Error.Message
+}
+
+// maskImportSourcesRequest returns the import sources request with the passwords removed
+func maskImportSourcesRequest(sources []AggregationSource) string {
+	masked := make([]AggregationSource, len(sources))
+	for i, source := range sources {
+		masked[i] = source
+		masked[i].Password = ""
+	}
+	data, _ := json.Marshal(ImportSourcesRequest{Sources: masked})
+	return string(data)
+}
+
+// GetImportSourcesReport returns the report of an AggregationService.ImportSources action,
+// the report is returned as CSV when the URL is of ImportSourcesReportCSV
+func (e *ExternalInterface) GetImportSourcesReport(ctx context.Context, reqURL string) response.RPC {
+	path := strings.TrimSuffix(strings.SplitN(reqURL, "?", 2)[0], "/")
+	id := strings.TrimPrefix(path, ImportSourcesReportsURI+"/")
+	asCSV := strings.HasSuffix(id, "/"+ImportSourcesReportCSV)
+	id = strings.TrimSuffix(id, "/"+ImportSourcesReportCSV)
+	report, err := e.GetImportSourcesReportInfo(id)
+	if err != nil {
+		errMsg := "unable to get the import sources report: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"ImportSourcesReport", id}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	reportURI := ImportSourcesReportsURI + "/" + id
+	if asCSV {
+		return response.RPC{
+			StatusCode:    http.StatusOK,
+			StatusMessage: response.Success,
+			Header: map[string]string{
+				"Content-Type":        "text/csv; charset=utf-8",
+				"Content-Disposition": "attachment; filename=\"import-sources-" + id + ".csv\"",
+			},
+			Body: importSourcesReportCSV(report),
+		}
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body: agresponse.ImportSourcesReportResponse{
+			OdataContext:      "/redfish/v1/$metadata#ImportSourcesReport.ImportSourcesReport",
+			OdataID:           reportURI,
+			ID:                id,
+			Name:              "Import Sources Report",
+			Created:           report.Created,
+			Task:              agmodel.OdataID{OdataID: "/redfish/v1/TaskService/Tasks/" + id},
+			Total:             report.Total,
+			Succeeded:         report.Succeeded,
+			Failed:            report.Failed,
+			Rows:              report.Rows,
+			AdditionalDataURI: reportURI + "/" + ImportSourcesReportCSV,
+		},
+	}
+}
+
+// importSourcesReportCSV writes the rows of the report as CSV
+func importSourcesReportCSV(report agmodel.ImportSourcesReport) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"Row", "HostName", "Result", "StatusCode", "AggregationSource", "SubTask", "Message"})
+	for _, row := range report.Rows {
+		writer.Write([]string{strconv.Itoa(row.Row), row.HostName, row.Result, strconv.Itoa(int(row.StatusCode)),
+			row.AggregationSource, row.SubTask, row.Message})
+	}
+	writer.Flush()
+	return buf.String()
+}
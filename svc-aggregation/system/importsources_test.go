@@ -0,0 +1,240 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const testConnectionMethod = "/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d3906"
+
+func TestParseImportSourcesManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    map[string]interface{}
+		want    []AggregationSource
+		wantErr int32
+	}{
+		{
+			name: "sources manifest",
+			body: map[string]interface{}{
+				"Sources": []map[string]interface{}{
+					{"HostName": "10.0.0.1", "UserName": "admin", "Password": "password",
+						"Links": map[string]interface{}{"ConnectionMethod": map[string]string{"@odata.id": testConnectionMethod}}},
+				},
+			},
+			want: []AggregationSource{
+				{HostName: "10.0.0.1", UserName: "admin", Password: "password",
+					Links: &Links{ConnectionMethod: &ConnectionMethod{OdataID: testConnectionMethod}}},
+			},
+		},
+		{
+			name: "csv manifest with columns in any order",
+			body: map[string]interface{}{
+				"CSVManifest": "UserName,Password,ConnectionMethod,HostName\nadmin,password," + testConnectionMethod + ",10.0.0.1\nadmin,password,,10.0.0.2\n",
+			},
+			want: []AggregationSource{
+				{HostName: "10.0.0.1", UserName: "admin", Password: "password",
+					Links: &Links{ConnectionMethod: &ConnectionMethod{OdataID: testConnectionMethod}}},
+				{HostName: "10.0.0.2", UserName: "admin", Password: "password"},
+			},
+		},
+		{
+			name: "both manifests",
+			body: map[string]interface{}{
+				"Sources":     []map[string]interface{}{{"HostName": "10.0.0.1"}},
+				"CSVManifest": "HostName,UserName,Password,ConnectionMethod\n10.0.0.1,admin,password,\n",
+			},
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "csv manifest without column",
+			body:    map[string]interface{}{"CSVManifest": "HostName,UserName,Password\n10.0.0.1,admin,password\n"},
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "csv manifest with unknown column",
+			body:    map[string]interface{}{"CSVManifest": "HostName,UserName,Password,ConnectionMethod,Rack\n10.0.0.1,admin,password,,1\n"},
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "csv manifest without sources",
+			body:    map[string]interface{}{"CSVManifest": "HostName,UserName,Password,ConnectionMethod\n"},
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "unknown property",
+			body:    map[string]interface{}{"sources": []map[string]interface{}{{"HostName": "10.0.0.1"}}},
+			wantErr: http.StatusBadRequest,
+		},
+		{
+			name:    "empty manifest",
+			body:    map[string]interface{}{},
+			wantErr: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.body)
+			got, resp := ParseImportSourcesManifest(body)
+			if resp.StatusCode != tt.wantErr {
+				t.Fatalf("ParseImportSourcesManifest() status code = %v, want %v", resp.StatusCode, tt.wantErr)
+			}
+			gotData, _ := json.Marshal(got)
+			wantData, _ := json.Marshal(tt.want)
+			if string(gotData) != string(wantData) {
+				t.Errorf("ParseImportSourcesManifest() = %s, want %s", gotData, wantData)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_ImportSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	var (
+		lock        sync.Mutex
+		saved       agmodel.ImportSourcesReport
+		taskRequest string
+		subTasks    int
+	)
+	p := getMockExternalInterface()
+	p.SaveImportSourcesReport = func(report agmodel.ImportSourcesReport) *errors.Error {
+		saved = report
+		return nil
+	}
+	p.UpdateTask = func(ctx context.Context, task common.TaskData) error {
+		if task.TaskID == "someTask" {
+			taskRequest = task.TaskRequest
+		}
+		return nil
+	}
+	p.CreateChildTask = func(ctx context.Context, sessionID, taskID string) (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		subTasks++
+		return "/redfish/v1/TaskService/Tasks/" + taskID + "/SubTasks/someSubTaskID", nil
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"CSVManifest": "HostName,UserName,Password,ConnectionMethod\n" +
+			"10.0.0.1,admin,secret,\n" +
+			"10.0.0.2,,secret," + testConnectionMethod + "\n" +
+			",admin,secret," + testConnectionMethod + "\n",
+	})
+	resp := p.ImportSources(mockContext(), "someTask", "admin", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: body})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ImportSources() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	result := resp.Body.(agresponse.ImportSourcesResponse)
+	if result.Total != 3 || result.Failed != 3 || result.Succeeded != 0 {
+		t.Errorf("ImportSources() total = %d, failed = %d, succeeded = %d", result.Total, result.Failed, result.Succeeded)
+	}
+	if result.Report.OdataID != ImportSourcesReportsURI+"/someTask" {
+		t.Errorf("ImportSources() report = %v", result.Report.OdataID)
+	}
+	if subTasks != 3 {
+		t.Errorf("ImportSources() created %d subtasks, want 3", subTasks)
+	}
+	if len(saved.Rows) != 3 || saved.Failed != 3 {
+		t.Fatalf("ImportSources() saved report = %+v", saved)
+	}
+	for i, missing := range []string{"ConnectionMethod", "UserName", "HostName"} {
+		row := saved.Rows[i]
+		if row.Row != i+1 || row.Result != importFailed || row.StatusCode != http.StatusBadRequest || !strings.Contains(row.Message, missing) {
+			t.Errorf("ImportSources() row %d = %+v, want missing %s", i+1, row, missing)
+		}
+		if row.SubTask != "/redfish/v1/TaskService/Tasks/someTask/SubTasks/someSubTaskID" {
+			t.Errorf("ImportSources() row %d subtask = %v", i+1, row.SubTask)
+		}
+	}
+	if strings.Contains(taskRequest, "secret") {
+		t.Errorf("ImportSources() task request has the passwords: %s", taskRequest)
+	}
+
+	// invalid manifest
+	resp = p.ImportSources(mockContext(), "someTask", "admin", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte("{")})
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("ImportSources() status code = %v, want %v", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	// unable to save the report
+	p.SaveImportSourcesReport = func(report agmodel.ImportSourcesReport) *errors.Error {
+		return errors.PackError(errors.UndefinedErrorType, "unable to save")
+	}
+	resp = p.ImportSources(mockContext(), "someTask", "admin", &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: body})
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("ImportSources() status code = %v, want %v", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
+
+func TestExternalInterface_GetImportSourcesReport(t *testing.T) {
+	config.SetUpMockConfig(t)
+	p := getMockExternalInterface()
+	p.GetImportSourcesReportInfo = func(id string) (agmodel.ImportSourcesReport, *errors.Error) {
+		if id != "someTask" {
+			return agmodel.ImportSourcesReport{}, errors.PackError(errors.DBKeyNotFound, "not found")
+		}
+		return agmodel.ImportSourcesReport{
+			ID:        id,
+			Total:     2,
+			Succeeded: 1,
+			Failed:    1,
+			Rows: []agmodel.ImportSourcesReportRow{
+				{Row: 1, HostName: "10.0.0.1", Result: importSucceeded, StatusCode: http.StatusCreated,
+					AggregationSource: "/redfish/v1/AggregationService/AggregationSources/someSource"},
+				{Row: 2, HostName: "10.0.0.2", Result: importFailed, StatusCode: http.StatusConflict, Message: "error: \"10.0.0.2\" already added"},
+			},
+		}, nil
+	}
+
+	resp := p.GetImportSourcesReport(mockContext(), ImportSourcesReportsURI+"/someTask")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetImportSourcesReport() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	report := resp.Body.(agresponse.ImportSourcesReportResponse)
+	if report.ID != "someTask" || len(report.Rows) != 2 || report.AdditionalDataURI != ImportSourcesReportsURI+"/someTask/Report.csv" {
+		t.Errorf("GetImportSourcesReport() = %+v", report)
+	}
+
+	resp = p.GetImportSourcesReport(mockContext(), ImportSourcesReportsURI+"/someTask/Report.csv")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetImportSourcesReport() status code = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	want := "Row,HostName,Result,StatusCode,AggregationSource,SubTask,Message\n" +
+		"1,10.0.0.1,Success,201,/redfish/v1/AggregationService/AggregationSources/someSource,,\n" +
+		"2,10.0.0.2,Failure,409,,,\"error: \"\"10.0.0.2\"\" already added\"\n"
+	if resp.Body.(string) != want {
+		t.Errorf("GetImportSourcesReport() = %q, want %q", resp.Body, want)
+	}
+	if resp.Header["Content-Type"] != "text/csv; charset=utf-8" {
+		t.Errorf("GetImportSourcesReport() content type = %v", resp.Header["Content-Type"])
+	}
+
+	resp = p.GetImportSourcesReport(mockContext(), ImportSourcesReportsURI+"/otherTask")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GetImportSourcesReport() status code = %v, want %v", resp.StatusCode, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,504 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	// InventoryHistoryAction is the URI suffix of the Odim.InventoryHistory action of a server
+	InventoryHistoryAction = "/Actions/Oem/Odim.InventoryHistory"
+
+	inventoryTriggerAddCompute  = "AddCompute"
+	inventoryTriggerRediscovery = "Rediscovery"
+	inventoryTriggerResync      = "Resync"
+)
+
+var (
+	// NextInventoryRevisionID function pointer for the agmodel.NextInventoryRevisionID
+	NextInventoryRevisionID = agmodel.NextInventoryRevisionID
+	// SaveInventoryRevision function pointer for the agmodel.SaveInventoryRevision
+	SaveInventoryRevision = agmodel.SaveInventoryRevision
+	// SaveInventoryResourceContent function pointer for the agmodel.SaveInventoryResourceContent
+	SaveInventoryResourceContent = agmodel.SaveInventoryResourceContent
+	// DeleteInventoryRevision function pointer for the agmodel.DeleteInventoryRevision
+	DeleteInventoryRevision = agmodel.DeleteInventoryRevision
+	// DeleteInventoryResourceContents function pointer for the agmodel.DeleteInventoryResourceContents
+	DeleteInventoryResourceContents = agmodel.DeleteInventoryResourceContents
+)
+
+// InventoryHistoryRequest is the optional request of the Odim.InventoryHistory action,
+// the differences between the two revisions are returned when they are given
+type InventoryHistoryRequest struct {
+	FromRevision int `json:"FromRevision"`
+	ToRevision   int `json:"ToRevision"`
+}
+
+// recordInventoryRevision adds a revision of the inventory of the BMC stored in the in-memory DB to
+// its history when the inventory is changed since the latest revision, prunes the revisions which are
+// not retained anymore and publishes the events of the added and removed hardware components.
+// The history is best effort, a failure is logged and does not fail the operation which wrote the inventory
+func (e *ExternalInterface) recordInventoryRevision(ctx context.Context, deviceUUID, trigger string) {
+	keys, dbErr := e.GetAllMatchingDetails("*", deviceUUID, common.InMemory)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to record the inventory revision of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+		return
+	}
+	resourceKeys, _ := inventoryResourceKeys(keys)
+	contents := make(map[string]string, len(resourceKeys))
+	resources := make(map[string]string, len(resourceKeys))
+	for _, key := range resourceKeys {
+		table, uri, _ := strings.Cut(key, ":")
+		content, dbErr := e.GetResource(ctx, table, uri)
+		if dbErr != nil {
+			// the resource may be removed while the revision is recorded
+			l.LogWithFields(ctx).Warn("unable to get the stored " + key + ": " + dbErr.Error())
+			continue
+		}
+		hash := resourceHash(content)
+		resources[key] = hash
+		contents[hash] = content
+	}
+
+	revisions, dbErr := e.GetInventoryRevisions(deviceUUID)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to record the inventory revision of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+		return
+	}
+	revision := agmodel.InventoryRevision{
+		Created:   time.Now().UTC().Format(time.RFC3339),
+		Trigger:   trigger,
+		Resources: resources,
+	}
+	var latestID int
+	storedHashes := make(map[string]bool)
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		latestID = latest.ID
+		revision.Added, revision.Removed, revision.Changed = compareInventoryRevisions(latest.Resources, resources)
+		if len(revision.Added) == 0 && len(revision.Removed) == 0 && len(revision.Changed) == 0 {
+			return
+		}
+		for _, stored := range revisions {
+			for _, hash := range stored.Resources {
+				storedHashes[hash] = true
+			}
+		}
+	}
+	// the inventory of the BMC may be written concurrently, for example by a rediscovery and a resync,
+	// the ID is taken from a counter so that the concurrent revisions do not overwrite each other
+	revision.ID, dbErr = NextInventoryRevisionID(deviceUUID, latestID)
+	if dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to record the inventory revision of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+		return
+	}
+	for hash, content := range contents {
+		if storedHashes[hash] {
+			continue
+		}
+		if dbErr := SaveInventoryResourceContent(deviceUUID, hash, content); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to record the inventory revision of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+			return
+		}
+	}
+	if dbErr := SaveInventoryRevision(deviceUUID, revision); dbErr != nil {
+		l.LogWithFields(ctx).Error("unable to record the inventory revision of the BMC with ID " + deviceUUID + ": " + dbErr.Error())
+		return
+	}
+	l.LogWithFields(ctx).Infof("inventory revision %d of the BMC with ID %s is recorded", revision.ID, deviceUUID)
+
+	pruneInventoryRevisions(ctx, deviceUUID, append(revisions, revision))
+	// the components of the first revision are the ones of the added server
+	if len(revisions) > 0 {
+		e.publishHardwareComponentEvents(ctx, revision.Added, agmessagebus.HardwareComponentAdded)
+		e.publishHardwareComponentEvents(ctx, revision.Removed, agmessagebus.HardwareComponentRemoved)
+	}
+}
+
+// compareInventoryRevisions returns the URIs of the resources added, removed and changed
+// between the resources of two revisions keyed by Table:URI
+func compareInventoryRevisions(from, to map[string]string) ([]string, []string, []string) {
+	var added, removed, changed []string
+	for key, hash := range to {
+		_, uri, _ := strings.Cut(key, ":")
+		previousHash, ok := from[key]
+		if !ok {
+			added = append(added, uri)
+		} else if previousHash != hash {
+			changed = append(changed, uri)
+		}
+	}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			_, uri, _ := strings.Cut(key, ":")
+			removed = append(removed, uri)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(changed)
+	return added, removed, changed
+}
+
+// pruneInventoryRevisions deletes the revisions which are not retained anymore
+// along with the contents of the resources which are referenced only by them
+func pruneInventoryRevisions(ctx context.Context, deviceUUID string, revisions []agmodel.InventoryRevision) {
+	conf := config.Data.InventoryHistoryConf
+	expired := expiredInventoryRevisions(revisions, conf.MaxRevisions, conf.RetentionDays, time.Now())
+	if len(expired) == 0 {
+		return
+	}
+	expiredIDs := make(map[int]bool, len(expired))
+	for _, revision := range expired {
+		if err := DeleteInventoryRevision(deviceUUID, revision.ID); err != nil {
+			l.LogWithFields(ctx).Error("unable to delete the inventory revision " + strconv.Itoa(revision.ID) + " of the BMC with ID " + deviceUUID + ": " + err.Error())
+			return
+		}
+		expiredIDs[revision.ID] = true
+	}
+	retainedHashes := make(map[string]bool)
+	for _, revision := range revisions {
+		if expiredIDs[revision.ID] {
+			continue
+		}
+		for _, hash := range revision.Resources {
+			retainedHashes[hash] = true
+		}
+	}
+	var unreferenced []string
+	for _, revision := range expired {
+		for _, hash := range revision.Resources {
+			if !retainedHashes[hash] {
+				retainedHashes[hash] = true
+				unreferenced = append(unreferenced, hash)
+			}
+		}
+	}
+	if err := DeleteInventoryResourceContents(deviceUUID, unreferenced); err != nil {
+		l.LogWithFields(ctx).Error("unable to delete the inventory resource contents of the BMC with ID " + deviceUUID + ": " + err.Error())
+	}
+}
+
+// expiredInventoryRevisions returns the revisions, sorted by ID, which exceed the maximum number of
+// revisions or are older than the retention period, the latest revision is always retained
+func expiredInventoryRevisions(revisions []agmodel.InventoryRevision, maxRevisions, retentionDays int, now time.Time) []agmodel.InventoryRevision {
+	var expired []agmodel.InventoryRevision
+	oldest := now.AddDate(0, 0, -retentionDays)
+	for i, revision := range revisions[:len(revisions)-1] {
+		if len(revisions)-i > maxRevisions {
+			expired = append(expired, revision)
+			continue
+		}
+		if created, err := time.Parse(time.RFC3339, revision.Created); err == nil && created.Before(oldest) {
+			expired = append(expired, revision)
+		}
+	}
+	return expired
+}
+
+// publishHardwareComponentEvents publishes an event of the eventType for each of the resources
+// which are hardware components as per the InventoryHistoryConf.HardwareComponentResources
+func (e *ExternalInterface) publishHardwareComponentEvents(ctx context.Context, uris []string, eventType string) {
+	for _, uri := range uris {
+		if !isHardwareComponent(uri) {
+			continue
+		}
+		if err := e.EventNotification(ctx, uri, eventType, inventoryCollectionType(uri), agmessagebus.InitMQSCom()); err != nil {
+			l.LogWithFields(ctx).Error("unable to publish the " + eventType + " event of " + uri + ": " + err.Error())
+		}
+	}
+}
+
+// isHardwareComponent reports whether the resource is a member of one of the
+// collections listed in the InventoryHistoryConf.HardwareComponentResources
+func isHardwareComponent(uri string) bool {
+	segments := strings.Split(strings.TrimSuffix(uri, "/"), "/")
+	if len(segments) < 2 {
+		return false
+	}
+	collection := segments[len(segments)-2]
+	for _, resource := range config.Data.InventoryHistoryConf.HardwareComponentResources {
+		if resource == collection {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInventoryHistory returns the revisions of the inventory of the BMC of the server targeted by the
+// Odim.InventoryHistory action, and the differences between two of them when they are requested
+func (e *ExternalInterface) GetInventoryHistory(ctx context.Context, req *aggregatorproto.AggregatorRequest) response.RPC {
+	systemURI := strings.TrimSuffix(strings.TrimSuffix(req.URL, "/"), InventoryHistoryAction)
+	systemID := systemURI[strings.LastIndex(systemURI, "/")+1:]
+	deviceUUID, _, found := strings.Cut(systemID, ".")
+	if !found {
+		errMsg := "error: invalid system ID " + systemID
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"ComputerSystem", systemURI}, nil)
+	}
+	if _, err := e.GetResource(ctx, "ComputerSystem", systemURI); err != nil {
+		errMsg := "unable to get the system " + systemURI + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"ComputerSystem", systemURI}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	historyReq, resp := parseInventoryHistoryRequest(req.RequestBody)
+	if resp.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid inventory history request for " + systemURI)
+		return resp
+	}
+	revisions, err := e.GetInventoryRevisions(deviceUUID)
+	if err != nil {
+		errMsg := "unable to get the inventory revisions of " + systemURI + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	historyResp := agresponse.InventoryHistoryResponse{
+		Response: response.Response{
+			OdataType: "#OdimInventoryHistory.v1_0_0.OdimInventoryHistory",
+			OdataID:   systemURI + InventoryHistoryAction,
+			ID:        "InventoryHistory",
+			Name:      "Inventory History",
+		},
+		Revisions: make([]agresponse.InventoryRevisionSummary, 0, len(revisions)),
+	}
+	revisionsByID := make(map[int]agmodel.InventoryRevision, len(revisions))
+	for _, revision := range revisions {
+		revisionsByID[revision.ID] = revision
+		historyResp.Revisions = append(historyResp.Revisions, agresponse.InventoryRevisionSummary{
+			ID:      revision.ID,
+			Created: revision.Created,
+			Trigger: revision.Trigger,
+			Added:   nonNilStrings(revision.Added),
+			Removed: nonNilStrings(revision.Removed),
+			Changed: nonNilStrings(revision.Changed),
+		})
+	}
+	if historyReq.FromRevision != 0 {
+		from, ok := revisionsByID[historyReq.FromRevision]
+		if !ok {
+			return inventoryRevisionNotFound(ctx, historyReq.FromRevision, "FromRevision")
+		}
+		to, ok := revisionsByID[historyReq.ToRevision]
+		if !ok {
+			return inventoryRevisionNotFound(ctx, historyReq.ToRevision, "ToRevision")
+		}
+		diff, err := e.getInventoryDiff(deviceUUID, from, to)
+		if err != nil {
+			errMsg := "unable to get the inventory differences of " + systemURI + ": " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		}
+		historyResp.Diff = diff
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          historyResp,
+	}
+}
+
+// parseInventoryHistoryRequest returns the request of the Odim.InventoryHistory action,
+// the returned response is not empty when the request is not valid
+func parseInventoryHistoryRequest(body []byte) (InventoryHistoryRequest, response.RPC) {
+	var req InventoryHistoryRequest
+	if len(body) == 0 {
+		return req, response.RPC{}
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		errMsg := "unable to parse the inventory history request: " + err.Error()
+		return req, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(body, req)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		return req, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	switch {
+	case req.FromRevision != 0 && req.ToRevision == 0:
+		errMsg := "error: mandatory field ToRevision missing in the request"
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"ToRevision"}, nil)
+	case req.FromRevision == 0 && req.ToRevision != 0:
+		errMsg := "error: mandatory field FromRevision missing in the request"
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"FromRevision"}, nil)
+	}
+	return req, response.RPC{}
+}
+
+func inventoryRevisionNotFound(ctx context.Context, id int, property string) response.RPC {
+	errMsg := "error: the inventory revision " + strconv.Itoa(id) + " is not retained"
+	l.LogWithFields(ctx).Error(errMsg)
+	return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{strconv.Itoa(id), property}, nil)
+}
+
+// getInventoryDiff returns the resources added, removed and changed between two revisions
+func (e *ExternalInterface) getInventoryDiff(deviceUUID string, from, to agmodel.InventoryRevision) (*agresponse.InventoryDiff, *errors.Error) {
+	diff := agresponse.InventoryDiff{
+		FromRevision: from.ID,
+		ToRevision:   to.ID,
+		Added:        []agresponse.InventoryResourceDiff{},
+		Removed:      []agresponse.InventoryResourceDiff{},
+		Changed:      []agresponse.InventoryResourceChange{},
+	}
+	var keys []string
+	for key := range from.Resources {
+		keys = append(keys, key)
+	}
+	for key := range to.Resources {
+		if _, ok := from.Resources[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, uri, _ := strings.Cut(key, ":")
+		fromHash, inFrom := from.Resources[key]
+		toHash, inTo := to.Resources[key]
+		switch {
+		case inFrom && inTo && fromHash == toHash:
+			continue
+		case !inFrom:
+			resource, err := e.getInventoryResourceContent(deviceUUID, toHash)
+			if err != nil {
+				return nil, err
+			}
+			diff.Added = append(diff.Added, agresponse.InventoryResourceDiff{OdataID: uri, Resource: resource})
+		case !inTo:
+			resource, err := e.getInventoryResourceContent(deviceUUID, fromHash)
+			if err != nil {
+				return nil, err
+			}
+			diff.Removed = append(diff.Removed, agresponse.InventoryResourceDiff{OdataID: uri, Resource: resource})
+		default:
+			previous, err := e.getInventoryResourceContent(deviceUUID, fromHash)
+			if err != nil {
+				return nil, err
+			}
+			current, err := e.getInventoryResourceContent(deviceUUID, toHash)
+			if err != nil {
+				return nil, err
+			}
+			changes := diffJSON("", previous, current, []agresponse.InventoryChange{})
+			diff.Changed = append(diff.Changed, agresponse.InventoryResourceChange{OdataID: uri, Changes: changes})
+		}
+	}
+	return &diff, nil
+}
+
+func (e *ExternalInterface) getInventoryResourceContent(deviceUUID, hash string) (interface{}, *errors.Error) {
+	content, err := e.GetInventoryResourceContent(deviceUUID, hash)
+	if err != nil {
+		return nil, err
+	}
+	var resource interface{}
+	if jerr := json.Unmarshal([]byte(content), &resource); jerr != nil {
+		return nil, errors.PackError(errors.JSONUnmarshalFailed, jerr)
+	}
+	return resource, nil
+}
+
+// diffJSON appends the changes between two JSON values to changes, the paths of the
+// changes are JSON pointers relative to the path of the values
+func diffJSON(path string, from, to interface{}, changes []agresponse.InventoryChange) []agresponse.InventoryChange {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		toValue, ok := to.(map[string]interface{})
+		if !ok {
+			break
+		}
+		var keys []string
+		for key := range fromValue {
+			keys = append(keys, key)
+		}
+		for key := range toValue {
+			if _, ok := fromValue[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propertyPath := path + "/" + escapeJSONPointer(key)
+			previous, inFrom := fromValue[key]
+			current, inTo := toValue[key]
+			switch {
+			case !inFrom:
+				changes = append(changes, agresponse.InventoryChange{Op: "add", Path: propertyPath, Value: current})
+			case !inTo:
+				changes = append(changes, agresponse.InventoryChange{Op: "remove", Path: propertyPath, PreviousValue: previous})
+			default:
+				changes = diffJSON(propertyPath, previous, current, changes)
+			}
+		}
+		return changes
+	case []interface{}:
+		toValue, ok := to.([]interface{})
+		if !ok {
+			break
+		}
+		for i := 0; i < len(fromValue) || i < len(toValue); i++ {
+			elementPath := path + "/" + strconv.Itoa(i)
+			switch {
+			case i >= len(fromValue):
+				changes = append(changes, agresponse.InventoryChange{Op: "add", Path: elementPath, Value: toValue[i]})
+			case i >= len(toValue):
+				changes = append(changes, agresponse.InventoryChange{Op: "remove", Path: elementPath, PreviousValue: fromValue[i]})
+			default:
+				changes = diffJSON(elementPath, fromValue[i], toValue[i], changes)
+			}
+		}
+		return changes
+	}
+	if !reflect.DeepEqual(from, to) {
+		changes = append(changes, agresponse.InventoryChange{Op: "replace", Path: path, Value: to, PreviousValue: from})
+	}
+	return changes
+}
+
+// escapeJSONPointer escapes a property name as a reference token of a JSON pointer
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
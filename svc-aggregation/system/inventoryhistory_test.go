@@ -0,0 +1,371 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+func TestCompareInventoryRevisions(t *testing.T) {
+	from := map[string]string{
+		"Memory:/redfish/v1/Systems/uuid.1/Memory/1":                   "hash1",
+		"Memory:/redfish/v1/Systems/uuid.1/Memory/2":                   "hash2",
+		"NetworkAdapters:/redfish/v1/Chassis/uuid.1/NetworkAdapters/1": "hash3",
+	}
+	to := map[string]string{
+		"Memory:/redfish/v1/Systems/uuid.1/Memory/1":                   "hash1",
+		"Memory:/redfish/v1/Systems/uuid.1/Memory/3":                   "hash4",
+		"NetworkAdapters:/redfish/v1/Chassis/uuid.1/NetworkAdapters/1": "hash5",
+	}
+	added, removed, changed := compareInventoryRevisions(from, to)
+	if !reflect.DeepEqual(added, []string{"/redfish/v1/Systems/uuid.1/Memory/3"}) {
+		t.Errorf("compareInventoryRevisions() added = %v", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"/redfish/v1/Systems/uuid.1/Memory/2"}) {
+		t.Errorf("compareInventoryRevisions() removed = %v", removed)
+	}
+	if !reflect.DeepEqual(changed, []string{"/redfish/v1/Chassis/uuid.1/NetworkAdapters/1"}) {
+		t.Errorf("compareInventoryRevisions() changed = %v", changed)
+	}
+}
+
+func TestExpiredInventoryRevisions(t *testing.T) {
+	now := time.Now().UTC()
+	revision := func(id int, age time.Duration) agmodel.InventoryRevision {
+		return agmodel.InventoryRevision{ID: id, Created: now.Add(-age).Format(time.RFC3339)}
+	}
+	revisions := []agmodel.InventoryRevision{
+		revision(1, 100*24*time.Hour),
+		revision(2, 50*24*time.Hour),
+		revision(3, 40*24*time.Hour),
+		revision(4, 24*time.Hour),
+		revision(5, time.Hour),
+	}
+	ids := func(revisions []agmodel.InventoryRevision) []int {
+		var ids []int
+		for _, revision := range revisions {
+			ids = append(ids, revision.ID)
+		}
+		return ids
+	}
+	if got := ids(expiredInventoryRevisions(revisions, 3, 90, now)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expiredInventoryRevisions() = %v, want [1 2]", got)
+	}
+	if got := ids(expiredInventoryRevisions(revisions, 10, 45, now)); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expiredInventoryRevisions() = %v, want [1 2]", got)
+	}
+	// the latest revision is retained even when it is older than the retention period
+	if got := ids(expiredInventoryRevisions(revisions[:1], 10, 1, now)); len(got) != 0 {
+		t.Errorf("expiredInventoryRevisions() = %v, want the latest revision retained", got)
+	}
+}
+
+func TestIsHardwareComponent(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := map[string]bool{
+		"/redfish/v1/Systems/uuid.1/Memory/DIMM1":              true,
+		"/redfish/v1/Chassis/uuid.1/NetworkAdapters/1/":        true,
+		"/redfish/v1/Systems/uuid.1/Memory":                    false,
+		"/redfish/v1/Systems/uuid.1/EthernetInterfaces/1":      false,
+		"/redfish/v1/Systems/uuid.1/Storage/1/Drives/0":        true,
+		"/redfish/v1/Systems/uuid.1/Processors/1/SubProcessor": false,
+	}
+	for uri, want := range tests {
+		if got := isHardwareComponent(uri); got != want {
+			t.Errorf("isHardwareComponent(%s) = %v, want %v", uri, got, want)
+		}
+	}
+}
+
+func TestParseInventoryHistoryRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int32
+	}{
+		{name: "no body", body: "", want: 0},
+		{name: "both revisions", body: `{"FromRevision":1,"ToRevision":2}`, want: 0},
+		{name: "malformed", body: `{"FromRevision":"1"}`, want: http.StatusBadRequest},
+		{name: "unknown property", body: `{"fromRevision":1,"ToRevision":2}`, want: http.StatusBadRequest},
+		{name: "missing ToRevision", body: `{"FromRevision":1}`, want: http.StatusBadRequest},
+		{name: "missing FromRevision", body: `{"ToRevision":2}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, resp := parseInventoryHistoryRequest([]byte(tt.body)); resp.StatusCode != tt.want {
+				t.Errorf("parseInventoryHistoryRequest() status = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	var from, to interface{}
+	json.Unmarshal([]byte(`{"Id":"1","CapacityMiB":16384,"Status":{"State":"Enabled"},"Location":{"a/b":1},"Ports":["1","2"],"Old":true}`), &from)
+	json.Unmarshal([]byte(`{"Id":"1","CapacityMiB":32768,"Status":{"State":"Absent"},"Location":{"a/b":2},"Ports":["1"],"New":"x"}`), &to)
+	want := []agresponse.InventoryChange{
+		{Op: "replace", Path: "/CapacityMiB", Value: float64(32768), PreviousValue: float64(16384)},
+		{Op: "replace", Path: "/Location/a~1b", Value: float64(2), PreviousValue: float64(1)},
+		{Op: "add", Path: "/New", Value: "x"},
+		{Op: "remove", Path: "/Old", PreviousValue: true},
+		{Op: "remove", Path: "/Ports/1", PreviousValue: "2"},
+		{Op: "replace", Path: "/Status/State", Value: "Absent", PreviousValue: "Enabled"},
+	}
+	if got := diffJSON("", from, to, []agresponse.InventoryChange{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffJSON() = %v, want %v", got, want)
+	}
+}
+
+// mockInventoryHistory is the inventory history of a BMC stored in the on-disk DB
+type mockInventoryHistory struct {
+	revisions map[int]agmodel.InventoryRevision
+	contents  map[string]string
+	counter   int
+}
+
+func (h *mockInventoryHistory) getInventoryRevisions(deviceUUID string) ([]agmodel.InventoryRevision, *errors.Error) {
+	var revisions []agmodel.InventoryRevision
+	for _, revision := range h.revisions {
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].ID < revisions[j].ID })
+	return revisions, nil
+}
+
+func (h *mockInventoryHistory) getInventoryResourceContent(deviceUUID, hash string) (string, *errors.Error) {
+	content, ok := h.contents[hash]
+	if !ok {
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the key "+hash+" found")
+	}
+	return content, nil
+}
+
+// stubInventoryHistoryDB replaces the writes of the inventory history with the mock history
+func stubInventoryHistoryDB(t *testing.T, h *mockInventoryHistory) {
+	nextInventoryRevisionID, saveInventoryRevision, saveInventoryResourceContent := NextInventoryRevisionID, SaveInventoryRevision, SaveInventoryResourceContent
+	deleteInventoryRevision, deleteInventoryResourceContents := DeleteInventoryRevision, DeleteInventoryResourceContents
+	t.Cleanup(func() {
+		NextInventoryRevisionID, SaveInventoryRevision, SaveInventoryResourceContent = nextInventoryRevisionID, saveInventoryRevision, saveInventoryResourceContent
+		DeleteInventoryRevision, DeleteInventoryResourceContents = deleteInventoryRevision, deleteInventoryResourceContents
+	})
+	NextInventoryRevisionID = func(deviceUUID string, latestID int) (int, *errors.Error) {
+		if h.counter == 0 {
+			h.counter = latestID
+		}
+		h.counter++
+		return h.counter, nil
+	}
+	SaveInventoryRevision = func(deviceUUID string, revision agmodel.InventoryRevision) *errors.Error {
+		h.revisions[revision.ID] = revision
+		return nil
+	}
+	SaveInventoryResourceContent = func(deviceUUID, hash, content string) *errors.Error {
+		h.contents[hash] = content
+		return nil
+	}
+	DeleteInventoryRevision = func(deviceUUID string, id int) *errors.Error {
+		delete(h.revisions, id)
+		return nil
+	}
+	DeleteInventoryResourceContents = func(deviceUUID string, hashes []string) *errors.Error {
+		for _, hash := range hashes {
+			delete(h.contents, hash)
+		}
+		return nil
+	}
+}
+
+func TestRecordInventoryRevision(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	systemURI := "/redfish/v1/Systems/" + resyncDeviceUUID + ".1"
+	b := newMockResyncBMC()
+	h := b.history
+	stubInventoryHistoryDB(t, h)
+	e := b.externalInterface()
+
+	// the first revision holds the inventory of the added server
+	e.recordInventoryRevision(ctx, resyncDeviceUUID, inventoryTriggerAddCompute)
+	first, ok := h.revisions[1]
+	if !ok || len(h.revisions) != 1 || first.Trigger != inventoryTriggerAddCompute || len(first.Resources) != len(b.stored) {
+		t.Fatalf("recordInventoryRevision() revisions = %+v, want the first revision of the inventory", h.revisions)
+	}
+	if len(h.contents) != len(b.stored) || len(b.events) != 0 {
+		t.Errorf("recordInventoryRevision() saved %d contents and published %v for the first revision", len(h.contents), b.events)
+	}
+
+	// the unchanged inventory is not recorded
+	e.recordInventoryRevision(ctx, resyncDeviceUUID, inventoryTriggerResync)
+	if len(h.revisions) != 1 {
+		t.Errorf("recordInventoryRevision() recorded %d revisions of an unchanged inventory", len(h.revisions))
+	}
+
+	// the hardware components added and removed are published
+	b.stored["Processors:"+systemURI+"/Processors/3"] = `{"@odata.id":"` + systemURI + `/Processors/3","Id":"3"}`
+	delete(b.stored, "Processors:"+systemURI+"/Processors/2")
+	b.stored["ComputerSystem:"+systemURI] = `{"@odata.id":"` + systemURI + `","Id":"1","PowerState":"Off"}`
+	e.recordInventoryRevision(ctx, resyncDeviceUUID, inventoryTriggerResync)
+	second, ok := h.revisions[2]
+	if !ok {
+		t.Fatalf("recordInventoryRevision() revisions = %+v, want the second revision", h.revisions)
+	}
+	if !reflect.DeepEqual(second.Added, []string{systemURI + "/Processors/3"}) ||
+		!reflect.DeepEqual(second.Removed, []string{systemURI + "/Processors/2"}) ||
+		!reflect.DeepEqual(second.Changed, []string{systemURI}) {
+		t.Errorf("recordInventoryRevision() second revision = %+v", second)
+	}
+	if len(h.contents) != len(b.stored)+2 {
+		t.Errorf("recordInventoryRevision() saved %d contents, want the contents of the new resources added", len(h.contents))
+	}
+	wantEvents := []string{
+		"HardwareComponentAdded " + systemURI + "/Processors/3",
+		"HardwareComponentRemoved " + systemURI + "/Processors/2",
+	}
+	if !reflect.DeepEqual(b.events, wantEvents) {
+		t.Errorf("recordInventoryRevision() published %v, want %v", b.events, wantEvents)
+	}
+
+	// the revisions recorded concurrently from the same latest revision get distinct IDs
+	e.GetInventoryRevisions = func(deviceUUID string) ([]agmodel.InventoryRevision, *errors.Error) {
+		return []agmodel.InventoryRevision{first, second}, nil
+	}
+	b.stored["ComputerSystem:"+systemURI] = `{"@odata.id":"` + systemURI + `","Id":"1","PowerState":"On"}`
+	e.recordInventoryRevision(ctx, resyncDeviceUUID, inventoryTriggerRediscovery)
+	e.recordInventoryRevision(ctx, resyncDeviceUUID, inventoryTriggerResync)
+	if h.revisions[3].Trigger != inventoryTriggerRediscovery || h.revisions[4].Trigger != inventoryTriggerResync {
+		t.Errorf("recordInventoryRevision() revisions = %+v, want the concurrent revisions 3 and 4", h.revisions)
+	}
+}
+
+func TestGetInventoryHistory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	systemURI := "/redfish/v1/Systems/" + resyncDeviceUUID + ".1"
+	memoryURI := systemURI + "/Memory/1"
+	b := newMockResyncBMC()
+	h := &mockInventoryHistory{
+		revisions: map[int]agmodel.InventoryRevision{
+			1: {
+				ID:        1,
+				Created:   "2023-01-01T00:00:00Z",
+				Trigger:   inventoryTriggerAddCompute,
+				Resources: map[string]string{"ComputerSystem:" + systemURI: "hash1"},
+			},
+			2: {
+				ID:        2,
+				Created:   "2023-01-02T00:00:00Z",
+				Trigger:   inventoryTriggerResync,
+				Resources: map[string]string{"ComputerSystem:" + systemURI: "hash2", "Memory:" + memoryURI: "hash3"},
+				Added:     []string{memoryURI},
+				Changed:   []string{systemURI},
+			},
+		},
+		contents: map[string]string{
+			"hash1": `{"Id":"1","PowerState":"On"}`,
+			"hash2": `{"Id":"1","PowerState":"Off"}`,
+			"hash3": `{"Id":"1","CapacityMiB":32768}`,
+		},
+	}
+	e := b.externalInterface()
+	e.GetInventoryRevisions = h.getInventoryRevisions
+	e.GetInventoryResourceContent = h.getInventoryResourceContent
+
+	resp := e.GetInventoryHistory(ctx, &aggregatorproto.AggregatorRequest{URL: systemURI + InventoryHistoryAction})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GetInventoryHistory() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	history := resp.Body.(agresponse.InventoryHistoryResponse)
+	if len(history.Revisions) != 2 || history.Revisions[0].Removed == nil || history.Diff != nil {
+		t.Errorf("GetInventoryHistory() = %+v, want the summaries of the 2 revisions", history)
+	}
+
+	resp = e.GetInventoryHistory(ctx, &aggregatorproto.AggregatorRequest{
+		URL:         systemURI + InventoryHistoryAction,
+		RequestBody: []byte(`{"FromRevision":1,"ToRevision":2}`),
+	})
+	history = resp.Body.(agresponse.InventoryHistoryResponse)
+	if resp.StatusCode != http.StatusOK || history.Diff == nil {
+		t.Fatalf("GetInventoryHistory() = %+v, want the differences of the revisions", resp)
+	}
+	wantDiff := agresponse.InventoryDiff{
+		FromRevision: 1,
+		ToRevision:   2,
+		Added: []agresponse.InventoryResourceDiff{
+			{OdataID: memoryURI, Resource: map[string]interface{}{"Id": "1", "CapacityMiB": float64(32768)}},
+		},
+		Removed: []agresponse.InventoryResourceDiff{},
+		Changed: []agresponse.InventoryResourceChange{
+			{OdataID: systemURI, Changes: []agresponse.InventoryChange{{Op: "replace", Path: "/PowerState", Value: "Off", PreviousValue: "On"}}},
+		},
+	}
+	if !reflect.DeepEqual(*history.Diff, wantDiff) {
+		t.Errorf("GetInventoryHistory() diff = %+v, want %+v", *history.Diff, wantDiff)
+	}
+
+	tests := []struct {
+		name          string
+		url           string
+		body          string
+		statusCode    int32
+		statusMessage string
+	}{
+		{
+			name:          "invalid system ID",
+			url:           "/redfish/v1/Systems/1" + InventoryHistoryAction,
+			statusCode:    http.StatusNotFound,
+			statusMessage: response.ResourceNotFound,
+		},
+		{
+			name:          "unknown system",
+			url:           "/redfish/v1/Systems/" + resyncDeviceUUID + ".2" + InventoryHistoryAction,
+			statusCode:    http.StatusNotFound,
+			statusMessage: response.ResourceNotFound,
+		},
+		{
+			name:          "revision not retained",
+			url:           systemURI + InventoryHistoryAction,
+			body:          `{"FromRevision":1,"ToRevision":3}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyValueNotInList,
+		},
+		{
+			name:          "missing ToRevision",
+			url:           systemURI + InventoryHistoryAction,
+			body:          `{"FromRevision":1}`,
+			statusCode:    http.StatusBadRequest,
+			statusMessage: response.PropertyMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := e.GetInventoryHistory(ctx, &aggregatorproto.AggregatorRequest{URL: tt.url, RequestBody: []byte(tt.body)})
+			if resp.StatusCode != tt.statusCode || resp.StatusMessage != tt.statusMessage {
+				t.Errorf("GetInventoryHistory() = %v %v, want %v %v", resp.StatusCode, resp.StatusMessage, tt.statusCode, tt.statusMessage)
+			}
+		})
+	}
+}
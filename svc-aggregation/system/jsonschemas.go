@@ -0,0 +1,110 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// jsonSchemasTable is the in-memory table from which svc-api serves the OEM schemas
+const jsonSchemasTable = "JsonSchemas"
+
+// savePluginJSONSchemas stores the json schemas published by the plugin, which are not
+// part of the standard schema store, so that they are served under /redfish/v1/JsonSchemas.
+// The schemas are optional for a plugin, so failures are only logged
+func savePluginJSONSchemas(ctx context.Context, req getResourceRequest) {
+	req.HTTPMethodType = http.MethodGet
+	req.OID = "/ODIM/v1/JsonSchemas"
+	body, _, _, err := contactPlugin(ctx, req, "error while getting the json schemas of plugin "+req.Plugin.ID+": ")
+	if err != nil {
+		l.LogWithFields(ctx).Info("plugin " + req.Plugin.ID + " does not publish any json schema: " + err.Error())
+		return
+	}
+	var schemaCollection struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := json.Unmarshal(body, &schemaCollection); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to unmarshal the json schemas of plugin " + req.Plugin.ID + ": " + err.Error())
+		return
+	}
+	for _, member := range schemaCollection.Members {
+		if member.OdataID == "" {
+			continue
+		}
+		savePluginJSONSchema(ctx, req, member.OdataID)
+	}
+}
+
+// savePluginJSONSchema gets the JsonSchemaFile resource from the plugin and
+// stores the schema document found at its english location
+func savePluginJSONSchema(ctx context.Context, req getResourceRequest, oid string) {
+	req.OID = oid
+	body, _, _, err := contactPlugin(ctx, req, "error while getting the json schema file "+oid+": ")
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return
+	}
+	var schemaFile struct {
+		ID       string `json:"Id"`
+		Location []struct {
+			Language string `json:"Language"`
+			URI      string `json:"Uri"`
+		} `json:"Location"`
+	}
+	if err := json.Unmarshal(body, &schemaFile); err != nil {
+		l.LogWithFields(ctx).Error("error while trying to unmarshal the json schema file " + oid + ": " + err.Error())
+		return
+	}
+	if schemaFile.ID == "" {
+		schemaFile.ID = oid[strings.LastIndex(oid, "/")+1:]
+	}
+	fileName := schemaFile.ID + ".json"
+	// the standard schemas are served from the schema store
+	if schemaStore := config.Data.JSONSchemaStorePath; schemaStore != "" {
+		if _, err := os.Stat(filepath.Join(schemaStore, fileName)); err == nil {
+			return
+		}
+	}
+	for _, location := range schemaFile.Location {
+		if location.Language != "en" || location.URI == "" {
+			continue
+		}
+		req.OID = location.URI
+		schema, _, _, err := contactPlugin(ctx, req, "error while getting the json schema "+location.URI+": ")
+		if err != nil {
+			l.LogWithFields(ctx).Error(err.Error())
+			return
+		}
+		if !json.Valid(schema) {
+			l.LogWithFields(ctx).Error("json schema " + location.URI + " of plugin " + req.Plugin.ID + " is not a valid json document")
+			return
+		}
+		if err := agmodel.GenericSave(schema, jsonSchemasTable, fileName); err != nil {
+			l.LogWithFields(ctx).Error("error while saving the json schema " + fileName + ": " + err.Error())
+		}
+		return
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
@@ -47,6 +48,14 @@ var (
 	podName               = os.Getenv("POD_NAME")
 )
 
+// plugin health metrics, updated on every health check of the plugins
+var (
+	pluginUp = metrics.NewGaugeVec("odim_plugin_up",
+		"Whether the plugin was reachable on the last health check (1) or not (0)", "plugin")
+	pluginFailedHealthChecks = metrics.NewGaugeVec("odim_plugin_failed_health_checks",
+		"Number of consecutive health checks the plugin failed", "plugin")
+)
+
 const (
 	// PluginHealthCheckActionID action id for logging
 	PluginHealthCheckActionID = "216"
@@ -101,6 +110,7 @@ func PerformPluginHealthCheck() {
 	phc := agcommon.PluginHealthCheckInterface{
 		DecryptPassword: DecryptWithPrivateKey,
 	}
+	monitoredPlugins := make(map[string]bool)
 	for {
 		phc.DupPluginConf()
 		DBInterface := agcommon.DBDataInterface{
@@ -110,6 +120,7 @@ func PerformPluginHealthCheck() {
 		if pluginList, err := GetAllPluginfunc(ctx, DBInterface); err != nil {
 			l.LogWithFields(ctx).Error("failed to get list of all plugins:", err.Error())
 		} else {
+			monitoredPlugins = removeDeletedPluginMetrics(monitoredPlugins, pluginList)
 			for _, plugin := range pluginList {
 				threadID := 1
 				ctxt := context.WithValue(ctx, common.ThreadName, common.CheckPluginStatus)
@@ -142,6 +153,57 @@ func checkPluginStatus(ctx context.Context, phc *agcommon.PluginHealthCheckInter
 			agcommon.SetPluginStatusRecord(plugin.ID, count+1)
 		}
 	}
+	setPluginHealthMetrics(plugin.ID, active)
+}
+
+// setPluginHealthMetrics updates the health metrics of the plugin with the result of its health check
+func setPluginHealthMetrics(pluginID string, active bool) {
+	up := 0.0
+	if active {
+		up = 1
+	}
+	pluginUp.Set(up, pluginID)
+	count, _ := GetPluginStatusRecord(pluginID)
+	pluginFailedHealthChecks.Set(float64(count), pluginID)
+}
+
+// removeDeletedPluginMetrics removes the health metrics of the plugins, which are
+// not in the plugin list anymore, and returns the IDs of the plugins in the list
+func removeDeletedPluginMetrics(monitoredPlugins map[string]bool, pluginList []agmodel.Plugin) map[string]bool {
+	plugins := make(map[string]bool, len(pluginList))
+	for _, plugin := range pluginList {
+		plugins[plugin.ID] = true
+	}
+	for pluginID := range monitoredPlugins {
+		if !plugins[pluginID] {
+			pluginUp.Delete(pluginID)
+			pluginFailedHealthChecks.Delete(pluginID)
+		}
+	}
+	return plugins
+}
+
+// CheckPluginsHealth reports the plugins which failed their last health check.
+// It is registered as the services.HealthPlugins check of the aggregation service
+func CheckPluginsHealth() error {
+	DBInterface := agcommon.DBDataInterface{
+		GetAllKeysFromTableFunc: agcommon.GetAllKeysFromTableFunc,
+		GetPluginData:           agmodel.GetPluginData,
+	}
+	pluginList, err := GetAllPluginfunc(context.TODO(), DBInterface)
+	if err != nil {
+		return fmt.Errorf("failed to get list of all plugins: %v", err)
+	}
+	var unreachable []string
+	for _, plugin := range pluginList {
+		if count, exist := GetPluginStatusRecord(plugin.ID); exist && count > 0 {
+			unreachable = append(unreachable, plugin.ID)
+		}
+	}
+	if len(unreachable) > 0 {
+		return fmt.Errorf("plugins %v failed their last health check", unreachable)
+	}
+	return nil
 }
 
 // SendPluginStartUpData is for sending the plugin startup data
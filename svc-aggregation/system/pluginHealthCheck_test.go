@@ -18,6 +18,8 @@
 package system
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -226,3 +228,30 @@ func TestSendPluginStartUpData(t *testing.T) {
 	assert.Nil(t, err, "There should be no error")
 
 }
+
+func TestCheckPluginsHealth(t *testing.T) {
+	defer func() {
+		GetAllPluginfunc = agcommon.GetAllPlugins
+		GetPluginStatusRecord = agcommon.GetPluginStatusRecord
+	}()
+	GetAllPluginfunc = func(ctx context.Context, dbData agcommon.DBDataInterface) ([]agmodel.Plugin, error) {
+		return []agmodel.Plugin{{ID: "GRF"}, {ID: "ILO"}, {ID: "URP"}}, nil
+	}
+	records := map[string]int{"GRF": 0, "ILO": 2}
+	GetPluginStatusRecord = func(pluginID string) (int, bool) {
+		count, exist := records[pluginID]
+		return count, exist
+	}
+	err := CheckPluginsHealth()
+	assert.NotNil(t, err, "plugin ILO failed its last health check")
+	assert.Contains(t, err.Error(), "ILO")
+	assert.NotContains(t, err.Error(), "GRF")
+
+	records["ILO"] = 0
+	assert.Nil(t, CheckPluginsHealth(), "all the plugins are healthy")
+
+	GetAllPluginfunc = func(ctx context.Context, dbData agcommon.DBDataInterface) ([]agmodel.Plugin, error) {
+		return nil, fmt.Errorf("DB error")
+	}
+	assert.NotNil(t, CheckPluginsHealth(), "plugin list can not be read")
+}
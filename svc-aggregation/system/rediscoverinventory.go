@@ -79,7 +79,7 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 	dbPluginConn := agmodel.DBPluginDataRead{
 		DBReadclient: agmodel.GetPluginDBConnection,
 	}
-	plugin, errs := agmodel.GetPluginData(target.PluginID, dbPluginConn)
+	plugin, errs := GetPluginData(target.PluginID, dbPluginConn)
 	if errs != nil {
 		genError(ctx, errs.Error(), &resp, http.StatusBadRequest, errors.ResourceNotFound, map[string]string{
 			"Content-type": "application/json; charset=utf-8",
@@ -179,9 +179,14 @@ func (e *ExternalInterface) RediscoverSystemInventory(ctx context.Context, devic
 		registriesEstimatedWork := int32(5)
 		progress = h.getAllRegistries(ctx, "", progress, registriesEstimatedWork, req)
 		agmodel.SaveBMCInventory(h.InventoryData)
+		if err := agmodel.SaveFilterIndexes(h.InventoryData, true); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to update index values of chassis and managers: " + err.Error())
+		}
 
 	}
 
+	e.recordInventoryRevision(ctx, deviceUUID, inventoryTriggerRediscovery)
+
 	var responseBody = map[string]string{
 		"UUID": deviceUUID,
 	}
@@ -259,23 +264,39 @@ func (e *ExternalInterface) RediscoverResources() error {
 
 }
 func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, target agmodel.Target) ([]byte, error) {
+	req, err := e.getTargetPluginRequest(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	req.OID = "/redfish/v1/Systems"
+
+	// Make the call to Plugin with above request
+	l.LogWithFields(ctx).Debugf("plugin contact request data for %s: %s", req.OID, string(req.Data))
+	body, _, _, err := contactPlugin(ctx, req, "error while trying to get the system collection details: ")
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get the system collection details")
+	}
+	return body, nil
+}
 
+// getTargetPluginRequest returns the request for getting the resources of the target from its plugin
+func (e *ExternalInterface) getTargetPluginRequest(ctx context.Context, target agmodel.Target) (getResourceRequest, error) {
+	var req getResourceRequest
 	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
 	if err != nil {
-		return nil, err
+		return req, err
 	}
 	target.Password = decryptedPasswordByte
 	// get the plugin information
 	dbPluginConn := agmodel.DBPluginDataRead{
 		DBReadclient: agmodel.GetPluginDBConnection,
 	}
-	plugin, errs := agmodel.GetPluginData(target.PluginID, dbPluginConn)
+	plugin, errs := GetPluginData(target.PluginID, dbPluginConn)
 	if errs != nil {
 		l.LogWithFields(ctx).Error(errs.Error())
-		return nil, errs
+		return req, errs
 	}
 
-	var req getResourceRequest
 	req.ContactClient = e.ContactClient
 	req.GetPluginStatus = e.GetPluginStatus
 	req.Plugin = plugin
@@ -291,7 +312,7 @@ func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, targe
 		l.LogWithFields(ctx).Debugf("plugin contact request data for %s: %s", req.OID, string(req.Data))
 		_, token, _, err := contactPlugin(ctx, req, "error while getting the details "+req.OID+": ")
 		if err != nil {
-			return nil, err
+			return req, err
 		}
 		req.Token = token
 	} else {
@@ -302,51 +323,46 @@ func (e *ExternalInterface) getTargetSystemCollection(ctx context.Context, targe
 
 	}
 
+	req.HTTPMethodType = http.MethodGet
 	req.DeviceUUID = target.DeviceUUID
 	req.DeviceInfo = target
-	req.OID = "/redfish/v1/Systems"
-
-	// Make the call to Plugin with above request
-	l.LogWithFields(ctx).Debugf("plugin contact request data for %s: %s", req.OID, string(req.Data))
-	body, _, _, err := contactPlugin(ctx, req, "error while trying to get the system collection details: ")
-	if err != nil {
-		return nil, fmt.Errorf("error while trying to get the system collection details")
-	}
-	return body, nil
+	req.BMCAddress = target.ManagerAddress
+	return req, nil
 }
 
 func (e *ExternalInterface) isServerRediscoveryRequired(ctx context.Context, deviceUUID string, systemKey string) bool {
 	systemKey = strings.TrimSuffix(systemKey, "/")
 	key := strings.Replace(systemKey, "/redfish/v1/Systems/", "/redfish/v1/Systems/"+deviceUUID+".", -1)
-	_, err := agmodel.GetResource(ctx, "ComputerSystem", key)
+	_, err := e.GetResource(ctx, "ComputerSystem", key)
 	if err != nil {
 		l.LogWithFields(ctx).Error(err.Error())
 		l.LogWithFields(ctx).Info("Rediscovery required for the server with UUID: " + deviceUUID)
 		return true
 
 	}
-	key = strings.Replace(systemKey, "Systems", "Chassis", -1)
-	keys, err := agmodel.GetAllMatchingDetails("Chassis", key, common.InMemory)
+	// the chassis and the managers of the BMC are stored with the ID of the BMC as prefix of their IDs
+	key = "/redfish/v1/Chassis/" + deviceUUID + "."
+	keys, err := e.GetAllMatchingDetails("Chassis", key, common.InMemory)
 	if err != nil || len(keys) == 0 {
 		l.LogWithFields(ctx).Info("Rediscovery required for the server with UUID: " + deviceUUID)
 		return true
 	}
 	for _, chassiskey := range keys {
-		if _, err = agmodel.GetResource(ctx, "Chassis", chassiskey); err != nil {
+		if _, err = e.GetResource(ctx, "Chassis", chassiskey); err != nil {
 			l.LogWithFields(ctx).Error(err.Error())
 			l.LogWithFields(ctx).Info("Rediscovery required for the server with UUID: " + deviceUUID)
 			return true
 		}
 	}
 
-	key = strings.Replace(systemKey, "Systems", "Managers", -1)
-	keys, err = agmodel.GetAllMatchingDetails("Managers", key, common.InMemory)
+	key = "/redfish/v1/Managers/" + deviceUUID + "."
+	keys, err = e.GetAllMatchingDetails("Managers", key, common.InMemory)
 	if err != nil || len(keys) == 0 {
 		l.LogWithFields(ctx).Info("Rediscovery required for the server with UUID: " + deviceUUID)
 		return true
 	}
 	for _, managerKey := range keys {
-		if _, err = agmodel.GetResource(ctx, "Managers", managerKey); err != nil {
+		if _, err = e.GetResource(ctx, "Managers", managerKey); err != nil {
 			l.LogWithFields(ctx).Error(err.Error())
 			l.LogWithFields(ctx).Info("Rediscovery required for the server with UUID: " + deviceUUID)
 			return true
@@ -267,6 +267,15 @@ func TestExternalInterface_RediscoverSystemInventory(t *testing.T) {
 		})
 	}
 }
+
+// getMockDBExternalInterface returns the mock interface reading the inventory stored in the in-memory DB
+func getMockDBExternalInterface() *ExternalInterface {
+	e := getMockExternalInterface()
+	e.GetResource = agmodel.GetResource
+	e.GetAllMatchingDetails = agmodel.GetAllMatchingDetails
+	return e
+}
+
 func TestExternalInterface_isServerRediscoveryRequired(t *testing.T) {
 	config.SetUpMockConfig(t)
 	defer func() {
@@ -332,7 +341,7 @@ func TestExternalInterface_isServerRediscoveryRequired(t *testing.T) {
 	}{
 		{
 			name: "Negative case: NO Inventory in db,  Resource discovery is required.",
-			e:    getMockExternalInterface(),
+			e:    getMockDBExternalInterface(),
 			args: args{
 				deviceUUID: "someUUID",
 				systemKey:  "/redfish/v1/Systems/1",
@@ -342,7 +351,7 @@ func TestExternalInterface_isServerRediscoveryRequired(t *testing.T) {
 		},
 		{
 			name: "Negative case: Only ComputerSystem Inventory in db,  Resource discovery is required.",
-			e:    getMockExternalInterface(),
+			e:    getMockDBExternalInterface(),
 			args: args{
 				deviceUUID: "ComputerSystem",
 				systemKey:  "/redfish/v1/Systems/1",
@@ -352,7 +361,7 @@ func TestExternalInterface_isServerRediscoveryRequired(t *testing.T) {
 		},
 		{
 			name: "Negative case: Only Chassis and System Inventory in db,  Resource discovery is required.",
-			e:    getMockExternalInterface(),
+			e:    getMockDBExternalInterface(),
 			args: args{
 				deviceUUID: "Chassis&System",
 				systemKey:  "/redfish/v1/Systems/1",
@@ -362,13 +371,13 @@ func TestExternalInterface_isServerRediscoveryRequired(t *testing.T) {
 		},
 		{
 			name: "Positive case: ComputerSystem, Chassis and  Manager Inventory in db,  Resource discovery not required.",
-			e:    getMockExternalInterface(),
+			e:    getMockDBExternalInterface(),
 			args: args{
 				deviceUUID: "Chassis&System&Manager",
 				systemKey:  "/redfish/v1/Systems/1",
 				updateFlag: true,
 			},
-			want: true,
+			want: false,
 		},
 	}
 	for _, tt := range tests {
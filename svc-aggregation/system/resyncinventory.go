@@ -0,0 +1,645 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+	"github.com/google/uuid"
+)
+
+const (
+	// InventoryResyncActionID action id
+	InventoryResyncActionID = "238"
+	// InventoryResyncActionName action name
+	InventoryResyncActionName = "InventoryResync"
+
+	// inventoryResyncCheckInterval is the interval at which the scheduler checks the due resyncs
+	inventoryResyncCheckInterval = time.Minute
+	// globalInventoryResync is the schedule key of the resync of all the servers
+	globalInventoryResync = ""
+	aggregatesURI         = "/redfish/v1/AggregationService/Aggregates/"
+	systemsCollectionURI  = "/redfish/v1/Systems"
+	inventoryResyncName   = "Inventory Resync"
+	inventoryResyncOp     = "InventoryResync"
+)
+
+// inventoryResyncResult is the result of the resync of the inventory of a BMC
+type inventoryResyncResult struct {
+	checked int
+	updated int
+	added   int
+	removed int
+	err     error
+}
+
+var (
+	// GetAllSystems function pointer for the agmodel.GetAllSystems
+	GetAllSystems = agmodel.GetAllSystems
+	// GetAggregate function pointer for the agmodel.GetAggregate
+	GetAggregate = agmodel.GetAggregate
+	// GetTarget function pointer for the agmodel.GetTarget
+	GetTarget = agmodel.GetTarget
+	// GetPluginData function pointer for the agmodel.GetPluginData
+	GetPluginData = agmodel.GetPluginData
+	// GetSystemOperationInfo function pointer for the agmodel.GetSystemOperationInfo
+	GetSystemOperationInfo = agmodel.GetSystemOperationInfo
+	// ClaimSystemOperation function pointer for the agmodel.SystemOperation.ClaimSystemOperation
+	ClaimSystemOperation = func(systemOperation agmodel.SystemOperation, systemURI string) (bool, *errors.Error) {
+		return systemOperation.ClaimSystemOperation(systemURI)
+	}
+	// ClaimInventoryResync function pointer for the agmodel.ClaimInventoryResync
+	ClaimInventoryResync = agmodel.ClaimInventoryResync
+	// DeleteSystemOperationInfo function pointer for the agmodel.DeleteSystemOperationInfo
+	DeleteSystemOperationInfo = agmodel.DeleteSystemOperationInfo
+	// SaveBMCInventory function pointer for the agmodel.SaveBMCInventory
+	SaveBMCInventory = agmodel.SaveBMCInventory
+	// SaveFilterIndexes function pointer for the agmodel.SaveFilterIndexes
+	SaveFilterIndexes = agmodel.SaveFilterIndexes
+	// UpdateIndex function pointer for the agmodel.UpdateIndex
+	UpdateIndex = agmodel.UpdateIndex
+)
+
+// ScheduleInventoryResync periodically resyncs the inventory of the servers with their BMCs,
+// the intervals of the resyncs of all the servers and of the servers of an aggregate
+// are read from the InventoryResyncConf at each check so that they can be reloaded.
+// Every instance of the service runs the scheduler, a due resync is run by the instance which claims it
+func (e *ExternalInterface) ScheduleInventoryResync() {
+	var lock sync.Mutex
+	running := make(map[string]bool)
+	lastRuns := make(map[string]time.Time)
+	for {
+		schedules := inventoryResyncSchedules()
+		for _, aggregateID := range dueInventoryResyncs(schedules, lastRuns, time.Now()) {
+			lock.Lock()
+			if running[aggregateID] {
+				// the previous resync of the schedule is not completed yet
+				lock.Unlock()
+				continue
+			}
+			running[aggregateID] = true
+			lock.Unlock()
+			go func(aggregateID string, interval time.Duration) {
+				defer func() {
+					lock.Lock()
+					delete(running, aggregateID)
+					lock.Unlock()
+				}()
+				transactionID := uuid.New()
+				ctx := agcommon.CreateContext(transactionID.String(), InventoryResyncActionID, InventoryResyncActionName, "1", common.ResyncInventory, podName)
+				if !claimInventoryResync(ctx, aggregateID, interval) {
+					return
+				}
+				e.ResyncInventory(ctx, aggregateID)
+			}(aggregateID, schedules[aggregateID])
+		}
+		time.Sleep(inventoryResyncCheckInterval)
+	}
+}
+
+// claimInventoryResync claims a due resync for the interval of its schedule, so that the resync is run
+// once in the interval by the instances of the service
+func claimInventoryResync(ctx context.Context, aggregateID string, interval time.Duration) bool {
+	targetURI := inventoryResyncTargetURI(aggregateID)
+	claimed, err := ClaimInventoryResync(targetURI, podName, int(interval.Seconds()))
+	if err != nil {
+		l.LogWithFields(ctx).Error("unable to claim the inventory resync of " + targetURI + ": " + err.Error())
+		return false
+	}
+	if !claimed {
+		l.LogWithFields(ctx).Debug("inventory resync of " + targetURI + " is claimed by another instance")
+	}
+	return claimed
+}
+
+// inventoryResyncTargetURI returns the URI of the servers resynced by a schedule
+func inventoryResyncTargetURI(aggregateID string) string {
+	if aggregateID == globalInventoryResync {
+		return systemsCollectionURI
+	}
+	return aggregatesURI + aggregateID
+}
+
+// inventoryResyncSchedules returns the intervals of the configured resyncs keyed by aggregate ID,
+// the resync of all the servers is keyed by globalInventoryResync
+func inventoryResyncSchedules() map[string]time.Duration {
+	schedules := make(map[string]time.Duration)
+	conf := config.Data.InventoryResyncConf
+	if conf == nil {
+		return schedules
+	}
+	if conf.IntervalInMinutes > 0 {
+		schedules[globalInventoryResync] = time.Duration(conf.IntervalInMinutes) * time.Minute
+	}
+	for aggregateID, interval := range conf.AggregateIntervalsInMinutes {
+		if interval > 0 {
+			schedules[aggregateID] = time.Duration(interval) * time.Minute
+		}
+	}
+	return schedules
+}
+
+// dueInventoryResyncs returns the schedules whose interval is elapsed since their last run and records now
+// as their last run, a new schedule first runs one interval after it is found
+func dueInventoryResyncs(schedules map[string]time.Duration, lastRuns map[string]time.Time, now time.Time) []string {
+	for aggregateID := range lastRuns {
+		if _, ok := schedules[aggregateID]; !ok {
+			delete(lastRuns, aggregateID)
+		}
+	}
+	var due []string
+	for aggregateID, interval := range schedules {
+		lastRun, ok := lastRuns[aggregateID]
+		if !ok {
+			lastRuns[aggregateID] = now
+			continue
+		}
+		if now.Sub(lastRun) >= interval {
+			lastRuns[aggregateID] = now
+			due = append(due, aggregateID)
+		}
+	}
+	sort.Strings(due)
+	return due
+}
+
+// ResyncInventory resyncs the inventory of all the servers, or of the servers of the aggregate
+// when aggregateID is not empty, with their BMCs in a task owned by the InventoryResyncConf.TaskUserName.
+// Only the resources which are changed on the BMCs are rewritten in the in-memory DB, the resources
+// which are added or removed on the BMCs are added or removed, and a ResourceUpdated, ResourceAdded
+// or ResourceRemoved event is published for each of them
+func (e *ExternalInterface) ResyncInventory(ctx context.Context, aggregateID string) response.RPC {
+	var resp response.RPC
+	targetURI := inventoryResyncTargetURI(aggregateID)
+	targets, err := getInventoryResyncTargets(ctx, aggregateID)
+	if err != nil {
+		errMsg := "unable to get the servers to resync for " + targetURI + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	if len(targets) == 0 {
+		l.LogWithFields(ctx).Info("no server to resync for " + targetURI)
+		resp.StatusCode = http.StatusOK
+		resp.StatusMessage = response.Success
+		return resp
+	}
+
+	taskURI, err := e.CreateTask(ctx, config.Data.InventoryResyncConf.TaskUserName)
+	if err != nil {
+		errMsg := "unable to create the task of the inventory resync: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	taskURI = strings.TrimSuffix(taskURI, "/")
+	taskID := taskURI[strings.LastIndex(taskURI, "/")+1:]
+	if err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, "", resp, common.Running, common.OK, 0, http.MethodPost)); err != nil {
+		l.LogWithFields(ctx).Error("error while starting the task of the inventory resync: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
+	l.LogWithFields(ctx).Infof("inventory resync task %s of %d servers is started for %s", taskID, len(targets), targetURI)
+
+	results := make([]inventoryResyncResult, len(targets))
+	var percentComplete int32
+	batchSize := config.Data.ServerRediscoveryBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	completed := runInWorkerPool(batchSize, len(targets), func(i int) {
+		results[i] = e.resyncTargetInventory(ctx, targets[i])
+		if results[i].err != nil {
+			l.LogWithFields(ctx).Error("inventory resync of the BMC with ID " + targets[i].DeviceUUID + " failed: " + results[i].err.Error())
+		}
+	}, func(completed int) bool {
+		percentComplete = int32(completed * 100 / len(targets))
+		err := e.UpdateTask(ctx, fillTaskData(taskID, targetURI, "", resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		return err == nil || err.Error() != common.Cancelling
+	})
+	if !completed {
+		// the servers which are being resynced are completed, the remaining ones are not started
+		l.LogWithFields(ctx).Info("inventory resync task " + taskID + " is cancelled")
+		e.UpdateTask(ctx, fillTaskData(taskID, targetURI, "", resp, common.Cancelled, common.OK, percentComplete, http.MethodPost))
+		return resp
+	}
+
+	body := agresponse.InventoryResyncResponse{}
+	for _, result := range results {
+		if result.err != nil {
+			body.ServersFailed++
+		} else {
+			body.ServersResynced++
+		}
+		body.ResourcesChecked += result.checked
+		body.ResourcesUpdated += result.updated
+		body.ResourcesAdded += result.added
+		body.ResourcesRemoved += result.removed
+	}
+	taskStatus := common.OK
+	if body.ServersFailed > 0 {
+		taskStatus = common.Warning
+	}
+	body.Response = response.Response{
+		OdataType: "#ActionResponse.v1_0_0.ActionResponse",
+		OdataID:   targetURI,
+		ID:        taskID,
+		Name:      inventoryResyncName,
+	}
+	body.Response.CreateGenericResponse(response.Success)
+	body.Response.Message = fmt.Sprintf("%d of the %d servers are resynced, %d of the %d resources checked are updated, %d are added and %d are removed",
+		body.ServersResynced, len(targets), body.ResourcesUpdated, body.ResourcesChecked, body.ResourcesAdded, body.ResourcesRemoved)
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = body
+	e.UpdateTask(ctx, fillTaskData(taskID, targetURI, "", resp, common.Completed, taskStatus, 100, http.MethodPost))
+	l.LogWithFields(ctx).Infof("inventory resync task %s completed: %d servers resynced, %d failed, %d resources updated, %d added, %d removed",
+		taskID, body.ServersResynced, body.ServersFailed, body.ResourcesUpdated, body.ResourcesAdded, body.ResourcesRemoved)
+	return resp
+}
+
+// getInventoryResyncTargets returns the BMCs of all the servers or of the servers of the aggregate
+func getInventoryResyncTargets(ctx context.Context, aggregateID string) ([]agmodel.Target, error) {
+	if aggregateID == globalInventoryResync {
+		targets, err := GetAllSystems()
+		if err != nil {
+			return nil, err
+		}
+		return targets, nil
+	}
+	aggregate, err := GetAggregate(aggregatesURI + aggregateID)
+	if err != nil {
+		return nil, err
+	}
+	var targets []agmodel.Target
+	for _, deviceUUID := range aggregateDeviceUUIDs(aggregate.Elements) {
+		target, err := GetTarget(deviceUUID)
+		if err != nil {
+			// the server may be removed after it was added to the aggregate
+			l.LogWithFields(ctx).Warn("unable to get the BMC with ID " + deviceUUID + " of the aggregate " + aggregateID + ": " + err.Error())
+			continue
+		}
+		targets = append(targets, *target)
+	}
+	return targets, nil
+}
+
+// aggregateDeviceUUIDs returns the IDs of the BMCs of the servers of an aggregate,
+// the server URIs of the elements are of the form /redfish/v1/Systems/<BMC ID>.<system ID>
+func aggregateDeviceUUIDs(elements []agmodel.OdataID) []string {
+	var deviceUUIDs []string
+	found := make(map[string]bool)
+	for _, element := range elements {
+		systemID := strings.TrimPrefix(strings.TrimSuffix(element.OdataID, "/"), systemsCollectionURI+"/")
+		deviceUUID := strings.Split(systemID, ".")[0]
+		if deviceUUID == "" || found[deviceUUID] {
+			continue
+		}
+		found[deviceUUID] = true
+		deviceUUIDs = append(deviceUUIDs, deviceUUID)
+	}
+	return deviceUUIDs
+}
+
+// resyncTargetInventory resyncs the inventory of a BMC, the inventory of a server which is missing
+// in the in-memory DB is fully rediscovered, otherwise each stored resource is compared with the BMC one
+func (e *ExternalInterface) resyncTargetInventory(ctx context.Context, target agmodel.Target) inventoryResyncResult {
+	var result inventoryResyncResult
+	req, err := e.getTargetPluginRequest(ctx, target)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	req.OID = systemsCollectionURI
+	body, _, _, err := contactPlugin(ctx, req, "error while trying to get the system collection details: ")
+	if err != nil {
+		result.err = err
+		return result
+	}
+	var systemsCollection struct {
+		Members []agmodel.OdataID `json:"Members"`
+	}
+	if err := json.Unmarshal(body, &systemsCollection); err != nil {
+		result.err = fmt.Errorf("error while trying to unmarshal the system collection: %v", err)
+		return result
+	}
+	var rediscovered []string
+	for _, member := range systemsCollection.Members {
+		if e.isServerRediscoveryRequired(ctx, target.DeviceUUID, member.OdataID) {
+			e.RediscoverSystemInventory(ctx, target.DeviceUUID, member.OdataID, true)
+			rediscovered = append(rediscovered, updateResourceDataWithUUID(strings.TrimSuffix(member.OdataID, "/"), target.DeviceUUID))
+		}
+	}
+	if len(rediscovered) > 0 {
+		result.checked = len(rediscovered)
+		result.updated = len(rediscovered)
+		e.publishInventoryEvents(ctx, rediscovered, "ResourceUpdated")
+		return result
+	}
+	return e.resyncResources(ctx, req, target)
+}
+
+// resyncResources compares each resource of the BMC stored in the in-memory DB with the resource
+// returned by the plugin and rewrites only the changed resources. The members added to the stored
+// collections are discovered and the resources which are not found on the BMC anymore are removed
+func (e *ExternalInterface) resyncResources(ctx context.Context, req getResourceRequest, target agmodel.Target) inventoryResyncResult {
+	var result inventoryResyncResult
+	keys, dbErr := e.GetAllMatchingDetails("*", target.DeviceUUID, common.InMemory)
+	if dbErr != nil {
+		result.err = dbErr
+		return result
+	}
+	resourceKeys, systemURIs := inventoryResourceKeys(keys)
+
+	// the servers must not be deleted or rediscovered while they are resynced
+	for _, systemURI := range systemURIs {
+		claimed, dbErr := ClaimSystemOperation(agmodel.SystemOperation{Operation: inventoryResyncOp}, systemURI)
+		if dbErr != nil {
+			result.err = dbErr
+			return result
+		}
+		if !claimed {
+			systemOperation, _ := GetSystemOperationInfo(ctx, systemURI)
+			result.err = fmt.Errorf("%s operation is under progress for the system %s", systemOperation.Operation, systemURI)
+			return result
+		}
+		defer DeleteSystemOperationInfo(systemURI)
+	}
+
+	storedURIs := make(map[string]bool, len(resourceKeys))
+	for _, key := range resourceKeys {
+		_, uri, _ := strings.Cut(key, ":")
+		storedURIs[uri] = true
+	}
+	changed := make(map[string]interface{})
+	var updatedURIs, addedMembers, removedKeys []string
+	for _, key := range resourceKeys {
+		table, uri, _ := strings.Cut(key, ":")
+		stored, dbErr := e.GetResource(ctx, table, uri)
+		if dbErr != nil {
+			// the resource may be removed while the inventory is resynced
+			l.LogWithFields(ctx).Warn("unable to get the stored " + key + ": " + dbErr.Error())
+			continue
+		}
+		req.OID = pluginResourceOID(uri, target.DeviceUUID)
+		body, _, getResponse, err := contactPlugin(ctx, req, "error while trying to get the "+req.OID+" details: ")
+		if err != nil {
+			if getResponse.StatusCode == http.StatusNotFound && !isTopLevelInventoryTable(table) {
+				// the resource is removed from the BMC
+				removedKeys = append(removedKeys, key)
+				continue
+			}
+			if result.err == nil {
+				result.err = err
+			}
+			continue
+		}
+		result.checked++
+		current := formInventoryResource(table, req.OID, target.DeviceUUID, body)
+		if resourceChanged(stored, current) {
+			changed[key] = current
+			updatedURIs = append(updatedURIs, uri)
+			addedMembers = append(addedMembers, newCollectionMembers(current, storedURIs)...)
+		}
+	}
+
+	var addedURIs []string
+	for key, data := range e.discoverInventoryMembers(ctx, req, target.DeviceUUID, addedMembers, storedURIs) {
+		_, uri, _ := strings.Cut(key, ":")
+		changed[key] = data
+		addedURIs = append(addedURIs, uri)
+	}
+	sort.Strings(addedURIs)
+	var removedURIs []string
+	for _, key := range removedKeys {
+		table, uri, _ := strings.Cut(key, ":")
+		if dbErr := e.Delete(table, uri, common.InMemory); dbErr != nil {
+			l.LogWithFields(ctx).Error("unable to remove the " + key + " which is not found on the BMC: " + dbErr.Error())
+			continue
+		}
+		removedURIs = append(removedURIs, uri)
+	}
+	result.removed = len(removedURIs)
+	if len(changed) == 0 && len(removedURIs) == 0 {
+		l.LogWithFields(ctx).Info("inventory of the BMC with ID " + target.DeviceUUID + " is unchanged")
+		return result
+	}
+
+	if len(changed) > 0 {
+		if err := SaveBMCInventory(changed); err != nil {
+			result.err = err
+			return result
+		}
+		result.updated = len(updatedURIs)
+		result.added = len(addedURIs)
+		if err := SaveFilterIndexes(changed, true); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to update index values of chassis and managers: " + err.Error())
+		}
+	}
+	for _, systemURI := range systemURIs {
+		data, ok := changed["ComputerSystem:"+systemURI]
+		if !ok {
+			continue
+		}
+		var computeSystem map[string]interface{}
+		if err := json.Unmarshal([]byte(data.(string)), &computeSystem); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to unmarshal " + systemURI + ": " + err.Error())
+			continue
+		}
+		computeSystemUUID, _ := computeSystem["UUID"].(string)
+		searchForm := createServerSearchIndex(ctx, computeSystem, systemURI, target.DeviceUUID)
+		if err := UpdateIndex(searchForm, systemURI, computeSystemUUID, target.ManagerAddress); err != nil {
+			l.LogWithFields(ctx).Error("error while trying to update index values of " + systemURI + ": " + err.Error())
+		}
+	}
+	e.publishInventoryEvents(ctx, updatedURIs, "ResourceUpdated")
+	e.publishInventoryEvents(ctx, addedURIs, "ResourceAdded")
+	e.publishInventoryEvents(ctx, removedURIs, "ResourceRemoved")
+	e.recordInventoryRevision(ctx, target.DeviceUUID, inventoryTriggerResync)
+	l.LogWithFields(ctx).Infof("%d of the %d resources of the BMC with ID %s are updated, %d are added and %d are removed",
+		result.updated, result.checked, target.DeviceUUID, result.added, result.removed)
+	return result
+}
+
+// isTopLevelInventoryTable reports whether the resources of a table are the servers, chassis or managers
+// of the BMC, which are only removed along with their aggregation source
+func isTopLevelInventoryTable(table string) bool {
+	switch table {
+	case "ComputerSystem", "Chassis", "Managers":
+		return true
+	}
+	return false
+}
+
+// newCollectionMembers returns the URIs of the members of a collection which are not stored yet
+func newCollectionMembers(collection string, storedURIs map[string]bool) []string {
+	var data struct {
+		Members []agmodel.OdataID `json:"Members"`
+	}
+	if err := json.Unmarshal([]byte(collection), &data); err != nil {
+		return nil
+	}
+	var members []string
+	for _, member := range data.Members {
+		uri := strings.TrimSuffix(member.OdataID, "/")
+		if uri != "" && !storedURIs[uri] {
+			members = append(members, uri)
+		}
+	}
+	return members
+}
+
+// discoverInventoryMembers discovers the members added to the collections of a BMC along with their
+// subordinate resources and returns them keyed by Table:URI, the stored resources are not fetched again
+func (e *ExternalInterface) discoverInventoryMembers(ctx context.Context, req getResourceRequest, deviceUUID string, members []string, storedURIs map[string]bool) map[string]interface{} {
+	h := &respHolder{
+		TraversedLinks: make(map[string]bool),
+		InventoryData:  make(map[string]interface{}),
+	}
+	for uri := range storedURIs {
+		h.TraversedLinks[pluginResourceOID(uri, deviceUUID)] = true
+	}
+	for _, member := range members {
+		memberReq := req
+		memberReq.OID = pluginResourceOID(member, deviceUUID)
+		if h.TraversedLinks[memberReq.OID] {
+			continue
+		}
+		memberReq.SystemID = pluginSystemID(memberReq.OID)
+		memberReq.ParentOID = ""
+		memberReq.OemFlag = false
+		h.getResourceDetails(ctx, "", 0, 0, memberReq)
+		if h.ErrorMessage != "" {
+			// the other members are still discovered, the failed one is added by the next resync
+			l.LogWithFields(ctx).Warn("unable to discover the added " + member + ": " + h.ErrorMessage)
+			h.ErrorMessage = ""
+		}
+	}
+	return h.InventoryData
+}
+
+// pluginSystemID returns the ID of the server of a resource as known by its plugin
+func pluginSystemID(oid string) string {
+	if !strings.HasPrefix(oid, systemsCollectionURI+"/") {
+		return ""
+	}
+	return strings.Split(strings.TrimPrefix(oid, systemsCollectionURI+"/"), "/")[0]
+}
+
+// inventoryResourceKeys returns the Table:URI keys of the inventory resources among the in-memory DB
+// keys of a BMC, and the URIs of its servers
+func inventoryResourceKeys(keys []string) ([]string, []string) {
+	var resourceKeys, systemURIs []string
+	for _, key := range keys {
+		table, uri, found := strings.Cut(key, ":")
+		if !found || !strings.HasPrefix(uri, "/redfish/v1/") {
+			continue
+		}
+		switch table {
+		case "SystemReset", "SystemOperation":
+			continue
+		case "ComputerSystem":
+			systemURIs = append(systemURIs, uri)
+		}
+		resourceKeys = append(resourceKeys, key)
+	}
+	sort.Strings(resourceKeys)
+	return resourceKeys, systemURIs
+}
+
+// pluginResourceOID returns the URI of a stored resource of a BMC as known by its plugin
+func pluginResourceOID(uri, deviceUUID string) string {
+	return strings.Replace(uri, deviceUUID+".", "", -1)
+}
+
+// formInventoryResource returns a resource returned by the plugin as it is stored in the in-memory DB
+func formInventoryResource(table, oid, deviceUUID string, body []byte) string {
+	if strings.Contains(table, "VolumesCollection") {
+		var resourceData map[string]interface{}
+		if err := json.Unmarshal(body, &resourceData); err == nil {
+			if _, ok := resourceData["Members"]; ok {
+				addVolumeCollectionCapabilities(oid, resourceData)
+				body, _ = json.Marshal(resourceData)
+			}
+		}
+	}
+	return updateResourceDataWithUUID(string(body), deviceUUID)
+}
+
+// resourceChanged reports whether a resource differs from its stored copy, the ETags of the copies
+// are compared when both have one, otherwise the hashes of their canonical JSON are compared
+func resourceChanged(stored, current string) bool {
+	storedETag, currentETag := resourceETag(stored), resourceETag(current)
+	if storedETag != "" && currentETag != "" {
+		return storedETag != currentETag
+	}
+	return resourceHash(stored) != resourceHash(current)
+}
+
+func resourceETag(resource string) string {
+	var data struct {
+		ETag string `json:"@odata.etag"`
+	}
+	json.Unmarshal([]byte(resource), &data)
+	return data.ETag
+}
+
+// resourceHash returns the hash of a resource independent of the order and formatting of its properties
+func resourceHash(resource string) string {
+	canonical := []byte(resource)
+	var data interface{}
+	if err := json.Unmarshal(canonical, &data); err == nil {
+		canonical, _ = json.Marshal(data)
+	}
+	hash := sha256.Sum256(canonical)
+	return hex.EncodeToString(hash[:])
+}
+
+// publishInventoryEvents publishes an event of the given type for each of the resources
+func (e *ExternalInterface) publishInventoryEvents(ctx context.Context, uris []string, eventType string) {
+	for _, uri := range uris {
+		if err := e.EventNotification(ctx, uri, eventType, inventoryCollectionType(uri), agmessagebus.InitMQSCom()); err != nil {
+			l.LogWithFields(ctx).Error("unable to publish the " + eventType + " event of " + uri + ": " + err.Error())
+		}
+	}
+}
+
+// inventoryCollectionType returns the collection of the events of a resource,
+// the firmware and software inventories are collected with the servers
+func inventoryCollectionType(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "/redfish/v1/Chassis"):
+		return "ChassisCollection"
+	case strings.HasPrefix(uri, "/redfish/v1/Managers"):
+		return "ManagerCollection"
+	default:
+		return "SystemsCollection"
+	}
+}
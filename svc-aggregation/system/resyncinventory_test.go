@@ -0,0 +1,589 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+func TestInventoryResyncSchedules(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.InventoryResyncConf = &config.InventoryResyncConf{
+		IntervalInMinutes:           60,
+		AggregateIntervalsInMinutes: map[string]int{"agg1": 15, "agg2": 0},
+	}
+	want := map[string]time.Duration{
+		globalInventoryResync: time.Hour,
+		"agg1":                15 * time.Minute,
+	}
+	if got := inventoryResyncSchedules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("inventoryResyncSchedules() = %v, want %v", got, want)
+	}
+	config.Data.InventoryResyncConf = nil
+	if got := inventoryResyncSchedules(); len(got) != 0 {
+		t.Errorf("inventoryResyncSchedules() = %v, want no schedule", got)
+	}
+}
+
+func TestDueInventoryResyncs(t *testing.T) {
+	start := time.Now()
+	schedules := map[string]time.Duration{
+		globalInventoryResync: time.Hour,
+		"agg1":                15 * time.Minute,
+	}
+	lastRuns := map[string]time.Time{"removed": start}
+	if got := dueInventoryResyncs(schedules, lastRuns, start); len(got) != 0 {
+		t.Errorf("dueInventoryResyncs() = %v, new schedules must not be due", got)
+	}
+	if _, ok := lastRuns["removed"]; ok {
+		t.Errorf("dueInventoryResyncs() kept the last run of a removed schedule")
+	}
+	if got := dueInventoryResyncs(schedules, lastRuns, start.Add(20*time.Minute)); !reflect.DeepEqual(got, []string{"agg1"}) {
+		t.Errorf("dueInventoryResyncs() = %v, want [agg1]", got)
+	}
+	if got := dueInventoryResyncs(schedules, lastRuns, start.Add(time.Hour)); !reflect.DeepEqual(got, []string{globalInventoryResync, "agg1"}) {
+		t.Errorf("dueInventoryResyncs() = %v, want both schedules", got)
+	}
+}
+
+func TestClaimInventoryResync(t *testing.T) {
+	claimInventoryResyncFunc := ClaimInventoryResync
+	defer func() {
+		ClaimInventoryResync = claimInventoryResyncFunc
+	}()
+	ctx := mockContext()
+	claims := make(map[string]int)
+	ClaimInventoryResync = func(targetURI, owner string, expiry int) (bool, *errors.Error) {
+		if targetURI == aggregatesURI+"unreachable" {
+			return false, errors.PackError(errors.UndefinedErrorType, "unable to connect DB")
+		}
+		if _, ok := claims[targetURI]; ok {
+			return false, nil
+		}
+		claims[targetURI] = expiry
+		return true, nil
+	}
+	if !claimInventoryResync(ctx, globalInventoryResync, time.Hour) {
+		t.Errorf("claimInventoryResync() = false, want the resync of all the servers claimed")
+	}
+	if claimInventoryResync(ctx, globalInventoryResync, time.Hour) {
+		t.Errorf("claimInventoryResync() = true, want the resync claimed once")
+	}
+	if !claimInventoryResync(ctx, "agg1", 15*time.Minute) {
+		t.Errorf("claimInventoryResync() = false, want the resync of the aggregate claimed")
+	}
+	if claimInventoryResync(ctx, "unreachable", time.Hour) {
+		t.Errorf("claimInventoryResync() = true, want the resync not claimed when the DB is unreachable")
+	}
+	want := map[string]int{systemsCollectionURI: 3600, aggregatesURI + "agg1": 900}
+	if !reflect.DeepEqual(claims, want) {
+		t.Errorf("claimInventoryResync() claims = %v, want %v", claims, want)
+	}
+}
+
+func TestAggregateDeviceUUIDs(t *testing.T) {
+	elements := []agmodel.OdataID{
+		{OdataID: "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1"},
+		{OdataID: "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.2/"},
+		{OdataID: "/redfish/v1/Systems/a8b47c32-ee08-4b30-8b91-d7e6e2ee1a3c.1"},
+	}
+	want := []string{"6d4a0a66-7efa-578e-83cf-44dc68d2874e", "a8b47c32-ee08-4b30-8b91-d7e6e2ee1a3c"}
+	if got := aggregateDeviceUUIDs(elements); !reflect.DeepEqual(got, want) {
+		t.Errorf("aggregateDeviceUUIDs() = %v, want %v", got, want)
+	}
+}
+
+func TestPluginResourceOID(t *testing.T) {
+	deviceUUID := "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+	got := pluginResourceOID("/redfish/v1/Systems/"+deviceUUID+".1/Storage/1", deviceUUID)
+	if got != "/redfish/v1/Systems/1/Storage/1" {
+		t.Errorf("pluginResourceOID() = %v", got)
+	}
+}
+
+func TestFormInventoryResource(t *testing.T) {
+	deviceUUID := "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+	got := formInventoryResource("ComputerSystem", "/redfish/v1/Systems/1", deviceUUID, []byte(`{"@odata.id":"/redfish/v1/Systems/1"}`))
+	if got != `{"@odata.id":"/redfish/v1/Systems/`+deviceUUID+`.1"}` {
+		t.Errorf("formInventoryResource() = %v", got)
+	}
+	got = formInventoryResource("VolumesCollection", "/redfish/v1/Systems/1/Storage/1/Volumes", deviceUUID, []byte(`{"Members":[]}`))
+	if !strings.Contains(got, "@Redfish.CollectionCapabilities") || !strings.Contains(got, "/redfish/v1/Systems/"+deviceUUID+".1/Storage/1/Volumes/Capabilities") {
+		t.Errorf("formInventoryResource() = %v, want the collection capabilities", got)
+	}
+}
+
+func TestResourceChanged(t *testing.T) {
+	tests := []struct {
+		name    string
+		stored  string
+		current string
+		want    bool
+	}{
+		{
+			name:    "same resource with properties reordered",
+			stored:  `{"Id":"1","PowerState":"On"}`,
+			current: `{"PowerState": "On", "Id": "1"}`,
+			want:    false,
+		},
+		{
+			name:    "changed resource",
+			stored:  `{"Id":"1","PowerState":"On"}`,
+			current: `{"Id":"1","PowerState":"Off"}`,
+			want:    true,
+		},
+		{
+			name:    "same ETags",
+			stored:  `{"@odata.etag":"W/\"1\"","Id":"1","PowerState":"On"}`,
+			current: `{"@odata.etag":"W/\"1\"","Id":"1","PowerState":"Off"}`,
+			want:    false,
+		},
+		{
+			name:    "different ETags",
+			stored:  `{"@odata.etag":"W/\"1\"","Id":"1"}`,
+			current: `{"@odata.etag":"W/\"2\"","Id":"1"}`,
+			want:    true,
+		},
+		{
+			name:    "ETag missing in the stored copy",
+			stored:  `{"Id":"1"}`,
+			current: `{"@odata.etag":"W/\"2\"","Id":"1"}`,
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourceChanged(tt.stored, tt.current); got != tt.want {
+				t.Errorf("resourceChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryCollectionType(t *testing.T) {
+	tests := map[string]string{
+		"/redfish/v1/Systems/uuid.1":                         "SystemsCollection",
+		"/redfish/v1/Chassis/uuid.1/Thermal":                 "ChassisCollection",
+		"/redfish/v1/Managers/uuid.1":                        "ManagerCollection",
+		"/redfish/v1/UpdateService/FirmwareInventory/uuid.1": "SystemsCollection",
+	}
+	for uri, want := range tests {
+		if got := inventoryCollectionType(uri); got != want {
+			t.Errorf("inventoryCollectionType(%s) = %v, want %v", uri, got, want)
+		}
+	}
+}
+
+const resyncDeviceUUID = "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+
+// mockResyncBMC is a BMC whose inventory is stored in the in-memory DB and returned by its plugin
+type mockResyncBMC struct {
+	lock sync.Mutex
+	// stored resources keyed by Table:URI
+	stored map[string]string
+	// resources returned by the plugin keyed by their URI as known by the plugin
+	plugin map[string]string
+	// resources for which the plugin returns an error status keyed by their URI as known by the plugin
+	pluginStatus map[string]int
+	history      *mockInventoryHistory
+	saved        map[string]interface{}
+	deleted      []string
+	events       []string
+	lastTask     common.TaskData
+}
+
+func newMockResyncBMC() *mockResyncBMC {
+	systemURI := "/redfish/v1/Systems/" + resyncDeviceUUID + ".1"
+	return &mockResyncBMC{
+		stored: map[string]string{
+			"ComputerSystem:" + systemURI:                              `{"@odata.etag":"W/\"1\"","@odata.id":"` + systemURI + `","Id":"1"}`,
+			"Chassis:/redfish/v1/Chassis/" + resyncDeviceUUID + ".1":   `{"@odata.etag":"W/\"1\"","@odata.id":"/redfish/v1/Chassis/` + resyncDeviceUUID + `.1","Id":"1"}`,
+			"Managers:/redfish/v1/Managers/" + resyncDeviceUUID + ".1": `{"@odata.etag":"W/\"1\"","@odata.id":"/redfish/v1/Managers/` + resyncDeviceUUID + `.1","Id":"1"}`,
+			"ProcessorsCollection:" + systemURI + "/Processors":        `{"@odata.id":"` + systemURI + `/Processors","Members":[{"@odata.id":"` + systemURI + `/Processors/1"},{"@odata.id":"` + systemURI + `/Processors/2"}]}`,
+			"Processors:" + systemURI + "/Processors/1":                `{"@odata.etag":"W/\"1\"","@odata.id":"` + systemURI + `/Processors/1","Id":"1"}`,
+			"Processors:" + systemURI + "/Processors/2":                `{"@odata.etag":"W/\"1\"","@odata.id":"` + systemURI + `/Processors/2","Id":"2"}`,
+		},
+		plugin: map[string]string{
+			"/redfish/v1/Systems":                `{"Members":[{"@odata.id":"/redfish/v1/Systems/1"}]}`,
+			"/redfish/v1/Systems/1":              `{"@odata.etag":"W/\"1\"","@odata.id":"/redfish/v1/Systems/1","Id":"1"}`,
+			"/redfish/v1/Chassis/1":              `{"@odata.etag":"W/\"1\"","@odata.id":"/redfish/v1/Chassis/1","Id":"1"}`,
+			"/redfish/v1/Managers/1":             `{"@odata.etag":"W/\"1\"","@odata.id":"/redfish/v1/Managers/1","Id":"1"}`,
+			"/redfish/v1/Systems/1/Processors":   `{"@odata.id":"/redfish/v1/Systems/1/Processors","Members":[{"@odata.id":"/redfish/v1/Systems/1/Processors/1"},{"@odata.id":"/redfish/v1/Systems/1/Processors/3"}]}`,
+			"/redfish/v1/Systems/1/Processors/1": `{"@odata.etag":"W/\"2\"","@odata.id":"/redfish/v1/Systems/1/Processors/1","Id":"1"}`,
+			"/redfish/v1/Systems/1/Processors/3": `{"@odata.etag":"W/\"1\"","@odata.id":"/redfish/v1/Systems/1/Processors/3","Id":"3","Links":{"Chassis":{"@odata.id":"/redfish/v1/Chassis/1"}}}`,
+		},
+		pluginStatus: map[string]int{},
+		history:      &mockInventoryHistory{revisions: map[int]agmodel.InventoryRevision{}, contents: map[string]string{}},
+		saved:        make(map[string]interface{}),
+	}
+}
+
+func (b *mockResyncBMC) contactClient(ctx context.Context, url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+	oid := strings.Replace(odataID, "/ODIM/", "/redfish/", 1)
+	if status, ok := b.pluginStatus[oid]; ok {
+		return &http.Response{
+			StatusCode: status,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"` + http.StatusText(status) + `"}`)),
+		}, nil
+	}
+	data, ok := b.plugin[oid]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"not found"}`)),
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(data)),
+	}, nil
+}
+
+func (b *mockResyncBMC) getAllMatchingDetails(table, pattern string, dbtype common.DbType) ([]string, *errors.Error) {
+	var keys []string
+	for key := range b.stored {
+		resourceTable, uri, _ := strings.Cut(key, ":")
+		switch {
+		case table == "*" && strings.Contains(key, pattern):
+			keys = append(keys, key)
+		case resourceTable == table && strings.Contains(uri, pattern):
+			keys = append(keys, uri)
+		}
+	}
+	return keys, nil
+}
+
+func (b *mockResyncBMC) getResource(ctx context.Context, table, key string) (string, *errors.Error) {
+	data, ok := b.stored[table+":"+key]
+	if !ok {
+		return "", errors.PackError(errors.DBKeyNotFound, "no data with the key "+key+" found")
+	}
+	return data, nil
+}
+
+func (b *mockResyncBMC) delete(table, key string, dbtype common.DbType) *errors.Error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.deleted = append(b.deleted, table+":"+key)
+	return nil
+}
+
+func (b *mockResyncBMC) eventNotification(ctx context.Context, uri, eventType, collectionType string, mq agmessagebus.MQBusCommunicator) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.events = append(b.events, eventType+" "+uri)
+	return nil
+}
+
+func (b *mockResyncBMC) saveBMCInventory(data map[string]interface{}) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for key, value := range data {
+		b.saved[key] = value
+	}
+	return nil
+}
+
+func (b *mockResyncBMC) updateTask(ctx context.Context, task common.TaskData) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.lastTask = task
+	return nil
+}
+
+func (b *mockResyncBMC) externalInterface() *ExternalInterface {
+	return &ExternalInterface{
+		ContactClient:         b.contactClient,
+		GetPluginStatus:       func(ctx context.Context, plugin agmodel.Plugin) bool { return false },
+		DecryptPassword:       func(password []byte) ([]byte, error) { return password, nil },
+		GetAllMatchingDetails: b.getAllMatchingDetails,
+		GetResource:           b.getResource,
+		Delete:                b.delete,
+		EventNotification:     b.eventNotification,
+		CreateTask: func(ctx context.Context, userName string) (string, error) {
+			return "/redfish/v1/TaskService/Tasks/task1", nil
+		},
+		UpdateTask:            b.updateTask,
+		GetInventoryRevisions: b.history.getInventoryRevisions,
+	}
+}
+
+// stubInventoryResyncDB replaces the DB accesses of the inventory resync with the mock BMC
+func stubInventoryResyncDB(t *testing.T, b *mockResyncBMC, systemOperation string) {
+	stubInventoryHistoryDB(t, b.history)
+	getAllSystems, getAggregate, getTarget, getPluginData := GetAllSystems, GetAggregate, GetTarget, GetPluginData
+	getSystemOperationInfo, claimSystemOperation, deleteSystemOperationInfo := GetSystemOperationInfo, ClaimSystemOperation, DeleteSystemOperationInfo
+	saveBMCInventory, saveFilterIndexes, updateIndex := SaveBMCInventory, SaveFilterIndexes, UpdateIndex
+	t.Cleanup(func() {
+		GetAllSystems, GetAggregate, GetTarget, GetPluginData = getAllSystems, getAggregate, getTarget, getPluginData
+		GetSystemOperationInfo, ClaimSystemOperation, DeleteSystemOperationInfo = getSystemOperationInfo, claimSystemOperation, deleteSystemOperationInfo
+		SaveBMCInventory, SaveFilterIndexes, UpdateIndex = saveBMCInventory, saveFilterIndexes, updateIndex
+	})
+	target := agmodel.Target{
+		ManagerAddress: "10.0.0.1",
+		Password:       []byte("password"),
+		UserName:       "admin",
+		DeviceUUID:     resyncDeviceUUID,
+		PluginID:       "GRF",
+	}
+	GetAllSystems = func() ([]agmodel.Target, *errors.Error) {
+		return []agmodel.Target{target}, nil
+	}
+	GetAggregate = func(aggregateURI string) (agmodel.Aggregate, *errors.Error) {
+		if aggregateURI != aggregatesURI+"agg1" {
+			return agmodel.Aggregate{}, errors.PackError(errors.DBKeyNotFound, "no data with the key "+aggregateURI+" found")
+		}
+		return agmodel.Aggregate{Elements: []agmodel.OdataID{{OdataID: "/redfish/v1/Systems/" + resyncDeviceUUID + ".1"}}}, nil
+	}
+	GetTarget = func(deviceUUID string) (*agmodel.Target, error) {
+		if deviceUUID != resyncDeviceUUID {
+			return nil, fmt.Errorf("no data with the key %s found", deviceUUID)
+		}
+		return &target, nil
+	}
+	GetPluginData = func(pluginID string, readPluginData agmodel.DBPluginDataRead) (agmodel.Plugin, *errors.Error) {
+		if pluginID != "GRF" {
+			return agmodel.Plugin{}, errors.PackError(errors.DBKeyNotFound, "no data with the key "+pluginID+" found")
+		}
+		return agmodel.Plugin{
+			IP:                "localhost",
+			Port:              "45001",
+			Username:          "admin",
+			Password:          []byte("password"),
+			ID:                "GRF",
+			PreferredAuthType: "BasicAuth",
+		}, nil
+	}
+	GetSystemOperationInfo = func(ctx context.Context, systemURI string) (agmodel.SystemOperation, *errors.Error) {
+		if systemOperation == "" {
+			return agmodel.SystemOperation{}, errors.PackError(errors.DBKeyNotFound, "no data with the key "+systemURI+" found")
+		}
+		return agmodel.SystemOperation{Operation: systemOperation}, nil
+	}
+	ClaimSystemOperation = func(claim agmodel.SystemOperation, systemURI string) (bool, *errors.Error) {
+		return systemOperation == "", nil
+	}
+	DeleteSystemOperationInfo = func(systemURI string) *errors.Error {
+		return nil
+	}
+	SaveBMCInventory = b.saveBMCInventory
+	SaveFilterIndexes = func(data map[string]interface{}, update bool) error {
+		return nil
+	}
+	UpdateIndex = func(searchForm map[string]interface{}, table, uuid, bmcAddress string) error {
+		return nil
+	}
+}
+
+func TestResyncResources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	systemURI := "/redfish/v1/Systems/" + resyncDeviceUUID + ".1"
+	b := newMockResyncBMC()
+	stubInventoryResyncDB(t, b, "")
+	e := b.externalInterface()
+	target, _ := GetTarget(resyncDeviceUUID)
+	req, err := e.getTargetPluginRequest(ctx, *target)
+	if err != nil {
+		t.Fatalf("getTargetPluginRequest() error = %v", err)
+	}
+
+	result := e.resyncResources(ctx, req, *target)
+	if result.err != nil {
+		t.Fatalf("resyncResources() error = %v", result.err)
+	}
+	if result.checked != 5 || result.updated != 2 || result.added != 1 || result.removed != 1 {
+		t.Errorf("resyncResources() = %+v, want 5 checked, 2 updated, 1 added and 1 removed", result)
+	}
+	var saved []string
+	for key := range b.saved {
+		saved = append(saved, key)
+	}
+	sort.Strings(saved)
+	wantSaved := []string{
+		"Processors:" + systemURI + "/Processors/1",
+		"Processors:" + systemURI + "/Processors/3",
+		"ProcessorsCollection:" + systemURI + "/Processors",
+	}
+	if !reflect.DeepEqual(saved, wantSaved) {
+		t.Errorf("resyncResources() saved %v, want %v", saved, wantSaved)
+	}
+	if !strings.Contains(b.saved["Processors:"+systemURI+"/Processors/3"].(string), `"`+systemURI+`/Processors/3"`) {
+		t.Errorf("resyncResources() saved the added processor as %v", b.saved["Processors:"+systemURI+"/Processors/3"])
+	}
+	if want := []string{"Processors:" + systemURI + "/Processors/2"}; !reflect.DeepEqual(b.deleted, want) {
+		t.Errorf("resyncResources() deleted %v, want %v", b.deleted, want)
+	}
+	wantEvents := []string{
+		"ResourceUpdated " + systemURI + "/Processors/1",
+		"ResourceUpdated " + systemURI + "/Processors",
+		"ResourceAdded " + systemURI + "/Processors/3",
+		"ResourceRemoved " + systemURI + "/Processors/2",
+	}
+	if !reflect.DeepEqual(b.events, wantEvents) {
+		t.Errorf("resyncResources() published %v, want %v", b.events, wantEvents)
+	}
+}
+
+func TestResyncResourcesUnchanged(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	b := newMockResyncBMC()
+	for key := range b.stored {
+		if strings.HasPrefix(key, "Processors") {
+			delete(b.stored, key)
+		}
+	}
+	stubInventoryResyncDB(t, b, "")
+	e := b.externalInterface()
+	target, _ := GetTarget(resyncDeviceUUID)
+	req, _ := e.getTargetPluginRequest(ctx, *target)
+
+	result := e.resyncResources(ctx, req, *target)
+	if result.err != nil || result.checked != 3 || result.updated != 0 || result.added != 0 || result.removed != 0 {
+		t.Errorf("resyncResources() = %+v, want 3 unchanged resources", result)
+	}
+	if len(b.saved) != 0 || len(b.deleted) != 0 || len(b.events) != 0 {
+		t.Errorf("resyncResources() saved %v, deleted %v and published %v for an unchanged inventory", b.saved, b.deleted, b.events)
+	}
+}
+
+func TestResyncResourcesErrors(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+
+	// the servers which are under another operation are not resynced
+	b := newMockResyncBMC()
+	stubInventoryResyncDB(t, b, "InventoryRediscovery")
+	e := b.externalInterface()
+	target, _ := GetTarget(resyncDeviceUUID)
+	req, _ := e.getTargetPluginRequest(ctx, *target)
+	if result := e.resyncResources(ctx, req, *target); result.err == nil || result.checked != 0 {
+		t.Errorf("resyncResources() = %+v, want an error for a server under another operation", result)
+	}
+
+	// the servers, chassis and managers are not removed, the other resources are still resynced
+	b = newMockResyncBMC()
+	delete(b.plugin, "/redfish/v1/Chassis/1")
+	b.pluginStatus["/redfish/v1/Systems/1/Processors/1"] = http.StatusInternalServerError
+	stubInventoryResyncDB(t, b, "")
+	e = b.externalInterface()
+	req, _ = e.getTargetPluginRequest(ctx, *target)
+	result := e.resyncResources(ctx, req, *target)
+	if result.err == nil {
+		t.Errorf("resyncResources() want an error for the missing chassis and the failed processor")
+	}
+	if result.checked != 3 || result.added != 1 || result.removed != 1 {
+		t.Errorf("resyncResources() = %+v, want 3 checked, 1 added and 1 removed", result)
+	}
+	for _, key := range b.deleted {
+		if strings.HasPrefix(key, "Chassis:") {
+			t.Errorf("resyncResources() removed the chassis %v", key)
+		}
+	}
+}
+
+func TestResyncTargetInventory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	b := newMockResyncBMC()
+	stubInventoryResyncDB(t, b, "")
+	e := b.externalInterface()
+	target, _ := GetTarget(resyncDeviceUUID)
+
+	result := e.resyncTargetInventory(ctx, *target)
+	if result.err != nil || result.checked != 5 || result.updated != 2 || result.added != 1 || result.removed != 1 {
+		t.Errorf("resyncTargetInventory() = %+v, want 5 checked, 2 updated, 1 added and 1 removed", result)
+	}
+
+	// a server of the BMC which is not stored is fully rediscovered instead
+	if !e.isServerRediscoveryRequired(ctx, resyncDeviceUUID, "/redfish/v1/Systems/2") {
+		t.Errorf("isServerRediscoveryRequired() = false for a server which is not stored")
+	}
+	if e.isServerRediscoveryRequired(ctx, resyncDeviceUUID, "/redfish/v1/Systems/1") {
+		t.Errorf("isServerRediscoveryRequired() = true for a stored server")
+	}
+
+	// the plugin of the BMC is not found
+	target.PluginID = "unknown"
+	if result := e.resyncTargetInventory(ctx, *target); result.err == nil {
+		t.Errorf("resyncTargetInventory() want an error for an unknown plugin")
+	}
+
+	// the plugin does not return the systems collection
+	target.PluginID = "GRF"
+	b.pluginStatus["/redfish/v1/Systems"] = http.StatusServiceUnavailable
+	if result := e.resyncTargetInventory(ctx, *target); result.err == nil {
+		t.Errorf("resyncTargetInventory() want an error when the systems collection is not returned")
+	}
+}
+
+func TestResyncInventory(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.InventoryResyncConf = &config.InventoryResyncConf{TaskUserName: "admin"}
+	ctx := mockContext()
+	b := newMockResyncBMC()
+	stubInventoryResyncDB(t, b, "")
+	e := b.externalInterface()
+
+	resp := e.ResyncInventory(ctx, "agg1")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ResyncInventory() status = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	body, ok := resp.Body.(agresponse.InventoryResyncResponse)
+	if !ok {
+		t.Fatalf("ResyncInventory() body = %v", resp.Body)
+	}
+	if body.ServersResynced != 1 || body.ServersFailed != 0 || body.ResourcesChecked != 5 ||
+		body.ResourcesUpdated != 2 || body.ResourcesAdded != 1 || body.ResourcesRemoved != 1 {
+		t.Errorf("ResyncInventory() body = %+v", body)
+	}
+	if b.lastTask.TaskState != common.Completed || b.lastTask.TaskStatus != common.OK || b.lastTask.PercentComplete != 100 {
+		t.Errorf("ResyncInventory() last task update = %+v, want completed", b.lastTask)
+	}
+
+	// the failed servers are reported with a warning
+	b = newMockResyncBMC()
+	b.pluginStatus["/redfish/v1/Systems"] = http.StatusServiceUnavailable
+	stubInventoryResyncDB(t, b, "")
+	e = b.externalInterface()
+	resp = e.ResyncInventory(ctx, globalInventoryResync)
+	body, _ = resp.Body.(agresponse.InventoryResyncResponse)
+	if resp.StatusCode != http.StatusOK || body.ServersFailed != 1 || b.lastTask.TaskStatus != common.Warning {
+		t.Errorf("ResyncInventory() = %+v with task %+v, want a failed server", resp, b.lastTask)
+	}
+
+	// the aggregate is not found
+	if resp := e.ResyncInventory(ctx, "unknown"); resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("ResyncInventory() status = %v, want %v", resp.StatusCode, http.StatusInternalServerError)
+	}
+}
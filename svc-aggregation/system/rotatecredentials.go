@@ -0,0 +1,485 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+const (
+	rotateCredentialsTargetURI = "/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials"
+	bmcAccountsURI             = "/redfish/v1/AccountService/Accounts"
+	credentialRotationOp       = "CredentialRotation"
+
+	credentialsRotated    = "Rotated"
+	credentialsRolledBack = "RolledBack"
+	credentialsFailed     = "Failed"
+
+	defaultPasswordLength = 16
+	minPasswordLength     = 8
+	maxPasswordLength     = 64
+)
+
+var (
+	// UpdateSystemData function pointer for the agmodel.UpdateSystemData
+	UpdateSystemData = agmodel.UpdateSystemData
+	// UpdateAggregtionSource function pointer for the agmodel.UpdateAggregtionSource
+	UpdateAggregtionSource = agmodel.UpdateAggregtionSource
+)
+
+// passwordCharacterClasses are the classes of the characters of a generated password,
+// a generated password has at least one character of each class
+var passwordCharacterClasses = []string{
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"abcdefghijkmnopqrstuvwxyz",
+	"23456789",
+	"!#%*+-=?@_",
+}
+
+// RotateCredentialsRequest is the request of the AggregationService.RotateCredentials action.
+// The BMC aggregation sources are either the ones of the servers of the Aggregate or the listed
+// AggregationSources, a password is generated with PasswordLength characters for the sources
+// whose new password is not given
+type RotateCredentialsRequest struct {
+	Aggregate          *agmodel.OdataID          `json:"Aggregate,omitempty"`
+	AggregationSources []RotateCredentialsSource `json:"AggregationSources,omitempty"`
+	PasswordLength     int                       `json:"PasswordLength,omitempty"`
+}
+
+// RotateCredentialsSource is an aggregation source of the AggregationService.RotateCredentials action
+type RotateCredentialsSource struct {
+	OdataID  string `json:"@odata.id"`
+	Password string `json:"Password,omitempty"`
+}
+
+// ParseRotateCredentialsRequest returns the request of the AggregationService.RotateCredentials action.
+// The returned response is not empty when the request is not valid
+func ParseRotateCredentialsRequest(body []byte) (RotateCredentialsRequest, response.RPC) {
+	var req RotateCredentialsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		errMsg := "unable to parse the rotate credentials request: " + err.Error()
+		return req, common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(body, req)
+	if err != nil {
+		errMsg := "error while validating request parameters: " + err.Error()
+		return req, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	} else if invalidProperties != "" {
+		errMsg := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errMsg, []interface{}{invalidProperties}, nil)
+	}
+	switch {
+	case req.Aggregate != nil && len(req.AggregationSources) > 0:
+		errMsg := "error: the aggregation sources must be given either in Aggregate or in AggregationSources"
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"Aggregate", "AggregationSources"}, nil)
+	case req.Aggregate == nil && len(req.AggregationSources) == 0:
+		errMsg := "error: mandatory field AggregationSources missing in the request"
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"AggregationSources"}, nil)
+	case req.Aggregate != nil && !strings.HasPrefix(req.Aggregate.OdataID, aggregatesURI):
+		errMsg := "error: invalid aggregate " + req.Aggregate.OdataID
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{req.Aggregate.OdataID, "Aggregate"}, nil)
+	case req.PasswordLength != 0 && (req.PasswordLength < minPasswordLength || req.PasswordLength > maxPasswordLength):
+		errMsg := fmt.Sprintf("error: PasswordLength must be between %d and %d", minPasswordLength, maxPasswordLength)
+		return req, common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{fmt.Sprint(req.PasswordLength), "PasswordLength"}, nil)
+	}
+	// an aggregation source listed more than once is rotated once, with the first of its passwords
+	var sources []RotateCredentialsSource
+	listed := make(map[string]bool)
+	for _, source := range req.AggregationSources {
+		if !strings.HasPrefix(source.OdataID, aggregationSourcesURI+"/") {
+			errMsg := "error: invalid aggregation source " + source.OdataID
+			return req, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{source.OdataID, "AggregationSources"}, nil)
+		}
+		sourceURI := strings.TrimSuffix(source.OdataID, "/")
+		if listed[sourceURI] {
+			continue
+		}
+		listed[sourceURI] = true
+		sources = append(sources, source)
+	}
+	req.AggregationSources = sources
+	if req.PasswordLength == 0 {
+		req.PasswordLength = defaultPasswordLength
+	}
+	return req, response.RPC{}
+}
+
+// RotateCredentials rotates the passwords of the BMC aggregation sources of the request, CredentialRotationWorkerPoolCount
+// sources at a time. Each source is rotated in a subtask of the task of the action: the password of the account of
+// the aggregation source is changed on the BMC through its plugin, the login with the new password is verified and
+// the encrypted password is updated in the DB. The password of the BMC is rolled back when a step after its change fails
+func (e *ExternalInterface) RotateCredentials(ctx context.Context, taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	rotateReq, perr := ParseRotateCredentialsRequest(req.RequestBody)
+	// the passwords of the request are not kept in the task
+	taskRequest := maskRotateCredentialsRequest(rotateReq)
+	taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: taskID, TargetURI: rotateCredentialsTargetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	if perr.StatusCode != 0 {
+		l.LogWithFields(ctx).Error("invalid rotate credentials request")
+		e.UpdateTask(ctx, fillTaskData(taskID, rotateCredentialsTargetURI, taskRequest, perr, common.Exception, common.Critical, 100, http.MethodPost))
+		return perr
+	}
+	sources := rotateReq.AggregationSources
+	if rotateReq.Aggregate != nil {
+		var errResp response.RPC
+		sources, errResp = e.getAggregateSources(ctx, rotateReq.Aggregate.OdataID, taskInfo)
+		if errResp.StatusCode != 0 {
+			return errResp
+		}
+	}
+	err := e.UpdateTask(ctx, fillTaskData(taskID, rotateCredentialsTargetURI, taskRequest, resp, common.Running, common.OK, 0, http.MethodPost))
+	if err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	results := make([]agresponse.RotateCredentialsResult, len(sources))
+	var percentComplete int32
+	completed := runInWorkerPool(config.Data.CredentialRotationWorkerPoolCount, len(sources), func(i int) {
+		results[i] = e.rotateSourceCredentials(ctx, taskID, sessionUserName, sources[i], rotateReq.PasswordLength)
+	}, func(completed int) bool {
+		percentComplete = int32(completed * 100 / len(sources))
+		err := e.UpdateTask(ctx, fillTaskData(taskID, rotateCredentialsTargetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost))
+		return err == nil || err.Error() != common.Cancelling
+	})
+	if !completed {
+		// the sources which are being rotated are completed, the remaining ones are not started
+		l.LogWithFields(ctx).Info("rotate credentials task " + taskID + " is cancelled")
+		e.UpdateTask(ctx, fillTaskData(taskID, rotateCredentialsTargetURI, taskRequest, resp, common.Cancelled, common.OK, percentComplete, http.MethodPost))
+		return resp
+	}
+
+	body := agresponse.RotateCredentialsResponse{
+		Total:   len(results),
+		Results: results,
+	}
+	for _, result := range results {
+		switch result.Result {
+		case credentialsRotated:
+			body.Rotated++
+		case credentialsRolledBack:
+			body.RolledBack++
+		default:
+			body.Failed++
+		}
+	}
+	taskStatus := common.OK
+	if body.Rotated != body.Total {
+		taskStatus = common.Warning
+	}
+	commonResponse := response.Response{
+		OdataType: "#ActionResponse.v1_0_0.ActionResponse",
+		OdataID:   rotateCredentialsTargetURI,
+		ID:        taskID,
+		Name:      "Rotate Credentials",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	commonResponse.Message = fmt.Sprintf("the credentials of %d of the %d aggregation sources are rotated, for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%s",
+		body.Rotated, body.Total, taskID)
+	body.Response = commonResponse
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	resp.Body = body
+	e.UpdateTask(ctx, fillTaskData(taskID, rotateCredentialsTargetURI, taskRequest, resp, common.Completed, taskStatus, 100, http.MethodPost))
+	l.LogWithFields(ctx).Infof("rotate credentials task %s completed: %d rotated, %d rolled back, %d failed", taskID, body.Rotated, body.RolledBack, body.Failed)
+	return resp
+}
+
+// getAggregateSources returns the BMC aggregation sources of the servers of the aggregate
+func (e *ExternalInterface) getAggregateSources(ctx context.Context, aggregateURI string, taskInfo *common.TaskUpdateInfo) ([]RotateCredentialsSource, response.RPC) {
+	aggregate, err := GetAggregate(aggregateURI)
+	if err != nil {
+		errMsg := "unable to get the aggregate " + aggregateURI + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return nil, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Aggregate", aggregateURI}, taskInfo)
+		}
+		return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+	}
+	var sources []RotateCredentialsSource
+	for _, deviceUUID := range aggregateDeviceUUIDs(aggregate.Elements) {
+		keys, err := agmodel.GetAllMatchingDetails("AggregationSource", aggregationSourcesURI+"/"+deviceUUID+".", common.OnDisk)
+		if err != nil {
+			errMsg := "unable to get the aggregation source of the BMC with ID " + deviceUUID + ": " + err.Error()
+			l.LogWithFields(ctx).Error(errMsg)
+			return nil, common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		}
+		for _, key := range keys {
+			sources = append(sources, RotateCredentialsSource{OdataID: key})
+		}
+	}
+	if len(sources) == 0 {
+		errMsg := "error: the aggregate " + aggregateURI + " has no BMC aggregation source"
+		l.LogWithFields(ctx).Error(errMsg)
+		return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"Aggregate", "Elements"}, taskInfo)
+	}
+	return sources, response.RPC{}
+}
+
+// rotateSourceCredentials rotates the password of an aggregation source in a subtask of the task
+func (e *ExternalInterface) rotateSourceCredentials(ctx context.Context, taskID, sessionUserName string, source RotateCredentialsSource, passwordLength int) agresponse.RotateCredentialsResult {
+	sourceURI := strings.TrimSuffix(source.OdataID, "/")
+	result := agresponse.RotateCredentialsResult{OdataID: sourceURI, Result: credentialsFailed}
+	subTaskURI, err := e.CreateChildTask(ctx, sessionUserName, taskID)
+	if err != nil {
+		result.Message = "unable to create the subtask: " + err.Error()
+		l.LogWithFields(ctx).Error(result.Message)
+		return result
+	}
+	subTaskURI = strings.TrimSuffix(subTaskURI, "/")
+	subTaskID := subTaskURI[strings.LastIndex(subTaskURI, "/")+1:]
+	result.SubTask = subTaskURI
+
+	password := source.Password
+	if password == "" {
+		password, err = generateBMCPassword(passwordLength)
+		if err != nil {
+			result.Message = "unable to generate the password: " + err.Error()
+			l.LogWithFields(ctx).Error(result.Message)
+			taskInfo := &common.TaskUpdateInfo{Context: ctx, TaskID: subTaskID, TargetURI: sourceURI, UpdateTask: e.UpdateTask}
+			common.GeneralError(http.StatusInternalServerError, response.InternalError, result.Message, nil, taskInfo)
+			return result
+		}
+	}
+	result.Result, err = e.rotateBMCCredentials(ctx, sourceURI, password)
+	if err != nil {
+		result.Message = err.Error()
+		l.LogWithFields(ctx).Error("credential rotation of " + sourceURI + " failed: " + result.Message)
+		taskStatus := common.Critical
+		if result.Result == credentialsRolledBack {
+			taskStatus = common.Warning
+		}
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, result.Message, nil, nil)
+		e.UpdateTask(ctx, fillTaskData(subTaskID, sourceURI, "", resp, common.Exception, taskStatus, 100, http.MethodPost))
+		return result
+	}
+	commonResponse := response.Response{
+		OdataType:    common.AggregationSourceType,
+		OdataID:      sourceURI,
+		OdataContext: "/redfish/v1/$metadata#AggregationSource.AggregationSource",
+		ID:           sourceURI[strings.LastIndex(sourceURI, "/")+1:],
+		Name:         "Aggregation Source",
+	}
+	commonResponse.CreateGenericResponse(response.Success)
+	resp := response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          commonResponse,
+	}
+	e.UpdateTask(ctx, fillTaskData(subTaskID, sourceURI, "", resp, common.Completed, common.OK, 100, http.MethodPost))
+	l.LogWithFields(ctx).Info("credentials of " + sourceURI + " are rotated")
+	return result
+}
+
+// rotateBMCCredentials changes the password of the account of the BMC aggregation source on the BMC, verifies
+// the login with the new password and updates the encrypted password of the BMC and of the aggregation source in the DB.
+// It returns the result of the rotation and the error of the failed step, the password of the BMC is rolled back
+// when a step after its change fails
+func (e *ExternalInterface) rotateBMCCredentials(ctx context.Context, sourceURI, password string) (string, error) {
+	aggregationSource, dbErr := e.GetAggregationSourceInfo(ctx, sourceURI)
+	if dbErr != nil {
+		return credentialsFailed, fmt.Errorf("unable to get the aggregation source: %v", dbErr.Error())
+	}
+	deviceUUID := strings.SplitN(sourceURI[strings.LastIndex(sourceURI, "/")+1:], ".", 2)[0]
+	target, err := GetTarget(deviceUUID)
+	if err != nil || target == nil {
+		return credentialsFailed, fmt.Errorf("the aggregation source is not of a BMC")
+	}
+
+	// the credentials of the aggregation source must not be rotated concurrently, and
+	// the servers must not be deleted or rediscovered while the credentials are rotated
+	keys, dbErr := e.GetAllMatchingDetails("*", deviceUUID, common.InMemory)
+	if dbErr != nil {
+		return credentialsFailed, dbErr
+	}
+	_, systemURIs := inventoryResourceKeys(keys)
+	for _, uri := range append([]string{sourceURI}, systemURIs...) {
+		claimed, dbErr := ClaimSystemOperation(agmodel.SystemOperation{Operation: credentialRotationOp}, uri)
+		if dbErr != nil {
+			return credentialsFailed, dbErr
+		}
+		if !claimed {
+			systemOperation, _ := GetSystemOperationInfo(ctx, uri)
+			return credentialsFailed, fmt.Errorf("%s operation is under progress for %s", systemOperation.Operation, uri)
+		}
+		defer DeleteSystemOperationInfo(uri)
+	}
+
+	req, err := e.getTargetPluginRequest(ctx, *target)
+	if err != nil {
+		return credentialsFailed, err
+	}
+	device := req.DeviceInfo.(agmodel.Target)
+	oldPassword := string(device.Password)
+	accountURI, err := findBMCAccount(ctx, req, device.UserName)
+	if err != nil {
+		return credentialsFailed, err
+	}
+	if err := changeBMCPassword(ctx, req, device, accountURI, password); err != nil {
+		return credentialsFailed, err
+	}
+
+	// the BMC has the new password from now on, it is rolled back on failure
+	device.Password = []byte(password)
+	rollback := func(err error) (string, error) {
+		if rerr := changeBMCPassword(ctx, req, device, accountURI, oldPassword); rerr != nil {
+			return credentialsFailed, fmt.Errorf("%v, and the rollback of the password on the BMC failed: %v", err, rerr)
+		}
+		return credentialsRolledBack, fmt.Errorf("%v, the password is rolled back on the BMC", err)
+	}
+	if err := verifyBMCLogin(ctx, req, device); err != nil {
+		return rollback(err)
+	}
+	ciphertext, err := e.EncryptPassword([]byte(password))
+	if err != nil {
+		return rollback(fmt.Errorf("encryption of the password failed: %v", err))
+	}
+	saveSystem := agmodel.SaveSystem{
+		ManagerAddress: target.ManagerAddress,
+		Password:       ciphertext,
+		UserName:       target.UserName,
+		DeviceUUID:     target.DeviceUUID,
+		PluginID:       target.PluginID,
+	}
+	if dbErr := UpdateSystemData(saveSystem, deviceUUID); dbErr != nil {
+		return rollback(fmt.Errorf("unable to update the password of the BMC in the DB: %v", dbErr.Error()))
+	}
+	aggregationSource.Password = ciphertext
+	if dbErr := UpdateAggregtionSource(aggregationSource, sourceURI); dbErr != nil {
+		saveSystem.Password = target.Password
+		if rerr := UpdateSystemData(saveSystem, deviceUUID); rerr != nil {
+			l.LogWithFields(ctx).Error("unable to restore the password of the BMC with ID " + deviceUUID + " in the DB: " + rerr.Error())
+		}
+		return rollback(fmt.Errorf("unable to update the password of the aggregation source in the DB: %v", dbErr.Error()))
+	}
+	return credentialsRotated, nil
+}
+
+// findBMCAccount returns the URI of the account of the user on the BMC
+func findBMCAccount(ctx context.Context, req getResourceRequest, userName string) (string, error) {
+	req.HTTPMethodType = http.MethodGet
+	req.OID = bmcAccountsURI
+	body, _, resp, err := contactPlugin(ctx, req, "error while trying to get the accounts of the BMC: ")
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed {
+		// the plugin has no AccountService routes, the BMC is left untouched
+		return "", fmt.Errorf("the plugin %s does not support the rotation of the credentials of the BMC", req.Plugin.ID)
+	}
+	if err != nil {
+		return "", err
+	}
+	var accounts struct {
+		Members []agmodel.OdataID `json:"Members"`
+	}
+	if err := json.Unmarshal(body, &accounts); err != nil {
+		return "", fmt.Errorf("unable to parse the accounts of the BMC: %v", err)
+	}
+	for _, member := range accounts.Members {
+		req.OID = member.OdataID
+		body, _, _, err := contactPlugin(ctx, req, "error while trying to get the account "+req.OID+" of the BMC: ")
+		if err != nil {
+			return "", err
+		}
+		var account struct {
+			UserName string `json:"UserName"`
+		}
+		if err := json.Unmarshal(body, &account); err != nil {
+			return "", fmt.Errorf("unable to parse the account %s of the BMC: %v", req.OID, err)
+		}
+		if account.UserName == userName {
+			return req.OID, nil
+		}
+	}
+	return "", fmt.Errorf("the BMC has no account of the user %s", userName)
+}
+
+// changeBMCPassword changes the password of the account of the BMC, the plugin logs in to the BMC with the device credentials
+func changeBMCPassword(ctx context.Context, req getResourceRequest, device agmodel.Target, accountURI, password string) error {
+	device.PostBody, _ = json.Marshal(map[string]string{"Password": password})
+	req.DeviceInfo = device
+	req.HTTPMethodType = http.MethodPatch
+	req.OID = accountURI
+	_, _, resp, err := contactPlugin(ctx, req, "error while trying to change the password of the account "+accountURI+" of the BMC: ")
+	if err != nil && resp.StatusCode != http.StatusNoContent {
+		return err
+	}
+	return nil
+}
+
+// verifyBMCLogin verifies the login to the BMC with the device credentials
+func verifyBMCLogin(ctx context.Context, req getResourceRequest, device agmodel.Target) error {
+	req.DeviceInfo = agmodel.SaveSystem{
+		ManagerAddress: device.ManagerAddress,
+		UserName:       device.UserName,
+		Password:       device.Password,
+	}
+	req.HTTPMethodType = http.MethodPost
+	req.OID = "/ODIM/v1/validate"
+	_, _, _, err := contactPlugin(ctx, req, "error while trying to login to the BMC with the new password: ")
+	return err
+}
+
+// generateBMCPassword returns a random password of length characters with at least one character of each of the passwordCharacterClasses
+func generateBMCPassword(length int) (string, error) {
+	characters := strings.Join(passwordCharacterClasses, "")
+	password := make([]byte, length)
+	for {
+		for i := range password {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(characters))))
+			if err != nil {
+				return "", err
+			}
+			password[i] = characters[n.Int64()]
+		}
+		if hasAllCharacterClasses(string(password)) {
+			return string(password), nil
+		}
+	}
+}
+
+func hasAllCharacterClasses(password string) bool {
+	for _, class := range passwordCharacterClasses {
+		if !strings.ContainsAny(password, class) {
+			return false
+		}
+	}
+	return true
+}
+
+// maskRotateCredentialsRequest returns the rotate credentials request with the passwords removed
+func maskRotateCredentialsRequest(req RotateCredentialsRequest) string {
+	masked := req
+	masked.AggregationSources = make([]RotateCredentialsSource, len(req.AggregationSources))
+	for i, source := range req.AggregationSources {
+		masked.AggregationSources[i] = RotateCredentialsSource{OdataID: source.OdataID}
+	}
+	data, _ := json.Marshal(masked)
+	return string(data)
+}
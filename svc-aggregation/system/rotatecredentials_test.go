@@ -0,0 +1,283 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestParseRotateCredentialsRequest(t *testing.T) {
+	source := `{"@odata.id":"/redfish/v1/AggregationService/AggregationSources/uuid.1"}`
+	tests := []struct {
+		name string
+		body string
+		want int32
+	}{
+		{name: "aggregation sources", body: `{"AggregationSources":[` + source + `]}`, want: 0},
+		{name: "aggregate", body: `{"Aggregate":{"@odata.id":"/redfish/v1/AggregationService/Aggregates/a1"},"PasswordLength":20}`, want: 0},
+		{name: "malformed", body: `{"AggregationSources":{}}`, want: http.StatusBadRequest},
+		{name: "unknown property", body: `{"aggregationSources":[` + source + `]}`, want: http.StatusBadRequest},
+		{name: "missing sources", body: `{}`, want: http.StatusBadRequest},
+		{name: "aggregate and sources", body: `{"Aggregate":{"@odata.id":"/redfish/v1/AggregationService/Aggregates/a1"},"AggregationSources":[` + source + `]}`, want: http.StatusBadRequest},
+		{name: "invalid aggregate", body: `{"Aggregate":{"@odata.id":"/redfish/v1/Systems/a1"}}`, want: http.StatusBadRequest},
+		{name: "invalid source", body: `{"AggregationSources":[{"@odata.id":"/redfish/v1/Systems/uuid.1"}]}`, want: http.StatusBadRequest},
+		{name: "password too short", body: `{"AggregationSources":[` + source + `],"PasswordLength":4}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, resp := ParseRotateCredentialsRequest([]byte(tt.body)); resp.StatusCode != tt.want {
+				t.Errorf("ParseRotateCredentialsRequest() status = %v, want %v", resp.StatusCode, tt.want)
+			}
+		})
+	}
+	if req, _ := ParseRotateCredentialsRequest([]byte(`{"AggregationSources":[` + source + `]}`)); req.PasswordLength != defaultPasswordLength {
+		t.Errorf("ParseRotateCredentialsRequest() PasswordLength = %v, want %v", req.PasswordLength, defaultPasswordLength)
+	}
+	duplicates := `{"AggregationSources":[` + source + `,{"@odata.id":"/redfish/v1/AggregationService/AggregationSources/uuid.1/"},` +
+		`{"@odata.id":"/redfish/v1/AggregationService/AggregationSources/uuid.2"},` + source + `]}`
+	req, _ := ParseRotateCredentialsRequest([]byte(duplicates))
+	want := []RotateCredentialsSource{
+		{OdataID: "/redfish/v1/AggregationService/AggregationSources/uuid.1"},
+		{OdataID: "/redfish/v1/AggregationService/AggregationSources/uuid.2"},
+	}
+	if !reflect.DeepEqual(req.AggregationSources, want) {
+		t.Errorf("ParseRotateCredentialsRequest() AggregationSources = %v, want %v", req.AggregationSources, want)
+	}
+}
+
+func TestGenerateBMCPassword(t *testing.T) {
+	for _, length := range []int{minPasswordLength, defaultPasswordLength, maxPasswordLength} {
+		password, err := generateBMCPassword(length)
+		if err != nil {
+			t.Fatalf("generateBMCPassword() error = %v", err)
+		}
+		if len(password) != length || !hasAllCharacterClasses(password) {
+			t.Errorf("generateBMCPassword(%d) = %s", length, password)
+		}
+	}
+}
+
+func TestMaskRotateCredentialsRequest(t *testing.T) {
+	req := RotateCredentialsRequest{
+		AggregationSources: []RotateCredentialsSource{
+			{OdataID: "/redfish/v1/AggregationService/AggregationSources/uuid.1", Password: "secret"},
+		},
+	}
+	if masked := maskRotateCredentialsRequest(req); strings.Contains(masked, "secret") {
+		t.Errorf("maskRotateCredentialsRequest() = %s", masked)
+	}
+	if req.AggregationSources[0].Password != "secret" {
+		t.Errorf("maskRotateCredentialsRequest() changed the request")
+	}
+}
+
+// mockRotateBMC is a BMC whose password is rotated through its plugin
+type mockRotateBMC struct {
+	*mockResyncBMC
+	// passwords set on the BMC, the PATCH requests listed in failedPatches fail
+	patches       []string
+	failedPatches map[int]bool
+	failedLogin   bool
+	unsupported   bool
+	// encrypted passwords updated in the DB
+	systemUpdates    []string
+	sourceUpdates    []string
+	failSystemUpdate bool
+	failSourceUpdate bool
+}
+
+func (b *mockRotateBMC) contactClient(ctx context.Context, url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+	status := http.StatusOK
+	switch {
+	case method == http.MethodPatch:
+		if b.failedPatches[len(b.patches)+1] {
+			status = http.StatusInternalServerError
+		}
+		if strings.Replace(odataID, "/ODIM/", "/redfish/", 1) != bmcAccountsURI+"/odim.admin" {
+			status = http.StatusNotFound
+		}
+		var patch struct {
+			Password string `json:"Password"`
+		}
+		json.Unmarshal(body.(agmodel.Target).PostBody, &patch)
+		b.patches = append(b.patches, patch.Password)
+	case odataID == "/ODIM/v1/validate":
+		if b.failedLogin {
+			status = http.StatusUnauthorized
+		}
+	default:
+		return b.mockResyncBMC.contactClient(ctx, url, method, token, odataID, body, credentials)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+	}, nil
+}
+
+func TestRotateBMCCredentials(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	sourceURI := aggregationSourcesURI + "/" + resyncDeviceUUID + ".1"
+	tests := []struct {
+		name             string
+		bmc              *mockRotateBMC
+		systemOperation  string
+		sourceClaimed    bool
+		want             string
+		wantErr          string
+		wantPatches      []string
+		wantSystemUpdate []string
+		wantSourceUpdate []string
+	}{
+		{
+			name:             "rotated",
+			bmc:              &mockRotateBMC{},
+			want:             credentialsRotated,
+			wantPatches:      []string{"newPassword"},
+			wantSystemUpdate: []string{"encrypted:newPassword"},
+			wantSourceUpdate: []string{"encrypted:newPassword"},
+		},
+		{
+			name:            "server under another operation",
+			bmc:             &mockRotateBMC{},
+			systemOperation: inventoryResyncOp,
+			want:            credentialsFailed,
+			wantErr:         "InventoryResync operation is under progress",
+		},
+		{
+			name:          "aggregation source under a concurrent rotation",
+			bmc:           &mockRotateBMC{},
+			sourceClaimed: true,
+			want:          credentialsFailed,
+			wantErr:       "CredentialRotation operation is under progress for " + sourceURI,
+		},
+		{
+			name:    "plugin without AccountService",
+			bmc:     &mockRotateBMC{unsupported: true},
+			want:    credentialsFailed,
+			wantErr: "does not support the rotation of the credentials of the BMC",
+		},
+		{
+			name:    "password change refused by the BMC",
+			bmc:     &mockRotateBMC{failedPatches: map[int]bool{1: true}},
+			want:    credentialsFailed,
+			wantErr: "error while trying to change the password",
+			// the password of the BMC is unchanged, so it is not rolled back
+			wantPatches: []string{"newPassword"},
+		},
+		{
+			name:        "login with the new password fails",
+			bmc:         &mockRotateBMC{failedLogin: true},
+			want:        credentialsRolledBack,
+			wantErr:     "the password is rolled back on the BMC",
+			wantPatches: []string{"newPassword", "password"},
+		},
+		{
+			name:             "update of the aggregation source in the DB fails",
+			bmc:              &mockRotateBMC{failSourceUpdate: true},
+			want:             credentialsRolledBack,
+			wantErr:          "unable to update the password of the aggregation source in the DB",
+			wantPatches:      []string{"newPassword", "password"},
+			wantSystemUpdate: []string{"encrypted:newPassword", "password"},
+		},
+		{
+			name:        "update of the BMC in the DB fails",
+			bmc:         &mockRotateBMC{failSystemUpdate: true},
+			want:        credentialsRolledBack,
+			wantErr:     "unable to update the password of the BMC in the DB",
+			wantPatches: []string{"newPassword", "password"},
+		},
+		{
+			name:        "rollback fails",
+			bmc:         &mockRotateBMC{failedLogin: true, failedPatches: map[int]bool{2: true}},
+			want:        credentialsFailed,
+			wantErr:     "the rollback of the password on the BMC failed",
+			wantPatches: []string{"newPassword", "password"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.bmc
+			b.mockResyncBMC = newMockResyncBMC()
+			b.plugin[bmcAccountsURI] = `{"Members":[{"@odata.id":"/redfish/v1/AccountService/Accounts/1"},{"@odata.id":"/redfish/v1/AccountService/Accounts/odim.admin"}]}`
+			b.plugin[bmcAccountsURI+"/1"] = `{"UserName":"operator"}`
+			b.plugin[bmcAccountsURI+"/odim.admin"] = `{"UserName":"admin"}`
+			if b.unsupported {
+				b.pluginStatus[bmcAccountsURI] = http.StatusNotFound
+			}
+			stubInventoryResyncDB(t, b.mockResyncBMC, tt.systemOperation)
+			if tt.sourceClaimed {
+				ClaimSystemOperation = func(claim agmodel.SystemOperation, uri string) (bool, *errors.Error) {
+					return uri != sourceURI, nil
+				}
+				GetSystemOperationInfo = func(ctx context.Context, uri string) (agmodel.SystemOperation, *errors.Error) {
+					return agmodel.SystemOperation{Operation: credentialRotationOp}, nil
+				}
+			}
+			updateSystemData, updateAggregtionSource := UpdateSystemData, UpdateAggregtionSource
+			defer func() {
+				UpdateSystemData, UpdateAggregtionSource = updateSystemData, updateAggregtionSource
+			}()
+			UpdateSystemData = func(system agmodel.SaveSystem, key string) *errors.Error {
+				if b.failSystemUpdate {
+					return errors.PackError(errors.UndefinedErrorType, "unable to connect DB")
+				}
+				b.systemUpdates = append(b.systemUpdates, string(system.Password))
+				return nil
+			}
+			UpdateAggregtionSource = func(aggregationSource agmodel.AggregationSource, key string) *errors.Error {
+				if b.failSourceUpdate {
+					return errors.PackError(errors.UndefinedErrorType, "unable to connect DB")
+				}
+				b.sourceUpdates = append(b.sourceUpdates, string(aggregationSource.Password))
+				return nil
+			}
+			e := b.externalInterface()
+			e.ContactClient = b.contactClient
+			e.EncryptPassword = func(password []byte) ([]byte, error) {
+				return append([]byte("encrypted:"), password...), nil
+			}
+			e.GetAggregationSourceInfo = func(ctx context.Context, uri string) (agmodel.AggregationSource, *errors.Error) {
+				return agmodel.AggregationSource{HostName: "10.0.0.1", UserName: "admin", Password: []byte("password")}, nil
+			}
+
+			got, err := e.rotateBMCCredentials(ctx, sourceURI, "newPassword")
+			if got != tt.want {
+				t.Errorf("rotateBMCCredentials() = %v, want %v", got, tt.want)
+			}
+			if (err == nil) != (tt.wantErr == "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("rotateBMCCredentials() error = %v, want %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(b.patches, tt.wantPatches) {
+				t.Errorf("rotateBMCCredentials() set the passwords %v on the BMC, want %v", b.patches, tt.wantPatches)
+			}
+			if !reflect.DeepEqual(b.systemUpdates, tt.wantSystemUpdate) || !reflect.DeepEqual(b.sourceUpdates, tt.wantSourceUpdate) {
+				t.Errorf("rotateBMCCredentials() updated the BMC with %v and the source with %v in the DB, want %v and %v",
+					b.systemUpdates, b.sourceUpdates, tt.wantSystemUpdate, tt.wantSourceUpdate)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -28,11 +31,16 @@ import (
 // AccountRPCs defines all the RPC methods in account service
 type AccountRPCs struct {
 	GetServiceRPC     func(context.Context, accountproto.AccountRequest) (*accountproto.AccountResponse, error)
+	UpdateServiceRPC  func(context.Context, accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error)
 	CreateRPC         func(context.Context, accountproto.CreateAccountRequest) (*accountproto.AccountResponse, error)
 	GetAllAccountsRPC func(context.Context, accountproto.AccountRequest) (*accountproto.AccountResponse, error)
 	GetAccountRPC     func(context.Context, accountproto.GetAccountRequest) (*accountproto.AccountResponse, error)
 	UpdateRPC         func(context.Context, accountproto.UpdateAccountRequest) (*accountproto.AccountResponse, error)
 	DeleteRPC         func(context.Context, accountproto.DeleteAccountRequest) (*accountproto.AccountResponse, error)
+	CreateAPIKeyRPC   func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
+	GetAllAPIKeysRPC  func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
+	GetAPIKeyRPC      func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
+	DeleteAPIKeyRPC   func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error)
 }
 
 // GetAccountService defines the GetAccountService iris handler.
@@ -60,11 +68,59 @@ func (a *AccountRPCs) GetAccountService(ctx iris.Context) {
 		return
 	}
 	l.LogWithFields(ctx).Debugf("Outgoing response for Getting Account service is %s and response status %d", string(resp.Body), int(resp.StatusCode))
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	sendAccountResponse(ctx, resp)
 
 }
 
+// UpdateAccountService defines the UpdateAccountService iris handler.
+// The method extract the session token and the request body for configuring
+// the external account providers, and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) UpdateAccountService(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	ctxt := ctx.Request().Context()
+
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the account service update request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	l.LogWithFields(ctxt).Debug("Incoming request received for updating the account service")
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	// Marshalling the req to make account service request
+	// Since account service update request accepts byte stream
+	request, err := json.Marshal(req)
+	updateRequest := accountproto.UpdateAccountServiceRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+
+	resp, err := a.UpdateServiceRPC(ctxt, updateRequest)
+	if err != nil && resp == nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
+	sendAccountResponse(ctx, resp)
+	l.LogWithFields(ctxt).Debugf("outgoing response for updating account service is %s and response status %d", string(resp.Body), int(resp.StatusCode))
+}
+
 // CreateAccount defines the CreateAccount iris handler.
 // The method extract the session token, and necessary
 // request parameters and creates the RPC request.
@@ -201,6 +257,12 @@ func (a *AccountRPCs) UpdateAccount(ctx iris.Context) {
 		common.SendInvalidSessionResponse(ctx, errorMessage)
 	}
 
+	release, ok := checkPreconditions(ctx, a.getAccountBody(sessionToken))
+	if !ok {
+		return
+	}
+	defer release()
+
 	// Marshalling the req to make account request
 	// Since account update request accepts byte stream
 	request, err := json.Marshal(req)
@@ -242,6 +304,12 @@ func (a *AccountRPCs) DeleteAccount(ctx iris.Context) {
 		common.SendInvalidSessionResponse(ctx, errorMessage)
 	}
 
+	release, ok := checkPreconditions(ctx, a.getAccountBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
+
 	resp, err := a.DeleteRPC(ctxt, req)
 	if err != nil && resp == nil {
 		errorMessage := rpcCallFailedErrMsg + err.Error()
@@ -254,9 +322,113 @@ func (a *AccountRPCs) DeleteAccount(ctx iris.Context) {
 	l.LogWithFields(ctxt).Debugf("outgoing response for deleting account with %s and response status %d", req.AccountID, int(resp.StatusCode))
 }
 
+// CreateAPIKey defines the CreateAPIKey iris handler.
+// The method extract the session token, the account ID and the request body
+// for creating an API key of the account, and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) CreateAPIKey(ctx iris.Context) {
+	defer ctx.Next()
+	var req interface{}
+	ctxt := ctx.Request().Context()
+
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the create API key request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	// Marshalling the req to make API key request
+	// Since create API key request accepts byte stream
+	request, err := json.Marshal(req)
+	a.sendAPIKeyRequest(ctx, a.CreateAPIKeyRPC, request, "creating API key", "GET, POST")
+}
+
+// GetAllAPIKeys defines the GetAllAPIKeys iris handler.
+// The method extract the session token and the account ID, and creates the RPC request
+// for listing the API keys of the account.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) GetAllAPIKeys(ctx iris.Context) {
+	defer ctx.Next()
+	a.sendAPIKeyRequest(ctx, a.GetAllAPIKeysRPC, nil, "listing API keys", "GET, POST")
+}
+
+// GetAPIKey defines the GetAPIKey iris handler.
+// The method extract the session token, the account ID and the key ID,
+// and creates the RPC request for fetching the API key.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) GetAPIKey(ctx iris.Context) {
+	defer ctx.Next()
+	a.sendAPIKeyRequest(ctx, a.GetAPIKeyRPC, nil, "fetching API key", "GET, DELETE")
+}
+
+// DeleteAPIKey defines the DeleteAPIKey iris handler.
+// The method extract the session token, the account ID and the key ID,
+// and creates the RPC request for revoking the API key.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (a *AccountRPCs) DeleteAPIKey(ctx iris.Context) {
+	defer ctx.Next()
+	a.sendAPIKeyRequest(ctx, a.DeleteAPIKeyRPC, nil, "deleting API key", "GET, DELETE")
+}
+
+// sendAPIKeyRequest makes the RPC request of the API key of the account in the path
+// and writes the response to client
+func (a *AccountRPCs) sendAPIKeyRequest(ctx iris.Context, rpcFunc func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error),
+	requestBody []byte, operation, allow string) {
+	ctxt := ctx.Request().Context()
+	req := accountproto.APIKeyRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		AccountID:    ctx.Params().Get("id"),
+		KeyID:        ctx.Params().Get("rid"),
+		RequestBody:  requestBody,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request for %s received for the account %s", operation, req.AccountID)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	resp, err := rpcFunc(ctxt, req)
+	if err != nil && resp == nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	ctx.ResponseWriter().Header().Set("Allow", allow)
+	sendAccountResponse(ctx, resp)
+	l.LogWithFields(ctxt).Debugf("outgoing response for %s is %s and response status %d", operation, string(resp.Body), int(resp.StatusCode))
+}
+
 // sendAccountResponse writes the account response to client
 func sendAccountResponse(ctx iris.Context, resp *accountproto.AccountResponse) {
+	setETag(ctx, resp.StatusCode, resp.Body)
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// getAccountBody returns a resourceFetcher which gets the account details
+// through GetAccountRPC, used to evaluate the conditional requests
+func (a *AccountRPCs) getAccountBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := a.GetAccountRPC(ctx, accountproto.GetAccountRequest{
+			SessionToken: sessionToken,
+			AccountID:    oid[strings.LastIndex(oid, "/")+1:],
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
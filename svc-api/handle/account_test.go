@@ -43,6 +43,15 @@ func mockGetAccountServiceRPC(ctx context.Context, req accountproto.AccountReque
 	}, nil
 }
 
+func mockUpdateAccountServiceRPC(ctx context.Context, req accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error) {
+	if req.SessionToken == "TokenRPC" {
+		return nil, errors.New("RPC Error")
+	}
+	return &accountproto.AccountResponse{
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
 func mockCreateAccountRPC(ctx context.Context, req accountproto.CreateAccountRequest) (*accountproto.AccountResponse, error) {
 	if req.SessionToken == "TokenRPC" {
 		return nil, errors.New("RPC Error")
@@ -89,7 +98,7 @@ func mockDeleteAccountRPC(ctx context.Context, req accountproto.DeleteAccountReq
 }
 
 func TestAccountRPCs_GetAccountService(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, PATCH"}
 	defer delete(header, "Allow")
 	var a AccountRPCs
 	a.GetServiceRPC = mockGetAccountServiceRPC
@@ -121,6 +130,97 @@ func TestAccountRPCs_GetAccountServiceWithOutToken(t *testing.T) {
 	).Expect().Status(http.StatusUnauthorized).Headers().Equal(header)
 }
 
+func TestAccountRPCs_UpdateAccountService(t *testing.T) {
+	var a AccountRPCs
+	a.UpdateServiceRPC = mockUpdateAccountServiceRPC
+
+	body := map[string]interface{}{
+		"LDAP": map[string]interface{}{
+			"ServiceEnabled":   true,
+			"ServiceAddresses": []string{"ldaps://ldap.example.com"},
+		},
+	}
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Patch("/AccountService", a.UpdateAccountService)
+
+	e := httptest.New(t, mockApp)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusOK)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.PATCH(
+		"/redfish/v1/AccountService",
+	).WithHeader("X-Auth-Token", "TokenRPC").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
+func mockAPIKeyRPC(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	if req.SessionToken == "TokenRPC" {
+		return nil, errors.New("RPC Error")
+	}
+	if req.KeyID != "" && req.KeyID != "key1" {
+		return &accountproto.AccountResponse{
+			StatusCode: http.StatusNotFound,
+		}, nil
+	}
+	return &accountproto.AccountResponse{
+		StatusCode: http.StatusOK,
+	}, nil
+}
+
+func TestAccountRPCs_APIKeys(t *testing.T) {
+	var a AccountRPCs
+	a.CreateAPIKeyRPC = mockAPIKeyRPC
+	a.GetAllAPIKeysRPC = mockAPIKeyRPC
+	a.GetAPIKeyRPC = mockAPIKeyRPC
+	a.DeleteAPIKeyRPC = mockAPIKeyRPC
+
+	body := map[string]interface{}{
+		"Name":           "backup",
+		"ExpirationDate": "2030-01-01T00:00:00Z",
+	}
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/AccountService/Accounts/{id}/APIKeys")
+	redfishRoutes.Get("/", a.GetAllAPIKeys)
+	redfishRoutes.Post("/", a.CreateAPIKey)
+	redfishRoutes.Get("/{rid}", a.GetAPIKey)
+	redfishRoutes.Delete("/{rid}", a.DeleteAPIKey)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusOK)
+	e.POST(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.POST(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	e.GET(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).Header("Allow").Equal("GET, POST")
+	e.GET(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys",
+	).WithHeader("X-Auth-Token", "TokenRPC").Expect().Status(http.StatusInternalServerError)
+	e.GET(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys/key1",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK).Header("Allow").Equal("GET, DELETE")
+	e.GET(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys/key2",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusNotFound)
+	e.DELETE(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys/key1",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK)
+	e.DELETE(
+		"/redfish/v1/AccountService/Accounts/admin/APIKeys/key1",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+}
+
 func TestAccountRPCs_CreateAccount(t *testing.T) {
 	var a AccountRPCs
 	a.CreateRPC = mockCreateAccountRPC
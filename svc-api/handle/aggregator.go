@@ -18,6 +18,7 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -49,6 +50,15 @@ type AggregatorRPCs struct {
 	GetConnectionMethodRPC                  func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetResetActionInfoServiceRPC            func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetSetDefaultBootOrderActionInfoRPC     func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	ImportSourcesRPC                        func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	GetImportSourcesReportRPC               func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	DiscoverSourcesRPC                      func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	GetAllDiscoveredSourcesRPC              func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	GetDiscoveredSourceRPC                  func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	ApproveDiscoveredSourceRPC              func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	DeleteDiscoveredSourceRPC               func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	GetInventoryHistoryRPC                  func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	RotateCredentialsRPC                    func(context.Context, aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 }
 
 const (
@@ -293,6 +303,11 @@ func (a *AggregatorRPCs) UpdateAggregationSource(ctx iris.Context) {
 		URL:          ctx.Request().RequestURI,
 	}
 	l.LogWithFields(ctxt).Debugf("Incoming request received for updating aggregationsource with uri %s", updateRequest.URL)
+	release, ok := checkPreconditions(ctx, a.getAggregationSourceBody(sessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := a.UpdateAggregationSourceRPC(ctxt, updateRequest)
 	if err != nil {
 		errorMessage := "RPC error: " + err.Error()
@@ -319,6 +334,11 @@ func (a *AggregatorRPCs) DeleteAggregationSource(ctx iris.Context) {
 		errorMessage := invalidAuthTokenErrMsg
 		common.SendInvalidSessionResponse(ctx, errorMessage)
 	}
+	release, ok := checkPreconditions(ctx, a.getAggregationSourceBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := a.DeleteAggregationSourceRPC(ctxt, req)
 	if err != nil {
 		errorMessage := " RPC error:" + err.Error()
@@ -683,9 +703,347 @@ func (a *AggregatorRPCs) GetSetDefaultBootOrderActionInfo(ctx iris.Context) {
 
 }
 
+// ImportSources is the handler for the bulk import of aggregation sources from a manifest
+func (a *AggregatorRPCs) ImportSources(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for importing aggregation sources")
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	// marshalling the req to make aggregator ImportSources request
+	// Since aggregator ImportSources accepts []byte stream
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	importRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.ImportSourcesRPC(ctxt, importRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for importing aggregation sources is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	sendAggregatorResponse(ctx, resp)
+}
+
+// RotateCredentials is the handler for the rotation of the credentials of BMC aggregation sources
+func (a *AggregatorRPCs) RotateCredentials(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for rotating the credentials of aggregation sources")
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	// marshalling the req to make aggregator RotateCredentials request
+	// Since aggregator RotateCredentials accepts []byte stream
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	rotateRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.RotateCredentialsRPC(ctxt, rotateRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for rotating the credentials of aggregation sources is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	sendAggregatorResponse(ctx, resp)
+}
+
+// GetImportSourcesReport is the handler for getting the report of an import sources action,
+// as JSON or as CSV for the Report.csv URI
+func (a *AggregatorRPCs) GetImportSourcesReport(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting the import sources report with the request URL %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetImportSourcesReportRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting the import sources report has response code %d", int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	sendAggregatorResponse(ctx, resp)
+}
+
+// DiscoverSources is the handler for the discovery of the Redfish services of subnets
+func (a *AggregatorRPCs) DiscoverSources(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for discovering aggregation sources")
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	// marshalling the req to make aggregator DiscoverSources request
+	// Since aggregator DiscoverSources accepts []byte stream
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	discoverRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.DiscoverSourcesRPC(ctxt, discoverRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for discovering aggregation sources is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	sendAggregatorResponse(ctx, resp)
+}
+
+// GetAllDiscoveredSources is the handler for getting the sources found by the discovery
+func (a *AggregatorRPCs) GetAllDiscoveredSources(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting all discovered sources")
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetAllDiscoveredSourcesRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting all discovered sources is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	sendAggregatorResponse(ctx, resp)
+}
+
+// GetDiscoveredSource is the handler for getting a source found by the discovery
+func (a *AggregatorRPCs) GetDiscoveredSource(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting the discovered source with the request URL %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetDiscoveredSourceRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting the discovered source is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	sendAggregatorResponse(ctx, resp)
+}
+
+// ApproveDiscoveredSource is the handler for adding a discovered source as an aggregation source
+func (a *AggregatorRPCs) ApproveDiscoveredSource(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for approving the discovered source with the request URL %s", ctx.Request().RequestURI)
+	var req interface{}
+	err := ctx.ReadJSON(&req)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+
+	// marshalling the req to make aggregator ApproveDiscoveredSource request
+	// Since aggregator ApproveDiscoveredSource accepts []byte stream
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+
+	approveRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	resp, err := a.ApproveDiscoveredSourceRPC(ctxt, approveRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for approving the discovered source is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	sendAggregatorResponse(ctx, resp)
+}
+
+// DeleteDiscoveredSource is the handler for dismissing a source found by the discovery
+func (a *AggregatorRPCs) DeleteDiscoveredSource(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+	}
+	l.LogWithFields(ctxt).Debugf("Incoming request received for deleting the discovered source with the request URL %s", req.URL)
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.DeleteDiscoveredSourceRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for deleting the discovered source has response code %d", int(resp.StatusCode))
+	sendAggregatorResponse(ctx, resp)
+}
+
 // sendSystemsResponse writes the aggregator response to client
 func sendAggregatorResponse(ctx iris.Context, resp *aggregatorproto.AggregatorResponse) {
+	setETag(ctx, resp.StatusCode, resp.Body)
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// getAggregationSourceBody returns a resourceFetcher which gets the aggregation source details
+// through GetAggregationSourceRPC, used to evaluate the conditional requests
+func (a *AggregatorRPCs) getAggregationSourceBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := a.GetAggregationSourceRPC(ctx, aggregatorproto.AggregatorRequest{
+			SessionToken: sessionToken,
+			URL:          oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
+// GetInventoryHistory is the handler for the Odim.InventoryHistory action of a server,
+// the request body selecting the revisions to compare is optional
+func (a *AggregatorRPCs) GetInventoryHistory(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	l.LogWithFields(ctxt).Debugf("Incoming request received for getting the inventory history with the request URL %s", ctx.Request().RequestURI)
+	body, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to get the request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	req := aggregatorproto.AggregatorRequest{
+		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  body,
+	}
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	resp, err := a.GetInventoryHistoryRPC(ctxt, req)
+	if err != nil {
+		errorMessage := rpcCallFailedErrMsg + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debugf("Outgoing response for getting the inventory history is %s with response code %d", string(resp.Body), int(resp.StatusCode))
+	sendAggregatorResponse(ctx, resp)
+}
@@ -705,3 +705,206 @@ func getMockAggregatorResponse(ctx context.Context, req aggregatorproto.Aggregat
 	}
 	return response, nil
 }
+
+func TestImportSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.ImportSourcesRPC = testGetAggregateRPCCall
+	var importRequest = map[string]interface{}{
+		"CSVManifest": "HostName,UserName,Password,ConnectionMethod\n10.0.0.1,admin,password,/redfish/v1/AggregationService/ConnectionMethods/c41cbd97-937d-1b73-c41c-1b7385d3906\n",
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/AggregationService.ImportSources")
+	redfishRoutes.Post("/", a.ImportSources)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.ImportSources",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(importRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.ImportSources",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(importRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.ImportSources",
+	).WithHeader("X-Auth-Token", "").WithJSON(importRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without body
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.ImportSources",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.ImportSources",
+	).WithHeader("X-Auth-Token", "token").WithJSON(importRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestRotateCredentials(t *testing.T) {
+	var a AggregatorRPCs
+	a.RotateCredentialsRPC = testGetAggregateRPCCall
+	var rotateRequest = map[string]interface{}{
+		"Aggregate": map[string]string{
+			"@odata.id": "/redfish/v1/AggregationService/Aggregates/74116e00-0a4a-53e6-a959-e6a7465d6358",
+		},
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials")
+	redfishRoutes.Post("/", a.RotateCredentials)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(rotateRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(rotateRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials",
+	).WithHeader("X-Auth-Token", "").WithJSON(rotateRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without body
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials",
+	).WithHeader("X-Auth-Token", "token").WithJSON(rotateRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestGetImportSourcesReport(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetImportSourcesReportRPC = testGetAggregateRPCCall
+
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/ImportSourcesReports")
+	redfishRoutes.Get("/{id}", a.GetImportSourcesReport)
+	redfishRoutes.Get("/{id}/Report.csv", a.GetImportSourcesReport)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.GET(
+		"/redfish/v1/AggregationService/ImportSourcesReports/74116e00-0a4a-53e6-a959-e6a7465d6358",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+	test.GET(
+		"/redfish/v1/AggregationService/ImportSourcesReports/74116e00-0a4a-53e6-a959-e6a7465d6358/Report.csv",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.GET(
+		"/redfish/v1/AggregationService/ImportSourcesReports/74116e00-0a4a-53e6-a959-e6a7465d6358",
+	).WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.GET(
+		"/redfish/v1/AggregationService/ImportSourcesReports/74116e00-0a4a-53e6-a959-e6a7465d6358",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	test.GET(
+		"/redfish/v1/AggregationService/ImportSourcesReports/74116e00-0a4a-53e6-a959-e6a7465d6358",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestDiscoverSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.DiscoverSourcesRPC = testGetAggregateRPCCall
+	var discoverRequest = map[string]interface{}{
+		"Subnets": []string{"10.0.0.0/24"},
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources")
+	redfishRoutes.Post("/", a.DiscoverSources)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(discoverRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(discoverRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources",
+	).WithHeader("X-Auth-Token", "").WithJSON(discoverRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without body
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources",
+	).WithHeader("X-Auth-Token", "token").WithJSON(discoverRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestDiscoveredSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetAllDiscoveredSourcesRPC = testGetAggregateRPCCall
+	a.GetDiscoveredSourceRPC = testGetAggregateRPCCall
+	a.ApproveDiscoveredSourceRPC = testGetAggregateRPCCall
+	a.DeleteDiscoveredSourceRPC = testGetAggregateRPCCall
+	var approveRequest = map[string]interface{}{
+		"CredentialProfile": "default",
+	}
+	const sourceURI = "/redfish/v1/AggregationService/DiscoveredSources/74116e00-0a4a-53e6-a959-e6a7465d6358"
+
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/DiscoveredSources")
+	redfishRoutes.Get("/", a.GetAllDiscoveredSources)
+	redfishRoutes.Get("/{id}", a.GetDiscoveredSource)
+	redfishRoutes.Delete("/{id}", a.DeleteDiscoveredSource)
+	redfishRoutes.Post("/{id}/Actions/DiscoveredSource.Approve", a.ApproveDiscoveredSource)
+	test := httptest.New(t, testApp)
+	for _, token := range []struct {
+		value  string
+		status int
+	}{
+		{"ValidToken", http.StatusOK},
+		{"InvalidToken", http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+		{"token", http.StatusInternalServerError},
+	} {
+		test.GET("/redfish/v1/AggregationService/DiscoveredSources").WithHeader("X-Auth-Token", token.value).Expect().Status(token.status)
+		test.GET(sourceURI).WithHeader("X-Auth-Token", token.value).Expect().Status(token.status)
+		test.DELETE(sourceURI).WithHeader("X-Auth-Token", token.value).Expect().Status(token.status)
+		test.POST(sourceURI+"/Actions/DiscoveredSource.Approve").WithHeader("X-Auth-Token", token.value).WithJSON(approveRequest).Expect().Status(token.status)
+	}
+
+	// test without body
+	test.POST(sourceURI+"/Actions/DiscoveredSource.Approve").WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+}
+
+func TestGetInventoryHistory(t *testing.T) {
+	var a AggregatorRPCs
+	a.GetInventoryHistoryRPC = testGetAggregateRPCCall
+	const actionURI = "/redfish/v1/Systems/74116e00-0a4a-53e6-a959-e6a7465d6358.1/Actions/Oem/Odim.InventoryHistory"
+
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/Systems")
+	redfishRoutes.Post("/{id}/Actions/Oem/Odim.InventoryHistory", a.GetInventoryHistory)
+	test := httptest.New(t, testApp)
+	for _, token := range []struct {
+		value  string
+		status int
+	}{
+		{"ValidToken", http.StatusOK},
+		{"InvalidToken", http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+		{"token", http.StatusInternalServerError},
+	} {
+		test.POST(actionURI).WithHeader("X-Auth-Token", token.value).Expect().Status(token.status)
+		test.POST(actionURI).WithHeader("X-Auth-Token", token.value).WithJSON(map[string]interface{}{"FromRevision": 1, "ToRevision": 2}).Expect().Status(token.status)
+	}
+}
@@ -19,7 +19,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -87,9 +89,13 @@ func writeResponse(ctx iris.Context, headers map[string]string, status int32, bo
 func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 	defer ctx.Next()
 	ctxt := ctx.Request().Context()
+	expand, ok := getExpandQuery(ctx)
+	if !ok {
+		return
+	}
 	req := chassisproto.GetChassisRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
-		URL:          ctx.Request().RequestURI}
+		URL:          stripQueryOptions(ctx.Request().RequestURI, expandQueryOption)}
 	l.LogWithFields(ctxt).Debugf("Incoming request received for getting chassis collection with request uri %s", string(req.URL))
 	if req.SessionToken == "" {
 		errorMessage := "no X-Auth-Token found in request header"
@@ -109,6 +115,9 @@ func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	if resp.StatusCode == http.StatusOK {
+		resp.Body = expandResponseBody(ctxt, resp.Body, expand, inventoryFetcher("/redfish/v1/Chassis", chassis.getChassisBody(req.SessionToken)))
+	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for get chassis collections with response body %s and status code %d", string(resp.Body), int(resp.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
@@ -116,6 +125,25 @@ func (chassis *ChassisRPCs) GetChassisCollection(ctx iris.Context) {
 	ctx.Write(resp.Body)
 }
 
+// getChassisBody returns a resourceFetcher which gets the chassis details
+// through GetChassisRPC, used when the chassis is not in the inventory
+func (chassis *ChassisRPCs) getChassisBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := chassis.GetChassisRPC(ctx, chassisproto.GetChassisRequest{
+			SessionToken: sessionToken,
+			RequestParam: oid[strings.LastIndex(oid, "/")+1:],
+			URL:          oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
 // GetChassisResource defines the GetChassisResource iris handler.
 // The method extract the session token,uuid and request url and creates the RPC request.
 // After the RPC call the method will feed the response to the iris
@@ -188,6 +216,7 @@ func (chassis *ChassisRPCs) GetChassis(ctx iris.Context) {
 	l.LogWithFields(ctxt).Debugf("Outgoing response for get chassis with is %s with status code %d", string(resp.Body), int(resp.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
+	setETag(ctx, resp.StatusCode, resp.Body)
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -209,6 +238,11 @@ func (chassis *ChassisRPCs) UpdateChassis(ctx iris.Context) {
 		return
 	}
 	l.LogWithFields(ctxt).Debugf("Incoming request received for updating chassis with request body %s", string(*requestBody))
+	release, ok := checkPreconditions(ctx, chassis.getChassisBody(ctx.Request().Header.Get(AuthTokenHeader)))
+	if !ok {
+		return
+	}
+	defer release()
 	rr, rerr := chassis.UpdateChassisRPC(ctxt, chassisproto.UpdateChassisRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
 		URL:          ctx.Request().RequestURI,
@@ -231,6 +265,11 @@ func (chassis *ChassisRPCs) DeleteChassis(ctx iris.Context) {
 	defer ctx.Next()
 	ctxt := ctx.Request().Context()
 	l.LogWithFields(ctxt).Debugf("Incoming request received for deleting chassis with request uri %s", ctx.Request().RequestURI)
+	release, ok := checkPreconditions(ctx, chassis.getChassisBody(ctx.Request().Header.Get(AuthTokenHeader)))
+	if !ok {
+		return
+	}
+	defer release()
 	rpcResp, rpcErr := chassis.DeleteChassisRPC(ctxt, chassisproto.DeleteChassisRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
 		URL:          ctx.Request().RequestURI,
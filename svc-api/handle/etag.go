@@ -0,0 +1,203 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/google/uuid"
+	iris "github.com/kataras/iris/v12"
+)
+
+const (
+	// ETagHeader is the response header holding the entity tag of the resource
+	ETagHeader = "ETag"
+	// IfMatchHeader is the request header which makes a write conditional on the entity tag of the resource
+	IfMatchHeader = "If-Match"
+	// IfNoneMatchHeader is the request header which makes a write conditional on the resource not having the entity tag
+	IfNoneMatchHeader = "If-None-Match"
+	// conditionalWriteLock is the table of the locks held by the conditional writes on a resource
+	conditionalWriteLock = "ConditionalWriteLock"
+	// conditionalWriteLockExpiry is the time in seconds after which the lock of a conditional write
+	// expires, in case the API instance holding it goes down before releasing it
+	conditionalWriteLockExpiry = 120
+	// conditionalWriteLockRetry is the interval between the attempts to acquire the lock
+	conditionalWriteLockRetry = 100 * time.Millisecond
+	// conditionalWriteRetryAfter is the time in seconds after which the client is asked to retry
+	// the conditional write which could not acquire the lock
+	conditionalWriteRetryAfter = 5
+)
+
+// conditionalWriteLockWait is the time a conditional write waits for the lock held by another one
+var conditionalWriteLockWait = 30 * time.Second
+
+// errConditionalWriteInProgress is the error of a conditional write which could not acquire
+// the lock held by another one
+var errConditionalWriteInProgress = errors.New("another conditional request is in progress")
+
+// lockResource acquires the lock of the conditional writes on the resource and returns the function
+// releasing it. acquired is false when the lock is held by another write
+var lockResource = lockResourceInDB
+
+// computeETag returns the strong entity tag of the resource body
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// setETag sets the ETag header for the successful response of a GET request.
+// It has to be called before the status code and the body are written
+func setETag(ctx iris.Context, statusCode int32, body []byte) {
+	if ctx.Method() != http.MethodGet || statusCode != http.StatusOK || len(body) == 0 {
+		return
+	}
+	ctx.ResponseWriter().Header().Set(ETagHeader, computeETag(body))
+}
+
+// checkPreconditions evaluates the If-Match and If-None-Match headers of a PATCH, PUT or
+// DELETE request against the entity tag of the resource, as returned by fetch.
+// It writes the PreconditionFailed response and returns false when the
+// request should not be processed.
+// For a conditional request the lock of the resource shared by the API instances is held from
+// the evaluation till the returned release function is called, which the caller has to do once
+// the write is completed. So the conditional writes on a resource are serialized, and only one
+// of the concurrent writes with the same entity tag is processed. The writes without the
+// conditional headers and the updates made by the services on their own do not take the lock.
+func checkPreconditions(ctx iris.Context, fetch resourceFetcher) (func(), bool) {
+	ifMatch := ctx.GetHeader(IfMatchHeader)
+	ifNoneMatch := ctx.GetHeader(IfNoneMatchHeader)
+	if ifMatch == "" && ifNoneMatch == "" {
+		return func() {}, true
+	}
+	ctxt := ctx.Request().Context()
+	uri := ctx.Request().URL.Path
+	release, err := waitForResourceLock(ctxt, uri)
+	if err != nil {
+		// the entity tag is not evaluated, so the failure is not reported as a failed precondition
+		l.LogWithFields(ctxt).Error(err.Error())
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		if errors.Is(err, errConditionalWriteInProgress) {
+			resp = common.GeneralError(http.StatusConflict, response.ResourceInUse, err.Error(), nil, nil)
+			ctx.ResponseWriter().Header().Set("Retry-After", strconv.Itoa(conditionalWriteRetryAfter))
+		}
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(int(resp.StatusCode))
+		ctx.JSON(&resp.Body)
+		return nil, false
+	}
+	var currentETag string
+	body, err := fetch(ctxt, uri)
+	if err != nil {
+		// the resource does not exist or is not accessible,
+		// so it can not match any of the entity tags
+		l.LogWithFields(ctxt).Debugf("unable to get the current state of %s: %s", uri, err.Error())
+	} else {
+		currentETag = computeETag(body)
+	}
+
+	var errorMessage string
+	switch {
+	case ifMatch != "" && !matchETag(ifMatch, currentETag):
+		errorMessage = fmt.Sprintf("%s %s does not match the current ETag of %s", IfMatchHeader, ifMatch, uri)
+	case ifNoneMatch != "" && matchETag(ifNoneMatch, currentETag):
+		errorMessage = fmt.Sprintf("%s %s matches the current ETag of %s", IfNoneMatchHeader, ifNoneMatch, uri)
+	default:
+		return release, true
+	}
+	release()
+	l.LogWithFields(ctxt).Error(errorMessage)
+	resp := common.GeneralError(http.StatusPreconditionFailed, response.PreconditionFailed, errorMessage, nil, nil)
+	common.SetResponseHeader(ctx, resp.Header)
+	if currentETag != "" {
+		ctx.ResponseWriter().Header().Set(ETagHeader, currentETag)
+	}
+	ctx.StatusCode(http.StatusPreconditionFailed)
+	ctx.JSON(&resp.Body)
+	return nil, false
+}
+
+// waitForResourceLock acquires the lock of the conditional writes on the resource,
+// waiting for the conditional write holding it to complete
+func waitForResourceLock(ctx context.Context, uri string) (func(), error) {
+	deadline := time.Now().Add(conditionalWriteLockWait)
+	for {
+		release, acquired, err := lockResource(ctx, uri)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate the preconditions of the request on %s: %s", uri, err.Error())
+		}
+		if acquired {
+			return release, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w on %s", errConditionalWriteInProgress, uri)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request is cancelled while waiting, %w on %s", errConditionalWriteInProgress, uri)
+		case <-time.After(conditionalWriteLockRetry):
+		}
+	}
+}
+
+// lockResourceInDB acquires the lock of the resource in the in-memory DB, which is shared by the API instances.
+// The lock holds a token of the request, so that it is only released by the request holding it and not by
+// a request which lock has expired
+func lockResourceInDB(ctx context.Context, uri string) (func(), bool, error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return nil, false, err
+	}
+	token := uuid.New().String()
+	acquired, err := conn.SetNX(conditionalWriteLock, uri, token, conditionalWriteLockExpiry)
+	if err != nil {
+		return nil, false, err
+	}
+	release := func() {
+		released, err := conn.DeleteIfEqual(conditionalWriteLock, uri, token)
+		if err != nil {
+			l.LogWithFields(ctx).Error("unable to release the conditional write lock of " + uri + ": " + err.Error())
+		} else if !released {
+			l.LogWithFields(ctx).Warn("the conditional write lock of " + uri + " expired before the write completed")
+		}
+	}
+	return release, acquired, nil
+}
+
+// matchETag reports whether the entity tag is one of the comma separated entity tags of
+// a conditional header, using the strong comparison. "*" matches any existing resource.
+func matchETag(header, eTag string) bool {
+	if eTag == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == eTag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+var mockAccountBody = []byte(`{"@odata.id":"/redfish/v1/AccountService/Accounts/admin","UserName":"admin","RoleId":"Administrator"}`)
+
+func mockGetAccountWithBodyRPC(ctx context.Context, req accountproto.GetAccountRequest) (*accountproto.AccountResponse, error) {
+	if req.AccountID != "admin" {
+		return &accountproto.AccountResponse{StatusCode: http.StatusNotFound}, nil
+	}
+	return &accountproto.AccountResponse{
+		StatusCode: http.StatusOK,
+		Body:       mockAccountBody,
+	}, nil
+}
+
+// mockResourceLock replaces the lock in the in-memory DB with a lock held in the process
+func mockResourceLock(t *testing.T) {
+	var mutex sync.Mutex
+	locked := make(map[string]bool)
+	lockResource = func(ctx context.Context, uri string) (func(), bool, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if locked[uri] {
+			return nil, false, nil
+		}
+		locked[uri] = true
+		return func() {
+			mutex.Lock()
+			delete(locked, uri)
+			mutex.Unlock()
+		}, true, nil
+	}
+	t.Cleanup(func() {
+		lockResource = lockResourceInDB
+	})
+}
+
+func TestAccountRPCs_ConditionalRequests(t *testing.T) {
+	mockResourceLock(t)
+	var a AccountRPCs
+	a.GetAccountRPC = mockGetAccountWithBodyRPC
+	a.UpdateRPC = mockUpdateAccountRPC
+	a.DeleteRPC = mockDeleteAccountRPC
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/AccountService")
+	redfishRoutes.Get("/Accounts/{id}", a.GetAccount)
+	redfishRoutes.Patch("/Accounts/{id}", a.UpdateAccount)
+	redfishRoutes.Delete("/Accounts/{id}", a.DeleteAccount)
+	eTag := computeETag(mockAccountBody)
+
+	e := httptest.New(t, mockApp)
+	e.GET("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		Expect().Status(http.StatusOK).Header(ETagHeader).Equal(eTag)
+	e.PATCH("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfMatchHeader, eTag).WithJSON(map[string]string{"Password": "P@$$w0rd"}).
+		Expect().Status(http.StatusOK)
+	e.PATCH("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfMatchHeader, `"stale"`).WithJSON(map[string]string{"Password": "P@$$w0rd"}).
+		Expect().Status(http.StatusPreconditionFailed).Header(ETagHeader).Equal(eTag)
+	e.PATCH("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfMatchHeader, `"stale", `+eTag).WithJSON(map[string]string{"Password": "P@$$w0rd"}).
+		Expect().Status(http.StatusOK)
+	e.DELETE("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfNoneMatchHeader, "*").Expect().Status(http.StatusPreconditionFailed)
+	e.DELETE("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfNoneMatchHeader, `"stale"`).Expect().Status(http.StatusOK)
+	e.DELETE("/redfish/v1/AccountService/Accounts/user").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfMatchHeader, "*").Expect().Status(http.StatusPreconditionFailed)
+}
+
+func TestAccountRPCs_ConditionalRequestsWithoutLock(t *testing.T) {
+	defer func(wait time.Duration) {
+		lockResource = lockResourceInDB
+		conditionalWriteLockWait = wait
+	}(conditionalWriteLockWait)
+	conditionalWriteLockWait = 200 * time.Millisecond
+	var a AccountRPCs
+	a.GetAccountRPC = mockGetAccountWithBodyRPC
+	a.UpdateRPC = mockUpdateAccountRPC
+	mockApp := iris.New()
+	mockApp.Patch("/redfish/v1/AccountService/Accounts/{id}", a.UpdateAccount)
+	eTag := computeETag(mockAccountBody)
+
+	e := httptest.New(t, mockApp)
+	lockResource = func(ctx context.Context, uri string) (func(), bool, error) {
+		return nil, false, errors.New("DB is not reachable")
+	}
+	e.PATCH("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfMatchHeader, eTag).WithJSON(map[string]string{"Password": "P@$$w0rd"}).
+		Expect().Status(http.StatusInternalServerError)
+	lockResource = func(ctx context.Context, uri string) (func(), bool, error) {
+		return nil, false, nil
+	}
+	e.PATCH("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+		WithHeader(IfMatchHeader, eTag).WithJSON(map[string]string{"Password": "P@$$w0rd"}).
+		Expect().Status(http.StatusConflict).Header("Retry-After").Equal(strconv.Itoa(conditionalWriteRetryAfter))
+}
+
+func TestMatchETag(t *testing.T) {
+	tests := []struct {
+		header string
+		eTag   string
+		want   bool
+	}{
+		{`"abc"`, `"abc"`, true},
+		{`"xyz", "abc"`, `"abc"`, true},
+		{`W/"abc"`, `"abc"`, false},
+		{`*`, `"abc"`, true},
+		{`*`, ``, false},
+		{`"abc"`, `"xyz"`, false},
+	}
+	for _, tt := range tests {
+		if got := matchETag(tt.header, tt.eTag); got != tt.want {
+			t.Errorf("matchETag(%q, %q) = %v, want %v", tt.header, tt.eTag, got, tt.want)
+		}
+	}
+}
+
+func TestAccountRPCs_ConcurrentConditionalRequests(t *testing.T) {
+	mockResourceLock(t)
+	var mutex sync.Mutex
+	version := 0
+	body := func() []byte {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return []byte(`{"@odata.id":"/redfish/v1/AccountService/Accounts/admin","Version":` + strconv.Itoa(version) + `}`)
+	}
+	var a AccountRPCs
+	a.GetAccountRPC = func(ctx context.Context, req accountproto.GetAccountRequest) (*accountproto.AccountResponse, error) {
+		return &accountproto.AccountResponse{StatusCode: http.StatusOK, Body: body()}, nil
+	}
+	a.UpdateRPC = func(ctx context.Context, req accountproto.UpdateAccountRequest) (*accountproto.AccountResponse, error) {
+		// give the other request the time to evaluate its precondition before the update is saved
+		time.Sleep(200 * time.Millisecond)
+		mutex.Lock()
+		version++
+		mutex.Unlock()
+		return &accountproto.AccountResponse{StatusCode: http.StatusOK}, nil
+	}
+	mockApp := iris.New()
+	mockApp.Patch("/redfish/v1/AccountService/Accounts/{id}", a.UpdateAccount)
+	eTag := computeETag(body())
+
+	e := httptest.New(t, mockApp)
+	statusCodes := make(chan int, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statusCodes <- e.PATCH("/redfish/v1/AccountService/Accounts/admin").WithHeader("X-Auth-Token", "token").
+				WithHeader(IfMatchHeader, eTag).WithJSON(map[string]string{"Password": "P@$$w0rd"}).
+				Expect().Raw().StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statusCodes)
+	got := map[int]int{}
+	for statusCode := range statusCodes {
+		got[statusCode]++
+	}
+	if got[http.StatusOK] != 1 || got[http.StatusPreconditionFailed] != 1 {
+		t.Errorf("concurrent conditional updates got the status codes %v, want one 200 and one 412", got)
+	}
+}
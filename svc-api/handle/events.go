@@ -18,6 +18,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -144,6 +147,7 @@ func (e *EventsRPCs) GetEventSubscription(ctx iris.Context) {
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting event subscription is %s with response code %d", string(resp.Body), int(resp.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	setETag(ctx, resp.StatusCode, resp.Body)
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
@@ -162,6 +166,11 @@ func (e *EventsRPCs) DeleteEventSubscription(ctx iris.Context) {
 		common.SendInvalidSessionResponse(ctx, errorMessage)
 	}
 
+	release, ok := checkPreconditions(ctx, e.getEventSubscriptionBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := e.DeleteEventSubscriptionRPC(ctxt, req)
 	if err != nil {
 		l.LogWithFields(ctxt).Error(err.Error())
@@ -177,8 +186,13 @@ func (e *EventsRPCs) DeleteEventSubscription(ctx iris.Context) {
 func (e *EventsRPCs) GetEventSubscriptionsCollection(ctx iris.Context) {
 	defer ctx.Next()
 	ctxt := ctx.Request().Context()
+	expand, ok := getExpandQuery(ctx)
+	if !ok {
+		return
+	}
 	var req eventsproto.EventRequest
 	req.SessionToken = ctx.Request().Header.Get(AuthTokenHeader)
+	req.URL = stripQueryOptions(ctx.Request().RequestURI, expandQueryOption)
 	l.LogWithFields(ctxt).Debugf("Incoming request received for getting all the  event subscription collections")
 	if req.SessionToken == "" {
 		errorMessage := invalidAuthTokenErrMsg
@@ -190,9 +204,30 @@ func (e *EventsRPCs) GetEventSubscriptionsCollection(ctx iris.Context) {
 		l.LogWithFields(ctxt).Error(err.Error())
 		common.SendFailedRPCCallResponse(ctx, err.Error())
 	}
+	if resp.StatusCode == http.StatusOK {
+		resp.Body = expandResponseBody(ctxt, resp.Body, expand, e.getEventSubscriptionBody(req.SessionToken))
+	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting event subscription collections is %s with response code %d", string(resp.Body), int(resp.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// getEventSubscriptionBody returns a resourceFetcher which gets the event
+// subscription details through GetEventSubscriptionRPC
+func (e *EventsRPCs) getEventSubscriptionBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := e.GetEventSubscriptionRPC(ctx, eventsproto.EventRequest{
+			EventSubscriptionID: oid[strings.LastIndex(oid, "/")+1:],
+			SessionToken:        sessionToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
@@ -0,0 +1,335 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+const (
+	// expandQueryOption is the OData query option used for inlining navigation properties
+	expandQueryOption = "$expand"
+	// MaxExpandLevels is the maximum value accepted for $levels in the $expand query option
+	MaxExpandLevels = 6
+)
+
+// getInventoryResourceFunc function pointer for the getInventoryResource
+var getInventoryResourceFunc = getInventoryResource
+
+// getScopeCheckFunc function pointer for the getScopeCheck
+var getScopeCheckFunc = getScopeCheck
+
+// resourceFetcher returns the body of the resource identified by the given @odata.id
+type resourceFetcher func(ctx context.Context, oid string) ([]byte, error)
+
+// expandQuery holds the parsed value of the $expand query option.
+//
+// subordinate is set for "." and "*", which expands the hyperlinks which are not under Links.
+// links is set for "~" and "*", which expands the hyperlinks under Links.
+// levels is the number of levels of hyperlinks to be expanded.
+// value is the query option value as requested.
+type expandQuery struct {
+	subordinate bool
+	links       bool
+	levels      int
+	value       string
+}
+
+// parseExpandQuery parses the $expand query option value, for example
+// ".", "*", "~" or ".($levels=2)". It returns nil when expansion is not requested.
+func parseExpandQuery(value string) (*expandQuery, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	query := expandQuery{levels: 1, value: value}
+	switch value[0] {
+	case '.':
+		query.subordinate = true
+	case '~':
+		query.links = true
+	case '*':
+		query.subordinate = true
+		query.links = true
+	default:
+		return nil, fmt.Errorf("%v is not a supported value for %v, supported values are '.', '~' and '*'", value, expandQueryOption)
+	}
+	option := strings.TrimSpace(value[1:])
+	if option == "" {
+		return &query, nil
+	}
+	if !strings.HasPrefix(option, "(") || !strings.HasSuffix(option, ")") {
+		return nil, fmt.Errorf("%v is not a valid value for %v", value, expandQueryOption)
+	}
+	levels := strings.SplitN(strings.Trim(option, "()"), "=", 2)
+	if len(levels) != 2 || strings.TrimSpace(levels[0]) != "$levels" {
+		return nil, fmt.Errorf("%v is not a valid value for %v", value, expandQueryOption)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(levels[1]))
+	if err != nil || n < 1 || n > MaxExpandLevels {
+		return nil, fmt.Errorf("$levels should be a number between 1 and %d", MaxExpandLevels)
+	}
+	query.levels = n
+	return &query, nil
+}
+
+// getExpandQuery reads the $expand query option from the request.
+// If the value is invalid, the error response is written to the context and
+// the second return value will be false.
+func getExpandQuery(ctx iris.Context) (*expandQuery, bool) {
+	query, err := parseExpandQuery(ctx.URLParam(expandQueryOption))
+	if err != nil {
+		l.LogWithFields(ctx.Request().Context()).Error(err.Error())
+		resp := common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.JSON(&resp.Body)
+		return nil, false
+	}
+	return query, true
+}
+
+// stripQueryOptions removes the given query options from the request URI,
+// so that the services behind the gateway receive only the options they understand.
+// The remaining query options are kept as they are.
+func stripQueryOptions(requestURI string, options ...string) string {
+	uri := strings.SplitN(requestURI, "?", 2)
+	if len(uri) < 2 {
+		return requestURI
+	}
+	var params []string
+	for _, param := range strings.Split(uri[1], "&") {
+		key := strings.SplitN(param, "=", 2)[0]
+		if decodedKey, err := url.QueryUnescape(key); err == nil {
+			key = decodedKey
+		}
+		strip := false
+		for _, option := range options {
+			if key == option {
+				strip = true
+				break
+			}
+		}
+		if !strip && param != "" {
+			params = append(params, param)
+		}
+	}
+	if len(params) == 0 {
+		return uri[0]
+	}
+	return uri[0] + "?" + strings.Join(params, "&")
+}
+
+// expandResponseBody inlines the hyperlinks of the response body as requested
+// in the $expand query. The body is returned as it is if expansion is not
+// requested or if the body is not a JSON object. Hyperlinks which cannot be
+// fetched are left untouched.
+func expandResponseBody(ctx context.Context, body []byte, query *expandQuery, fetch resourceFetcher) []byte {
+	if query == nil {
+		return body
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		l.LogWithFields(ctx).Error("while trying to unmarshal the response body for expansion: " + err.Error())
+		return body
+	}
+	query.expandProperties(ctx, resource, 1, fetch)
+	if nextLink, ok := resource["Members@odata.nextLink"].(string); ok {
+		resource["Members@odata.nextLink"] = common.AppendQueryOption(nextLink, expandQueryOption, query.value)
+	}
+	data, err := json.Marshal(resource)
+	if err != nil {
+		l.LogWithFields(ctx).Error("while trying to marshal the expanded response body: " + err.Error())
+		return body
+	}
+	return data
+}
+
+// expandProperties expands the hyperlinks in the properties of a resource
+func (q *expandQuery) expandProperties(ctx context.Context, resource map[string]interface{}, level int, fetch resourceFetcher) {
+	for key, value := range resource {
+		if strings.Contains(key, "@") {
+			continue
+		}
+		resource[key] = q.expandNode(ctx, value, key == "Links", level, fetch)
+	}
+}
+
+// expandNode replaces a hyperlink with the resource it refers to and walks
+// through objects and arrays looking for hyperlinks.
+func (q *expandQuery) expandNode(ctx context.Context, node interface{}, inLinks bool, level int, fetch resourceFetcher) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if oid, ok := value["@odata.id"].(string); ok && len(value) == 1 {
+			if (inLinks && !q.links) || (!inLinks && !q.subordinate) {
+				return value
+			}
+			body, err := fetch(ctx, oid)
+			if err != nil {
+				l.LogWithFields(ctx).Debugf("unable to expand %s: %s", oid, err.Error())
+				return value
+			}
+			var resource map[string]interface{}
+			if err := json.Unmarshal(body, &resource); err != nil {
+				l.LogWithFields(ctx).Debugf("unable to expand %s: %s", oid, err.Error())
+				return value
+			}
+			if level < q.levels {
+				q.expandProperties(ctx, resource, level+1, fetch)
+			}
+			return resource
+		}
+		for key, val := range value {
+			if strings.Contains(key, "@") {
+				continue
+			}
+			value[key] = q.expandNode(ctx, val, inLinks || key == "Links", level, fetch)
+		}
+		return value
+	case []interface{}:
+		for i := range value {
+			value[i] = q.expandNode(ctx, value[i], inLinks, level, fetch)
+		}
+		return value
+	}
+	return node
+}
+
+// inventoryFetcher returns a resourceFetcher which looks up the resource in the
+// in-memory inventory first and falls back to the given fetcher when the resource
+// is not available there. The fallback is used only for the members of the
+// collection, since it is expected to work only on those.
+// The resources out of the aggregates the session is restricted to are not fetched,
+// as the in-memory inventory is read without the scope check of the services.
+func inventoryFetcher(collectionURI string, fallback resourceFetcher) resourceFetcher {
+	var allows func(uri string) bool
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		if allows == nil {
+			var err error
+			if allows, err = getScopeCheckFunc(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if !allows(oid) {
+			return nil, fmt.Errorf("%s is not in the scope of the session", oid)
+		}
+		body, err := getInventoryResourceFunc(oid)
+		if err == nil {
+			return body, nil
+		}
+		if fallback == nil || !isCollectionMember(collectionURI, oid) {
+			return nil, err
+		}
+		return fallback(ctx, oid)
+	}
+}
+
+// getScopeCheck returns the function checking whether a resource is in the scope of the session
+func getScopeCheck(ctx context.Context) (func(uri string) bool, error) {
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scope.Allows, nil
+}
+
+// isCollectionMember checks whether oid is a direct member of the collection
+func isCollectionMember(collectionURI, oid string) bool {
+	collectionURI = strings.TrimSuffix(collectionURI, "/") + "/"
+	return strings.HasPrefix(oid, collectionURI) && !strings.Contains(strings.TrimPrefix(oid, collectionURI), "/")
+}
+
+// getInventoryResource reads the resource from the in-memory inventory which
+// is populated while adding a server to ODIM
+func getInventoryResource(oid string) ([]byte, error) {
+	oid = strings.TrimSuffix(oid, "/")
+	table := getInventoryTable(oid)
+	if table == "" {
+		return nil, fmt.Errorf("%s is not a part of the inventory", oid)
+	}
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	data, err := conn.Read(table, oid)
+	if err != nil {
+		return nil, err
+	}
+	var resourceData string
+	if err := json.Unmarshal([]byte(data), &resourceData); err != nil {
+		return nil, err
+	}
+	var resource map[string]interface{}
+	if err := json.Unmarshal([]byte(resourceData), &resource); err != nil {
+		return nil, err
+	}
+	fixInventoryResourceID(oid, resource)
+	return json.Marshal(resource)
+}
+
+// getInventoryTable returns the in-memory table name in which the resource
+// with the given @odata.id is stored by the aggregation service
+func getInventoryTable(oid string) string {
+	parts := strings.Split(oid, "/")
+	// a valid inventory URI is of the form /redfish/v1/<Collection>/<ID>/...
+	if len(parts) < 5 || parts[1] != "redfish" || parts[2] != "v1" {
+		return ""
+	}
+	var resources map[string]string
+	switch parts[3] {
+	case "Systems":
+		if len(parts) == 5 {
+			return "ComputerSystem"
+		}
+		resources = common.SystemResource
+	case "Chassis":
+		if len(parts) == 5 {
+			return "Chassis"
+		}
+		resources = common.ChassisResource
+	case "Managers":
+		if len(parts) == 5 {
+			return "Managers"
+		}
+		resources = common.ManagersResource
+	default:
+		return ""
+	}
+	if table, ok := resources[parts[len(parts)-1]]; ok {
+		return table
+	}
+	return parts[len(parts)-2]
+}
+
+// fixInventoryResourceID updates the Id of the top level inventory resources,
+// which is stored without the server UUID, to match the @odata.id
+func fixInventoryResourceID(oid string, resource map[string]interface{}) {
+	id := oid[strings.LastIndex(oid, "/")+1:]
+	if storedID, ok := resource["Id"].(string); ok && strings.HasSuffix(id, "."+storedID) {
+		resource["Id"] = id
+	}
+}
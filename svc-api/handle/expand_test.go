@@ -0,0 +1,226 @@
+// (C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	systemsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/systems"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+var mockInventory = map[string]string{
+	"/redfish/v1/Systems/uuid.1":      `{"@odata.id":"/redfish/v1/Systems/uuid.1","Id":"uuid.1","Bios":{"@odata.id":"/redfish/v1/Systems/uuid.1/Bios"},"Links":{"Chassis":[{"@odata.id":"/redfish/v1/Chassis/uuid.1"}]}}`,
+	"/redfish/v1/Systems/uuid.1/Bios": `{"@odata.id":"/redfish/v1/Systems/uuid.1/Bios","Id":"Bios"}`,
+	"/redfish/v1/Chassis/uuid.1":      `{"@odata.id":"/redfish/v1/Chassis/uuid.1","Id":"uuid.1"}`,
+}
+
+func mockGetInventoryResource(oid string) ([]byte, error) {
+	if data, ok := mockInventory[oid]; ok {
+		return []byte(data), nil
+	}
+	return nil, errors.New("not found")
+}
+
+func mockFetcher(ctx context.Context, oid string) ([]byte, error) {
+	return mockGetInventoryResource(oid)
+}
+
+func TestParseExpandQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    *expandQuery
+		wantErr bool
+	}{
+		{name: "no expand", value: "", want: nil},
+		{name: "subordinate", value: ".", want: &expandQuery{subordinate: true, levels: 1, value: "."}},
+		{name: "links", value: "~", want: &expandQuery{links: true, levels: 1, value: "~"}},
+		{name: "all with levels", value: "*($levels=3)", want: &expandQuery{subordinate: true, links: true, levels: 3, value: "*($levels=3)"}},
+		{name: "invalid value", value: "Members", wantErr: true},
+		{name: "invalid option", value: ".($top=2)", wantErr: true},
+		{name: "levels out of range", value: ".($levels=7)", wantErr: true},
+		{name: "levels not a number", value: ".($levels=a)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExpandQuery(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseExpandQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseExpandQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripQueryOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want string
+	}{
+		{name: "no query", uri: "/redfish/v1/Systems", want: "/redfish/v1/Systems"},
+		{name: "only expand", uri: "/redfish/v1/Systems?$expand=.", want: "/redfish/v1/Systems"},
+		{name: "encoded expand", uri: "/redfish/v1/Systems?%24expand=*", want: "/redfish/v1/Systems"},
+		{name: "with filter", uri: "/redfish/v1/Systems?$expand=.&$filter=MemorySummary%2FTotalSystemMemoryGiB%20eq%20384", want: "/redfish/v1/Systems?$filter=MemorySummary%2FTotalSystemMemoryGiB%20eq%20384"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripQueryOptions(tt.uri, expandQueryOption); got != tt.want {
+				t.Errorf("stripQueryOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandResponseBody(t *testing.T) {
+	collection := []byte(`{"@odata.id":"/redfish/v1/Systems","Members":[{"@odata.id":"/redfish/v1/Systems/uuid.1"}],"Members@odata.count":1}`)
+	tests := []struct {
+		name        string
+		query       *expandQuery
+		wantBios    bool
+		wantChassis bool
+	}{
+		{name: "single level", query: &expandQuery{subordinate: true, levels: 1}},
+		{name: "subordinate two levels", query: &expandQuery{subordinate: true, levels: 2}, wantBios: true},
+		{name: "all two levels", query: &expandQuery{subordinate: true, links: true, levels: 2}, wantBios: true, wantChassis: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				Members []struct {
+					ID    string                 `json:"Id"`
+					Bios  map[string]interface{} `json:"Bios"`
+					Links struct {
+						Chassis []map[string]interface{} `json:"Chassis"`
+					} `json:"Links"`
+				} `json:"Members"`
+			}
+			body := expandResponseBody(context.TODO(), collection, tt.query, mockFetcher)
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatalf("expandResponseBody() returned invalid JSON: %v", err)
+			}
+			if len(got.Members) != 1 || got.Members[0].ID != "uuid.1" {
+				t.Fatalf("expandResponseBody() members are not expanded: %s", string(body))
+			}
+			if _, ok := got.Members[0].Bios["Id"]; ok != tt.wantBios {
+				t.Errorf("expandResponseBody() Bios expanded = %v, want %v", ok, tt.wantBios)
+			}
+			if _, ok := got.Members[0].Links.Chassis[0]["Id"]; ok != tt.wantChassis {
+				t.Errorf("expandResponseBody() Links expanded = %v, want %v", ok, tt.wantChassis)
+			}
+		})
+	}
+	if body := expandResponseBody(context.TODO(), collection, nil, mockFetcher); string(body) != string(collection) {
+		t.Errorf("expandResponseBody() modified the body without $expand: %s", string(body))
+	}
+}
+
+func mockGetExpandSystemsCollection(ctx context.Context, req systemsproto.GetSystemsRequest) (*systemsproto.SystemsResponse, error) {
+	if req.URL != "/redfish/v1/Systems" {
+		return &systemsproto.SystemsResponse{
+			StatusCode: http.StatusBadRequest,
+			Body:       []byte(`{"Response":"BadRequest"}`),
+		}, nil
+	}
+	return &systemsproto.SystemsResponse{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"@odata.id":"/redfish/v1/Systems","Members":[{"@odata.id":"/redfish/v1/Systems/uuid.1"},{"@odata.id":"/redfish/v1/Systems/uuid.2"}]}`),
+	}, nil
+}
+
+func mockGetExpandSystem(ctx context.Context, req systemsproto.GetSystemsRequest) (*systemsproto.SystemsResponse, error) {
+	if req.RequestParam != "uuid.2" {
+		return &systemsproto.SystemsResponse{StatusCode: http.StatusNotFound}, nil
+	}
+	return &systemsproto.SystemsResponse{
+		StatusCode: http.StatusOK,
+		Body:       []byte(`{"@odata.id":"/redfish/v1/Systems/uuid.2","Id":"uuid.2"}`),
+	}, nil
+}
+
+func TestGetSystemsCollection_Expand(t *testing.T) {
+	getInventoryResourceFunc = mockGetInventoryResource
+	defer func() {
+		getInventoryResourceFunc = getInventoryResource
+	}()
+	var sys SystemRPCs
+	sys.GetSystemsCollectionRPC = mockGetExpandSystemsCollection
+	sys.GetSystemRPC = mockGetExpandSystem
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Get("/Systems", sys.GetSystemsCollection)
+	test := httptest.New(t, mockApp)
+	members := test.GET("/redfish/v1/Systems").WithQuery("$expand", ".").
+		WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK).
+		JSON().Object().Value("Members").Array()
+	members.Length().Equal(2)
+	members.Element(0).Object().Value("Id").Equal("uuid.1")
+	members.Element(1).Object().Value("Id").Equal("uuid.2")
+	test.GET("/redfish/v1/Systems").WithQuery("$expand", ".($levels=10)").
+		WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusBadRequest)
+}
+
+func TestInventoryFetcher_Scope(t *testing.T) {
+	getInventoryResourceFunc = mockGetInventoryResource
+	getScopeCheckFunc = func(ctx context.Context) (func(uri string) bool, error) {
+		return func(uri string) bool {
+			return uri == "/redfish/v1/Systems/uuid.1" || uri == "/redfish/v1/Systems/uuid.1/Bios"
+		}, nil
+	}
+	defer func() {
+		getInventoryResourceFunc = getInventoryResource
+		getScopeCheckFunc = getScopeCheck
+	}()
+	collection := []byte(`{"@odata.id":"/redfish/v1/Systems","Members":[{"@odata.id":"/redfish/v1/Systems/uuid.1"},{"@odata.id":"/redfish/v1/Systems/uuid.2"}]}`)
+	var fallbackCalls []string
+	fetch := inventoryFetcher("/redfish/v1/Systems", func(ctx context.Context, oid string) ([]byte, error) {
+		fallbackCalls = append(fallbackCalls, oid)
+		return []byte(`{"@odata.id":"` + oid + `"}`), nil
+	})
+	query := &expandQuery{subordinate: true, links: true, levels: 2, value: "*($levels=2)"}
+	var resource struct {
+		Members []map[string]interface{}
+	}
+	if err := json.Unmarshal(expandResponseBody(context.TODO(), collection, query, fetch), &resource); err != nil {
+		t.Fatalf("error while unmarshaling the expanded body: %v", err)
+	}
+	if resource.Members[0]["Id"] != "uuid.1" {
+		t.Errorf("member in the scope is not expanded: %v", resource.Members[0])
+	}
+	links, _ := resource.Members[0]["Links"].(map[string]interface{})
+	if chassis, _ := links["Chassis"].([]interface{}); len(chassis) != 1 || len(chassis[0].(map[string]interface{})) != 1 {
+		t.Errorf("chassis out of the scope is expanded: %v", links)
+	}
+	if len(resource.Members[1]) != 1 || len(fallbackCalls) != 0 {
+		t.Errorf("member out of the scope is expanded: %v, fetched %v", resource.Members[1], fallbackCalls)
+	}
+
+	getScopeCheckFunc = func(ctx context.Context) (func(uri string) bool, error) {
+		return nil, errors.New("DB error")
+	}
+	fetch = inventoryFetcher("/redfish/v1/Systems", nil)
+	if _, err := fetch(context.TODO(), "/redfish/v1/Systems/uuid.1"); err == nil {
+		t.Errorf("inventoryFetcher() expected an error when the scope is not resolved")
+	}
+}
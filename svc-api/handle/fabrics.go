@@ -18,6 +18,8 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -85,6 +87,7 @@ func (f *FabricRPCs) GetFabric(ctx iris.Context) {
 		"Allow": `"GET"`,
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for creating fabric is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendFabricResponse(ctx, resp)
 }
 
@@ -137,6 +140,7 @@ func (f *FabricRPCs) GetFabricSwitch(ctx iris.Context) {
 		"Allow": `"GET"`,
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for creating fabric switch is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendFabricResponse(ctx, resp)
 }
 
@@ -189,6 +193,7 @@ func (f *FabricRPCs) GetSwitchPort(ctx iris.Context) {
 		"Allow": `"GET", "PATCH"`,
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting switch port is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendFabricResponse(ctx, resp)
 }
 
@@ -241,6 +246,7 @@ func (f *FabricRPCs) GetFabricZone(ctx iris.Context) {
 		"Allow": `"GET", "PUT", "PATCH", "DELETE"`,
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting fabric zone is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendFabricResponse(ctx, resp)
 }
 
@@ -293,6 +299,7 @@ func (f *FabricRPCs) GetFabricEndPoints(ctx iris.Context) {
 		"Allow": `"GET", "PUT", "PATCH", "DELETE"`,
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting fabric endpoint is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendFabricResponse(ctx, resp)
 }
 
@@ -345,6 +352,7 @@ func (f *FabricRPCs) GetFabricAddressPool(ctx iris.Context) {
 		"Allow": `"GET", "PUT", "PATCH", "DELETE"`,
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting fabric address pool is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendFabricResponse(ctx, resp)
 }
 
@@ -382,6 +390,11 @@ func (f *FabricRPCs) UpdateFabricResource(ctx iris.Context) {
 	}
 	l.LogWithFields(ctxt).Debugf("Incoming request received for updating fabric resources with request url %s and request body %s", req.URL, string(request))
 	req.RequestBody = request
+	release, ok := checkPreconditions(ctx, f.getFabricResourceBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := f.UpdateFabricResourceRPC(ctxt, req)
 	if err != nil && resp == nil {
 		errorMessage := rpcFailedErrMsg + err.Error()
@@ -405,6 +418,11 @@ func (f *FabricRPCs) DeleteFabricResource(ctx iris.Context) {
 		common.SendInvalidSessionResponse(ctx, errorMessage)
 	}
 
+	release, ok := checkPreconditions(ctx, f.getFabricResourceBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := f.DeleteFabricResourceRPC(ctxt, req)
 	if err != nil && resp == nil {
 		errorMessage := rpcFailedErrMsg + err.Error()
@@ -430,3 +448,21 @@ func getFabricRequest(ctx iris.Context) fabricsproto.FabricRequest {
 		URL:          ctx.Request().RequestURI,
 	}
 }
+
+// getFabricResourceBody returns a resourceFetcher which gets the fabric resource details
+// through GetFabricResourceRPC, used to evaluate the conditional requests
+func (f *FabricRPCs) getFabricResourceBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := f.GetFabricResourceRPC(ctx, fabricsproto.FabricRequest{
+			SessionToken: sessionToken,
+			URL:          oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
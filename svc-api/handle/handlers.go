@@ -70,7 +70,20 @@ func getService(microServices []string, uuid string) models.ServiceRoot {
 			Sessions: models.Sessions{
 				OdataID: "/redfish/v1/SessionService/Sessions"},
 		},
-		Registries: &models.Service{OdataID: "/redfish/v1/Registries"},
+		Registries:  &models.Service{OdataID: "/redfish/v1/Registries"},
+		JSONSchemas: &models.Service{OdataID: "/redfish/v1/JsonSchemas"},
+		ProtocolFeaturesSupported: &models.PFSupported{
+			ExpandQuery: &models.ExpandQuery{
+				ExpandAll: true,
+				Levels:    true,
+				Links:     true,
+				MaxLevels: MaxExpandLevels,
+				NoLinks:   true,
+			},
+			FilterQuery:  true,
+			SelectQuery:  true,
+			TopSkipQuery: true,
+		},
 	}
 	// To discover the services we need registry
 	//Get Service options to retrive the Registry from it.
@@ -83,8 +96,6 @@ func getService(microServices []string, uuid string) models.ServiceRoot {
 			serviceRoot.EventService = &models.Service{OdataID: servicePath}
 		case "SessionService":
 			serviceRoot.SessionService = &models.Service{OdataID: servicePath}
-		case "JSONSchemas":
-			serviceRoot.JSONSchemas = &models.Service{OdataID: servicePath}
 		case "Systems":
 			serviceRoot.Systems = &models.Service{OdataID: servicePath}
 		case "Chassis":
@@ -149,7 +160,7 @@ func GetOdata(ctx iris.Context) {
 		if service == "Service" {
 			Odata.Value = append(Odata.Value, &models.Value{Name: service, Kind: "Singleton", URL: "/redfish/v1/"})
 		} else if service == "JsonSchemas" {
-			Odata.Value = append(Odata.Value, &models.Value{Name: service, Kind: "Singleton", URL: "/redfish/v1/Schemas"})
+			Odata.Value = append(Odata.Value, &models.Value{Name: service, Kind: "Singleton", URL: "/redfish/v1/JsonSchemas"})
 		} else if service == "Sessions" {
 			Odata.Value = append(Odata.Value, &models.Value{Name: service, Kind: "Singleton", URL: "/redfish/v1/SessionService/Sessions/"})
 		} else {
@@ -1075,11 +1086,15 @@ func AsMethodNotAllowed(ctx iris.Context) {
 	id := ctx.Params().Get("id")
 	switch path {
 	case "/redfish/v1/AccountService":
-		ctx.ResponseWriter().Header().Set("Allow", "GET")
+		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH")
 	case "/redfish/v1/AccountService/Accounts":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AccountService/Accounts/" + id:
 		ctx.ResponseWriter().Header().Set("Allow", "GET, PATCH, DELETE")
+	case "/redfish/v1/AccountService/Accounts/" + id + "/APIKeys":
+		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
+	case "/redfish/v1/AccountService/Accounts/" + id + "/APIKeys/" + ctx.Params().Get("rid"):
+		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
 	default:
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
@@ -1128,6 +1143,8 @@ func SystemsMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/Systems/" + systemID + "/Storage/" + storageid + "/Volumes/" + resourceID:
 		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	case "/redfish/v1/Systems/" + systemID + "/Actions/Oem/Odim.InventoryHistory":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	default:
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
@@ -1305,6 +1322,16 @@ func AggMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Actions/AggregationService.Reset":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/AggregationService.ImportSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/AggregationService.DiscoverSources":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Actions/AggregationService.RotateCredentials":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/DiscoveredSources/" + id:
+		ctx.ResponseWriter().Header().Set("Allow", "GET, DELETE")
+	case "/redfish/v1/AggregationService/DiscoveredSources/" + id + "/Actions/DiscoveredSource.Approve":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/AggregationSources":
 		ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	case "/redfish/v1/AggregationService/AggregationSources/" + id:
@@ -102,7 +102,7 @@ func TestGetMetadata(t *testing.T) {
 
 // TestAsMethodNotAllowed is unittest method for AsMethodNotAllowed func.
 func TestAsMethodNotAllowed(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, PATCH"}
 	defer delete(header, "Allow")
 	router := iris.New()
 	redfishRoutes := router.Party("/redfish")
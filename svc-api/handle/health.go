@@ -0,0 +1,204 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	iris "github.com/kataras/iris/v12"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// HealthzURI is the URI of the liveness probe of the API gateway
+	HealthzURI = "/healthz"
+	// ReadyzURI is the URI of the readiness probe of ODIM
+	ReadyzURI = "/readyz"
+
+	// healthCheckTimeout is the time given to each check of the readiness probe
+	healthCheckTimeout = 2 * time.Second
+	// dbHealthCheck is the name of the check on the databases in the readiness response
+	dbHealthCheck = "db"
+)
+
+// Health status values reported by the probes. The overall status is
+// Critical only when a dependency, which ODIM can not serve without, failed
+const (
+	healthOK       = "OK"
+	healthWarning  = "Warning"
+	healthCritical = "Critical"
+)
+
+// Health defines the functions used for checking the liveness and readiness of ODIM
+type Health struct {
+	CheckDB         func() error
+	CheckService    func(ctx context.Context, serviceName, healthService string) (healthpb.HealthCheckResponse_ServingStatus, error)
+	EnabledServices func() []string
+}
+
+// HealthStatus is the response body of the probes
+type HealthStatus struct {
+	Status string        `json:"Status"`
+	Checks []HealthCheck `json:"Checks,omitempty"`
+}
+
+// HealthCheck is the result of the check of one dependency of ODIM
+type HealthCheck struct {
+	Name    string `json:"Name"`
+	Status  string `json:"Status"`
+	Message string `json:"Message,omitempty"`
+}
+
+// healthCheck describes a check run by the readiness probe
+type healthCheck struct {
+	name     string
+	critical bool
+	run      func(ctx context.Context) error
+}
+
+// GetLiveness reports that the API gateway is up and serving requests
+func (h *Health) GetLiveness(ctx iris.Context) {
+	defer ctx.Next()
+	common.SetResponseHeader(ctx, nil)
+	ctx.StatusCode(http.StatusOK)
+	ctx.JSON(HealthStatus{Status: healthOK})
+}
+
+// GetReadiness checks the databases, the enabled micro services, the message bus
+// and the plugins. It responds with 503 when a critical dependency is not serving
+func (h *Health) GetReadiness(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	checks := h.readinessChecks()
+	results := make([]HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check healthCheck) {
+			defer wg.Done()
+			results[i] = runHealthCheck(ctxt, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	status := HealthStatus{Status: healthOK, Checks: results}
+	for _, result := range results {
+		if result.Status == healthCritical {
+			status.Status = healthCritical
+			break
+		}
+		if result.Status == healthWarning {
+			status.Status = healthWarning
+		}
+	}
+	statusCode := http.StatusOK
+	if status.Status == healthCritical {
+		l.LogWithFields(ctxt).Warn("ODIM is not ready to serve the requests")
+		statusCode = http.StatusServiceUnavailable
+	}
+	common.SetResponseHeader(ctx, nil)
+	ctx.StatusCode(statusCode)
+	ctx.JSON(status)
+}
+
+// readinessChecks lists the checks of the readiness probe. The message bus is checked
+// through the event service and the plugins through the aggregation service
+func (h *Health) readinessChecks() []healthCheck {
+	checks := []healthCheck{
+		{
+			name:     dbHealthCheck,
+			critical: true,
+			run:      func(ctx context.Context) error { return h.CheckDB() },
+		},
+	}
+	for _, serviceName := range h.EnabledServices() {
+		checks = append(checks, h.serviceCheck(serviceName, serviceName, "", true))
+		switch serviceName {
+		case services.Events:
+			checks = append(checks, h.serviceCheck(services.HealthMessageBus, serviceName, services.HealthMessageBus, true))
+		case services.Aggregator:
+			checks = append(checks, h.serviceCheck(services.HealthPlugins, serviceName, services.HealthPlugins, false))
+		}
+	}
+	return checks
+}
+
+// serviceCheck queries the grpc.health.v1.Health service of the micro service
+func (h *Health) serviceCheck(name, serviceName, healthService string, critical bool) healthCheck {
+	return healthCheck{
+		name:     name,
+		critical: critical,
+		run: func(ctx context.Context) error {
+			status, err := h.CheckService(ctx, serviceName, healthService)
+			if err != nil {
+				return err
+			}
+			if status != healthpb.HealthCheckResponse_SERVING {
+				return fmt.Errorf("%s reported %s", serviceName, status.String())
+			}
+			return nil
+		},
+	}
+}
+
+// runHealthCheck runs the check within healthCheckTimeout
+func runHealthCheck(ctx context.Context, check healthCheck) HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- check.run(ctx)
+	}()
+	var err error
+	select {
+	case err = <-errChan:
+	case <-ctx.Done():
+		err = fmt.Errorf("health check timed out after %v", healthCheckTimeout)
+	}
+	if err == nil {
+		return HealthCheck{Name: check.name, Status: healthOK}
+	}
+	l.LogWithFields(ctx).Warn("Health check " + check.name + " failed: " + err.Error())
+	result := HealthCheck{Name: check.name, Status: healthWarning, Message: err.Error()}
+	if check.critical {
+		result.Status = healthCritical
+	}
+	return result
+}
+
+// GetEnabledServices returns the registry names of the micro services enabled in the odim config
+func GetEnabledServices() []string {
+	var serviceNames []string
+	enabled := map[string]bool{}
+	for _, service := range config.Data.EnabledServices {
+		serviceName, exist := services.ServiceRegistryNames[service]
+		if exist && !enabled[serviceName] {
+			enabled[serviceName] = true
+			serviceNames = append(serviceNames, serviceName)
+		}
+	}
+	sort.Strings(serviceNames)
+	return serviceNames
+}
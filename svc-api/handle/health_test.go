@@ -0,0 +1,130 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type mockHealth struct {
+	dbErr   error
+	serving map[string]bool
+	hang    string
+}
+
+func (m *mockHealth) checkDB() error {
+	return m.dbErr
+}
+
+func (m *mockHealth) checkService(ctx context.Context, serviceName, healthService string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	name := serviceName
+	if healthService != "" {
+		name = healthService
+	}
+	if name == m.hang {
+		time.Sleep(healthCheckTimeout + time.Second)
+	}
+	serving, exist := m.serving[name]
+	if !exist {
+		return healthpb.HealthCheckResponse_UNKNOWN, fmt.Errorf("%s is not registered", serviceName)
+	}
+	if !serving {
+		return healthpb.HealthCheckResponse_NOT_SERVING, nil
+	}
+	return healthpb.HealthCheckResponse_SERVING, nil
+}
+
+func newHealthTestRouter(t *testing.T, m *mockHealth) *httptest.Expect {
+	h := Health{
+		CheckDB:      m.checkDB,
+		CheckService: m.checkService,
+		EnabledServices: func() []string {
+			return []string{services.Aggregator, services.Events, services.Systems}
+		},
+	}
+	router := iris.New()
+	router.Get(HealthzURI, h.GetLiveness)
+	router.Get(ReadyzURI, h.GetReadiness)
+	return httptest.New(t, router)
+}
+
+func healthyServices() map[string]bool {
+	return map[string]bool{
+		services.Aggregator:       true,
+		services.Events:           true,
+		services.Systems:          true,
+		services.HealthMessageBus: true,
+		services.HealthPlugins:    true,
+	}
+}
+
+func TestGetLiveness(t *testing.T) {
+	test := newHealthTestRouter(t, &mockHealth{dbErr: fmt.Errorf("DB is down")})
+	test.GET(HealthzURI).Expect().Status(http.StatusOK).JSON().Object().Value("Status").String().Equal(healthOK)
+}
+
+func TestGetReadiness(t *testing.T) {
+	m := &mockHealth{serving: healthyServices()}
+	test := newHealthTestRouter(t, m)
+	body := test.GET(ReadyzURI).Expect().Status(http.StatusOK).JSON().Object()
+	body.Value("Status").String().Equal(healthOK)
+	body.Value("Checks").Array().Length().Equal(6)
+
+	// plugins are not critical for the readiness
+	m.serving[services.HealthPlugins] = false
+	body = test.GET(ReadyzURI).Expect().Status(http.StatusOK).JSON().Object()
+	body.Value("Status").String().Equal(healthWarning)
+
+	m.serving[services.HealthMessageBus] = false
+	body = test.GET(ReadyzURI).Expect().Status(http.StatusServiceUnavailable).JSON().Object()
+	body.Value("Status").String().Equal(healthCritical)
+
+	m.serving = healthyServices()
+	delete(m.serving, services.Systems)
+	test.GET(ReadyzURI).Expect().Status(http.StatusServiceUnavailable)
+
+	m.serving = healthyServices()
+	m.dbErr = fmt.Errorf("DB is down")
+	body = test.GET(ReadyzURI).Expect().Status(http.StatusServiceUnavailable).JSON().Object()
+	check := body.Value("Checks").Array().Element(0).Object()
+	check.Value("Name").String().Equal(dbHealthCheck)
+	check.Value("Message").String().Equal("DB is down")
+}
+
+func TestGetReadinessTimeout(t *testing.T) {
+	test := newHealthTestRouter(t, &mockHealth{serving: healthyServices(), hang: services.Systems})
+	test.GET(ReadyzURI).Expect().Status(http.StatusServiceUnavailable).JSON().Object().Value("Status").String().Equal(healthCritical)
+}
+
+func TestGetEnabledServices(t *testing.T) {
+	config.SetUpMockConfig(t)
+	config.Data.EnabledServices = []string{"SessionService", "AccountService", "Systems", "Chassis", "Unknown"}
+	got := GetEnabledServices()
+	want := []string{services.AccountSession, services.Systems}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetEnabledServices() = %v, want %v", got, want)
+	}
+}
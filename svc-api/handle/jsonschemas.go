@@ -0,0 +1,264 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	errResponse "github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-api/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+const (
+	// jsonSchemasTable is the in-memory table holding the OEM schemas published by the plugins
+	jsonSchemasTable = "JsonSchemas"
+	// jsonSchemaPublicationURI is the DMTF location of the standard schemas
+	jsonSchemaPublicationURI = "http://redfish.dmtf.org/schemas/v1/"
+)
+
+// JSONSchemas defines the functions used for serving the json schema files.
+// The standard DMTF schemas are read from config.Data.JSONSchemaStorePath and
+// the OEM schemas published by the plugins are read from DB
+type JSONSchemas struct {
+	Auth               func(context.Context, string, []string, []string) (errResponse.RPC, error)
+	GetSchemaFileNames func(context.Context, string) ([]string, *errors.Error)
+	GetSchemaFile      func(context.Context, string, string) ([]byte, *errors.Error)
+}
+
+// GetJSONSchemaFileCollection lists the json schema files available in the schema store and DB
+func (j *JSONSchemas) GetJSONSchemaFileCollection(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	if !j.authorize(ctx) {
+		return
+	}
+	var headers = map[string]string{
+		"Allow": "GET",
+		"Link":  "</redfish/v1/SchemaStore/en/JsonSchemaFileCollection.json/>; rel=describedby",
+	}
+	listMembers := []response.ListMember{}
+	for _, schemaID := range j.getSchemaIDs(ctxt) {
+		listMembers = append(listMembers, response.ListMember{
+			OdataID: "/redfish/v1/JsonSchemas/" + schemaID,
+		})
+	}
+	schemaCollectionResp := response.ListResponse{
+		OdataContext: "/redfish/v1/$metadata#JsonSchemaFileCollection.JsonSchemaFileCollection",
+		OdataID:      "/redfish/v1/JsonSchemas",
+		OdataType:    "#JsonSchemaFileCollection.JsonSchemaFileCollection",
+		Name:         "JSON Schema File Collection",
+		Description:  "Collection of JSON Schema Files",
+		MembersCount: len(listMembers),
+		Members:      listMembers,
+	}
+	common.SetResponseHeader(ctx, headers)
+	ctx.JSON(schemaCollectionResp)
+}
+
+// GetJSONSchemaFile gives the locations of a json schema file. If the requested
+// id is the name of a .json or .xml document, the document itself is returned
+func (j *JSONSchemas) GetJSONSchemaFile(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	schemaID := ctx.Params().Get("id")
+	if strings.HasSuffix(schemaID, ".json") || strings.HasSuffix(schemaID, ".xml") {
+		j.getJSONSchemaDocument(ctx)
+		return
+	}
+	if !j.authorize(ctx) {
+		return
+	}
+	var headers = map[string]string{
+		"Allow": "GET",
+		"Link":  "</redfish/v1/SchemaStore/en/JsonSchemaFile.json/>; rel=describedby",
+	}
+	location := response.Location{
+		Language: "en",
+		URI:      "/redfish/v1/JsonSchemas/" + schemaID + ".json",
+	}
+	if _, err := j.readStoreFile(schemaID + ".json"); err == nil {
+		location.PublicationURI = jsonSchemaPublicationURI + schemaID + ".json"
+	} else if _, dbErr := j.GetSchemaFile(ctxt, jsonSchemasTable, schemaID+".json"); dbErr != nil {
+		errorMessage := "error: resource not found"
+		l.LogWithFields(ctxt).Error(errorMessage + ": " + schemaID)
+		resp := common.GeneralError(http.StatusNotFound, errResponse.ResourceNotFound, errorMessage, []interface{}{"JsonSchemaFile", schemaID}, nil)
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(http.StatusNotFound)
+		ctx.JSON(&resp.Body)
+		return
+	}
+	// the schema of a versioned file such as ComputerSystem.v1_20_0 is #ComputerSystem.v1_20_0.ComputerSystem
+	schemaType := strings.Split(schemaID, ".")[0]
+	resp := response.JSONSchemaFile{
+		ID:           schemaID,
+		OdataContext: "/redfish/v1/$metadata#JsonSchemaFile.JsonSchemaFile",
+		OdataID:      "/redfish/v1/JsonSchemas/" + schemaID,
+		OdataType:    "#JsonSchemaFile.v1_1_4.JsonSchemaFile",
+		Name:         schemaType + " Schema File",
+		Description:  schemaType + " Schema File Location",
+		Languages:    []string{"en"},
+		Location:     []response.Location{location},
+		Schema:       "#" + schemaID + "." + schemaType,
+	}
+	common.SetResponseHeader(ctx, headers)
+	ctx.JSON(resp)
+}
+
+// getJSONSchemaDocument writes the json schema or CSDL document as it is stored
+func (j *JSONSchemas) getJSONSchemaDocument(ctx iris.Context) {
+	ctxt := ctx.Request().Context()
+	fileName := ctx.Params().Get("id")
+	if !j.authorize(ctx) {
+		return
+	}
+	content, err := j.readStoreFile(fileName)
+	if err != nil && strings.HasSuffix(fileName, ".json") {
+		// OEM schemas are published by the plugins and only available in DB
+		content, _ = j.GetSchemaFile(ctxt, jsonSchemasTable, fileName)
+	}
+	if content == nil {
+		errorMessage := "error: resource not found"
+		l.LogWithFields(ctxt).Error(errorMessage + ": " + fileName)
+		resp := common.GeneralError(http.StatusNotFound, errResponse.ResourceNotFound, errorMessage, []interface{}{"JsonSchemaFile", fileName}, nil)
+		common.SetResponseHeader(ctx, resp.Header)
+		ctx.StatusCode(http.StatusNotFound)
+		ctx.JSON(&resp.Body)
+		return
+	}
+	var headers = map[string]string{
+		"Allow": "GET",
+	}
+	if strings.HasSuffix(fileName, ".xml") {
+		headers["Content-type"] = "application/xml; charset=utf-8"
+	} else if !json.Valid(content) {
+		errorMessage := "error: schema file " + fileName + " is not a valid json document"
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	common.SetResponseHeader(ctx, headers)
+	ctx.Write(content)
+}
+
+// getSchemaIDs returns the ids of the json schema files from the schema store followed by the ones from DB
+func (j *JSONSchemas) getSchemaIDs(ctx context.Context) []string {
+	var schemaIDs []string
+	added := make(map[string]bool)
+	add := func(fileName string) {
+		// hidden files and CSDL documents are not listed as members
+		if strings.HasPrefix(fileName, ".") || !strings.HasSuffix(fileName, ".json") {
+			return
+		}
+		schemaID := strings.TrimSuffix(fileName, ".json")
+		if !added[schemaID] {
+			added[schemaID] = true
+			schemaIDs = append(schemaIDs, schemaID)
+		}
+	}
+	if schemaStore := config.Data.JSONSchemaStorePath; schemaStore != "" {
+		schemaFiles, err := ioutil.ReadDir(schemaStore)
+		if err != nil {
+			l.LogWithFields(ctx).Error("error while reading the json schema store " + schemaStore + ": " + err.Error())
+		}
+		for _, schemaFile := range schemaFiles {
+			add(schemaFile.Name())
+		}
+	}
+	schemaFileKeys, err := j.GetSchemaFileNames(ctx, jsonSchemasTable)
+	if err != nil {
+		// the standard schemas can still be listed
+		l.LogWithFields(ctx).Error("error while trying to get the json schema file names from DB: " + err.Error())
+	}
+	for _, schemaFile := range schemaFileKeys {
+		add(schemaFile)
+	}
+	return schemaIDs
+}
+
+// CheckJSONSchemaStore verifies that the json schema store has the DMTF json schema and CSDL
+// documents, without which the @odata.type of the resources cannot be resolved by the clients
+func CheckJSONSchemaStore(schemaStore string) error {
+	if schemaStore == "" {
+		return fmt.Errorf("no value set for JSONSchemaStorePath")
+	}
+	schemaFiles, err := ioutil.ReadDir(schemaStore)
+	if err != nil {
+		return fmt.Errorf("unable to read the json schema store %s: %v", schemaStore, err)
+	}
+	var jsonSchemas, csdlDocuments int
+	for _, schemaFile := range schemaFiles {
+		switch filepath.Ext(schemaFile.Name()) {
+		case ".json":
+			jsonSchemas++
+		case ".xml":
+			csdlDocuments++
+		}
+	}
+	if jsonSchemas == 0 || csdlDocuments == 0 {
+		return fmt.Errorf("the json schema store %s has %d json schemas and %d CSDL documents, the json-schema and csdl "+
+			"files of the DMTF Redfish schema bundle (DSP8010) should be copied to it", schemaStore, jsonSchemas, csdlDocuments)
+	}
+	return nil
+}
+
+// readStoreFile reads a file of the json schema store
+func (j *JSONSchemas) readStoreFile(fileName string) ([]byte, error) {
+	schemaStore := config.Data.JSONSchemaStorePath
+	if schemaStore == "" || fileName != filepath.Base(fileName) || strings.HasPrefix(fileName, ".") {
+		return nil, fmt.Errorf("schema file %s is not available in the schema store", fileName)
+	}
+	return ioutil.ReadFile(filepath.Join(schemaStore, fileName))
+}
+
+// authorize validates the session token for the Login privilege
+// and writes the error response when the request is not authorized
+func (j *JSONSchemas) authorize(ctx iris.Context) bool {
+	ctxt := ctx.Request().Context()
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		common.SendInvalidSessionResponse(ctx, invalidAuthTokenErrorMsg)
+		return false
+	}
+	authResp, err := j.Auth(ctxt, sessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		errMsg := "error while trying to authenticate session"
+		if err != nil {
+			errMsg = errMsg + ": " + err.Error()
+		}
+		sendAuthErrorResponse(ctxt, ctx, errMsg, authResp)
+		return false
+	}
+	return true
+}
+
+// JSONSchemasMethodNotAllowed builds reponse for the unallowed http operation on JsonSchemas URLs and returns 405 error.
+func JSONSchemasMethodNotAllowed(ctx iris.Context) {
+	defer ctx.Next()
+	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	fillMethodNotAllowedErrorResponse(ctx)
+	return
+}
@@ -0,0 +1,147 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+var mockOEMSchemas = map[string]string{
+	"OemChassis.v1_0_0.json": `{"title": "#OemChassis.v1_0_0.OemChassis"}`,
+}
+
+func mockGetSchemaFileNames(ctx context.Context, table string) ([]string, *errors.Error) {
+	var keys []string
+	for key := range mockOEMSchemas {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func mockGetSchemaFile(ctx context.Context, table, key string) ([]byte, *errors.Error) {
+	if schema, ok := mockOEMSchemas[key]; ok {
+		return []byte(schema), nil
+	}
+	return nil, errors.PackError(errors.DBKeyNotFound, "no data with the key ", key, " found")
+}
+
+func setUpJSONSchemaStore(t *testing.T) {
+	config.SetUpMockConfig(t)
+	schemaStore := t.TempDir()
+	files := map[string]string{
+		"ComputerSystem.v1_20_0.json": `{"title": "#ComputerSystem.v1_20_0.ComputerSystem"}`,
+		"ComputerSystem_v1.xml":       `<edmx:Edmx Version="4.0"></edmx:Edmx>`,
+		".hidden.json":                `{}`,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(schemaStore, name), []byte(content), 0644); err != nil {
+			t.Fatalf("error while writing the schema file: %v", err)
+		}
+	}
+	config.Data.JSONSchemaStorePath = schemaStore
+}
+
+func newJSONSchemasTestRouter(t *testing.T) *httptest.Expect {
+	j := JSONSchemas{
+		Auth:               authMock,
+		GetSchemaFileNames: mockGetSchemaFileNames,
+		GetSchemaFile:      mockGetSchemaFile,
+	}
+	router := iris.New()
+	redfishRoutes := router.Party("/redfish/v1")
+	redfishRoutes.Get("/JsonSchemas", j.GetJSONSchemaFileCollection)
+	redfishRoutes.Get("/JsonSchemas/{id}", j.GetJSONSchemaFile)
+	return httptest.New(t, router)
+}
+
+func TestGetJSONSchemaFileCollection(t *testing.T) {
+	setUpJSONSchemaStore(t)
+	test := newJSONSchemasTestRouter(t)
+	test.GET("/redfish/v1/JsonSchemas").Expect().Status(http.StatusUnauthorized)
+	test.GET("/redfish/v1/JsonSchemas").WithHeader("X-Auth-Token", "invalidToken").Expect().Status(http.StatusUnauthorized)
+	body := test.GET("/redfish/v1/JsonSchemas").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK).JSON().Object()
+	body.Value("Members@odata.count").Number().Equal(2)
+	members := body.Value("Members").Array()
+	members.Element(0).Object().Value("@odata.id").String().Equal("/redfish/v1/JsonSchemas/ComputerSystem.v1_20_0")
+	members.Element(1).Object().Value("@odata.id").String().Equal("/redfish/v1/JsonSchemas/OemChassis.v1_0_0")
+}
+
+func TestGetJSONSchemaFile(t *testing.T) {
+	setUpJSONSchemaStore(t)
+	test := newJSONSchemasTestRouter(t)
+	test.GET("/redfish/v1/JsonSchemas/ComputerSystem.v1_20_0").Expect().Status(http.StatusUnauthorized)
+	test.GET("/redfish/v1/JsonSchemas/Unknown.v1_0_0").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusNotFound)
+
+	body := test.GET("/redfish/v1/JsonSchemas/ComputerSystem.v1_20_0").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK).JSON().Object()
+	body.Value("Schema").String().Equal("#ComputerSystem.v1_20_0.ComputerSystem")
+	location := body.Value("Location").Array().Element(0).Object()
+	location.Value("Uri").String().Equal("/redfish/v1/JsonSchemas/ComputerSystem.v1_20_0.json")
+	location.Value("PublicationUri").String().Equal("http://redfish.dmtf.org/schemas/v1/ComputerSystem.v1_20_0.json")
+
+	body = test.GET("/redfish/v1/JsonSchemas/OemChassis.v1_0_0").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK).JSON().Object()
+	body.Value("Schema").String().Equal("#OemChassis.v1_0_0.OemChassis")
+	body.Value("Location").Array().Element(0).Object().NotContainsKey("PublicationUri")
+}
+
+func TestGetJSONSchemaDocument(t *testing.T) {
+	setUpJSONSchemaStore(t)
+	test := newJSONSchemasTestRouter(t)
+	test.GET("/redfish/v1/JsonSchemas/ComputerSystem.v1_20_0.json").Expect().Status(http.StatusUnauthorized)
+	test.GET("/redfish/v1/JsonSchemas/.hidden.json").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusNotFound)
+	test.GET("/redfish/v1/JsonSchemas/Unknown_v1.xml").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusNotFound)
+
+	resp := test.GET("/redfish/v1/JsonSchemas/ComputerSystem.v1_20_0.json").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK)
+	resp.JSON().Object().Value("title").String().Equal("#ComputerSystem.v1_20_0.ComputerSystem")
+
+	resp = test.GET("/redfish/v1/JsonSchemas/OemChassis.v1_0_0.json").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK)
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(resp.Body().Raw()), &schema); err != nil || schema["title"] != "#OemChassis.v1_0_0.OemChassis" {
+		t.Errorf("unexpected OEM schema document: %v, %v", schema, err)
+	}
+
+	resp = test.GET("/redfish/v1/JsonSchemas/ComputerSystem_v1.xml").WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK)
+	resp.Header("Content-type").Equal("application/xml; charset=utf-8")
+	resp.Body().Equal(`<edmx:Edmx Version="4.0"></edmx:Edmx>`)
+}
+
+func TestCheckJSONSchemaStore(t *testing.T) {
+	setUpJSONSchemaStore(t)
+	if err := CheckJSONSchemaStore(config.Data.JSONSchemaStorePath); err != nil {
+		t.Errorf("CheckJSONSchemaStore() error = %v", err)
+	}
+	if err := CheckJSONSchemaStore(""); err == nil {
+		t.Error("CheckJSONSchemaStore() expected an error for an unset schema store")
+	}
+	if err := CheckJSONSchemaStore(filepath.Join(t.TempDir(), "jsonschemastore")); err == nil {
+		t.Error("CheckJSONSchemaStore() expected an error for a missing schema store")
+	}
+	schemaStore := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(schemaStore, "ComputerSystem.v1_20_0.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatalf("error while writing the schema file: %v", err)
+	}
+	if err := CheckJSONSchemaStore(schemaStore); err == nil {
+		t.Error("CheckJSONSchemaStore() expected an error for a schema store without CSDL documents")
+	}
+}
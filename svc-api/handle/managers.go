@@ -18,6 +18,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -42,8 +45,13 @@ type ManagersRPCs struct {
 func (mgr *ManagersRPCs) GetManagersCollection(ctx iris.Context) {
 	defer ctx.Next()
 	ctxt := ctx.Request().Context()
+	expand, ok := getExpandQuery(ctx)
+	if !ok {
+		return
+	}
 	req := managersproto.ManagerRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          stripQueryOptions(ctx.Request().RequestURI, expandQueryOption),
 	}
 	l.LogWithFields(ctxt).Debug("Incoming request received for the getting all Managers collection")
 	if req.SessionToken == "" {
@@ -56,11 +64,34 @@ func (mgr *ManagersRPCs) GetManagersCollection(ctx iris.Context) {
 		l.LogWithFields(ctxt).Error(errorMessage)
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
+	if resp.StatusCode == http.StatusOK {
+		resp.Body = expandResponseBody(ctxt, resp.Body, expand, inventoryFetcher("/redfish/v1/Managers", mgr.getManagerBody(req.SessionToken)))
+	}
 	l.LogWithFields(ctx).Debugf("Outgoing response for Getting Managers collection is %s and response status %d", string(resp.Body), int(resp.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	sendManagersResponse(ctx, resp)
 }
 
+// getManagerBody returns a resourceFetcher which gets the manager details
+// through GetManagersRPC, used when the manager is not in the inventory,
+// like the manager of ODIM itself
+func (mgr *ManagersRPCs) getManagerBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := mgr.GetManagersRPC(ctx, managersproto.ManagerRequest{
+			SessionToken: sessionToken,
+			ManagerID:    oid[strings.LastIndex(oid, "/")+1:],
+			URL:          oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
 // GetManager fetches computer managers details
 func (mgr *ManagersRPCs) GetManager(ctx iris.Context) {
 	defer ctx.Next()
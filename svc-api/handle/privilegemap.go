@@ -0,0 +1,78 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package handle ...
+package handle
+
+import (
+	"context"
+	"net/http"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	errResponse "github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+// PrivilegeMapURI is the URI of the privilege registry used for the authorization
+const PrivilegeMapURI = "/redfish/v1/AccountService/PrivilegeMap"
+
+// PrivilegeMap defines the functions used for serving the privilege registry,
+// which is read from config.Data.RegistryStorePath
+type PrivilegeMap struct {
+	Auth             func(context.Context, string, []string, []string) (errResponse.RPC, error)
+	LoadPrivilegeMap func(string) (*dmtf.PrivilegeMap, string, error)
+}
+
+// GetPrivilegeMap serves the privilege registry, which maps the operations
+// on the Redfish resources to the privileges required for them
+func (p *PrivilegeMap) GetPrivilegeMap(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	sessionToken := ctx.Request().Header.Get(AuthTokenHeader)
+	if sessionToken == "" {
+		common.SendInvalidSessionResponse(ctx, invalidAuthTokenErrorMsg)
+		return
+	}
+	authResp, err := p.Auth(ctxt, sessionToken, []string{common.PrivilegeLogin}, []string{})
+	if authResp.StatusCode != http.StatusOK {
+		errMsg := "error while trying to authenticate session"
+		if err != nil {
+			errMsg = errMsg + ": " + err.Error()
+		}
+		sendAuthErrorResponse(ctxt, ctx, errMsg, authResp)
+		return
+	}
+
+	privilegeMap, _, err := p.LoadPrivilegeMap(config.Data.RegistryStorePath)
+	if err != nil {
+		errorMessage := "error while trying to load the privilege registry: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, errResponse.InternalError, errorMessage, nil, nil)
+		common.SetResponseHeader(ctx, response.Header)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+	privilegeMap.ODataID = PrivilegeMapURI
+	var headers = map[string]string{
+		"Allow": "GET",
+		"Link":  "</redfish/v1/SchemaStore/en/PrivilegeRegistry.json/>; rel=describedby",
+	}
+	common.SetResponseHeader(ctx, headers)
+	ctx.StatusCode(http.StatusOK)
+	ctx.JSON(privilegeMap)
+}
@@ -0,0 +1,61 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package handle
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+func mockLoadPrivilegeMap(registryStore string) (*dmtf.PrivilegeMap, string, error) {
+	if registryStore == "invalid" {
+		return nil, "", fmt.Errorf("no privilege registry found in %s", registryStore)
+	}
+	return &dmtf.PrivilegeMap{
+		ODataType:      "#PrivilegeRegistry.v1_1_4.PrivilegeRegistry",
+		ID:             "Redfish_1.3.0_PrivilegeRegistry",
+		PrivilegesUsed: []string{"Login", "ConfigureManager"},
+	}, registryStore + "/Redfish_1.3.0_PrivilegeRegistry.json", nil
+}
+
+func TestGetPrivilegeMap(t *testing.T) {
+	config.SetUpMockConfig(t)
+	p := PrivilegeMap{
+		Auth:             authMock,
+		LoadPrivilegeMap: mockLoadPrivilegeMap,
+	}
+	router := iris.New()
+	redfishRoutes := router.Party("/redfish/v1/AccountService")
+	redfishRoutes.Get("/PrivilegeMap", p.GetPrivilegeMap)
+	test := httptest.New(t, router)
+
+	test.GET(PrivilegeMapURI).Expect().Status(http.StatusUnauthorized)
+	test.GET(PrivilegeMapURI).WithHeader("X-Auth-Token", "invalidToken").Expect().Status(http.StatusUnauthorized)
+
+	resp := test.GET(PrivilegeMapURI).WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusOK)
+	resp.Header("Allow").Equal("GET")
+	body := resp.JSON().Object()
+	body.Value("@odata.id").String().Equal(PrivilegeMapURI)
+	body.Value("Id").String().Equal("Redfish_1.3.0_PrivilegeRegistry")
+
+	config.Data.RegistryStorePath = "invalid"
+	test.GET(PrivilegeMapURI).WithHeader("X-Auth-Token", "validToken").Expect().Status(http.StatusInternalServerError)
+}
@@ -18,6 +18,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -27,12 +30,48 @@ import (
 
 // RoleRPCs defines all the RPC methods in role
 type RoleRPCs struct {
+	CreateRoleRPC  func(context.Context, roleproto.RoleRequest) (*roleproto.RoleResponse, error)
 	GetAllRolesRPC func(context.Context, roleproto.GetRoleRequest) (*roleproto.RoleResponse, error)
 	GetRoleRPC     func(context.Context, roleproto.GetRoleRequest) (*roleproto.RoleResponse, error)
 	UpdateRoleRPC  func(context.Context, roleproto.UpdateRoleRequest) (*roleproto.RoleResponse, error)
 	DeleteRoleRPC  func(context.Context, roleproto.DeleteRoleRequest) (*roleproto.RoleResponse, error)
 }
 
+// CreateRole defines the CreateRole iris handler.
+// The method extract the session token and the role details
+// from the request body and creates the RPC request.
+// After the RPC call the method will feed the response to the iris
+// and gives out a proper response.
+func (r *RoleRPCs) CreateRole(ctx iris.Context) {
+	defer ctx.Next()
+	ctxt := ctx.Request().Context()
+	var roleReq interface{}
+	err := ctx.ReadJSON(&roleReq)
+	if err != nil {
+		errorMessage := "error while trying to get JSON body from the role create request body: " + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendMalformedJSONRequestErrResponse(ctx, errorMessage)
+		return
+	}
+	req := roleproto.RoleRequest{SessionToken: ctx.Request().Header.Get("X-Auth-Token")}
+	if req.SessionToken == "" {
+		errorMessage := invalidAuthTokenErrorMsg
+		common.SendInvalidSessionResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctxt).Debug("Incoming request received for the creating a role")
+	req.RequestBody, _ = json.Marshal(&roleReq)
+	resp, err := r.CreateRoleRPC(ctxt, req)
+	if err != nil {
+		errorMessage := "RPC error:" + err.Error()
+		l.LogWithFields(ctxt).Error(errorMessage)
+		common.SendFailedRPCCallResponse(ctx, errorMessage)
+		return
+	}
+	l.LogWithFields(ctx).Debugf("Outgoing response for creating a role is %s and response status %d", string(resp.Body), int(resp.StatusCode))
+	sendRoleResponse(ctx, resp)
+}
+
 // GetAllRoles defines the GetAllRoles iris handler.
 // The method extract the session token and creates the RPC request.
 // After the RPC call the method will feed the response to the iris
@@ -54,7 +93,7 @@ func (r *RoleRPCs) GetAllRoles(ctx iris.Context) {
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
 	l.LogWithFields(ctx).Debugf("Outgoing response for Getting all roles is %s and response status %d", string(resp.Body), int(resp.StatusCode))
-	ctx.ResponseWriter().Header().Set("Allow", "GET")
+	ctx.ResponseWriter().Header().Set("Allow", "GET, POST")
 	sendRoleResponse(ctx, resp)
 }
 
@@ -114,6 +153,11 @@ func (r *RoleRPCs) UpdateRole(ctx iris.Context) {
 	}
 	req.Id = ctx.Params().Get("id")
 	l.LogWithFields(ctxt).Debugf("Incoming request received for the updating a role with id %s", req.Id)
+	release, ok := checkPreconditions(ctx, r.getRoleBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	req.UpdateRequest, _ = json.Marshal(&roleReq)
 	resp, err := r.UpdateRoleRPC(ctxt, req)
 	if err != nil {
@@ -138,6 +182,11 @@ func (r *RoleRPCs) DeleteRole(ctx iris.Context) {
 	}
 	req.ID = ctx.Params().Get("id")
 	l.LogWithFields(ctxt).Debugf("Incoming request received for the deleting a role with id %s", req.ID)
+	release, ok := checkPreconditions(ctx, r.getRoleBody(req.SessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := r.DeleteRoleRPC(ctxt, req)
 	if err != nil {
 		errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
@@ -150,7 +199,26 @@ func (r *RoleRPCs) DeleteRole(ctx iris.Context) {
 
 // sendRoleResponse writes the role response to client
 func sendRoleResponse(ctx iris.Context, resp *roleproto.RoleResponse) {
+	setETag(ctx, resp.StatusCode, resp.Body)
 	common.SetResponseHeader(ctx, resp.Header)
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// getRoleBody returns a resourceFetcher which gets the role details
+// through GetRoleRPC, used to evaluate the conditional requests
+func (r *RoleRPCs) getRoleBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := r.GetRoleRPC(ctx, roleproto.GetRoleRequest{
+			SessionToken: sessionToken,
+			Id:           oid[strings.LastIndex(oid, "/")+1:],
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
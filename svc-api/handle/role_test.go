@@ -69,7 +69,7 @@ func mockDeleteRoleRPC(context.Context, roleproto.DeleteRoleRequest) (*roleproto
 }
 
 func TestRoleRPCs_GetAllRoles(t *testing.T) {
-	header["Allow"] = []string{"GET"}
+	header["Allow"] = []string{"GET, POST"}
 	defer delete(header, "Allow")
 	var r RoleRPCs
 	r.GetAllRolesRPC = mockGetAllRolesRPC
@@ -109,6 +109,49 @@ func TestRoleRPCs_GetAllRolesWithoutToken(t *testing.T) {
 	).Expect().Status(http.StatusUnauthorized).Headers().Equal(header)
 }
 
+func TestRoleRPCs_CreateRole(t *testing.T) {
+	var r RoleRPCs
+	r.CreateRoleRPC = mockCreateRoleRPC
+	body := map[string]interface{}{
+		"RoleId":             "FirmwareOperator",
+		"AssignedPrivileges": []string{"Login"},
+		"OemPrivileges":      []string{"ConfigureFirmware"},
+	}
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/Roles", r.CreateRole)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusCreated)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithJSON(body).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestRoleRPCs_CreateRoleWithRPCError(t *testing.T) {
+	var r RoleRPCs
+	r.CreateRoleRPC = mockCreateRoleRPCWithRPCError
+	body := map[string]interface{}{
+		"RoleId":             "FirmwareOperator",
+		"AssignedPrivileges": []string{"Login"},
+	}
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/Roles", r.CreateRole)
+
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Roles",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
 func TestRoleRPCs_GetRole(t *testing.T) {
 	header["Allow"] = []string{"GET, PATCH, DELETE"}
 	defer delete(header, "Allow")
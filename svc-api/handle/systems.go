@@ -18,6 +18,9 @@ package handle
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -44,9 +47,13 @@ type SystemRPCs struct {
 func (sys *SystemRPCs) GetSystemsCollection(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
 	defer ctx.Next()
+	expand, ok := getExpandQuery(ctx)
+	if !ok {
+		return
+	}
 	req := systemsproto.GetSystemsRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
-		URL:          ctx.Request().RequestURI,
+		URL:          stripQueryOptions(ctx.Request().RequestURI, expandQueryOption),
 	}
 	l.LogWithFields(ctxt).Debugf("Incoming request received for getting systems collection %s", req.URL)
 	if req.SessionToken == "" {
@@ -59,11 +66,53 @@ func (sys *SystemRPCs) GetSystemsCollection(ctx iris.Context) {
 		l.LogWithFields(ctxt).Error(errorMessage)
 		common.SendFailedRPCCallResponse(ctx, errorMessage)
 	}
+	if resp.StatusCode == http.StatusOK {
+		resp.Body = expandResponseBody(ctxt, resp.Body, expand, inventoryFetcher("/redfish/v1/Systems", sys.getSystemBody(req.SessionToken)))
+	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting systems collection is %s with status code %d", string(resp.Body), int(resp.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	sendSystemsResponse(ctx, resp)
 }
 
+// getSystemBody returns a resourceFetcher which gets the computer system
+// details through GetSystemRPC, used when the system is not in the inventory
+func (sys *SystemRPCs) getSystemBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := sys.GetSystemRPC(ctx, systemsproto.GetSystemsRequest{
+			SessionToken: sessionToken,
+			RequestParam: oid[strings.LastIndex(oid, "/")+1:],
+			URL:          oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
+// getSystemResourceBody returns a resourceFetcher which gets the details of a
+// computer system sub resource through GetSystemResourceRPC, used to evaluate
+// the conditional requests
+func (sys *SystemRPCs) getSystemResourceBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := sys.GetSystemResourceRPC(ctx, systemsproto.GetSystemsRequest{
+			SessionToken: sessionToken,
+			RequestParam: strings.SplitN(strings.TrimPrefix(oid, "/redfish/v1/Systems/"), "/", 2)[0],
+			URL:          oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
 // GetSystem fetches computer system details
 func (sys *SystemRPCs) GetSystem(ctx iris.Context) {
 	ctxt := ctx.Request().Context()
@@ -129,6 +178,7 @@ func (sys *SystemRPCs) GetSystemResource(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "GET")
 	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting systems resources is %s with status code %d", string(resp.Body), int(resp.StatusCode))
+	setETag(ctx, resp.StatusCode, resp.Body)
 	sendSystemsResponse(ctx, resp)
 }
 
@@ -223,6 +273,11 @@ func (sys *SystemRPCs) ChangeBiosSettings(ctx iris.Context) {
 		SystemID:     ctx.Params().Get("id"),
 		RequestBody:  request,
 	}
+	release, ok := checkPreconditions(ctx, sys.getSystemResourceBody(sessionToken))
+	if !ok {
+		return
+	}
+	defer release()
 	resp, err := sys.ChangeBiosSettingsRPC(ctxt, biosRequest)
 	if err != nil {
 		errorMessage := rpcFailedErrMsg + err.Error()
@@ -17,7 +17,9 @@ package handle
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -177,8 +179,13 @@ func (task *TaskRPCs) GetTaskMonitor(ctx iris.Context) {
 func (task *TaskRPCs) TaskCollection(ctx iris.Context) {
 	defer ctx.Next()
 	ctxt := ctx.Request().Context()
+	expand, ok := getExpandQuery(ctx)
+	if !ok {
+		return
+	}
 	req := &taskproto.GetTaskRequest{
 		SessionToken: ctx.Request().Header.Get(AuthTokenHeader),
+		URL:          stripQueryOptions(ctx.Request().RequestURI, expandQueryOption),
 	}
 	l.LogWithFields(ctxt).Debugf("Incoming request received for getting task all the available tasks collection")
 	if req.SessionToken == "" {
@@ -200,12 +207,32 @@ func (task *TaskRPCs) TaskCollection(ctx iris.Context) {
 		ctx.JSON(&response.Body)
 		return
 	}
+	if response.StatusCode == http.StatusOK {
+		response.Body = expandResponseBody(ctxt, response.Body, expand, task.getTaskBody(req.SessionToken))
+	}
 	l.LogWithFields(ctxt).Debugf("Outgoing response for getting task collections is %s with status code %d", string(response.Body), int(response.StatusCode))
 	ctx.ResponseWriter().Header().Set("Allow", "GET")
 	sendTaskResponse(ctx, response)
 
 }
 
+// getTaskBody returns a resourceFetcher which gets the task details through GetTaskRPC
+func (task *TaskRPCs) getTaskBody(sessionToken string) resourceFetcher {
+	return func(ctx context.Context, oid string) ([]byte, error) {
+		resp, err := task.GetTaskRPC(ctx, &taskproto.GetTaskRequest{
+			TaskID:       oid[strings.LastIndex(oid, "/")+1:],
+			SessionToken: sessionToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s responded with status code %d", oid, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
 // GetTaskService fetches Task Service details
 // It takes iris context and extract auth token from the context
 // Create a request object in task proto request format and pass it to rpc call
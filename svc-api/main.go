@@ -16,12 +16,16 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
@@ -34,6 +38,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-api/apicommon"
+	"github.com/ODIM-Project/ODIM/svc-api/handle"
 	"github.com/ODIM-Project/ODIM/svc-api/router"
 	"github.com/ODIM-Project/ODIM/svc-api/rpc"
 	iris "github.com/kataras/iris/v12"
@@ -65,6 +70,10 @@ func main() {
 	if uid := os.Geteuid(); uid == 0 {
 		log.Fatal("Api Service should not be run as the root user")
 	}
+	// the standard schemas served under /redfish/v1/JsonSchemas are read from the schema store
+	if err := handle.CheckJSONSchemaStore(config.Data.JSONSchemaStorePath); err != nil {
+		log.Fatal("Error while checking the json schema store: " + err.Error())
+	}
 	router := router.Router()
 
 	//WrapRouter method removes the trailing slash from the URL if present in the request and convert the URL to lower case.
@@ -81,10 +90,14 @@ func main() {
 		ctx := createContext(r, transactionID, podName)
 		r = r.WithContext(ctx)
 		basicAuth := r.Header.Get("Authorization")
-		var basicAuthToken string
 
-		if basicAuth != "" {
-			var urlNoBasicAuth = []string{"/redfish/v1", "/redfish/v1/SessionService"}
+		// the API keys of the accounts and the bearer tokens issued by the OAuth2 service are passed
+		// as the session tokens, they are validated by the account-session service like the sessions
+		if apiKey := r.Header.Get("X-API-Key"); apiKey != "" {
+			r.Header.Set("X-Auth-Token", apiKey)
+		} else if bearerToken, ok := getBearerToken(basicAuth); ok {
+			r.Header.Set("X-Auth-Token", bearerToken)
+		} else if basicAuth != "" {
 			var authRequired bool
 			authRequired = true
 			for _, item := range urlNoBasicAuth {
@@ -134,45 +147,41 @@ func main() {
 					"Password": password,
 				}
 				//Marshalling input to get bytes since session create request accepts bytes
-				sessionReqData, err := json.Marshal(sessionReq)
+				sessionReqData, _ := json.Marshal(sessionReq)
 
 				var req sessionproto.SessionCreateRequest
 				req.RequestBody = sessionReqData
-				resp, err := rpc.DoSessionCreationRequest(ctx, req)
-				if err != nil && resp == nil {
-					errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
-					logs.LogWithFields(ctx).Error(errorMessage)
-					common.SetCommonHeaders(w)
-					w.WriteHeader(http.StatusInternalServerError)
-					body, _ := json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
-					w.Write([]byte(body))
+				if !createRequestSession(ctx, w, r, req, false) {
 					return
 				}
-				if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-					common.SetCommonHeaders(w)
-					w.WriteHeader(int(resp.StatusCode))
-					if resp.StatusCode == http.StatusServiceUnavailable {
-						logs.LogWithFields(ctx).Error("error: unable to establish connection with db")
-						w.Write(resp.Body)
-						return
-					}
-					errorMessage := "error: failed to create a sesssion"
-					logs.LogWithFields(ctx).Info(errorMessage)
-					body, _ := json.Marshal(common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, nil, nil).Body)
-					w.Write([]byte(body))
-					return
-				}
-				var sessionHeader map[string]string
-				var sessionID string
-				sessionHeader = resp.Header
-				basicAuthToken = sessionHeader["X-Auth-Token"]
-				sessionLocation := sessionHeader["Link"]
-				sessionLocationSlice := strings.Split(sessionLocation, "/")
-				if len(sessionLocationSlice) > 1 {
-					sessionID = sessionLocationSlice[len(sessionLocationSlice)-2]
-				}
-				r.Header.Set("X-Auth-Token", basicAuthToken)
-				r.Header.Set("Session-ID", sessionID)
+			}
+		} else if isClientCertificateLogin(r, path) {
+			// the HTTPS clients presenting a client certificate issued by the root CA of ODIM are
+			// given a session of the user mapped from the certificate, like the basic auth. The
+			// request is served without the session when the certificate is not authenticated
+			var req sessionproto.SessionCreateRequest
+			req.ClientCertificate = encodeCertificates(r.TLS.PeerCertificates)
+			if !createRequestSession(ctx, w, r, req, true) {
+				return
+			}
+		}
+		// the aggregates the user of the session is restricted to are added to the context,
+		// for the services to restrict the members of the collections to the aggregates.
+		// The aggregates are cached for the session, they are not got for each request
+		if sessionToken := r.Header.Get("X-Auth-Token"); sessionToken != "" {
+			aggregates, err := services.GetSessionUserAggregates(ctx, sessionToken)
+			if err != nil {
+				errorMessage := "error: unable to get the aggregates of the session: " + err.Error()
+				logs.LogWithFields(ctx).Error(errorMessage)
+				common.SetCommonHeaders(w)
+				w.WriteHeader(http.StatusInternalServerError)
+				body, _ := json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
+				w.Write([]byte(body))
+				return
+			}
+			if len(aggregates) > 0 {
+				ctx = context.WithValue(ctx, common.SessionAggregates, strings.Join(aggregates, ","))
+				r = r.WithContext(ctx)
 			}
 		}
 		// r.URL.Path = strings.ToLower(path)
@@ -201,6 +210,9 @@ func main() {
 	if err != nil {
 		logs.Log.Fatal("service initialization failed: " + err.Error())
 	}
+	// the client certificates are verified against the root CA of ODIM, when presented,
+	// for authenticating the clients by the certificate
+	apiServer.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
 
 	apicommon.ConfigFilePath = os.Getenv("CONFIG_FILE_PATH")
 	if apicommon.ConfigFilePath == "" {
@@ -214,6 +226,82 @@ func main() {
 	router.Run(iris.Server(apiServer))
 }
 
+// urlNoBasicAuth are the URLs which do not require authentication
+var urlNoBasicAuth = []string{"/redfish/v1", "/redfish/v1/SessionService"}
+
+// createRequestSession creates the session for the request authenticated by the basic auth or the client
+// certificate and sets the token and the ID of the session in the request, for the session to be deleted
+// after the request. The error response is written when the session is not created, except
+// the unauthorized response when the request is allowed to be served without the session
+func createRequestSession(ctx context.Context, w http.ResponseWriter, r *http.Request, req sessionproto.SessionCreateRequest,
+	allowUnauthorized bool) bool {
+	resp, err := rpc.DoSessionCreationRequest(ctx, req)
+	if err != nil && resp == nil {
+		errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
+		logs.LogWithFields(ctx).Error(errorMessage)
+		common.SetCommonHeaders(w)
+		w.WriteHeader(http.StatusInternalServerError)
+		body, _ := json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
+		w.Write([]byte(body))
+		return false
+	}
+	if allowUnauthorized && resp.StatusCode == http.StatusUnauthorized {
+		logs.LogWithFields(ctx).Info("Client certificate is not authenticated, serving the request without the session")
+		return true
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		common.SetCommonHeaders(w)
+		w.WriteHeader(int(resp.StatusCode))
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			logs.LogWithFields(ctx).Error("error: unable to establish connection with db")
+			w.Write(resp.Body)
+			return false
+		}
+		errorMessage := "error: failed to create a sesssion"
+		logs.LogWithFields(ctx).Info(errorMessage)
+		body, _ := json.Marshal(common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, nil, nil).Body)
+		w.Write([]byte(body))
+		return false
+	}
+	var sessionID string
+	sessionHeader := resp.Header
+	sessionLocation := sessionHeader["Link"]
+	sessionLocationSlice := strings.Split(sessionLocation, "/")
+	if len(sessionLocationSlice) > 1 {
+		sessionID = sessionLocationSlice[len(sessionLocationSlice)-2]
+	}
+	r.Header.Set("X-Auth-Token", sessionHeader["X-Auth-Token"])
+	r.Header.Set("Session-ID", sessionID)
+	return true
+}
+
+// isClientCertificateLogin checks the request without the credentials and the session token presents
+// a verified client certificate. The URLs which do not require authentication and the session login
+// are served without the session of the certificate
+func isClientCertificateLogin(r *http.Request, path string) bool {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || r.Header.Get("X-Auth-Token") != "" {
+		return false
+	}
+	if r.Method == http.MethodPost && strings.EqualFold(path, "/redfish/v1/SessionService/Sessions") {
+		return false
+	}
+	for _, item := range urlNoBasicAuth {
+		if item == path {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeCertificates returns the PEM encoded certificate chain presented by the client
+func encodeCertificates(certificates []*x509.Certificate) string {
+	var chain bytes.Buffer
+	for _, certificate := range certificates {
+		pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})
+	}
+	return chain.String()
+}
+
 // invalidAuthResp function is used to generate an invalid credentials response
 func invalidAuthResp(errMsg string, w http.ResponseWriter) {
 	common.SetCommonHeaders(w)
@@ -222,6 +310,25 @@ func invalidAuthResp(errMsg string, w http.ResponseWriter) {
 	w.Write([]byte(body))
 }
 
+// getPropertyNames returns the names of the top level properties of the request body
+func getPropertyNames(reqBody map[string]interface{}) []string {
+	properties := make([]string, 0, len(reqBody))
+	for property := range reqBody {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
+// getBearerToken returns the token of the Bearer authorization header
+func getBearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
 // getContext is used to create new context with fields which are required for logging (transcationID, actionID, actionName,
 // ThreadID, ThreadName and ProcessName)
 func createContext(r *http.Request, transactionID uuid.UUID, podName string) context.Context {
@@ -252,10 +359,17 @@ func createContext(r *http.Request, transactionID uuid.UUID, podName string) con
 	ctx = context.WithValue(ctx, common.ProcessName, podName)
 	ctx = context.WithValue(ctx, common.ThreadName, common.APIService)
 	ctx = context.WithValue(ctx, common.ThreadID, common.DefaultThreadID)
+	// Add the request URI and method for resolving the privileges of the request
+	ctx = context.WithValue(ctx, common.RequestURI, r.URL.Path)
+	ctx = context.WithValue(ctx, common.RequestMethod, r.Method)
 	if r.Body != nil {
 		body, _ := ioutil.ReadAll(r.Body)
 		json.Unmarshal(body, &reqBody)
 		ctx = context.WithValue(ctx, common.RequestBody, reqBody)
+		// the patched properties may carry their own privileges in the privilege registry
+		if r.Method == http.MethodPatch {
+			ctx = context.WithValue(ctx, common.RequestProperties, getPropertyNames(reqBody))
+		}
 		r.Body = ioutil.NopCloser(bytes.NewReader(body))
 	}
 
@@ -0,0 +1,43 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package middleware ...
+package middleware
+
+import (
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	iris "github.com/kataras/iris/v12"
+)
+
+// unmatchedRoute is the route label of the requests which do not match any route
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records the count and the latency of every request,
+// partitioned by the route template, the method and the status code
+func MetricsMiddleware(ctx iris.Context) {
+	start := time.Now()
+	ctx.Next()
+	metrics.ObserveHTTPRequest(routeTemplate(ctx), ctx.Method(), ctx.GetStatusCode(), time.Since(start))
+}
+
+// routeTemplate returns the template of the route matched by the request,
+// such as /redfish/v1/Systems/{id}, or unmatched if no route matched
+func routeTemplate(ctx iris.Context) string {
+	if currentRoute := ctx.GetCurrentRoute(); currentRoute != nil && currentRoute.StatusErrorCode() == 0 {
+		return currentRoute.Path()
+	}
+	return unmatchedRoute
+}
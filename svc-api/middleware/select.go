@@ -0,0 +1,218 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package middleware ...
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	iris "github.com/kataras/iris/v12"
+)
+
+// selectQueryOption is the OData query option used for selecting the properties of a resource
+const selectQueryOption = "$select"
+
+// selectPropertyRegex is the pattern of a single segment of a $select property path
+var selectPropertyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// selectTree holds the parsed $select property paths, each level of the
+// tree is a segment of the paths. An empty subtree selects the whole property.
+type selectTree map[string]selectTree
+
+// SelectMiddleware applies the $select query option on the response of every GET request.
+// The query option is removed from the request before it reaches the handlers, so that the
+// services behind the gateway are not aware of it, and the response written by the handler,
+// including the resources inlined for $expand, is reduced to the selected properties.
+func SelectMiddleware(ctx iris.Context) {
+	if ctx.Method() != http.MethodGet || !ctx.URLParamExists(selectQueryOption) {
+		ctx.Next()
+		return
+	}
+	ctxt := ctx.Request().Context()
+	paths, parseErr := parseSelectQuery(ctx.URLParam(selectQueryOption))
+	removeQueryOption(ctx.Request(), selectQueryOption)
+
+	ctx.Record()
+	ctx.Next()
+	if parseErr != nil {
+		l.LogWithFields(ctxt).Error(parseErr.Error())
+		writeSelectError(ctx, parseErr)
+		return
+	}
+	if ctx.GetStatusCode() != http.StatusOK {
+		return
+	}
+	body, err := selectResponseBody(ctx.Recorder().Body(), paths, ctx.URLParam(selectQueryOption))
+	if err != nil {
+		l.LogWithFields(ctxt).Error(err.Error())
+		writeSelectError(ctx, err)
+		return
+	}
+	ctx.Recorder().SetBody(body)
+}
+
+// writeSelectError replaces the recorded response with the QueryNotSupported error
+func writeSelectError(ctx iris.Context, err error) {
+	resp := common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+	ctx.Recorder().ResetBody()
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(http.StatusBadRequest)
+	ctx.JSON(&resp.Body)
+}
+
+// removeQueryOption removes the query option from the request URI and the parsed URL,
+// keeping the remaining query options as they are
+func removeQueryOption(req *http.Request, option string) {
+	var params []string
+	for _, param := range strings.Split(req.URL.RawQuery, "&") {
+		key := strings.SplitN(param, "=", 2)[0]
+		if decodedKey, err := url.QueryUnescape(key); err == nil {
+			key = decodedKey
+		}
+		if key != option && param != "" {
+			params = append(params, param)
+		}
+	}
+	req.URL.RawQuery = strings.Join(params, "&")
+	req.RequestURI = strings.SplitN(req.RequestURI, "?", 2)[0]
+	if req.URL.RawQuery != "" {
+		req.RequestURI += "?" + req.URL.RawQuery
+	}
+}
+
+// parseSelectQuery parses the comma separated property paths of the $select query option,
+// for example "PowerState,Status/Health"
+func parseSelectQuery(value string) (selectTree, error) {
+	tree := selectTree{}
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil, fmt.Errorf("%v should have a comma separated list of properties", selectQueryOption)
+		}
+		node := tree
+		for _, property := range strings.Split(path, "/") {
+			if !selectPropertyRegex.MatchString(property) {
+				return nil, fmt.Errorf("%v is not a valid property path for %v", path, selectQueryOption)
+			}
+			if _, ok := node[property]; !ok {
+				node[property] = selectTree{}
+			}
+			node = node[property]
+		}
+	}
+	return tree, nil
+}
+
+// selectResponseBody returns the body with only the selected properties. For a collection
+// with expanded members the properties are selected from each of the members, and the
+// query option is retained in the link to the next page of the collection.
+// Error is returned when one of the property paths is not present in the response.
+func selectResponseBody(body []byte, paths selectTree, value string) ([]byte, error) {
+	var resource map[string]interface{}
+	if err := json.Unmarshal(body, &resource); err != nil {
+		// $select is applicable only for JSON resources
+		return body, nil
+	}
+	found := map[string]bool{}
+	members, isCollection := resource["Members"].([]interface{})
+	if isCollection && isExpanded(members) {
+		for i, member := range members {
+			if m, ok := member.(map[string]interface{}); ok {
+				members[i] = paths.apply(m, "", found)
+			}
+		}
+	} else {
+		resource = paths.apply(resource, "", found)
+	}
+	if missing := paths.missing("", found); len(missing) > 0 && (!isCollection || len(members) > 0) {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%v is not a valid property path for %v", strings.Join(missing, ", "), selectQueryOption)
+	}
+	if nextLink, ok := resource["Members@odata.nextLink"].(string); ok {
+		resource["Members@odata.nextLink"] = common.AppendQueryOption(nextLink, selectQueryOption, value)
+	}
+	return json.Marshal(resource)
+}
+
+// isExpanded checks whether the collection members are inlined with $expand
+func isExpanded(members []interface{}) bool {
+	for _, member := range members {
+		if m, ok := member.(map[string]interface{}); ok && len(m) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns a copy of the resource having only the selected properties and the
+// @odata annotations. The paths found in the resource are recorded in found.
+func (tree selectTree) apply(resource map[string]interface{}, prefix string, found map[string]bool) map[string]interface{} {
+	selected := make(map[string]interface{})
+	for key, value := range resource {
+		if strings.Contains(key, "@odata.") {
+			selected[key] = value
+		}
+	}
+	for property, subTree := range tree {
+		value, ok := resource[property]
+		if !ok {
+			continue
+		}
+		path := prefix + property
+		if len(subTree) == 0 {
+			found[path] = true
+			selected[property] = value
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			selected[property] = subTree.apply(v, path+"/", found)
+		case []interface{}:
+			var list []interface{}
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					list = append(list, subTree.apply(m, path+"/", found))
+				}
+			}
+			selected[property] = list
+		}
+	}
+	return selected
+}
+
+// missing returns the property paths which are not recorded in found
+func (tree selectTree) missing(prefix string, found map[string]bool) []string {
+	var paths []string
+	for property, subTree := range tree {
+		path := prefix + property
+		if len(subTree) == 0 {
+			if !found[path] {
+				paths = append(paths, path)
+			}
+			continue
+		}
+		paths = append(paths, subTree.missing(path+"/", found)...)
+	}
+	return paths
+}
@@ -0,0 +1,125 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+const mockSystem = `{"@odata.id":"/redfish/v1/Systems/1","@odata.type":"#ComputerSystem.v1_18_0.ComputerSystem","Id":"1","Model":"DL360","PowerState":"On","Status":{"Health":"OK","State":"Enabled"},"Boot":{"BootOrder":["Hdd"]}}`
+
+func TestSelectResponseBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "top level properties",
+			body:  mockSystem,
+			query: "PowerState,Model",
+			want:  `{"@odata.id":"/redfish/v1/Systems/1","@odata.type":"#ComputerSystem.v1_18_0.ComputerSystem","Model":"DL360","PowerState":"On"}`,
+		},
+		{
+			name:  "nested property",
+			body:  mockSystem,
+			query: "Status/Health",
+			want:  `{"@odata.id":"/redfish/v1/Systems/1","@odata.type":"#ComputerSystem.v1_18_0.ComputerSystem","Status":{"Health":"OK"}}`,
+		},
+		{
+			name:    "invalid path",
+			body:    mockSystem,
+			query:   "Status/Unknown",
+			wantErr: true,
+		},
+		{
+			name:  "collection without expand",
+			body:  `{"@odata.id":"/redfish/v1/Systems","Name":"Systems","Members":[{"@odata.id":"/redfish/v1/Systems/1"}],"Members@odata.count":1}`,
+			query: "Name",
+			want:  `{"@odata.id":"/redfish/v1/Systems","Name":"Systems","Members@odata.count":1}`,
+		},
+		{
+			name:  "expanded collection",
+			body:  `{"@odata.id":"/redfish/v1/Systems","Name":"Systems","Members":[` + mockSystem + `],"Members@odata.count":1}`,
+			query: "PowerState",
+			want:  `{"@odata.id":"/redfish/v1/Systems","Name":"Systems","Members":[{"@odata.id":"/redfish/v1/Systems/1","@odata.type":"#ComputerSystem.v1_18_0.ComputerSystem","PowerState":"On"}],"Members@odata.count":1}`,
+		},
+		{
+			name:  "next link of a paged collection",
+			body:  `{"@odata.id":"/redfish/v1/Systems","Name":"Systems","Members":[` + mockSystem + `],"Members@odata.count":2,"Members@odata.nextLink":"/redfish/v1/Systems?$top=1&$skip=1"}`,
+			query: "Model",
+			want:  `{"@odata.id":"/redfish/v1/Systems","Name":"Systems","Members":[{"@odata.id":"/redfish/v1/Systems/1","@odata.type":"#ComputerSystem.v1_18_0.ComputerSystem","Model":"DL360"}],"Members@odata.count":2,"Members@odata.nextLink":"/redfish/v1/Systems?$top=1&$skip=1&$select=Model"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := parseSelectQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseSelectQuery() error = %v", err)
+			}
+			got, err := selectResponseBody([]byte(tt.body), paths, tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectResponseBody() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var gotResource, wantResource interface{}
+			json.Unmarshal(got, &gotResource)
+			json.Unmarshal([]byte(tt.want), &wantResource)
+			if !reflect.DeepEqual(gotResource, wantResource) {
+				t.Errorf("selectResponseBody() = %s, want %s", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectQuery_Invalid(t *testing.T) {
+	for _, query := range []string{"", "PowerState,", "Status//Health", "Status/He alth", "@odata.id"} {
+		if _, err := parseSelectQuery(query); err == nil {
+			t.Errorf("parseSelectQuery(%q) expected an error", query)
+		}
+	}
+}
+
+func TestSelectMiddleware(t *testing.T) {
+	var requestURI string
+	mockApp := iris.New()
+	mockApp.UseGlobal(SelectMiddleware)
+	mockApp.Get("/redfish/v1/Systems/{id}", func(ctx iris.Context) {
+		requestURI = ctx.Request().RequestURI
+		ctx.ContentType("application/json")
+		ctx.StatusCode(http.StatusOK)
+		ctx.Write([]byte(mockSystem))
+	})
+	test := httptest.New(t, mockApp)
+	resp := test.GET("/redfish/v1/Systems/1").WithQuery("$select", "PowerState").Expect().Status(http.StatusOK).JSON().Object()
+	resp.Value("PowerState").Equal("On")
+	resp.NotContainsKey("Model")
+	if requestURI != "/redfish/v1/Systems/1" {
+		t.Errorf("$select is not removed from the request URI: %s", requestURI)
+	}
+	test.GET("/redfish/v1/Systems/1").WithQuery("$select", "Unknown").Expect().Status(http.StatusBadRequest)
+	test.GET("/redfish/v1/Systems/1").WithQuery("$select", "Status//Health").Expect().Status(http.StatusBadRequest)
+	test.GET("/redfish/v1/Systems/1").Expect().Status(http.StatusOK).JSON().Object().ContainsKey("Model")
+}
@@ -0,0 +1,46 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+	iris "github.com/kataras/iris/v12"
+)
+
+// TracingMiddleware traces every request in a server span, which continues the
+// trace of the client when the request carries a W3C traceparent header. The
+// span is passed to the handlers in the request context, so that it is propagated
+// to the micro services by the gRPC client interceptor
+func TracingMiddleware(ctx iris.Context) {
+	req := ctx.Request()
+	route := routeTemplate(ctx)
+	reqCtx := tracing.Extract(req.Context(), tracing.HeaderCarrier(req.Header))
+	reqCtx, span := tracing.StartSpan(reqCtx, ctx.Method()+" "+route, tracing.SpanKindServer)
+	defer span.End()
+	span.SetAttribute("http.method", ctx.Method())
+	span.SetAttribute("http.route", route)
+	span.SetAttribute("http.target", req.URL.Path)
+	ctx.ResetRequest(req.WithContext(reqCtx))
+
+	ctx.Next()
+
+	statusCode := ctx.GetStatusCode()
+	span.SetAttribute("http.status_code", statusCode)
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(tracing.StatusError, http.StatusText(statusCode))
+	}
+}
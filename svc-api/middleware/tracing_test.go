@@ -0,0 +1,67 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+type exporterMock struct {
+	spans []tracing.SpanData
+}
+
+func (e *exporterMock) Export(span tracing.SpanData) {
+	e.spans = append(e.spans, span)
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := &exporterMock{}
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	var handlerTraceParent string
+	mockApp := iris.New()
+	mockApp.UseGlobal(TracingMiddleware)
+	mockApp.Get("/redfish/v1/Systems/{id}", func(ctx iris.Context) {
+		handlerTraceParent = tracing.TraceParent(ctx.Request().Context())
+		ctx.StatusCode(http.StatusInternalServerError)
+	})
+	test := httptest.New(t, mockApp)
+	test.GET("/redfish/v1/Systems/1").
+		WithHeader(tracing.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").
+		Expect().Status(http.StatusInternalServerError)
+
+	if len(exporter.spans) != 1 {
+		t.Fatalf("expected 1 exported span, got %d", len(exporter.spans))
+	}
+	span := exporter.spans[0]
+	if span.Name != "GET /redfish/v1/Systems/{id}" || span.Kind != tracing.SpanKindServer {
+		t.Errorf("unexpected span %s of kind %v", span.Name, span.Kind)
+	}
+	if span.SpanContext.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.ParentSpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("span does not continue the trace of the client: %+v", span)
+	}
+	if handlerTraceParent != span.SpanContext.TraceParent() {
+		t.Errorf("expected the handler to run within the span, got traceparent %s", handlerTraceParent)
+	}
+	if span.Attributes["http.status_code"] != http.StatusInternalServerError || span.StatusCode != tracing.StatusError {
+		t.Errorf("unexpected span status %+v", span)
+	}
+}
@@ -0,0 +1,29 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package response ...
+package response
+
+// JSONSchemaFile defines the json schema file resource, which holds the locations of a schema document
+type JSONSchemaFile struct {
+	ID           string     `json:"Id"`
+	OdataContext string     `json:"@odata.context"`
+	OdataID      string     `json:"@odata.id"`
+	OdataType    string     `json:"@odata.type"`
+	Name         string     `json:"Name"`
+	Description  string     `json:"Description"`
+	Languages    []string   `json:"Languages"`
+	Location     []Location `json:"Location"`
+	Schema       string     `json:"Schema"`
+}
@@ -0,0 +1,53 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//(C) Copyright 2020 Intel Corporation
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/sirupsen/logrus"
+)
+
+var saveAuditRecord = common.SaveAuditRecord
+
+// recordAudit persists the audit log entry of the request in the audit trail.
+// Read operations are recorded only when RecordReadOperations is enabled in the configuration
+func recordAudit(entry *logrus.Entry) {
+	record := common.AuditRecord{
+		Created: time.Now().UTC(),
+	}
+	record.Method, _ = entry.Data["method"].(string)
+	if (record.Method == http.MethodGet || record.Method == http.MethodHead) &&
+		(config.Data.AuditLogConf == nil || !config.Data.AuditLogConf.RecordReadOperations) {
+		return
+	}
+	record.TransactionID, _ = entry.Data["transaction_id"].(string)
+	record.ID = record.TransactionID
+	record.UserName, _ = entry.Data["sessionusername"].(string)
+	record.RoleID, _ = entry.Data["sessionroleid"].(string)
+	record.RequestURI, _ = entry.Data["rawuri"].(string)
+	record.RequestBody, _ = entry.Data["reqstr"].(string)
+	record.StatusCode, _ = entry.Data["statuscode"].(int32)
+	go func() {
+		if err := saveAuditRecord(record); err != nil {
+			l.Log.Error("failed to save the audit record of the transaction " + record.TransactionID + ": " + err.Error())
+		}
+	}()
+}
@@ -25,6 +25,7 @@ import (
 	"net/url"
 	"strings"
 
+	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	customLogs "github.com/ODIM-Project/ODIM/lib-utilities/logs"
@@ -34,6 +35,7 @@ import (
 	srv "github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-api/handle"
 	"github.com/ODIM-Project/ODIM/svc-api/middleware"
+	"github.com/ODIM-Project/ODIM/svc-api/models"
 	"github.com/ODIM-Project/ODIM/svc-api/ratelimiter"
 	"github.com/ODIM-Project/ODIM/svc-api/rpc"
 	"github.com/kataras/iris/v12"
@@ -45,6 +47,7 @@ var cs handle.CompositionServiceRPCs
 // Router method to register API handlers.
 func Router() *iris.Application {
 	r := handle.RoleRPCs{
+		CreateRoleRPC:  rpc.CreateRole,
 		GetAllRolesRPC: rpc.GetAllRoles,
 		GetRoleRPC:     rpc.GetRole,
 		UpdateRoleRPC:  rpc.UpdateRole,
@@ -52,11 +55,16 @@ func Router() *iris.Application {
 	}
 	a := handle.AccountRPCs{
 		GetServiceRPC:     rpc.DoGetAccountServiceRequest,
+		UpdateServiceRPC:  rpc.DoUpdateAccountServiceRequest,
 		CreateRPC:         rpc.DoAccountCreationRequest,
 		GetAllAccountsRPC: rpc.DoGetAllAccountRequest,
 		GetAccountRPC:     rpc.DoGetAccountRequest,
 		UpdateRPC:         rpc.DoUpdateAccountRequest,
 		DeleteRPC:         rpc.DoAccountDeleteRequest,
+		CreateAPIKeyRPC:   rpc.DoCreateAPIKeyRequest,
+		GetAllAPIKeysRPC:  rpc.DoGetAllAPIKeysRequest,
+		GetAPIKeyRPC:      rpc.DoGetAPIKeyRequest,
+		DeleteAPIKeyRPC:   rpc.DoDeleteAPIKeyRequest,
 	}
 	pc := handle.AggregatorRPCs{
 		GetAggregationServiceRPC:                rpc.DoGetAggregationService,
@@ -79,6 +87,15 @@ func Router() *iris.Application {
 		GetConnectionMethodRPC:                  rpc.DoGetConnectionMethod,
 		GetResetActionInfoServiceRPC:            rpc.DoGetResetActionInfoService,
 		GetSetDefaultBootOrderActionInfoRPC:     rpc.DoGetSetDefaultBootOrderActionInfo,
+		ImportSourcesRPC:                        rpc.DoImportSourcesRequest,
+		GetImportSourcesReportRPC:               rpc.DoGetImportSourcesReport,
+		DiscoverSourcesRPC:                      rpc.DoDiscoverSourcesRequest,
+		GetAllDiscoveredSourcesRPC:              rpc.DoGetAllDiscoveredSources,
+		GetDiscoveredSourceRPC:                  rpc.DoGetDiscoveredSource,
+		ApproveDiscoveredSourceRPC:              rpc.DoApproveDiscoveredSourceRequest,
+		DeleteDiscoveredSourceRPC:               rpc.DoDeleteDiscoveredSource,
+		GetInventoryHistoryRPC:                  rpc.DoGetInventoryHistory,
+		RotateCredentialsRPC:                    rpc.DoRotateCredentialsRequest,
 	}
 
 	s := handle.SessionRPCs{
@@ -206,14 +223,30 @@ func Router() *iris.Application {
 	registryFile := handle.Registry{
 		Auth: srv.IsAuthorized,
 	}
+	jsonSchemaFile := handle.JSONSchemas{
+		Auth:               srv.IsAuthorized,
+		GetSchemaFileNames: models.GetAllRegistryFileNamesFromDB,
+		GetSchemaFile:      models.GetRegistryFile,
+	}
+	privilegeMap := handle.PrivilegeMap{
+		Auth:             srv.IsAuthorized,
+		LoadPrivilegeMap: dmtf.LoadPrivilegeMap,
+	}
 	logService := l.Logging{
 		GetUserDetails: loggingService.GetUserDetails,
 	}
 
+	health := handle.Health{
+		CheckDB:         common.CheckDBConnection,
+		CheckService:    srv.CheckHealth,
+		EnabledServices: handle.GetEnabledServices,
+	}
+
 	serviceRoot := handle.InitServiceRoot()
 
 	router := iris.New()
 	router.OnErrorCode(iris.StatusNotFound, handle.SystemsMethodInvalidURI)
+	router.UseGlobal(middleware.TracingMiddleware, middleware.MetricsMiddleware, middleware.SelectMiddleware)
 	// Parses the URL and performs URL decoding for path
 	// Getting the request body copy
 	router.WrapRouter(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
@@ -284,7 +317,9 @@ func Router() *iris.Application {
 		if ctxt.Value(common.RequestBody) != nil {
 			reqBody = ctxt.Value(common.RequestBody).(map[string]interface{})
 		}
-		l.AuditLog(&logService, ctx, reqBody).Info()
+		auditEntry := l.AuditLog(&logService, ctx, reqBody)
+		auditEntry.Info()
+		recordAudit(auditEntry)
 		// before returning response, decrement the session limit counter
 		sessionToken := ctx.Request().Header.Get("X-Auth-Token")
 		if sessionToken != "" && config.Data.RequestLimitCountPerSession > 0 {
@@ -296,6 +331,9 @@ func Router() *iris.Application {
 	taskmon.Get("/{TaskID}", ts.GetTaskMonitor)
 	taskmon.Any("/{TaskID}", handle.TsMethodNotAllowed)
 
+	router.Get(handle.HealthzURI, health.GetLiveness)
+	router.Get(handle.ReadyzURI, health.GetReadiness)
+
 	redfish := router.Party("/redfish")
 	redfish.SetRegisterRule(iris.RouteSkip)
 	redfish.Get("/", handle.GetVersion)
@@ -316,6 +354,13 @@ func Router() *iris.Application {
 	registry.Any("/", handle.RegMethodNotAllowed)
 	registry.Any("/{id}", handle.RegMethodNotAllowed)
 
+	jsonSchemas := v1.Party("/JsonSchemas")
+	jsonSchemas.SetRegisterRule(iris.RouteSkip)
+	jsonSchemas.Get("/", jsonSchemaFile.GetJSONSchemaFileCollection)
+	jsonSchemas.Get("/{id}", jsonSchemaFile.GetJSONSchemaFile)
+	jsonSchemas.Any("/", handle.JSONSchemasMethodNotAllowed)
+	jsonSchemas.Any("/{id}", handle.JSONSchemasMethodNotAllowed)
+
 	session := v1.Party("/SessionService")
 	session.SetRegisterRule(iris.RouteSkip)
 	session.Get("/", s.GetSessionService)
@@ -330,15 +375,25 @@ func Router() *iris.Application {
 	account := v1.Party("/AccountService", middleware.SessionDelMiddleware)
 	account.SetRegisterRule(iris.RouteSkip)
 	account.Get("/", a.GetAccountService)
+	account.Patch("/", a.UpdateAccountService)
 	account.Get("/Accounts", a.GetAllAccounts)
 	account.Get("/Accounts/{id}", a.GetAccount)
 	account.Post("/Accounts", a.CreateAccount)
 	account.Patch("/Accounts/{id}", a.UpdateAccount)
 	account.Delete("/Accounts/{id}", a.DeleteAccount)
+	account.Get("/Accounts/{id}/APIKeys", a.GetAllAPIKeys)
+	account.Post("/Accounts/{id}/APIKeys", a.CreateAPIKey)
+	account.Get("/Accounts/{id}/APIKeys/{rid}", a.GetAPIKey)
+	account.Delete("/Accounts/{id}/APIKeys/{rid}", a.DeleteAPIKey)
 	account.Any("/", handle.AsMethodNotAllowed)
 	account.Any("/Accounts", handle.AsMethodNotAllowed)
 	account.Any("/Accounts/{id}", handle.AsMethodNotAllowed)
+	account.Any("/Accounts/{id}/APIKeys", handle.AsMethodNotAllowed)
+	account.Any("/Accounts/{id}/APIKeys/{rid}", handle.AsMethodNotAllowed)
+	account.Get("/PrivilegeMap", privilegeMap.GetPrivilegeMap)
+	account.Any("/PrivilegeMap", handle.AsMethodNotAllowed)
 	account.Get("/Roles/", r.GetAllRoles)
+	account.Post("/Roles", r.CreateRole)
 	account.Get("/Roles/{id}", r.GetRole)
 	account.Patch("/Roles/{id}", r.UpdateRole)
 	account.Delete("/Roles/{id}", r.DeleteRole)
@@ -428,6 +483,8 @@ func Router() *iris.Application {
 	systems.Any("/{id}/Memory/{rid}", handle.SystemsMethodNotAllowed)
 	systems.Post("/{id}/Actions/ComputerSystem.Reset", system.ComputerSystemReset)
 	systems.Post("/{id}/Actions/ComputerSystem.SetDefaultBootOrder", system.SetDefaultBootOrder)
+	systems.Post("/{id}/Actions/Oem/Odim.InventoryHistory", pc.GetInventoryHistory)
+	systems.Any("/{id}/Actions/Oem/Odim.InventoryHistory", handle.SystemsMethodNotAllowed)
 
 	storage := v1.Party("/Systems/{id}/Storage", middleware.SessionDelMiddleware)
 	storage.SetRegisterRule(iris.RouteSkip)
@@ -473,6 +530,23 @@ func Router() *iris.Application {
 	aggregation.Any("/Actions/AggregationService.Reset/", handle.AggMethodNotAllowed)
 	aggregation.Post("/Actions/AggregationService.SetDefaultBootOrder/", pc.SetDefaultBootOrder)
 	aggregation.Any("/Actions/AggregationService.SetDefaultBootOrder/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/AggregationService.ImportSources/", pc.ImportSources)
+	aggregation.Any("/Actions/AggregationService.ImportSources/", handle.AggMethodNotAllowed)
+	aggregation.Get("/ImportSourcesReports/{id}", pc.GetImportSourcesReport)
+	aggregation.Get("/ImportSourcesReports/{id}/Report.csv", pc.GetImportSourcesReport)
+	aggregation.Any("/ImportSourcesReports/{id}", handle.AggMethodNotAllowed)
+	aggregation.Any("/ImportSourcesReports/{id}/Report.csv", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/AggregationService.RotateCredentials/", pc.RotateCredentials)
+	aggregation.Any("/Actions/AggregationService.RotateCredentials/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/AggregationService.DiscoverSources/", pc.DiscoverSources)
+	aggregation.Any("/Actions/AggregationService.DiscoverSources/", handle.AggMethodNotAllowed)
+	aggregation.Get("/DiscoveredSources", pc.GetAllDiscoveredSources)
+	aggregation.Any("/DiscoveredSources", handle.AggMethodNotAllowed)
+	aggregation.Get("/DiscoveredSources/{id}", pc.GetDiscoveredSource)
+	aggregation.Delete("/DiscoveredSources/{id}", pc.DeleteDiscoveredSource)
+	aggregation.Any("/DiscoveredSources/{id}", handle.AggMethodNotAllowed)
+	aggregation.Post("/DiscoveredSources/{id}/Actions/DiscoveredSource.Approve", pc.ApproveDiscoveredSource)
+	aggregation.Any("/DiscoveredSources/{id}/Actions/DiscoveredSource.Approve", handle.AggMethodNotAllowed)
 	aggregation.Post("/AggregationSources/", pc.AddAggregationSource)
 	aggregation.Get("/AggregationSources", pc.GetAllAggregationSource)
 	aggregation.Any("/AggregationSources", handle.AggMethodNotAllowed)
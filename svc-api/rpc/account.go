@@ -123,6 +123,24 @@ func DoUpdateAccountRequest(ctx context.Context, req accountproto.UpdateAccountR
 	return resp, err
 }
 
+// DoUpdateAccountServiceRequest defines the RPC call function for
+// the UpdateAccountService from account-session micro service
+func DoUpdateAccountServiceRequest(ctx context.Context, req accountproto.UpdateAccountServiceRequest) (*accountproto.AccountResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	account := NewAccountClientFunc(conn)
+
+	resp, err := account.UpdateAccountService(ctx, &req)
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
 // DoAccountDeleteRequest defines the RPC call function for
 // the AccountDelete from account-session micro service
 func DoAccountDeleteRequest(ctx context.Context, req accountproto.DeleteAccountRequest) (*accountproto.AccountResponse, error) {
@@ -141,3 +159,75 @@ func DoAccountDeleteRequest(ctx context.Context, req accountproto.DeleteAccountR
 	defer conn.Close()
 	return resp, err
 }
+
+// DoCreateAPIKeyRequest defines the RPC call function for
+// the CreateAPIKey from account-session micro service
+func DoCreateAPIKeyRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	account := NewAccountClientFunc(conn)
+
+	resp, err := account.CreateAPIKey(ctx, &req)
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetAllAPIKeysRequest defines the RPC call function for
+// the GetAllAPIKeys from account-session micro service
+func DoGetAllAPIKeysRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	account := NewAccountClientFunc(conn)
+
+	resp, err := account.GetAllAPIKeys(ctx, &req)
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetAPIKeyRequest defines the RPC call function for
+// the GetAPIKey from account-session micro service
+func DoGetAPIKeyRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	account := NewAccountClientFunc(conn)
+
+	resp, err := account.GetAPIKey(ctx, &req)
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDeleteAPIKeyRequest defines the RPC call function for
+// the DeleteAPIKey from account-session micro service
+func DoDeleteAPIKeyRequest(ctx context.Context, req accountproto.APIKeyRequest) (*accountproto.AccountResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.AccountSession)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+	account := NewAccountClientFunc(conn)
+
+	resp, err := account.DeleteAPIKey(ctx, &req)
+	if err != nil && resp == nil {
+		return nil, fmt.Errorf("error: something went wrong with rpc call: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
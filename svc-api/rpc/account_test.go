@@ -192,6 +192,92 @@ func TestDoGetAccountServiceRequest(t *testing.T) {
 	}
 }
 
+func TestDoUpdateAccountServiceRequest(t *testing.T) {
+	tests := []struct {
+		name                 string
+		ClientFunc           func(clientName string) (*grpc.ClientConn, error)
+		NewAccountClientFunc func(cc *grpc.ClientConn) accountproto.AccountClient
+		want                 *accountproto.AccountResponse
+		wantErr              bool
+	}{
+		{
+			name:                 "Client func error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAccountClientFunc: func(cc *grpc.ClientConn) accountproto.AccountClient { return nil },
+			want:                 nil,
+			wantErr:              true,
+		},
+		{
+			name:                 "UpdateAccountService error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAccountClientFunc: func(cc *grpc.ClientConn) accountproto.AccountClient { return fakeStruct{} },
+			want:                 nil,
+			wantErr:              true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAccountClientFunc = tt.NewAccountClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoUpdateAccountServiceRequest(context.Background(), accountproto.UpdateAccountServiceRequest{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoUpdateAccountServiceRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoUpdateAccountServiceRequest() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoAPIKeyRequests(t *testing.T) {
+	tests := []struct {
+		name                 string
+		ClientFunc           func(clientName string) (*grpc.ClientConn, error)
+		NewAccountClientFunc func(cc *grpc.ClientConn) accountproto.AccountClient
+		want                 *accountproto.AccountResponse
+		wantErr              bool
+	}{
+		{
+			name:                 "Client func error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAccountClientFunc: func(cc *grpc.ClientConn) accountproto.AccountClient { return nil },
+			want:                 nil,
+			wantErr:              true,
+		},
+		{
+			name:                 "API key rpc error",
+			ClientFunc:           func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAccountClientFunc: func(cc *grpc.ClientConn) accountproto.AccountClient { return fakeStruct{} },
+			want:                 nil,
+			wantErr:              true,
+		},
+	}
+	requests := map[string]func(context.Context, accountproto.APIKeyRequest) (*accountproto.AccountResponse, error){
+		"DoCreateAPIKeyRequest":  DoCreateAPIKeyRequest,
+		"DoGetAllAPIKeysRequest": DoGetAllAPIKeysRequest,
+		"DoGetAPIKeyRequest":     DoGetAPIKeyRequest,
+		"DoDeleteAPIKeyRequest":  DoDeleteAPIKeyRequest,
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAccountClientFunc = tt.NewAccountClientFunc
+		for requestName, request := range requests {
+			t.Run(requestName+" "+tt.name, func(t *testing.T) {
+				got, err := request(context.Background(), accountproto.APIKeyRequest{})
+				if (err != nil) != tt.wantErr {
+					t.Errorf("%s() error = %v, wantErr %v", requestName, err, tt.wantErr)
+					return
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s() got = %v, want %v", requestName, got, tt.want)
+				}
+			})
+		}
+	}
+}
+
 func TestDoGetAllAccountRequest(t *testing.T) {
 	type args struct {
 		req accountproto.AccountRequest
@@ -407,3 +407,174 @@ func DoGetSetDefaultBootOrderActionInfo(ctx context.Context, req aggregatorproto
 	defer conn.Close()
 	return resp, err
 }
+
+// DoImportSourcesRequest defines the RPC call function for
+// the ImportSources from aggregator micro service
+func DoImportSourcesRequest(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ImportSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoRotateCredentialsRequest defines the RPC call function for
+// the RotateCredentials from aggregator micro service
+func DoRotateCredentialsRequest(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.RotateCredentials(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetImportSourcesReport defines the RPC call function for
+// the GetImportSourcesReport from aggregator micro service
+func DoGetImportSourcesReport(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetImportSourcesReport(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDiscoverSourcesRequest defines the RPC call function for
+// the DiscoverSources from aggregator micro service
+func DoDiscoverSourcesRequest(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.DiscoverSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetAllDiscoveredSources defines the RPC call function for
+// the GetAllDiscoveredSources from aggregator micro service
+func DoGetAllDiscoveredSources(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetAllDiscoveredSources(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetDiscoveredSource defines the RPC call function for
+// the GetDiscoveredSource from aggregator micro service
+func DoGetDiscoveredSource(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetDiscoveredSource(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoApproveDiscoveredSourceRequest defines the RPC call function for
+// the ApproveDiscoveredSource from aggregator micro service
+func DoApproveDiscoveredSourceRequest(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.ApproveDiscoveredSource(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoDeleteDiscoveredSource defines the RPC call function for
+// the DeleteDiscoveredSource from aggregator micro service
+func DoDeleteDiscoveredSource(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.DeleteDiscoveredSource(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
+
+// DoGetInventoryHistory defines the RPC call function for
+// the GetInventoryHistory from aggregator micro service
+func DoGetInventoryHistory(ctx context.Context, req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+	ctx = common.CreateMetadata(ctx)
+	conn, err := ClientFunc(services.Aggregator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create client connection: %v", err)
+	}
+
+	aggregator := NewAggregatorClientFunc(conn)
+
+	resp, err := aggregator.GetInventoryHistory(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	defer conn.Close()
+	return resp, err
+}
@@ -937,3 +937,363 @@ func TestDoGetSetDefaultBootOrderActionInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestDoImportSourcesRequest(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ImportSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoImportSourcesRequest(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoImportSourcesRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoImportSourcesRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoRotateCredentialsRequest(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "RotateCredentials error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoRotateCredentialsRequest(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoRotateCredentialsRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoRotateCredentialsRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetImportSourcesReport(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetImportSourcesReport error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetImportSourcesReport(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetImportSourcesReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetImportSourcesReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoDiscoverSourcesRequest(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "DiscoverSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoDiscoverSourcesRequest(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoDiscoverSourcesRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoDiscoverSourcesRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetAllDiscoveredSources(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetAllDiscoveredSources error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetAllDiscoveredSources(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetAllDiscoveredSources() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetAllDiscoveredSources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoGetDiscoveredSource(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "GetDiscoveredSource error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoGetDiscoveredSource(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoGetDiscoveredSource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoGetDiscoveredSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoApproveDiscoveredSourceRequest(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "ApproveDiscoveredSource error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoApproveDiscoveredSourceRequest(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoApproveDiscoveredSourceRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoApproveDiscoveredSourceRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoDeleteDiscoveredSource(t *testing.T) {
+	type args struct {
+		req aggregatorproto.AggregatorRequest
+	}
+	tests := []struct {
+		name                    string
+		args                    args
+		ClientFunc              func(clientName string) (*grpc.ClientConn, error)
+		NewAggregatorClientFunc func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient
+		want                    *aggregatorproto.AggregatorResponse
+		wantErr                 bool
+	}{
+		{
+			name:                    "Client func error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, errors.New("fakeError") },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return nil },
+			want:                    nil,
+			wantErr:                 true,
+		},
+		{
+			name:                    "DeleteDiscoveredSource error",
+			args:                    args{},
+			ClientFunc:              func(clientName string) (*grpc.ClientConn, error) { return nil, nil },
+			NewAggregatorClientFunc: func(cc *grpc.ClientConn) aggregatorproto.AggregatorClient { return fakeStruct{} },
+			want:                    nil,
+			wantErr:                 true,
+		},
+	}
+	for _, tt := range tests {
+		ClientFunc = tt.ClientFunc
+		NewAggregatorClientFunc = tt.NewAggregatorClientFunc
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DoDeleteDiscoveredSource(context.Background(), tt.args.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoDeleteDiscoveredSource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DoDeleteDiscoveredSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -48,6 +48,26 @@ func (fakeStruct) Delete(ctx context.Context, in *accountproto.DeleteAccountRequ
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) UpdateAccountService(ctx context.Context, in *accountproto.UpdateAccountServiceRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) CreateAPIKey(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAllAPIKeys(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAPIKey(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteAPIKey(ctx context.Context, in *accountproto.APIKeyRequest, opts ...grpc.CallOption) (*accountproto.AccountResponse, error) {
+	return nil, errors.New("fakeError")
+}
+
 //------------------------------------AGGREGATOR-------------------------------------------------
 
 func (fakeStruct) Reset(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
@@ -159,6 +179,46 @@ func (fakeStruct) GetSetDefaultBootOrderActionInfo(ctx context.Context, in *aggr
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) ImportSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetImportSourcesReport(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DiscoverSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetAllDiscoveredSources(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetDiscoveredSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) ApproveDiscoveredSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) DeleteDiscoveredSource(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
+func (fakeStruct) GetInventoryHistory(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
+
 func (fakeStruct) IsAggregateHaveSubscription(ctx context.Context, in *events.EventUpdateRequest, opts ...grpc.CallOption) (*events.SubscribeEMBResponse, error) {
 
 	return nil, errors.New("fakeError")
@@ -358,6 +418,10 @@ func (fakeStruct) GetSessionUserRoleID(ctx context.Context, in *sessionproto.Ses
 	return nil, errors.New("fakeError")
 }
 
+func (fakeStruct) GetSessionUserAggregates(ctx context.Context, in *sessionproto.SessionRequest, opts ...grpc.CallOption) (*sessionproto.SessionUserAggregates, error) {
+	return nil, errors.New("fakeError")
+}
+
 //--------------------------------------------SYSTEM-----------------------------------------
 
 func (fakeStruct2) GetSystemsCollection(ctx context.Context, in *systemsproto.GetSystemsRequest, opts ...grpc.CallOption) (*systemsproto.SystemsResponse, error) {
@@ -517,3 +581,8 @@ func (fakeStruct) SimepleUpdate(ctx context.Context, in *updateproto.UpdateReque
 func (fakeStruct) StartUpdate(ctx context.Context, in *updateproto.UpdateRequest, opts ...grpc.CallOption) (*updateproto.UpdateResponse, error) {
 	return nil, errors.New("fakeError")
 }
+
+func (fakeStruct) RotateCredentials(ctx context.Context, in *aggregatorproto.AggregatorRequest, opts ...grpc.CallOption) (*aggregatorproto.AggregatorResponse, error) {
+
+	return nil, errors.New("fakeError")
+}
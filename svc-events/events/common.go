@@ -43,6 +43,8 @@ var (
 	UpdateTaskService = services.UpdateTask
 	// IOUtilReadAllFunc function  pointer for calling the files
 	IOUtilReadAllFunc = ioutil.ReadAll
+	// TargetsOutOfScopeFunc function pointer for checking the origin resources against the scope of the session
+	TargetsOutOfScopeFunc = common.TargetsOutOfScope
 )
 
 // External struct to inject the contact external function into the handlers
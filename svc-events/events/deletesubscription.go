@@ -216,6 +216,15 @@ func (e *ExternalInterfaces) DeleteEventSubscriptionsDetails(ctx context.Context
 			common.Critical, percentComplete, http.MethodDelete))
 		return authResp
 	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		errorMessage := "error while resolving the scope of the session: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		resp = common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		e.UpdateTask(ctx, fillTaskData(taskId, targetURI, string(req.EventSubscriptionID), resp, common.Exception,
+			common.Critical, percentComplete, http.MethodDelete))
+		return resp
+	}
 	subscriptionDetails, err := e.GetEvtSubscriptions(req.EventSubscriptionID)
 	if err != nil && !strings.Contains(err.Error(), "No data found for the key") {
 		l.LogWithFields(ctx).Error("error while deleting event subscription details : " + err.Error())
@@ -239,8 +248,10 @@ func (e *ExternalInterfaces) DeleteEventSubscriptionsDetails(ctx context.Context
 	}
 	for _, evtSubscription := range subscriptionDetails {
 		// Since we are searching subscription id with pattern search
-		// we need to match the subscription id
-		if evtSubscription.SubscriptionID != req.EventSubscriptionID {
+		// we need to match the subscription id. The subscriptions out of
+		// the scope of the session are not found by the session
+		if evtSubscription.SubscriptionID != req.EventSubscriptionID ||
+			!isSubscriptionInScope(scope, evtSubscription.EventDestination) {
 			errorMessage := fmt.Sprintf("Subscription details not found for subscription id: %s", req.EventSubscriptionID)
 			l.LogWithFields(ctx).Error(errorMessage)
 			var msgArgs = []interface{}{"SubscriptionID", req.EventSubscriptionID}
@@ -97,6 +97,31 @@ func TestDeleteEventSubscription(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status Code should be StatusUnauthorized")
 }
 
+func TestDeleteEventSubscriptionOutOfScope(t *testing.T) {
+	config.SetUpMockConfig(t)
+	pc := getMockMethods()
+	deleted := false
+	pc.DB.DeleteEvtSubscription = func(s string) error {
+		deleted = true
+		return nil
+	}
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "81de0110-c35a-4859-984c-072d6c5a32d7",
+	}
+
+	// the subscription of the system of another aggregate is not found
+	ctx := mockScopedContext(t, "deletesubscription1", "/redfish/v1/Systems/1a2b3c4d-7efa-578e-83cf-44dc68d2874e.1")
+	resp := pc.DeleteEventSubscriptionsDetails(ctx, req, "admin", "1225122")
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+	assert.False(t, deleted, "subscription out of the scope should not be deleted")
+
+	ctx = mockScopedContext(t, "deletesubscription2", "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1")
+	resp = pc.DeleteEventSubscriptionsDetails(ctx, req, "admin", "1225122")
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	assert.True(t, deleted, "subscription in the scope should be deleted")
+}
+
 func TestDeleteEventSubscriptionOnDeletedServer(t *testing.T) {
 	config.SetUpMockConfig(t)
 	// Initializing plugin token
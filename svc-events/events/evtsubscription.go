@@ -75,6 +75,16 @@ func (e *ExternalInterfaces) ValidateRequest(ctx context.Context, req *eventspro
 		return http.StatusBadRequest, errResponse.PropertyValueFormatError, []interface{}{postRequest.Destination, "Destination"}, fmt.Errorf(errorMessage)
 	}
 
+	// a session restricted to aggregates can subscribe only to the resources of its aggregates
+	outOfScope, err := TargetsOutOfScopeFunc(ctx, removeOdataIDfromOriginResources(postRequest.OriginResources))
+	if err != nil {
+		errMsg := "error while resolving the aggregates of the session: " + err.Error()
+		return http.StatusInternalServerError, errResponse.InternalError, nil, fmt.Errorf(errMsg)
+	} else if len(outOfScope) > 0 {
+		errorMessage := fmt.Sprintf("error: origin resources %v are not in the scope of the session", outOfScope)
+		return http.StatusNotFound, errResponse.ResourceNotFound, []interface{}{"OriginResources", outOfScope[0]}, fmt.Errorf(errorMessage)
+	}
+
 	// check any of the subscription present for the destination from the request
 	// if errored out or no subscriptions then add subscriptions else return an error
 	subscriptionDetails, _ := e.GetEvtSubscriptions(postRequest.Destination)
@@ -125,8 +135,11 @@ func (e *ExternalInterfaces) CreateEventSubscription(ctx context.Context, taskID
 	// check and remove if duplicate OriginResources exist in the request
 	removeDuplicatesFromSlice(&originResources)
 
-	// If origin resource is nil then subscribe to all collection
-	if len(originResources) == 0 {
+	// If origin resource is nil then subscribe to all collection, or to
+	// the aggregates of a session restricted to aggregates
+	if aggregates, _ := ctx.Value(common.SessionAggregates).(string); len(originResources) == 0 && aggregates != "" {
+		originResources = strings.Split(aggregates, ",")
+	} else if len(originResources) == 0 {
 		originResources = []string{
 			"/redfish/v1/Systems",
 			"/redfish/v1/Chassis",
@@ -390,6 +390,44 @@ func TestNegativeCasesCreateEventSubscription(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status Code should be StatusBadRequest")
 }
 
+func TestCreateEventSubscription_OriginResourcesOutOfScope(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		TargetsOutOfScopeFunc = common.TargetsOutOfScope
+	}()
+	p := getMockMethods()
+	SubscriptionReq := map[string]interface{}{
+		"Name":                 "EventSubscription",
+		"Destination":          "https://odim.test24.com:8070/Destination1",
+		"EventTypes":           []string{"Alert"},
+		"Protocol":             "Redfish",
+		"Context":              "Event Subscription",
+		"SubscriptionType":     "RedfishEvent",
+		"EventFormatType":      "Event",
+		"SubordinateResources": true,
+		"OriginResources": []common.Link{
+			{Oid: "/redfish/v1/Systems/d72dade0-c35a-984c-4859-1108132d72da.1"},
+		},
+	}
+	postBody, _ := json.Marshal(&SubscriptionReq)
+	req := &eventsproto.EventSubRequest{
+		SessionToken: "token",
+		PostBody:     postBody,
+	}
+
+	TargetsOutOfScopeFunc = func(ctx context.Context, targets []string) ([]string, error) {
+		return targets, nil
+	}
+	resp := p.CreateEventSubscription(evcommon.MockContext(), "123", "admin", req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+
+	TargetsOutOfScopeFunc = func(ctx context.Context, targets []string) ([]string, error) {
+		return nil, fmt.Errorf("DB down")
+	}
+	resp = p.CreateEventSubscription(evcommon.MockContext(), "123", "admin", req)
+	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "Status Code should be StatusInternalServerError")
+}
+
 func TestCreateDefaultEventSubscription(t *testing.T) {
 	config.SetUpMockConfig(t)
 	p := getMockMethods()
@@ -23,6 +23,7 @@ package events
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -51,6 +52,11 @@ func (e *ExternalInterfaces) GetEventSubscriptionsDetails(ctx context.Context, r
 		l.LogWithFields(ctx).Error(errMsg)
 		return authResp
 	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while resolving the scope of the session: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
 	var subscriptions *evresponse.SubscriptionResponse
 
 	subscriptionDetails, err := e.GetEvtSubscriptions(req.EventSubscriptionID)
@@ -68,8 +74,10 @@ func (e *ExternalInterfaces) GetEventSubscriptionsDetails(ctx context.Context, r
 	for _, evtSubscription := range subscriptionDetails {
 
 		// Since we are searching subscription id with pattern search
-		// we need to match the subscription id
-		if evtSubscription.SubscriptionID != req.EventSubscriptionID {
+		// we need to match the subscription id. The subscriptions out of
+		// the scope of the session are not found by the session
+		if evtSubscription.SubscriptionID != req.EventSubscriptionID ||
+			!isSubscriptionInScope(scope, evtSubscription.EventDestination) {
 			errorMessage := fmt.Sprintf("Subscription details not found for subscription id: %s", req.EventSubscriptionID)
 			l.LogWithFields(ctx).Info(errorMessage)
 			var msgArgs = []interface{}{"SubscriptionID", req.EventSubscriptionID}
@@ -116,6 +124,21 @@ func (e *ExternalInterfaces) GetEventSubscriptionsCollection(ctx context.Context
 		l.LogWithFields(ctx).Error(errMsg)
 		return authResp
 	}
+	paging, err := common.GetPaging(req.URL)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+	}
+	filter, err := common.GetFilter(req.URL, common.EventSubscriptionsFilterResource)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while resolving the scope of the session: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
 	listMembers := []model.Link{}
 	searchKey := "*"
 
@@ -132,6 +155,12 @@ func (e *ExternalInterfaces) GetEventSubscriptionsCollection(ctx context.Context
 		if destination == "" {
 			continue
 		}
+		if filter != nil && !filter.MatchResource(eventDestinationResource(evtSubscription.EventDestination)) {
+			continue
+		}
+		if !isSubscriptionInScope(scope, evtSubscription.EventDestination) {
+			continue
+		}
 		member := model.Link{
 			Oid: "/redfish/v1/EventService/Subscriptions/" + subscriptionID + "/",
 		}
@@ -139,6 +168,7 @@ func (e *ExternalInterfaces) GetEventSubscriptionsCollection(ctx context.Context
 		listMembers = append(listMembers, member)
 	}
 
+	start, end := paging.Bounds(len(listMembers))
 	eventResp := evresponse.ListResponse{
 		OdataContext: "/redfish/v1/$metadata#EventDestinationCollection.EventDestinationCollection",
 		OdataID:      "/redfish/v1/EventService/Subscriptions",
@@ -146,14 +176,44 @@ func (e *ExternalInterfaces) GetEventSubscriptionsCollection(ctx context.Context
 		Name:         "EventSubscriptions",
 		Description:  "Event Subscriptions",
 		MembersCount: len(listMembers),
-		Members:      listMembers,
+		Members:      listMembers[start:end],
 	}
+	eventResp.MembersNextLink = paging.NextLink(eventResp.OdataID, len(listMembers))
 	resp.Body = eventResp
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
 	return resp
 }
 
+// isSubscriptionInScope checks the origin resources of the subscription are in the scope
+// of the session. A subscription without origin resources is for all the resources,
+// so it is not in the scope of a session restricted to aggregates
+func isSubscriptionInScope(scope *common.ResourceScope, destination *model.EventDestination) bool {
+	if scope == nil {
+		return true
+	}
+	if len(destination.OriginResources) == 0 {
+		return false
+	}
+	for _, origin := range destination.OriginResources {
+		if !scope.Allows(origin.Oid) {
+			return false
+		}
+	}
+	return true
+}
+
+// eventDestinationResource returns the event destination as a resource for evaluating $filter
+func eventDestinationResource(destination *model.EventDestination) map[string]interface{} {
+	var resource map[string]interface{}
+	data, err := json.Marshal(destination)
+	if err != nil {
+		return resource
+	}
+	json.Unmarshal(data, &resource)
+	return resource
+}
+
 // IsAggregateHaveSubscription validate any subscription contain aggregate id, return status
 func (e *ExternalInterfaces) IsAggregateHaveSubscription(ctx context.Context, req *eventsproto.EventUpdateRequest) bool {
 	authResp, err := e.Auth(ctx, req.SessionToken, []string{common.PrivilegeConfigureComponents}, []string{})
@@ -22,12 +22,14 @@ package events
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	errs "github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-events/evcommon"
@@ -88,7 +90,39 @@ func TestGetEventSubscriptionsCollection(t *testing.T) {
 	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
 	assert.Equal(t, 1, data.MembersCount, "MembersCount should be 1")
 
+	// paged collection
+	req.URL = "/redfish/v1/EventService/Subscriptions?$skip=1"
+	resp = pc.GetEventSubscriptionsCollection(evcommon.MockContext(), req)
+	data = resp.Body.(evresponse.ListResponse)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	assert.Equal(t, 1, data.MembersCount, "MembersCount should be 1")
+	assert.Equal(t, 0, len(data.Members), "Members should be empty")
+
+	// filtered collection
+	common.SetFilterSchema(common.EventSubscriptionsFilterResource, common.FilterSchema{
+		SearchKeys: []map[string]map[string]string{{"Context": {"type": "string"}}, {"Destination": {"type": "string"}}},
+	})
+	req.URL = "/redfish/v1/EventService/Subscriptions?$filter=Context%20eq%20context"
+	resp = pc.GetEventSubscriptionsCollection(evcommon.MockContext(), req)
+	data = resp.Body.(evresponse.ListResponse)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	assert.Equal(t, 1, data.MembersCount, "MembersCount should be 1")
+	req.URL = "/redfish/v1/EventService/Subscriptions?$filter=Destination%20ne%20%27https://odim.destination.com:9090/events%27"
+	resp = pc.GetEventSubscriptionsCollection(evcommon.MockContext(), req)
+	data = resp.Body.(evresponse.ListResponse)
+	assert.Equal(t, 0, data.MembersCount, "MembersCount should be 0")
+
 	// Negative test cases
+	// Invalid $filter
+	req.URL = "/redfish/v1/EventService/Subscriptions?$filter=Name%20eq%20abc"
+	resp = pc.GetEventSubscriptionsCollection(evcommon.MockContext(), req)
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status Code should be StatusBadRequest")
+	// Invalid $skip
+	req.URL = "/redfish/v1/EventService/Subscriptions?$skip=-1"
+	resp = pc.GetEventSubscriptionsCollection(evcommon.MockContext(), req)
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status Code should be StatusBadRequest")
+	req.URL = ""
+
 	// Invalid token
 	req1 := &eventsproto.EventRequest{
 		SessionToken: "InValidToken",
@@ -149,6 +183,44 @@ func TestGetEventSubscription(t *testing.T) {
 
 }
 
+// mockScopedContext returns the context of a session restricted to the aggregate
+// whose member systems are passed, the scope is resolved without the DB
+func mockScopedContext(t *testing.T, aggregateID string, systems ...string) context.Context {
+	readResourceScopeData := common.ReadResourceScopeData
+	t.Cleanup(func() { common.ReadResourceScopeData = readResourceScopeData })
+	aggregateURI := "/redfish/v1/AggregationService/Aggregates/" + aggregateID
+	common.ReadResourceScopeData = func(dbFlag common.DbType, table, key string) (string, *errs.Error) {
+		if table != "Aggregate" || key != aggregateURI {
+			return "", errs.PackError(errs.DBKeyNotFound, "no data with the key ", key, " found")
+		}
+		elements := []map[string]string{}
+		for _, system := range systems {
+			elements = append(elements, map[string]string{"@odata.id": system})
+		}
+		data, _ := json.Marshal(map[string]interface{}{"Elements": elements})
+		return string(data), nil
+	}
+	return context.WithValue(evcommon.MockContext(), common.SessionAggregates, aggregateURI)
+}
+
+func TestGetEventSubscriptionOutOfScope(t *testing.T) {
+	common.SetUpMockConfig()
+	pc := getMockMethods()
+	req := &eventsproto.EventRequest{
+		SessionToken:        "validToken",
+		EventSubscriptionID: "81de0110-c35a-4859-984c-072d6c5a32d7",
+	}
+
+	// the subscription of the system of another aggregate is not found
+	ctx := mockScopedContext(t, "getsubscription1", "/redfish/v1/Systems/1a2b3c4d-7efa-578e-83cf-44dc68d2874e.1")
+	resp := pc.GetEventSubscriptionsDetails(ctx, req)
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+
+	ctx = mockScopedContext(t, "getsubscription2", "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e.1")
+	resp = pc.GetEventSubscriptionsDetails(ctx, req)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+}
+
 func TestExternalInterfaces_IsAggregateHaveSubscription(t *testing.T) {
 	config.SetUpMockConfig(t)
 	pc := getMockMethods()
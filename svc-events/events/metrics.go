@@ -0,0 +1,53 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+// event forwarding metrics
+var (
+	eventForwardingBacklog = metrics.NewGaugeVec("odim_event_forwarding_backlog",
+		"Number of events queued for or being forwarded to the subscribers")
+	undeliveredEventsSaved = metrics.NewCounterVec("odim_undelivered_events_saved_total",
+		"Number of events saved as undelivered after the delivery to the subscriber failed")
+	undeliveredEventsDelivered = metrics.NewCounterVec("odim_undelivered_events_delivered_total",
+		"Number of undelivered events delivered to the subscriber on a later attempt")
+	// the undelivered events are read from the DB on every scrape,
+	// so that all the instances of the service report the same value
+	_ = metrics.NewGaugeFunc("odim_undelivered_events",
+		"Number of undelivered events saved in the DB, waiting to be delivered to the subscribers",
+		nil, func(emit func(float64, ...string)) {
+			keys, err := getUndeliveredEventKeysFunc(evmodel.UndeliveredEvents, "", common.OnDisk)
+			if err != nil {
+				l.Log.Warn("Unable to count the undelivered events: " + err.Error())
+				return
+			}
+			emit(float64(len(keys)))
+		})
+)
+
+// getUndeliveredEventKeysFunc is the function pointer for reading the keys of the undelivered events
+var getUndeliveredEventKeysFunc = evmodel.GetAllMatchingDetails
+
+// queueEventForwarding hands over the event to the event forwarding workers
+func queueEventForwarding(event evmodel.EventPost) {
+	eventForwardingBacklog.Add(1)
+	eventForwardingChanel <- event
+}
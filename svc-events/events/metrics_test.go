@@ -0,0 +1,51 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+func TestUndeliveredEventsGauge(t *testing.T) {
+	defer func() {
+		getUndeliveredEventKeysFunc = evmodel.GetAllMatchingDetails
+	}()
+	scrape := func() string {
+		var buf bytes.Buffer
+		metrics.DefaultRegistry.Write(&buf)
+		return buf.String()
+	}
+
+	getUndeliveredEventKeysFunc = func(table, pattern string, dbtype common.DbType) ([]string, *errors.Error) {
+		return []string{"https://destination1:event1", "https://destination1:event2"}, nil
+	}
+	if got := scrape(); !strings.Contains(got, "\nodim_undelivered_events 2\n") {
+		t.Errorf("expected 2 undelivered events in the metrics, got:\n%s", got)
+	}
+
+	getUndeliveredEventKeysFunc = func(table, pattern string, dbtype common.DbType) ([]string, *errors.Error) {
+		return nil, errors.PackError(errors.DBConnFailed, "DB down")
+	}
+	if got := scrape(); strings.Contains(got, "\nodim_undelivered_events ") {
+		t.Errorf("expected no undelivered events series when the DB is not reachable, got:\n%s", got)
+	}
+}
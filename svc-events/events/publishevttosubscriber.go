@@ -39,6 +39,7 @@ import (
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
 	fabricproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/fabrics"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
 	uuid "github.com/satori/go.uuid"
 	"github.com/sirupsen/logrus"
@@ -86,6 +87,10 @@ func (e *ExternalInterfaces) PublishEventsToDestination(ctx context.Context, dat
 		return false
 	}
 	event := data.(common.Events)
+	// continue the trace of the operation which published the event
+	ctx = tracing.Extract(ctx, tracing.MapCarrier{tracing.TraceParentHeader: event.TraceParent})
+	ctx, span := tracing.StartSpan(ctx, "PublishEventsToDestination", tracing.SpanKindConsumer)
+	defer span.End()
 	if event.EventType == "PluginStartUp" {
 		logging.Info("received plugin started event from ", event.IP)
 		go callPluginStartUp(ctx, event)
@@ -170,7 +175,7 @@ func (e *ExternalInterfaces) PublishEventsToDestination(ctx context.Context, dat
 			logging.Error("unable to converts event into bytes: ", err.Error())
 			continue
 		}
-		eventForwardingChanel <- evmodel.EventPost{Destination: key, EventID: eventUniqueID, Message: data}
+		queueEventForwarding(evmodel.EventPost{Destination: key, EventID: eventUniqueID, Message: data})
 	}
 	return flag
 }
@@ -182,7 +187,7 @@ func (e *ExternalInterfaces) publishMetricReport(ctx context.Context, requestDat
 		return false
 	}
 	for _, sub := range subscriptions {
-		eventForwardingChanel <- evmodel.EventPost{Destination: sub.EventDestination.Destination, EventID: eventUniqueID, Message: []byte(requestData)}
+		queueEventForwarding(evmodel.EventPost{Destination: sub.EventDestination.Destination, EventID: eventUniqueID, Message: []byte(requestData)})
 	}
 	return true
 }
@@ -353,6 +358,7 @@ func (e *ExternalInterfaces) reAttemptEvents(eventMessage evmodel.EventPost) {
 		if err == nil {
 			resp.Body.Close()
 			logging.Info("Event is successfully forwarded after reattempt ")
+			undeliveredEventsDelivered.Inc()
 			err = e.DeleteUndeliveredEvents(eventMessage.UndeliveredEventID)
 			if err != nil {
 				logging.Error("error while deleting undelivered events: ", err.Error())
@@ -554,6 +560,7 @@ func (e *ExternalInterfaces) checkUndeliveredEvents(destination string) {
 					break
 				}
 				logging.Debug("Event is successfully forwarded")
+				undeliveredEventsDelivered.Inc()
 				err = e.DeleteUndeliveredEvents(dest)
 				if err != nil {
 					logging.Error("error while deleting undelivered events: ", err.Error())
@@ -405,6 +405,7 @@ func (e *ExternalInterfaces) forwardUndeliveredEventToClient(ctx context.Context
 func (e *ExternalInterfaces) runEventForwardingWorkers() {
 	for job := range eventForwardingChanel {
 		e.postEvent(job)
+		eventForwardingBacklog.Add(-1)
 	}
 }
 
@@ -436,5 +437,8 @@ func (e *ExternalInterfaces) saveEventWorkers() {
 				logging.Error("error occurred while save saveEventWorker ", err.Error())
 			}
 		}
+		if err == nil {
+			undeliveredEventsSaved.Inc()
+		}
 	}
 }
@@ -44,14 +44,15 @@ type SubscriptionResponse struct {
 
 // ListResponse define list for odimra
 type ListResponse struct {
-	OdataContext string       `json:"@odata.context"`
-	Etag         string       `json:"@odata.etag,omitempty"`
-	OdataID      string       `json:"@odata.id"`
-	OdataType    string       `json:"@odata.type"`
-	Name         string       `json:"Name,omitempty"`
-	Description  string       `json:"Description,omitempty"`
-	MembersCount int          `json:"Members@odata.count"`
-	Members      []model.Link `json:"Members"`
+	OdataContext    string       `json:"@odata.context"`
+	Etag            string       `json:"@odata.etag,omitempty"`
+	OdataID         string       `json:"@odata.id"`
+	OdataType       string       `json:"@odata.type"`
+	Name            string       `json:"Name,omitempty"`
+	Description     string       `json:"Description,omitempty"`
+	MembersCount    int          `json:"Members@odata.count"`
+	Members         []model.Link `json:"Members"`
+	MembersNextLink string       `json:"Members@odata.nextLink,omitempty"`
 }
 
 // EventServiceResponse is struct for event service response
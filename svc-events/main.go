@@ -73,11 +73,19 @@ func main() {
 	if err := common.CheckDBConnection(); err != nil {
 		log.Fatal("error while trying to check DB connection health: " + err.Error())
 	}
+	if config.Data.ResourceFilterSchemaPath != "" {
+		if err := common.LoadFilterSchemas(config.Data.ResourceFilterSchemaPath); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
 
 	errChan := make(chan error)
 	if err := services.InitializeService(services.Events, errChan); err != nil {
 		log.Fatal("fatal: error while trying to initialize the service: " + err.Error())
 	}
+	services.RegisterHealthCheck(services.HealthMessageBus, func() error {
+		return dc.Ping(config.Data.MessageBusConf.MessageBusType)
+	})
 
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, common.ProcessName, podName)
@@ -111,10 +111,10 @@ func (p *PluginToken) getToken(pluginID string) string {
 func contactPlugin(ctx context.Context, req pluginContactRequest, errorMessage string) ([]byte, string, responseStatus, error) {
 	var resp responseStatus
 
-	pluginResponse, err := callPlugin(req)
+	pluginResponse, err := callPlugin(ctx, req)
 	if err != nil {
 		if getPluginStatus(ctx, req.Plugin) {
-			pluginResponse, err = callPlugin(req)
+			pluginResponse, err = callPlugin(ctx, req)
 		}
 		if err != nil {
 			errorMessage = errorMessage + err.Error()
@@ -151,12 +151,12 @@ func contactPlugin(ctx context.Context, req pluginContactRequest, errorMessage s
 	return body, pluginResponse.Header.Get("X-Auth-Token"), resp, nil
 }
 
-func callPlugin(req pluginContactRequest) (*http.Response, error) {
+func callPlugin(ctx context.Context, req pluginContactRequest) (*http.Response, error) {
 	var reqURL = "https://" + req.Plugin.IP + ":" + req.Plugin.Port + req.URL
 	if strings.EqualFold(req.Plugin.PreferredAuthType, "BasicAuth") {
-		req.ContactClient(context.TODO(), reqURL, req.HTTPMethodType, "", "", req.PostBody, req.LoginCredential)
+		req.ContactClient(ctx, reqURL, req.HTTPMethodType, "", "", req.PostBody, req.LoginCredential)
 	}
-	return req.ContactClient(context.TODO(), reqURL, req.HTTPMethodType, req.Token, "", req.PostBody, nil)
+	return req.ContactClient(ctx, reqURL, req.HTTPMethodType, req.Token, "", req.PostBody, nil)
 }
 
 // getPluginStatus checks the status of given plugin in configured interval
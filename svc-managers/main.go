@@ -68,6 +68,11 @@ func main() {
 	if err := common.CheckDBConnection(); err != nil {
 		log.Fatal(err.Error())
 	}
+	if config.Data.ResourceFilterSchemaPath != "" {
+		if err := common.LoadFilterSchemas(config.Data.ResourceFilterSchemaPath); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
 
 	var managerInterface = mgrcommon.DBInterface{
 		AddManagertoDBInterface: mgrmodel.AddManagertoDB,
@@ -135,8 +140,11 @@ func addManagertoDB(managerInterface mgrcommon.DBInterface) error {
 			&dmtf.Link{
 				Oid: "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/SL",
 			},
+			&dmtf.Link{
+				Oid: "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit",
+			},
 		},
-		MembersCount: 1,
+		MembersCount: 2,
 		Name:         "Logs",
 	}
 	dbdata, err := json.Marshal(data)
@@ -183,5 +191,25 @@ func addManagertoDB(managerInterface mgrcommon.DBInterface) error {
 	key = "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/SL/Entries"
 	mgrmodel.GenericSave([]byte(dbentriesdata), "EntriesCollection", key)
 
+	// adding the audit trail logservice, its entries are served from the audit records
+	auditLogData := dmtf.LogServices{
+		Ocontext:    "/redfish/v1/$metadata#LogService.LogService",
+		Oid:         "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit",
+		Otype:       "#LogService.v1_4_0.LogService",
+		Description: "Audit trail of the requests served by ODIM",
+		Entries: &dmtf.Entries{
+			Oid: "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit/Entries",
+		},
+		ID:              "Audit",
+		Name:            "Audit Log",
+		OverWritePolicy: "WrapsWhenFull",
+	}
+	dbdata, err = json.Marshal(auditLogData)
+	if err != nil {
+		return fmt.Errorf("unable to marshal manager data: %v", err)
+	}
+	key = "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit"
+	mgrmodel.GenericSave([]byte(dbdata), "LogServices", key)
+
 	return nil
 }
@@ -0,0 +1,160 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrresponse"
+)
+
+// auditLogEntriesURI returns the URI of the entries of the audit trail LogService of the ODIM manager
+func auditLogEntriesURI() string {
+	return "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit/Entries"
+}
+
+// IsAuditLogURL checks whether the URL is of the audit trail entries, which are
+// restricted to the users with the ConfigureManager privilege. The privilege registry
+// takes precedence over the privileges passed for the authorization, so the restriction
+// is defined by the ResourceURIOverrides of the audit entries in the registry as well
+func IsAuditLogURL(reqURL string) bool {
+	path, _, _ := strings.Cut(reqURL, "?")
+	return path == auditLogEntriesURI() || strings.HasPrefix(path, auditLogEntriesURI()+"/")
+}
+
+// getAuditLogResource serves the entries of the audit trail, which are read from the audit records
+// and not from the saved resources. The second return value is false if the URL is not of the audit trail entries
+func (e *ExternalInterface) getAuditLogResource(ctx context.Context, reqURL string) (response.RPC, bool) {
+	path, _, _ := strings.Cut(reqURL, "?")
+	entriesURI := auditLogEntriesURI()
+	if path == entriesURI {
+		return e.getAuditLogEntries(ctx, reqURL), true
+	}
+	if id := strings.TrimPrefix(path, entriesURI+"/"); id != path {
+		return e.getAuditLogEntry(ctx, id), true
+	}
+	return response.RPC{}, false
+}
+
+// getAuditLogEntries returns the collection of the audit trail entries, the latest first.
+// The entries can be filtered by $filter on the searchable properties of the AuditLogEntries and paged by $top and $skip
+func (e *ExternalInterface) getAuditLogEntries(ctx context.Context, reqURL string) response.RPC {
+	paging, perr := common.GetPaging(reqURL)
+	if perr != nil {
+		l.LogWithFields(ctx).Error(perr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, perr.Error(), nil, nil)
+	}
+	filter, ferr := common.GetFilter(reqURL, common.AuditLogEntriesFilterResource)
+	if ferr != nil {
+		l.LogWithFields(ctx).Error(ferr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, ferr.Error(), nil, nil)
+	}
+	ids, err := e.DB.GetAuditRecordIDs()
+	if err != nil {
+		errorMessage := "error while reading the audit records: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	var records []common.AuditRecord
+	total := len(ids)
+	if filter == nil {
+		// only the records of the page are read when all the records are listed
+		start, end := paging.Bounds(total)
+		records, err = e.DB.GetAuditRecords(ids[start:end])
+	} else {
+		var all []common.AuditRecord
+		all, err = e.DB.GetAuditRecords(ids)
+		for _, record := range all {
+			if filter.Match(record.FilterValue) {
+				records = append(records, record)
+			}
+		}
+		total = len(records)
+		start, end := paging.Bounds(total)
+		records = records[start:end]
+	}
+	if err != nil {
+		errorMessage := "error while reading the audit records: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+
+	collection := mgrresponse.AuditLogEntryCollection{
+		OdataContext: "/redfish/v1/$metadata#LogEntryCollection.LogEntryCollection",
+		OdataID:      auditLogEntriesURI(),
+		OdataType:    "#LogEntryCollection.LogEntryCollection",
+		Description:  "Audit Logs view",
+		Name:         "Audit Logs",
+		Members:      []mgrresponse.AuditLogEntry{},
+		MembersCount: total,
+	}
+	for _, record := range records {
+		collection.Members = append(collection.Members, auditLogEntry(record))
+	}
+	collection.MembersNextLink = paging.NextLink(collection.OdataID, total)
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          collection,
+	}
+}
+
+// getAuditLogEntry returns the audit trail entry of the ID
+func (e *ExternalInterface) getAuditLogEntry(ctx context.Context, id string) response.RPC {
+	record, err := e.DB.GetAuditRecord(id)
+	if err != nil {
+		errorMessage := "error while reading the audit record " + id + ": " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		if errors.DBKeyNotFound == err.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"LogEntry", id}, nil)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	entry := auditLogEntry(record)
+	entry.OdataContext = "/redfish/v1/$metadata#LogEntry.LogEntry"
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Body:          entry,
+	}
+}
+
+// auditLogEntry converts the audit record to the LogEntry of the audit trail
+func auditLogEntry(record common.AuditRecord) mgrresponse.AuditLogEntry {
+	severity := "OK"
+	if record.StatusCode >= http.StatusBadRequest {
+		severity = "Warning"
+	}
+	return mgrresponse.AuditLogEntry{
+		OdataID:   auditLogEntriesURI() + "/" + record.ID,
+		OdataType: "#LogEntry.v1_11_0.LogEntry",
+		ID:        record.ID,
+		Name:      "Audit Log Entry",
+		Created:   record.Created.UTC().Format(time.RFC3339),
+		EntryType: "Oem",
+		Severity:  severity,
+		Message:   fmt.Sprintf("%s %s requested by %s responded with %d", record.Method, record.RequestURI, record.UserName, record.StatusCode),
+		Oem:       &mgrresponse.AuditLogEntryOem{ODIM: record},
+	}
+}
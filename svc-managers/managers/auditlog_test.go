@@ -0,0 +1,93 @@
+// (C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package managers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrresponse"
+	"github.com/stretchr/testify/assert"
+)
+
+func setMockAuditLogFilterSchema() {
+	common.SetFilterSchema(common.AuditLogEntriesFilterResource, common.FilterSchema{
+		SearchKeys: []map[string]map[string]string{
+			{"Created": {"type": "datetime"}},
+			{"UserName": {"type": "string"}},
+			{"RequestURI": {"type": "string"}},
+		},
+	})
+}
+
+func TestGetManagersResource_AuditLogEntries(t *testing.T) {
+	config.SetUpMockConfig(t)
+	setMockAuditLogFilterSchema()
+	ctx := mockContext()
+	e := mockGetExternalInterface()
+	entriesURI := "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit/Entries"
+
+	resp := e.GetManagersResource(ctx, &managersproto.ManagerRequest{ManagerID: config.Data.RootServiceUUID, ResourceID: "Audit", URL: entriesURI})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	collection := resp.Body.(mgrresponse.AuditLogEntryCollection)
+	assert.Equal(t, 3, collection.MembersCount, "All the audit records should be listed")
+	assert.Equal(t, "txn.3", collection.Members[0].ID, "The latest audit record should be listed first")
+	assert.Equal(t, "Warning", collection.Members[1].Severity, "Failed requests should be listed with Warning severity")
+
+	resp = e.GetManagersResource(ctx, &managersproto.ManagerRequest{ManagerID: config.Data.RootServiceUUID, ResourceID: "Audit",
+		URL: entriesURI + "?$filter=UserName%20eq%20admin%20and%20Created%20ge%202022-06-14T00:00:00Z"})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	collection = resp.Body.(mgrresponse.AuditLogEntryCollection)
+	assert.Equal(t, 1, collection.MembersCount, "Only the matching audit records should be listed")
+	assert.Equal(t, "txn.3", collection.Members[0].ID, "Only the matching audit records should be listed")
+
+	resp = e.GetManagersResource(ctx, &managersproto.ManagerRequest{ManagerID: config.Data.RootServiceUUID, ResourceID: "Audit",
+		URL: entriesURI + "?$top=2"})
+	collection = resp.Body.(mgrresponse.AuditLogEntryCollection)
+	assert.Equal(t, 3, collection.MembersCount, "Members count should be the total number of audit records")
+	assert.Equal(t, 2, len(collection.Members), "Members should have only the requested page")
+	assert.Equal(t, entriesURI+"?$top=2&$skip=2", collection.MembersNextLink, "Next link should point to the next page")
+
+	resp = e.GetManagersResource(ctx, &managersproto.ManagerRequest{ManagerID: config.Data.RootServiceUUID, ResourceID: "Audit",
+		URL: entriesURI + "?$filter=StatusCode%20eq%20200"})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest for a property out of the filter schema.")
+}
+
+func TestGetManagersResource_AuditLogEntry(t *testing.T) {
+	config.SetUpMockConfig(t)
+	ctx := mockContext()
+	e := mockGetExternalInterface()
+	entriesURI := "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices/Audit/Entries"
+
+	resp := e.GetManagersResource(ctx, &managersproto.ManagerRequest{ManagerID: config.Data.RootServiceUUID, ResourceID: "Audit", URL: entriesURI + "/txn.2"})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	entry := resp.Body.(mgrresponse.AuditLogEntry)
+	assert.Equal(t, entriesURI+"/txn.2", entry.OdataID, "The entry should be of the audit record")
+	assert.Equal(t, "operator", entry.Oem.ODIM.UserName, "The entry should have the audit record")
+
+	resp = e.GetManagersResource(ctx, &managersproto.ManagerRequest{ManagerID: config.Data.RootServiceUUID, ResourceID: "Audit", URL: entriesURI + "/txn.9"})
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status code should be StatusNotFound.")
+}
+
+func TestIsAuditLogURL(t *testing.T) {
+	config.SetUpMockConfig(t)
+	logServicesURI := "/redfish/v1/Managers/" + config.Data.RootServiceUUID + "/LogServices"
+	assert.True(t, IsAuditLogURL(logServicesURI+"/Audit/Entries?$top=10"), "Audit log entries should be restricted")
+	assert.True(t, IsAuditLogURL(logServicesURI+"/Audit/Entries/txn.1"), "Audit log entry should be restricted")
+	assert.False(t, IsAuditLogURL(logServicesURI+"/SL/Entries"), "Other log entries should not be restricted")
+	assert.False(t, IsAuditLogURL("/redfish/v1/Managers/uuid.1/LogServices/Audit/Entries"), "Log entries of other managers should not be restricted")
+}
@@ -51,6 +51,10 @@ type DB struct {
 	UpdateData          func(string, map[string]interface{}, string) error
 	SavePluginTaskInfo  func(context.Context, string, string, string, string) error
 	GetResource         func(string, string) (string, *errors.Error)
+	GetFilterIndexes    func(string, []string) (map[string]map[string]string, error)
+	GetAuditRecordIDs   func() ([]string, *errors.Error)
+	GetAuditRecords     func([]string) ([]common.AuditRecord, *errors.Error)
+	GetAuditRecord      func(string) (common.AuditRecord, *errors.Error)
 }
 
 // RPC struct to inject the rpc call to other services
@@ -75,6 +79,10 @@ func GetExternalInterface() *ExternalInterface {
 			UpdateData:          mgrmodel.UpdateData,
 			SavePluginTaskInfo:  services.SavePluginTaskInfo,
 			GetResource:         mgrmodel.GetResource,
+			GetFilterIndexes:    common.GetFilterIndexes,
+			GetAuditRecordIDs:   common.GetAuditRecordIDs,
+			GetAuditRecords:     common.GetAuditRecords,
+			GetAuditRecord:      common.GetAuditRecord,
 		},
 		RPC: RPC{
 			UpdateTask: mgrcommon.UpdateTask,
@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"testing"
+	"time"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -63,6 +64,10 @@ func mockGetExternalInterface() *ExternalInterface {
 			UpdateData:          mockUpdateData,
 			SavePluginTaskInfo:  mockSavePluginTaskInfo,
 			GetResource:         mockGetResource,
+			GetFilterIndexes:    mockGetFilterIndexes,
+			GetAuditRecordIDs:   mockGetAuditRecordIDs,
+			GetAuditRecords:     mockGetAuditRecords,
+			GetAuditRecord:      mockGetAuditRecord,
 		},
 		RPC: RPC{
 			UpdateTask: mockUpdateTask,
@@ -70,6 +75,43 @@ func mockGetExternalInterface() *ExternalInterface {
 	}
 }
 
+func mockGetFilterIndexes(resourceType string, properties []string) (map[string]map[string]string, error) {
+	return map[string]map[string]string{
+		"ManagerType": {"/redfish/v1/Managers/uuid.1": "bmc"},
+	}, nil
+}
+
+var mockAuditRecords = map[string]common.AuditRecord{
+	"txn.3": {ID: "txn.3", Created: time.Date(2022, 6, 14, 12, 0, 0, 0, time.UTC), UserName: "admin", RoleID: common.RoleAdmin,
+		Method: http.MethodPost, RequestURI: "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", StatusCode: http.StatusAccepted, TransactionID: "txn.3"},
+	"txn.2": {ID: "txn.2", Created: time.Date(2022, 6, 14, 11, 0, 0, 0, time.UTC), UserName: "operator", RoleID: common.RoleMonitor,
+		Method: http.MethodPatch, RequestURI: "/redfish/v1/AccountService/Accounts/operator", StatusCode: http.StatusForbidden, TransactionID: "txn.2"},
+	"txn.1": {ID: "txn.1", Created: time.Date(2022, 6, 13, 10, 0, 0, 0, time.UTC), UserName: "admin", RoleID: common.RoleAdmin,
+		Method: http.MethodPost, RequestURI: "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset", StatusCode: http.StatusAccepted, TransactionID: "txn.1"},
+}
+
+func mockGetAuditRecordIDs() ([]string, *errors.Error) {
+	return []string{"txn.3", "txn.2", "txn.1"}, nil
+}
+
+func mockGetAuditRecords(ids []string) ([]common.AuditRecord, *errors.Error) {
+	records := []common.AuditRecord{}
+	for _, id := range ids {
+		if record, ok := mockAuditRecords[id]; ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func mockGetAuditRecord(id string) (common.AuditRecord, *errors.Error) {
+	record, ok := mockAuditRecords[id]
+	if !ok {
+		return record, errors.PackError(errors.DBKeyNotFound, "not found")
+	}
+	return record, nil
+}
+
 func mockGetAllKeysFromTable(table string) ([]string, error) {
 	return []string{"/redfish/v1/Managers/uuid.1"}, nil
 }
@@ -169,6 +211,11 @@ func mockGetResource(table, key string) (string, *errors.Error) {
 		return "", errors.PackError(errors.DBKeyNotFound, "not found")
 	} else if key == "/redfish/v1/Managers/uuid/EthernetInterfaces" {
 		return "", errors.PackError(errors.DBKeyNotFound, "not found")
+	} else if key == "/redfish/v1/Managers/uuid.1/LogServices/SEL/Entries" {
+		return `{"@odata.id":"/redfish/v1/Managers/uuid.1/LogServices/SEL/Entries","Members":[` +
+			`{"@odata.id":"/redfish/v1/Managers/uuid.1/LogServices/SEL/Entries/1"},` +
+			`{"@odata.id":"/redfish/v1/Managers/uuid.1/LogServices/SEL/Entries/2"},` +
+			`{"@odata.id":"/redfish/v1/Managers/uuid.1/LogServices/SEL/Entries/3"}],"Members@odata.count":3}`, nil
 	}
 	return "body", nil
 }
@@ -46,6 +46,21 @@ var (
 // GetManagersCollection will get the all the managers(odimra, Plugins, Servers)
 func (e *ExternalInterface) GetManagersCollection(ctx context.Context, req *managersproto.ManagerRequest) (response.RPC, error) {
 	var resp response.RPC
+	paging, perr := common.GetPaging(req.URL)
+	if perr != nil {
+		l.LogWithFields(ctx).Error(perr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, perr.Error(), nil, nil), nil
+	}
+	filter, ferr := common.GetFilter(req.URL, common.ManagersFilterResource)
+	if ferr != nil {
+		l.LogWithFields(ctx).Error(ferr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, ferr.Error(), nil, nil), nil
+	}
+	scope, serr := common.GetResourceScope(ctx)
+	if serr != nil {
+		l.LogWithFields(ctx).Error("error while resolving the scope of the session: " + serr.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, serr.Error(), nil, nil), nil
+	}
 	managers := mgrresponse.ManagersCollection{
 		OdataContext: "/redfish/v1/$metadata#ManagerCollection.ManagerCollection",
 		OdataID:      "/redfish/v1/Managers",
@@ -61,11 +76,24 @@ func (e *ExternalInterface) GetManagersCollection(ctx context.Context, req *mana
 		l.LogWithFields(ctx).Error("No servers found in odimra")
 	}
 
+	if filter != nil {
+		indexes, err := e.DB.GetFilterIndexes(common.ManagersFilterResource, filter.Properties())
+		if err != nil {
+			l.LogWithFields(ctx).Error("error while reading the manager indexes: " + err.Error())
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil), nil
+		}
+		managersCollectionKeysArray = filter.MatchIndexed(managersCollectionKeysArray, indexes)
+	}
+	// the managers of the systems out of the scope of the session are not listed
+	managersCollectionKeysArray = scope.Filter(managersCollectionKeysArray)
+	members = []dmtf.Link{}
 	for _, key := range managersCollectionKeysArray {
 		members = append(members, dmtf.Link{Oid: key})
 	}
-	managers.Members = members
+	start, end := paging.Bounds(len(members))
+	managers.Members = members[start:end]
 	managers.MembersCount = len(members)
+	managers.MembersNextLink = paging.NextLink(managers.OdataID, len(members))
 	resp.Body = managers
 	resp.StatusCode = http.StatusOK
 	respBody := fmt.Sprintf("%v", resp.Body)
@@ -269,6 +297,26 @@ func (e *ExternalInterface) getManagerDetails(ctx context.Context, id string) (m
 // There will be two return values for the function. One is the RPC response, which contains the
 // status code, status message, headers and body and the second value is error.
 func (e *ExternalInterface) GetManagersResource(ctx context.Context, req *managersproto.ManagerRequest) response.RPC {
+	if req.ManagerID == config.Data.RootServiceUUID {
+		if resp, ok := e.getAuditLogResource(ctx, req.URL); ok {
+			return resp
+		}
+	}
+	// the entries of a LogService are paged by $top and $skip, the resource is read without the query options
+	paging, path, perr := common.GetLogEntriesPaging(req.URL)
+	if perr != nil {
+		l.LogWithFields(ctx).Error(perr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, perr.Error(), nil, nil)
+	}
+	req.URL = path
+	resp := e.getManagersResource(ctx, req)
+	if resource, ok := resp.Body.(map[string]interface{}); ok && paging != nil && resp.StatusCode == http.StatusOK {
+		paging.PageMembers(resource, req.URL)
+	}
+	return resp
+}
+
+func (e *ExternalInterface) getManagersResource(ctx context.Context, req *managersproto.ManagerRequest) response.RPC {
 	var resp response.RPC
 	var tableName string
 	var resourceName string
@@ -55,6 +55,44 @@ func TestGetManagersCollection(t *testing.T) {
 	assert.Equal(t, manager.MembersCount, 1, fmt.Sprintf("Managers count is expected to be 1 but got %v", manager.MembersCount))
 }
 
+func TestGetManagersCollection_Paging(t *testing.T) {
+	ctx := mockContext()
+	e := mockGetExternalInterface()
+	e.DB.GetAllKeysFromTable = func(table string) ([]string, error) {
+		return []string{"/redfish/v1/Managers/uuid.1", "/redfish/v1/Managers/uuid.2", "/redfish/v1/Managers/uuid.3"}, nil
+	}
+	response, err := e.GetManagersCollection(ctx, &managersproto.ManagerRequest{URL: "/redfish/v1/Managers?$top=2"})
+	assert.Nil(t, err, "There should be no error")
+	manager := response.Body.(mgrresponse.ManagersCollection)
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, 3, manager.MembersCount, "Managers count should be the total number of managers")
+	assert.Equal(t, 2, len(manager.Members), "Members should have only the requested page")
+	assert.Equal(t, "/redfish/v1/Managers?$top=2&$skip=2", manager.MembersNextLink, "Next link should point to the next page")
+
+	response, _ = e.GetManagersCollection(ctx, &managersproto.ManagerRequest{URL: "/redfish/v1/Managers?$top=x"})
+	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
+}
+
+func TestGetManagersCollection_Filter(t *testing.T) {
+	ctx := mockContext()
+	e := mockGetExternalInterface()
+	common.SetFilterSchema(common.ManagersFilterResource, common.FilterSchema{
+		SearchKeys: []map[string]map[string]string{{"ManagerType": {"type": "string"}}},
+	})
+	e.DB.GetAllKeysFromTable = func(table string) ([]string, error) {
+		return []string{"/redfish/v1/Managers/uuid.1", "/redfish/v1/Managers/uuid.2"}, nil
+	}
+	response, err := e.GetManagersCollection(ctx, &managersproto.ManagerRequest{URL: "/redfish/v1/Managers?$filter=ManagerType%20eq%20BMC"})
+	assert.Nil(t, err, "There should be no error")
+	manager := response.Body.(mgrresponse.ManagersCollection)
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
+	assert.Equal(t, 1, manager.MembersCount, "Managers count should be the number of matching managers")
+	assert.Equal(t, "/redfish/v1/Managers/uuid.1", manager.Members[0].Oid, "Only the matching manager should be a member")
+
+	response, _ = e.GetManagersCollection(ctx, &managersproto.ManagerRequest{URL: "/redfish/v1/Managers?$filter=ManagerType%20gt%20BMC"})
+	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
+}
+
 func TestGetManagerRootUUIDNotFound(t *testing.T) {
 	config.SetUpMockConfig(t)
 	ctx := mockContext()
@@ -226,6 +264,36 @@ func TestGetManagerResourcewithValidURL(t *testing.T) {
 
 }
 
+func TestGetManagersResource_LogEntries(t *testing.T) {
+	ctx := mockContext()
+	config.SetUpMockConfig(t)
+	e := mockGetExternalInterface()
+	entriesURI := "/redfish/v1/Managers/uuid.1/LogServices/SEL/Entries"
+	req := &managersproto.ManagerRequest{
+		ManagerID:  "uuid.1",
+		ResourceID: "SEL",
+		URL:        entriesURI + "?$top=2&$skip=1",
+	}
+	response := e.GetManagersResource(ctx, req)
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
+	resource := response.Body.(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"@odata.id": entriesURI + "/2"},
+		map[string]interface{}{"@odata.id": entriesURI + "/3"},
+	}, resource["Members"], "Members should have the requested page of the entries")
+	assert.Equal(t, 3, resource["Members@odata.count"], "Members@odata.count should have all the entries")
+	assert.Nil(t, resource["Members@odata.nextLink"], "The last page should not have a next link")
+
+	req.URL = entriesURI + "?$top=1"
+	response = e.GetManagersResource(ctx, req)
+	resource = response.Body.(map[string]interface{})
+	assert.Equal(t, entriesURI+"?$top=1&$skip=1", resource["Members@odata.nextLink"], "The page should have the link to the next page")
+
+	req.URL = entriesURI + "?$top=abc"
+	response = e.GetManagersResource(ctx, req)
+	assert.Equal(t, http.StatusBadRequest, int(response.StatusCode), "Status code should be StatusBadRequest.")
+}
+
 func TestGetManagerResourcewithInvalidURL(t *testing.T) {
 	ctx := mockContext()
 	config.SetUpMockConfig(t)
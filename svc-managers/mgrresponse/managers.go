@@ -17,6 +17,7 @@ package mgrresponse
 
 import (
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 )
 
 // ManagersCollection for odimra
@@ -33,3 +34,35 @@ type ManagersCollection struct {
 	MembersNextLink string      `json:"Members@odata.nextLink,omitempty"`
 	Oem             *dmtf.Oem   `json:"Oem,omitempty"`
 }
+
+// AuditLogEntry is the LogEntry of the audit trail of ODIM
+type AuditLogEntry struct {
+	OdataContext string            `json:"@odata.context,omitempty"`
+	OdataID      string            `json:"@odata.id"`
+	OdataType    string            `json:"@odata.type"`
+	ID           string            `json:"Id"`
+	Name         string            `json:"Name"`
+	Created      string            `json:"Created"`
+	EntryType    string            `json:"EntryType"`
+	Severity     string            `json:"Severity"`
+	Message      string            `json:"Message"`
+	Oem          *AuditLogEntryOem `json:"Oem,omitempty"`
+}
+
+// AuditLogEntryOem holds the audit record of the AuditLogEntry
+type AuditLogEntryOem struct {
+	ODIM common.AuditRecord `json:"ODIM"`
+}
+
+// AuditLogEntryCollection is the LogEntryCollection of the audit trail of ODIM,
+// the members are expanded
+type AuditLogEntryCollection struct {
+	OdataContext    string          `json:"@odata.context"`
+	OdataID         string          `json:"@odata.id"`
+	OdataType       string          `json:"@odata.type"`
+	Description     string          `json:"Description,omitempty"`
+	Name            string          `json:"Name"`
+	Members         []AuditLogEntry `json:"Members"`
+	MembersCount    int             `json:"Members@odata.count"`
+	MembersNextLink string          `json:"Members@odata.nextLink,omitempty"`
+}
@@ -115,7 +115,11 @@ func (m *Managers) GetManagersResource(ctx context.Context, req *managersproto.M
 	ctx = context.WithValue(ctx, common.ProcessName, podName)
 	var resp managersproto.ManagerResponse
 	sessionToken := req.SessionToken
-	authResp, err := m.IsAuthorizedRPC(ctx, sessionToken, []string{common.PrivilegeLogin}, []string{})
+	privileges := []string{common.PrivilegeLogin}
+	if managers.IsAuditLogURL(req.URL) {
+		privileges = append(privileges, common.PrivilegeConfigureManager)
+	}
+	authResp, err := m.IsAuthorizedRPC(ctx, sessionToken, privileges, []string{})
 	if authResp.StatusCode != http.StatusOK {
 		if err != nil {
 			l.LogWithFields(ctx).Errorf("Error while authorizing the session token : %s", err.Error())
@@ -25,6 +25,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	chassisproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/chassis"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
 	"github.com/ODIM-Project/ODIM/svc-systems/sresponse"
@@ -32,6 +33,9 @@ import (
 
 const collectionURL = "/redfish/v1/Chassis"
 
+// GetFilterIndexesFunc function pointer for reading the indexes of the searchable chassis properties
+var GetFilterIndexesFunc = common.GetFilterIndexes
+
 // NewGetCollectionHandler returns an instance of GetCollection struct
 func NewGetCollectionHandler(
 	pcf plugin.ClientFactory,
@@ -52,7 +56,22 @@ type GetCollection struct {
 }
 
 // Handle defines the operations which handle the RPC request-response for getting chassis collection information
-func (h *GetCollection) Handle(ctx context.Context) (r response.RPC) {
+func (h *GetCollection) Handle(ctx context.Context, req *chassisproto.GetChassisRequest) (r response.RPC) {
+	paging, err := common.GetPaging(req.URL)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+	}
+	filter, err := common.GetFilter(req.URL, common.ChassisFilterResource)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while resolving the scope of the session: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
 	sources, e := h.sourcesProvider.findSources(ctx)
 	if e != nil {
 		return *e
@@ -70,11 +89,40 @@ func (h *GetCollection) Handle(ctx context.Context) (r response.RPC) {
 	}
 
 	h.sourcesProvider.findFabricChassis(ctx, &allChassisCollection)
+	allChassisCollection.Restrict(scope)
+	if filter != nil {
+		if e := filterChassis(ctx, filter, &allChassisCollection); e != nil {
+			return *e
+		}
+	}
+	allChassisCollection.Page(paging)
 
 	initializeRPCResponse(&r, allChassisCollection)
 	return
 }
 
+// filterChassis reduces the members of the collection to the chassis matching the filter,
+// using the indexes of the chassis properties created while adding the servers
+func filterChassis(ctx context.Context, filter *common.Filter, c *sresponse.Collection) *response.RPC {
+	indexes, err := GetFilterIndexesFunc(common.ChassisFilterResource, filter.Properties())
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while reading the chassis indexes: " + err.Error())
+		ge := common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+		return &ge
+	}
+	var oids []string
+	for _, m := range c.Members {
+		oids = append(oids, m.Oid)
+	}
+	members := []dmtf.Link{}
+	for _, oid := range filter.MatchIndexed(oids, indexes) {
+		members = append(members, dmtf.Link{Oid: oid})
+	}
+	c.Members = members
+	c.MembersCount = len(members)
+	return nil
+}
+
 type sourceProvider interface {
 	findSources(ctx context.Context) ([]source, *response.RPC)
 	findFabricChassis(ctx context.Context, c *sresponse.Collection)
@@ -23,8 +23,10 @@ import (
 	"testing"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	chassisproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/chassis"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-systems/plugin"
 	"github.com/ODIM-Project/ODIM/svc-systems/sresponse"
@@ -42,19 +44,66 @@ func Test_GetCollectionHandler_WhenMultipleSourcesAreAvailable(t *testing.T) {
 	cspMock.On("findSources").Return([]source{source1, source2}, nil)
 	sut := GetCollection{cspMock}
 	ctx := mockContext()
-	r := sut.Handle(ctx)
+	r := sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL})
 	require.EqualValues(t, http.StatusOK, r.StatusCode)
 	require.IsType(t, sresponse.NewChassisCollection(), r.Body)
 	require.Equal(t, []dmtfmodel.Link{{Oid: "1"}, {Oid: "3"}, {Oid: "2"}, {Oid: "4"}, {Oid: "5"}}, r.Body.(sresponse.Collection).Members)
 }
 
+func Test_GetCollectionHandler_WithPaging(t *testing.T) {
+	source1 := new(sourceMock)
+	source1.On("read").Return([]dmtfmodel.Link{{Oid: "1"}, {Oid: "3"}}, nil)
+	source2 := new(sourceMock)
+	source2.On("read").Return([]dmtfmodel.Link{{Oid: "2"}, {Oid: "4"}}, nil)
+
+	cspMock := new(collectionSourceProviderMock)
+	cspMock.On("findSources").Return([]source{source1, source2}, nil)
+	sut := GetCollection{cspMock}
+	ctx := mockContext()
+	r := sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL + "?$top=2&$skip=1"})
+	require.EqualValues(t, http.StatusOK, r.StatusCode)
+	collection := r.Body.(sresponse.Collection)
+	require.Equal(t, []dmtfmodel.Link{{Oid: "3"}, {Oid: "2"}}, collection.Members)
+	require.Equal(t, 5, collection.MembersCount)
+	require.Equal(t, collectionURL+"?$top=2&$skip=3", collection.MembersNextLink)
+
+	r = sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL + "?$skip=a"})
+	require.EqualValues(t, http.StatusBadRequest, r.StatusCode)
+}
+
+func Test_GetCollectionHandler_WithFilter(t *testing.T) {
+	common.SetFilterSchema(common.ChassisFilterResource, common.FilterSchema{
+		SearchKeys: []map[string]map[string]string{{"ChassisType": {"type": "string"}}},
+	})
+	GetFilterIndexesFunc = func(resourceType string, properties []string) (map[string]map[string]string, error) {
+		return map[string]map[string]string{"ChassisType": {"1": "rackmount", "2": "enclosure", "3": "rackmount"}}, nil
+	}
+	defer func() {
+		GetFilterIndexesFunc = common.GetFilterIndexes
+	}()
+	source1 := new(sourceMock)
+	source1.On("read").Return([]dmtfmodel.Link{{Oid: "1"}, {Oid: "2"}, {Oid: "3"}}, nil)
+	cspMock := new(collectionSourceProviderMock)
+	cspMock.On("findSources").Return([]source{source1}, nil)
+	sut := GetCollection{cspMock}
+	ctx := mockContext()
+	r := sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL + "?$filter=ChassisType%20eq%20RackMount"})
+	require.EqualValues(t, http.StatusOK, r.StatusCode)
+	collection := r.Body.(sresponse.Collection)
+	require.Equal(t, []dmtfmodel.Link{{Oid: "1"}, {Oid: "3"}}, collection.Members)
+	require.Equal(t, 2, collection.MembersCount)
+
+	r = sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL + "?$filter=Model%20eq%20abc"})
+	require.EqualValues(t, http.StatusBadRequest, r.StatusCode)
+}
+
 func Test_GetCollectionHandler_WhenCollectionSourcesCannotBeDetermined(t *testing.T) {
 	cspMock := new(collectionSourceProviderMock)
 
 	cspMock.On("findSources").Return([]source{}, &internalError)
 	sut := GetCollection{cspMock}
 	ctx := mockContext()
-	r := sut.Handle(ctx)
+	r := sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL})
 	require.NotEqual(t, http.StatusOK, r.StatusCode)
 	require.IsType(t, response.CommonError{}, r.Body)
 }
@@ -66,7 +115,7 @@ func Test_GetCollectionHandler_WhenFirstSourceReturnsError(t *testing.T) {
 	cspMock.On("findSources").Return([]source{source1}, nil)
 	sut := GetCollection{cspMock}
 	ctx := mockContext()
-	r := sut.Handle(ctx)
+	r := sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL})
 	require.NotEqual(t, http.StatusOK, r.StatusCode)
 	require.IsType(t, response.CommonError{}, r.Body)
 }
@@ -81,7 +130,7 @@ func Test_GetCollectionHandler_WhenNonFirstSourceReturnsError(t *testing.T) {
 	cspMock.On("findSources").Return([]source{source1, source2}, nil)
 	sut := GetCollection{cspMock}
 	ctx := mockContext()
-	r := sut.Handle(ctx)
+	r := sut.Handle(ctx, &chassisproto.GetChassisRequest{URL: collectionURL})
 	require.NotEqual(t, http.StatusOK, r.StatusCode)
 	require.IsType(t, response.CommonError{}, r.Body)
 }
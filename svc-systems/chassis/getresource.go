@@ -44,7 +44,14 @@ type PluginContact struct {
 // There will be two return values for the fuction. One is the RPC response, which contains the
 // status code, status message, headers and body and the second value is error.
 func (p *PluginContact) GetChassisResource(ctx context.Context, req *chassisproto.GetChassisRequest) (response.RPC, error) {
-	var resp response.RPC	
+	var resp response.RPC
+	// the entries of a LogService are paged by $top and $skip, the resource is read without the query options
+	paging, path, perr := common.GetLogEntriesPaging(req.URL)
+	if perr != nil {
+		l.LogWithFields(ctx).Error(perr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, perr.Error(), nil, nil), nil
+	}
+	req.URL = path
 	requestData := strings.SplitN(req.RequestParam, ".", 2)
 	if len(requestData) <= 1 {
 		errorMessage := "error: SystemUUID not found"
@@ -89,6 +96,9 @@ func (p *PluginContact) GetChassisResource(ctx context.Context, req *chassisprot
 	}
 	var resource map[string]interface{}
 	json.Unmarshal([]byte(data), &resource)
+	if paging != nil {
+		paging.PageMembers(resource, req.URL)
+	}
 	resp.Body = resource
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
@@ -82,6 +82,11 @@ func main() {
 	if err != nil {
 		log.Fatal("Error while trying to fetch search/filter schema json: " + err.Error())
 	}
+	if config.Data.ResourceFilterSchemaPath != "" {
+		if err := common.LoadFilterSchemas(config.Data.ResourceFilterSchemaPath); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
 
 	configFilePath := os.Getenv("CONFIG_FILE_PATH")
 	if configFilePath == "" {
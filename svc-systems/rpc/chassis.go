@@ -168,7 +168,7 @@ func (cha *ChassisRPC) GetChassisCollection(ctx context.Context, req *chassispro
 	l.LogWithFields(ctx).Debugf("incoming GetChassisCollection request with %s", req.URL)
 	var resp chassisproto.GetChassisResponse
 	r := auth(ctx, cha.IsAuthorizedRPC, req.SessionToken, []string{common.PrivilegeLogin}, func() response.RPC {
-		return cha.GetCollectionHandler.Handle(ctx)
+		return cha.GetCollectionHandler.Handle(ctx, req)
 	})
 	rewrite(ctx, r, &resp)
 	l.LogWithFields(ctx).Debugf("outgoing response Get ChassisCollection : %s", string(resp.Body))
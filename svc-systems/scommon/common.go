@@ -344,6 +344,11 @@ func TrackConfigFileChanges(configFilePath string, errChan chan error) {
 			if err != nil {
 				l.Log.Error("error while trying to fetch search/filter schema json" + err.Error())
 			}
+			if config.Data.ResourceFilterSchemaPath != "" {
+				if err := common.LoadFilterSchemas(config.Data.ResourceFilterSchemaPath); err != nil {
+					l.Log.Error(err.Error())
+				}
+			}
 			if l.Log.Level != config.Data.LogLevel {
 				l.Log.Info("Log level is updated, new log level is ", config.Data.LogLevel)
 				l.Log.Logger.SetLevel(config.Data.LogLevel)
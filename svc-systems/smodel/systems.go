@@ -232,6 +232,20 @@ func GetAllKeysFromTable(table string) ([]string, error) {
 	return keysArray, nil
 }
 
+// GetKeysPageFromTable retrieves the keys of the table in the range [skip, skip+top)
+// along with the total number of keys in the table
+func GetKeysPageFromTable(table string, skip, top int) ([]string, int, error) {
+	conn, err := GetDBConnectionFunc(common.InMemory)
+	if err != nil {
+		return nil, 0, err
+	}
+	keysArray, total, err := conn.GetKeysPage(table, skip, top)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error while trying to get keys from table - %v: %v", table, err.Error())
+	}
+	return keysArray, total, nil
+}
+
 // GetPluginData will fetch plugin details
 func GetPluginData(pluginID string) (Plugin, *errors.Error) {
 	var plugin Plugin
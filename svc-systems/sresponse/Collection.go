@@ -18,6 +18,7 @@ package sresponse
 
 import (
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 )
 
 //Collection holds response  of System and ChassisCollection
@@ -41,6 +42,31 @@ func (c *Collection) AddMember(m dmtf.Link) {
 	c.MembersCount = len(c.Members)
 }
 
+// Page reduces the members to the requested page of the collection and sets the link
+// to the next page. Members@odata.count will still have the total number of members.
+func (c *Collection) Page(paging common.Paging) {
+	start, end := paging.Bounds(len(c.Members))
+	c.MembersCount = len(c.Members)
+	c.MembersNextLink = paging.NextLink(c.OdataID, c.MembersCount)
+	c.Members = c.Members[start:end]
+}
+
+// Restrict reduces the members to the members in the scope of the session,
+// the members are not changed when the session is not restricted
+func (c *Collection) Restrict(scope *common.ResourceScope) {
+	if scope == nil {
+		return
+	}
+	members := []dmtf.Link{}
+	for _, m := range c.Members {
+		if scope.Allows(m.Oid) {
+			members = append(members, m)
+		}
+	}
+	c.Members = members
+	c.MembersCount = len(members)
+}
+
 // NewChassisCollection returns an instance of collection
 func NewChassisCollection() Collection {
 	return Collection{
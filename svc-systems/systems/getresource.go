@@ -47,12 +47,36 @@ var (
 	GetResourceInfoFromDeviceFunc = scommon.GetResourceInfoFromDevice
 	// GetAllKeysFromTableFunc function pointer for the smodel.GetAllKeysFromTable
 	GetAllKeysFromTableFunc = smodel.GetAllKeysFromTable
+	// GetKeysPageFromTableFunc function pointer for the smodel.GetKeysPageFromTable
+	GetKeysPageFromTableFunc = smodel.GetKeysPageFromTable
 	// GetDeviceLoadInfoFunc function pointer for the getDeviceLoadInfo
 	GetDeviceLoadInfoFunc = getDeviceLoadInfo
 	// GetStringFunc function pointer for the smodel.GetString
 	GetStringFunc = smodel.GetString
+	// GetRangeFunc function pointer for the smodel.GetRange
+	GetRangeFunc = smodel.GetRange
 )
 
+var (
+	// filterValueEncoder escapes the characters of a function or in operator value
+	// which are used as separators while evaluating the expression
+	filterValueEncoder = strings.NewReplacer(" ", "%20", ",", "%2C")
+	// filterValueDecoder reverts the filterValueEncoder escaping
+	filterValueDecoder = strings.NewReplacer("%20", " ", "%2C", ",")
+)
+
+// maxIndexScore is the upper bound of the score used for reading a numeric index in full
+// or from a value onwards, the values of the numeric properties are stored as the score
+const maxIndexScore = 100000000
+
+// filterFunctionOperators are the operators which are evaluated on the string value of a property
+var filterFunctionOperators = map[string]bool{
+	"contains":   true,
+	"startswith": true,
+	"endswith":   true,
+	"in":         true,
+}
+
 func setRegexFlag(ctx context.Context, val string) bool {
 	var re = regexp.MustCompile(`(?m)[\[\]!@#$%^&*(),.?":{}|<>]`)
 
@@ -63,45 +87,6 @@ func setRegexFlag(ctx context.Context, val string) bool {
 	return false
 }
 
-func errorResp(ctx context.Context, each string, resp response.RPC) (response.RPC, error) {
-	if each == "" {
-		errorMessage := " not a valid search/filter expression"
-		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
-	}
-	return resp, nil
-}
-
-func ifMatches(ctx context.Context, strPara string, operator string, resp response.RPC) (response.RPC, error) {
-	strSplit := strings.Split(strPara, operator)
-	for _, e := range strSplit {
-
-		resp, err := errorResp(ctx, e, resp)
-		if err != nil {
-			l.LogWithFields(ctx).Error(err.Error())
-			return resp, err
-		}
-	}
-	return resp, nil
-}
-
-func validate(ctx context.Context, strPara string, resp response.RPC) (response.RPC, error) {
-	if strings.Contains(strPara, "and") {
-		resp, err := ifMatches(ctx, strPara, "and", resp)
-		if err != nil {
-			l.LogWithFields(ctx).Error(err.Error())
-			return resp, err
-		}
-	}
-	if strings.Contains(strPara, "or") {
-		resp, err := ifMatches(ctx, strPara, "or", resp)
-		if err != nil {
-			l.LogWithFields(ctx).Error(err.Error())
-			return resp, err
-		}
-	}
-	return resp, nil
-}
-
 // validateLastParameter checks whether last parameter in the expression
 // is an operator or not. It throughs an error if the last parameter is operator.
 // It also checks whether the expression is empty eg: /redfish/v1/Systems?$filter=%20
@@ -114,12 +99,17 @@ func validateLastParameter(expression []string) error {
 		}
 	}
 	if lastParam == "" {
-		return fmt.Errorf("no valid expression found")
+		return fmt.Errorf("no valid expression found, expected <property> <operator> <value>")
 	}
-	operatorSet := []string{"eq", "ne", "gt", "ge", "lt", "le"}
+	operatorSet := []string{"eq", "ne", "gt", "ge", "lt", "le", "contains", "startswith", "endswith", "in"}
 	for _, op := range operatorSet {
 		if lastParam == op {
-			return fmt.Errorf("expression ends with an operator %v", op)
+			return fmt.Errorf("expression ends with the operator %v, a value is expected after it", op)
+		}
+	}
+	for _, op := range []string{"and", "or", "not"} {
+		if lastParam == op {
+			return fmt.Errorf("expression ends with the logical operator %v, a condition is expected after it", op)
 		}
 	}
 	return nil
@@ -165,8 +155,18 @@ func GetMembers(ctx context.Context, allowed map[string]map[string]bool, express
 				var val, regex string
 				if allowed["conditionKeys"][expression[new]] {
 					val = expression[new+1]
-					regexFlag = setRegexFlag(ctx, val)
 					key = strings.Replace(pam, "\\/", "/", -1)
+					if filterFunctionOperators[expression[new]] {
+						list, resp, err := getFunctionData(key, expression[new], val, typeFlag, arrayFlag)
+						if err != nil {
+							return nil, resp, err
+						}
+						for i := 0; i < len(list); i++ {
+							members = append(members, dmtf.Link{Oid: list[i]})
+						}
+						continue
+					}
+					regexFlag = setRegexFlag(ctx, val)
 					if regexFlag {
 						regex = val
 						// regular expression flag is true then get all data for key depending on the type
@@ -274,42 +274,13 @@ func getAllSystemIDs(ctx context.Context, resp response.RPC) ([]dmtf.Link, respo
 
 }
 
-// LogicalOperation will take slice of slices of member collection and logical operation to perform
-// return 1 slice of member collection with logical operation performed on them
-func LogicalOperation(interm [][]dmtf.Link, lo string) []dmtf.Link {
-	var respMembers []dmtf.Link
-	if lo == "and" {
-		if len(interm[0]) < len(interm[1]) {
-			for i := 0; i < len(interm[0]); i++ {
-				for _, v := range interm[1] {
-					if interm[0][i] == v {
-						respMembers = append(respMembers, v)
-					}
-				}
-			}
-		} else {
-			for i := 0; i < len(interm[1]); i++ {
-				for _, v := range interm[0] {
-					if interm[1][i] == v {
-						respMembers = append(respMembers, v)
-					}
-				}
-			}
-		}
-
-	} else if lo == "or" {
-		encountered := map[dmtf.Link]bool{}
-		var tmp []dmtf.Link
-		tmp = append(interm[0], interm[1]...)
-		for v := range tmp {
-			encountered[tmp[v]] = true
-		}
-		for key := range encountered {
-			respMembers = append(respMembers, key)
-		}
+// linkIDs returns the IDs of the member links
+func linkIDs(links []dmtf.Link) []string {
+	ids := make([]string, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.Oid)
 	}
-
-	return respMembers
+	return ids
 }
 
 // SearchAndFilter take the url as input and return the search result based on the filter expression
@@ -345,192 +316,54 @@ func SearchAndFilter(ctx context.Context, paramStr []string, resp response.RPC)
 			}
 		}
 	} else {
-		strPara = paramStr[1]
-	}
-	var respMembers []dmtf.Link
-	var err error
-
-	if checkParentheses(strPara) {
-		if strings.Count(strPara, "(") != strings.Count(strPara, ")") {
-			errorMessage := " not a valid search/filter expression"
-			return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, errorMessage, []interface{}{"ComputerSystem", ""}, nil), fmt.Errorf(errorMessage)
-		}
-
-		var sa []dmtf.Link
-		re := regexp.MustCompile(`\((.*?)\)`)
-		submatchall := re.FindAllString(strPara, -1)
-		for _, element := range submatchall {
-			element = strings.Trim(element, "(")
-			element = strings.Trim(element, ")")
-			if strings.Contains(element, " and ") || strings.Contains(element, " or ") {
-				var x []string
-				if strings.Contains(element, " and ") {
-					x = strings.Split(element, " and ")
-				} else {
-					x = strings.Split(element, " or ")
-				}
-				for _, each := range x {
-					sa, resp, err = GetMembers(ctx, allowed, strings.Split(each, " "), resp)
-					if err != nil {
-						return resp, err
-					}
-					s, err := json.Marshal(sa)
-					if err != nil {
-						errorMessage := " error while marshalling database data"
-						return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
-					}
-					strPara = strings.Replace(strPara, each, string(s), -1)
-				}
-			} else {
-				sa, resp, err = GetMembers(ctx, allowed, strings.Split(element, " "), resp)
-				if err != nil {
-					return resp, err
-				}
-				s, err := json.Marshal(sa)
-				if err != nil {
-					errorMessage := " error while marshalling database data"
-					return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), fmt.Errorf(errorMessage)
-				}
-				strPara = strings.Replace(strPara, element, string(s), -1)
-			}
-		}
-		expressionVals := re.FindAllString(strPara, -1)
-		for _, eles := range expressionVals {
-			var ww []dmtf.Link
-			ele := strings.Trim(eles, "(")
-			ele = strings.Trim(ele, ")")
-			if strings.Contains(ele, " and ") {
-				var inter [][]dmtf.Link
-				for _, e := range strings.Split(ele, " and ") {
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				interResp := LogicalOperation(inter, "and")
-				interSam, _ := json.Marshal(interResp)
-				strPara = strings.Replace(strPara, eles, string(interSam), 1)
-			} else if strings.Contains(ele, " or ") {
-				var inter [][]dmtf.Link
-				for _, e := range strings.Split(ele, " or ") {
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				interResp := LogicalOperation(inter, "or")
-				interSam, _ := json.Marshal(interResp)
-				strPara = strings.Replace(strPara, eles, string(interSam), 1)
-			} else {
-				strPara = strings.Replace(strPara, eles, ele, 1)
-			}
-		}
-
-		if strings.Contains(strPara, "and ") || strings.Contains(strPara, " and") || strings.Contains(strPara, "or ") || strings.Contains(strPara, " or") {
-			var ww []dmtf.Link
-			var inter [][]dmtf.Link
-			resp, err = validate(ctx, strPara, resp)
-			if err != nil {
-				l.LogWithFields(ctx).Error(err.Error())
-				return resp, err
-			}
-			if strings.Contains(strPara, " and ") {
-				for _, e := range strings.Split(strPara, " and ") {
-					resp, err = errorResp(ctx, e, resp)
-					if err != nil {
-						l.LogWithFields(ctx).Error(err.Error())
-						return resp, err
-					}
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				respMembers = LogicalOperation(inter, "and")
-			}
-			if strings.Contains(strPara, " or ") {
-				for _, e := range strings.Split(strPara, " or ") {
-					resp, err = errorResp(ctx, e, resp)
-					if err != nil {
-						l.LogWithFields(ctx).Error(err.Error())
-						return resp, err
-					}
-					_ = json.Unmarshal([]byte(e), &ww)
-					inter = append(inter, ww)
-				}
-				respMembers = LogicalOperation(inter, "or")
-
-			}
-		}
-		if !strings.Contains(strPara, " and ") && !strings.Contains(strPara, " or ") && !strings.Contains(strPara, " not ") {
-			var mems []dmtf.Link
-			var mem dmtf.Link
-			para := strings.Trim(strPara, "[")
-			para = strings.Trim(para, "]")
-			for _, e := range strings.Split(para, ",") {
-				_ = json.Unmarshal([]byte(e), &mem)
-				mems = append(mems, mem)
-			}
-			respMembers = mems
-		}
-
-	} else if strings.Contains(strPara, "and ") || strings.Contains(strPara, " and") || strings.Contains(strPara, "or ") || strings.Contains(strPara, " or") {
-		var ww []dmtf.Link
-		var inter [][]dmtf.Link
-		resp, err = validate(ctx, strPara, resp)
-		if err != nil {
-			l.LogWithFields(ctx).Error(err.Error())
-			return resp, err
-		}
-		if strings.Contains(strPara, " and ") {
-			for _, each := range strings.Split(strPara, " and ") {
-				resp, err = errorResp(ctx, each, resp)
-				if err != nil {
-					l.LogWithFields(ctx).Error(err.Error())
-					return resp, err
-				}
-				ww, resp, err = GetMembers(ctx, allowed, strings.Split(each, " "), resp)
-				if err != nil {
-					return resp, err
-				}
-				inter = append(inter, ww)
-			}
-			respMembers = LogicalOperation(inter, "and")
-		}
-		if strings.Contains(strPara, " or ") {
-			for _, each := range strings.Split(strPara, " or ") {
-				resp, err = errorResp(ctx, each, resp)
-				if err != nil {
-					l.LogWithFields(ctx).Error(err.Error())
-					return resp, err
-				}
-				ww, resp, err = GetMembers(ctx, allowed, strings.Split(each, " "), resp)
-				if err != nil {
-					return resp, err
-				}
-				inter = append(inter, ww)
+		strPara = query[1]
+	}
+	filter, err := common.ParseFilterWithSchema(strPara, common.FilterSchema{SearchKeys: scommon.SF.SearchKeys})
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryCombinationInvalid, err.Error(), []interface{}{"ComputerSystem", ""}, nil), err
+	}
+	conditionKeys := make(map[string]bool)
+	for _, value := range scommon.SF.ConditionKeys {
+		conditionKeys[value] = true
+	}
+	// each of the conditions is evaluated with the index of its property,
+	// and the members are combined as per the logical operators of the expression
+	members, err := filter.Evaluate(func(condition common.FilterCondition) ([]string, error) {
+		if !conditionKeys[condition.Operator] {
+			errorMessage := fmt.Sprintf("%v is not a supported operator for the ComputerSystem collection", condition.Operator)
+			resp = common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, errorMessage, nil, nil)
+			return nil, fmt.Errorf(errorMessage)
+		}
+		value := condition.Values[0]
+		if filterFunctionOperators[condition.Operator] {
+			values := make([]string, len(condition.Values))
+			for i := range condition.Values {
+				values[i] = filterValueEncoder.Replace(condition.Values[i])
 			}
-			respMembers = LogicalOperation(inter, "or")
-
+			value = strings.Join(values, ",")
 		}
-	} else if strings.Contains(strPara, " not ") || strings.Contains(strPara, "not ") {
-		var all, exp []dmtf.Link
-		all, resp, err = getAllSystemIDs(ctx, resp)
+		var links []dmtf.Link
+		links, resp, err = GetMembers(ctx, allowed, []string{condition.Property, condition.Operator, value}, resp)
 		if err != nil {
-			return resp, err
+			return nil, err
 		}
-		expression := strings.Split(strPara, "not ")
-		exp, resp, err = GetMembers(ctx, allowed, strings.Split(expression[1], " "), resp)
+		return linkIDs(links), nil
+	}, func() ([]string, error) {
+		var links []dmtf.Link
+		links, resp, err = getAllSystemIDs(ctx, resp)
 		if err != nil {
-			return resp, err
-		}
-		for _, eachA := range exp {
-			for j, eachB := range all {
-				if eachA == eachB {
-					all = append(all[:j], all[j+1:]...)
-				}
-			}
-		}
-		respMembers = all
-	} else {
-		respMembers, resp, err = GetMembers(ctx, allowed, strings.Split(strPara, " "), resp)
-		if err != nil {
-			return resp, err
+			return nil, err
 		}
+		return linkIDs(links), nil
+	})
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return resp, err
+	}
+	respMembers := make([]dmtf.Link, 0, len(members))
+	for _, member := range members {
+		respMembers = append(respMembers, dmtf.Link{Oid: member})
 	}
 	systemCollection := sresponse.Collection{
 		OdataContext: "/redfish/v1/$metadata#ComputerSystemCollection.ComputerSystemCollection",
@@ -558,6 +391,13 @@ func SearchAndFilter(ctx context.Context, paramStr []string, resp response.RPC)
 // status code, status message, headers and body and the second value is error.
 func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto.GetSystemsRequest) response.RPC {
 	var resp response.RPC
+	// the entries of a LogService are paged by $top and $skip, the resource is read without the query options
+	paging, path, perr := common.GetLogEntriesPaging(req.URL)
+	if perr != nil {
+		l.LogWithFields(ctx).Error(perr.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, perr.Error(), nil, nil)
+	}
+	req.URL = path
 	// Splitting the SystemID to get UUID
 	requestData := strings.SplitN(req.RequestParam, ".", 2)
 	if len(requestData) <= 1 {
@@ -656,6 +496,9 @@ func (p *PluginContact) GetSystemResource(ctx context.Context, req *systemsproto
 		}
 
 	}
+	if paging != nil {
+		paging.PageMembers(resource, req.URL)
+	}
 
 	resp.Body = resource
 	resp.StatusCode = http.StatusOK
@@ -751,16 +594,37 @@ func GetSystemsCollection(ctx context.Context, req *systemsproto.GetSystemsReque
 		allowed["queryKeys"][value] = true
 	}
 	var resp response.RPC
+	paging, err := common.GetPaging(req.URL)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil)
+	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while resolving the scope of the session: " + err.Error())
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, err.Error(), nil, nil)
+	}
 	paramStr := strings.SplitN(req.URL, "?", 2)
-	if len(paramStr) > 1 {
-		resp, retError := SearchAndFilter(ctx, paramStr, resp)
+	if query := paging.Query(); query != "" {
+		resp, retError := SearchAndFilter(ctx, []string{paramStr[0], query}, resp)
 		if retError != nil {
 			return resp
 		}
+		if systemCollection, ok := resp.Body.(sresponse.Collection); ok {
+			systemCollection.Restrict(scope)
+			systemCollection.Page(paging)
+			resp.Body = systemCollection
+		}
 		return resp
 	}
 
-	systemKeys, err := GetAllKeysFromTableFunc("ComputerSystem")
+	var systemKeys []string
+	var total int
+	if scope == nil {
+		systemKeys, total, err = GetKeysPageFromTableFunc("ComputerSystem", paging.Skip, paging.Top)
+	} else {
+		systemKeys, total, err = getScopedKeysPage("ComputerSystem", scope, paging)
+	}
 	if err != nil {
 		l.LogWithFields(ctx).Error("error getting all keys of systemcollection table : " + err.Error())
 		errorMessage := err.Error()
@@ -784,13 +648,27 @@ func GetSystemsCollection(ctx context.Context, req *systemsproto.GetSystemsReque
 		members = []dmtf.Link{}
 	}
 	systemCollection.Members = members
-	systemCollection.MembersCount = len(members)
+	systemCollection.MembersCount = total
+	systemCollection.MembersNextLink = paging.NextLink(systemCollection.OdataID, total)
 	resp.Body = systemCollection
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.Success
 	return resp
 }
 
+// getScopedKeysPage returns the page of the keys of the table which are in the scope of the
+// session, along with the total number of keys in the scope. The keys out of the scope
+// are removed before paging, so all the keys of the table are read
+func getScopedKeysPage(table string, scope *common.ResourceScope, paging common.Paging) ([]string, int, error) {
+	keys, err := GetAllKeysFromTableFunc(table)
+	if err != nil {
+		return nil, 0, err
+	}
+	keys = scope.Filter(keys)
+	start, end := paging.Bounds(len(keys))
+	return keys[start:end], len(keys), nil
+}
+
 // GetSystems is used to fetch resource data. The function is supposed to be used as part of RPC
 // For getting system resource information,  parameters need to be passed GetSystemsRequest .
 // GetSystemsRequest holds the  Uuid,Url,
@@ -830,11 +708,19 @@ func (p *PluginContact) GetSystems(ctx context.Context, req *systemsproto.GetSys
 	return resp
 }
 
-// getStringData supports the eq and ne only for  expression
+// getStringData supports the eq, ne, contains, startswith and endswith for expression
 func getStringData(key, match, expr string, regexFlag bool) ([]string, error) {
 	if expr == "eq" {
 		return smodel.GetString(key, match, regexFlag)
 	}
+	if expr == "contains" || expr == "startswith" || expr == "endswith" {
+		// get all data along with the values and match them with the function
+		list, err := GetStringFunc(key, "", true)
+		if err != nil {
+			return []string{}, err
+		}
+		return matchFunction(list, expr, match), nil
+	}
 	// get all data
 	allKeys, err := GetStringFunc(key, "", regexFlag)
 	if err != nil {
@@ -867,26 +753,33 @@ func getRangeData(key, expr string, match int, regexFlag bool) ([]string, error)
 		return []string{}, nil
 	}
 	switch expr {
+	case "contains", "startswith", "endswith":
+		// get all data along with the values and match them with the function
+		list, err := GetRangeFunc(key, 0, maxIndexScore, true)
+		if err != nil {
+			return []string{}, err
+		}
+		return matchFunction(list, expr, strconv.Itoa(match)), nil
 	case "eq":
 		return smodel.GetRange(key, match, match, regexFlag)
 	case "gt":
-		return smodel.GetRange(key, match+1, 100000000, regexFlag)
+		return smodel.GetRange(key, match+1, maxIndexScore, regexFlag)
 	case "ge":
-		return smodel.GetRange(key, match, 100000000, regexFlag)
+		return smodel.GetRange(key, match, maxIndexScore, regexFlag)
 	case "lt":
 		return smodel.GetRange(key, 0, match-1, regexFlag)
 	case "le":
 		return smodel.GetRange(key, 0, match, regexFlag)
 	case "ne":
 		if match == 0 {
-			return smodel.GetRange(key, match+1, 100000000, regexFlag)
+			return smodel.GetRange(key, match+1, maxIndexScore, regexFlag)
 
 		}
 		lowerboundKeys, err := smodel.GetRange(key, 0, match-1, regexFlag)
 		if err != nil {
 			return []string{}, err
 		}
-		upperboundKeys, err := smodel.GetRange(key, match+1, 100000000, regexFlag)
+		upperboundKeys, err := smodel.GetRange(key, match+1, maxIndexScore, regexFlag)
 		if err != nil {
 			return []string{}, err
 		}
@@ -917,14 +810,75 @@ func parseRegexData(ctx context.Context, data []string, regex string) ([]string,
 	return list, nil
 }
 
-// this function checks query has open bracket["("] as prefix to ignore the brackets inside the string
-// for e.g if query is ProcessorSummary/Model eq Intel(R) Xeon(R) Gold 6152 CPU @ 2.10GHz
-// here Inter(R) has bracket in between the string, so ignore this string for the first if search criteria
-func checkParentheses(strPara string) bool {
-	for _, val := range strings.Split(strPara, " ") {
-		if strings.HasPrefix(val, "(") {
-			return true
+// getFunctionData returns the members matching the string function or in operator
+// for the key. For the in operator the members matching any of the values are returned
+func getFunctionData(key, expr, val string, typeFlag, arrayFlag bool) ([]string, response.RPC, error) {
+	var values = []string{filterValueDecoder.Replace(val)}
+	if expr == "in" {
+		values = strings.Split(val, ",")
+		for i := range values {
+			values[i] = filterValueDecoder.Replace(values[i])
 		}
 	}
-	return false
+	if arrayFlag && expr != "in" {
+		errorMessage := fmt.Sprintf("%v is not supported for the property %v", expr, key)
+		return nil, common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, errorMessage, nil, nil), fmt.Errorf(errorMessage)
+	}
+	condition := expr
+	if expr == "in" {
+		condition = "eq"
+	}
+	var list []string
+	for _, value := range values {
+		var data []string
+		var err error
+		switch {
+		case arrayFlag:
+			searchValue, parseErr := strconv.ParseFloat(value, 64)
+			if parseErr != nil {
+				return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, parseErr.Error(), []interface{}{key, "Invalida value"}, nil), parseErr
+			}
+			data, err = smodel.GetStorageList(key, condition, searchValue, false)
+		case typeFlag:
+			searchValue, parseErr := strconv.Atoi(value)
+			if parseErr != nil {
+				return nil, common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, parseErr.Error(), []interface{}{key, "Invalida value"}, nil), parseErr
+			}
+			data, err = getRangeData(key, condition, searchValue, false)
+		default:
+			data, err = getStringData(key, value, condition, false)
+		}
+		if err != nil {
+			return nil, common.GeneralError(http.StatusServiceUnavailable, response.CouldNotEstablishConnection, err.Error(), []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil), err
+		}
+		list = append(list, data...)
+	}
+	return list, response.RPC{}, nil
+}
+
+// matchFunction filters the index entries of the form value::id with the string function
+// and returns the ids of the matching entries
+func matchFunction(data []string, expr, match string) []string {
+	match = strings.ToLower(match)
+	var list = make([]string, 0)
+	for _, entry := range data {
+		index := strings.LastIndex(entry, "::")
+		if index < 0 {
+			continue
+		}
+		value := strings.ToLower(entry[:index])
+		var found bool
+		switch expr {
+		case "contains":
+			found = strings.Contains(value, match)
+		case "startswith":
+			found = strings.HasPrefix(value, match)
+		case "endswith":
+			found = strings.HasSuffix(value, match)
+		}
+		if found {
+			list = append(list, entry[index+2:])
+		}
+	}
+	return list
 }
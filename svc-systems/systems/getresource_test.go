@@ -36,7 +36,7 @@ import (
 
 func mockSystemIndex(table, uuid string, indexData map[string]interface{}) error {
 	scommon.SF.QueryKeys = []string{"filter"}
-	scommon.SF.ConditionKeys = []string{"eq", "gt", "lt", "ge", "le", "ne"}
+	scommon.SF.ConditionKeys = []string{"eq", "gt", "lt", "ge", "le", "ne", "contains", "startswith", "endswith", "in"}
 	scommon.SF.SearchKeys = []map[string]map[string]string{
 		{
 			"ProcessorSummary/Count": {
@@ -463,11 +463,36 @@ func TestGetAllSystems(t *testing.T) {
 	GetAllKeysFromTableFunc = func(table string) ([]string, error) {
 		return nil, &errors.Error{}
 	}
+	GetKeysPageFromTableFunc = func(table string, skip, top int) ([]string, int, error) {
+		return nil, 0, &errors.Error{}
+	}
+	defer func() {
+		GetKeysPageFromTableFunc = smodel.GetKeysPageFromTable
+	}()
 	resp := GetSystemsCollection(context.Background(), &req)
 	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "Status code should be StatusInternalServerError")
 
 }
 
+func TestGetSystemsCollection_Paging(t *testing.T) {
+	GetKeysPageFromTableFunc = func(table string, skip, top int) ([]string, int, error) {
+		keys := []string{"/redfish/v1/Systems/uuid.1", "/redfish/v1/Systems/uuid.2", "/redfish/v1/Systems/uuid.3"}
+		return keys[skip : skip+top], len(keys), nil
+	}
+	defer func() {
+		GetKeysPageFromTableFunc = smodel.GetKeysPageFromTable
+	}()
+	resp := GetSystemsCollection(context.Background(), &systemsproto.GetSystemsRequest{URL: "/redfish/v1/Systems?$top=1&$skip=1"})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK")
+	collection := resp.Body.(sresponse.Collection)
+	assert.Equal(t, []dmtf.Link{{Oid: "/redfish/v1/Systems/uuid.2"}}, collection.Members, "Members should have only the requested page")
+	assert.Equal(t, 3, collection.MembersCount, "Members@odata.count should have the total number of members")
+	assert.Equal(t, "/redfish/v1/Systems?$top=1&$skip=2", collection.MembersNextLink, "Members@odata.nextLink should point to the next page")
+
+	resp = GetSystemsCollection(context.Background(), &systemsproto.GetSystemsRequest{URL: "/redfish/v1/Systems?$top=-1"})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest")
+}
+
 func TestGetSystems(t *testing.T) {
 	config.SetUpMockConfig(t)
 	ctx := mockContext()
@@ -1074,9 +1099,6 @@ func Test_getRangeData(t *testing.T) {
 
 func Test_rediscoverStorageInventory(t *testing.T) {
 	ctx := mockContext()
-	errorResp(ctx, "", response.RPC{})
-	validate(ctx, "and", response.RPC{})
-	validate(ctx, "or", response.RPC{})
 	validateLastParameter([]string{})
 	GetMembers(ctx, map[string]map[string]bool{}, []string{}, response.RPC{})
 
@@ -1113,3 +1135,97 @@ func Test_getStringData(t *testing.T) {
 	data, _ = getStringData("", "", "lt", false)
 	assert.True(t, true, data)
 }
+
+func Test_validateLastParameter(t *testing.T) {
+	assert.Nil(t, validateLastParameter([]string{"PowerState", "in", "On,Off"}), "There should be no error")
+	err := validateLastParameter([]string{"Model", "contains"})
+	assert.Equal(t, "expression ends with the operator contains, a value is expected after it", err.Error())
+	err = validateLastParameter([]string{"PowerState", "eq", "On", "and"})
+	assert.Equal(t, "expression ends with the logical operator and, a condition is expected after it", err.Error())
+}
+
+func Test_getStringData_Functions(t *testing.T) {
+	defer func() {
+		GetStringFunc = smodel.GetString
+		GetRangeFunc = smodel.GetRange
+	}()
+	GetStringFunc = func(index, match string, regexFlag bool) ([]string, error) {
+		return []string{"intel xeon gold::/redfish/v1/Systems/1", "amd epyc::/redfish/v1/Systems/2"}, nil
+	}
+	data, err := getStringData("ProcessorSummary/Model", "Xeon", "contains", false)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, []string{"/redfish/v1/Systems/1"}, data)
+	data, _ = getStringData("ProcessorSummary/Model", "amd", "startswith", false)
+	assert.Equal(t, []string{"/redfish/v1/Systems/2"}, data)
+	data, _ = getStringData("ProcessorSummary/Model", "intel", "endswith", false)
+	assert.Equal(t, []string{}, data)
+
+	GetRangeFunc = func(index string, min, max int, regexFlag bool) ([]string, error) {
+		return []string{"384::/redfish/v1/Systems/1", "128::/redfish/v1/Systems/2"}, nil
+	}
+	data, err = getRangeData("MemorySummary/TotalSystemMemoryGiB", "startswith", 38, false)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, []string{"/redfish/v1/Systems/1"}, data)
+	data, _ = getRangeData("MemorySummary/TotalSystemMemoryGiB", "endswith", 8, false)
+	assert.Equal(t, []string{"/redfish/v1/Systems/2"}, data)
+}
+
+func TestGetMembers_NestedPropertyFunction(t *testing.T) {
+	ctx := mockContext()
+	defer func() {
+		GetStringFunc = smodel.GetString
+	}()
+	scommon.SF.ConditionKeys = []string{"eq", "ne", "contains", "startswith", "endswith", "in"}
+	scommon.SF.SearchKeys = []map[string]map[string]string{
+		{"ProcessorSummary/Model": {"type": "string"}},
+	}
+	var index string
+	GetStringFunc = func(key, match string, regexFlag bool) ([]string, error) {
+		index = key
+		return []string{"intel xeon gold::/redfish/v1/Systems/1", "amd epyc::/redfish/v1/Systems/2"}, nil
+	}
+	members, _, err := GetMembers(ctx, map[string]map[string]bool{}, []string{"ProcessorSummary/Model", "contains", "xeon"}, response.RPC{})
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, "ProcessorSummary/Model", index, "index of the nested property should not be escaped")
+	assert.Equal(t, []dmtf.Link{{Oid: "/redfish/v1/Systems/1"}}, members)
+}
+
+func TestSearchAndFilter_NestedGroups(t *testing.T) {
+	ctx := mockContext()
+	defer func() {
+		GetStringFunc = smodel.GetString
+		GetAllKeysFromTableFunc = smodel.GetAllKeysFromTable
+	}()
+	scommon.SF.QueryKeys = []string{"filter"}
+	scommon.SF.ConditionKeys = []string{"eq", "ne", "contains", "startswith", "endswith", "in"}
+	scommon.SF.SearchKeys = []map[string]map[string]string{
+		{"ProcessorSummary/Model": {"type": "string"}},
+	}
+	GetStringFunc = func(key, match string, regexFlag bool) ([]string, error) {
+		return []string{
+			"intel xeon gold::/redfish/v1/Systems/1",
+			"intel xeon silver::/redfish/v1/Systems/2",
+			"amd epyc::/redfish/v1/Systems/3",
+			"arm::/redfish/v1/Systems/4",
+		}, nil
+	}
+	GetAllKeysFromTableFunc = func(table string) ([]string, error) {
+		return []string{"/redfish/v1/Systems/1", "/redfish/v1/Systems/2", "/redfish/v1/Systems/3", "/redfish/v1/Systems/4"}, nil
+	}
+	query := "$filter=((contains(ProcessorSummary/Model,'xeon')%20or%20startswith(ProcessorSummary/Model,amd))%20and%20not%20(endswith(ProcessorSummary/Model,'gold')))"
+	resp, err := SearchAndFilter(ctx, []string{"/redfish/v1/Systems", query}, response.RPC{})
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status code should be StatusOK.")
+	collection := resp.Body.(sresponse.Collection)
+	assert.Equal(t, []dmtf.Link{{Oid: "/redfish/v1/Systems/2"}, {Oid: "/redfish/v1/Systems/3"}}, collection.Members)
+
+	for _, query := range []string{
+		"$filter=((contains(ProcessorSummary/Model,'xeon'))",
+		"$filter=contains(ProcessorSummary/Model)",
+		"$filter=ProcessorSummary/Model%20gt%20xeon",
+	} {
+		resp, err = SearchAndFilter(ctx, []string{"/redfish/v1/Systems", query}, response.RPC{})
+		assert.NotNil(t, err, "There should be an error for %v", query)
+		assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "Status code should be StatusBadRequest for %v", query)
+	}
+}
@@ -73,6 +73,11 @@ func main() {
 	if err := common.CheckDBConnection(); err != nil {
 		log.Fatal("error while trying to check DB connection health: " + err.Error())
 	}
+	if config.Data.ResourceFilterSchemaPath != "" {
+		if err := common.LoadFilterSchemas(config.Data.ResourceFilterSchemaPath); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
 	tcommon.ConfigFilePath = os.Getenv("CONFIG_FILE_PATH")
 	if tcommon.ConfigFilePath == "" {
 		log.Fatal("error: no value get the environment variable CONFIG_FILE_PATH")
@@ -84,6 +89,9 @@ func main() {
 	if err := services.InitializeService(services.Tasks, errChan); err != nil {
 		log.Fatal("fatal: error while trying to initialize the service: " + err.Error())
 	}
+	services.RegisterHealthCheck(services.HealthMessageBus, func() error {
+		return dc.Ping(config.Data.MessageBusConf.MessageBusType)
+	})
 
 	tqueue.NewTaskQueue(config.Data.TaskQueueConf.QueueSize)
 
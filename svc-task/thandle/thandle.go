@@ -321,6 +321,10 @@ func (ts *TasksRPC) validateAndAuthorize(ctx context.Context, req *taskproto.Get
 		fillProtoResponse(ctx, rsp, common.GeneralError(http.StatusNotFound, response.ResourceNotFound, err.Error(), []interface{}{"Task", req.TaskID}, nil))
 		return nil, err
 	}
+	if resp := checkTaskInScope(ctx, task); resp.StatusCode != 0 {
+		fillProtoResponse(ctx, rsp, resp)
+		return nil, fmt.Errorf("task %s is not in the scope of the session", req.TaskID)
+	}
 	//Compare the task username with requesting session user name.
 	//If username doesn't match with task username, then check if the user
 	//is an Admin(PrivilegeConfigureUsers). If he is admin then proceed.
@@ -338,6 +342,23 @@ func (ts *TasksRPC) validateAndAuthorize(ctx context.Context, req *taskproto.Get
 	return task, nil
 }
 
+// checkTaskInScope checks the target of the task is in the scope of the session. The
+// task out of the scope is not found by the session, the response is empty when it is in the scope
+func checkTaskInScope(ctx context.Context, task *tmodel.Task) response.RPC {
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		errorMessage := "error: while trying to resolve the scope of the session: " + err.Error()
+		l.LogWithFields(ctx).Error(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+	}
+	if !scope.Allows(task.Payload.TargetURI) {
+		errorMessage := "error: no data with the task ID " + task.ID + " found"
+		l.LogWithFields(ctx).Error(errorMessage + " in the scope of the session")
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Task", task.ID}, nil)
+	}
+	return response.RPC{}
+}
+
 func (ts *TasksRPC) taskCancelCallBack(ctx context.Context, taskID string) error {
 	task, err := ts.GetTaskStatusModel(ctx, taskID, common.InMemory)
 	if err != nil {
@@ -499,6 +520,10 @@ func (ts *TasksRPC) GetSubTask(ctx context.Context, req *taskproto.GetTaskReques
 			[]interface{}{"Task", req.SubTaskID}, nil))
 		return &rsp, nil
 	}
+	if resp := checkTaskInScope(ctx, task); resp.StatusCode != 0 {
+		fillProtoResponse(ctx, &rsp, resp)
+		return &rsp, nil
+	}
 	//Compare the task username with requesting session user name
 	if sessionUserName != task.UserName {
 		privileges := []string{common.PrivilegeConfigureUsers}
@@ -608,6 +633,25 @@ func (ts *TasksRPC) TaskCollection(ctx context.Context, req *taskproto.GetTaskRe
 		fillProtoResponse(ctx, &rsp, authResp)
 		return &rsp, nil
 	}
+	paging, err := common.GetPaging(req.URL)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		fillProtoResponse(ctx, &rsp, common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil))
+		return &rsp, nil
+	}
+	filter, err := common.GetFilter(req.URL, common.TasksFilterResource)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		fillProtoResponse(ctx, &rsp, common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, err.Error(), nil, nil))
+		return &rsp, nil
+	}
+	scope, err := common.GetResourceScope(ctx)
+	if err != nil {
+		errorMessage := "error: while trying to resolve the scope of the session: " + err.Error()
+		fillProtoResponse(ctx, &rsp, common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil))
+		l.LogWithFields(ctx).Error(errorMessage)
+		return &rsp, nil
+	}
 	// Get all task in in-memory db
 	tasks, err := ts.GetAllTaskKeysModel(ctx)
 	if err != nil {
@@ -632,7 +676,9 @@ func (ts *TasksRPC) TaskCollection(ctx context.Context, req *taskproto.GetTaskRe
 		// Check who owns the task before returning, if this can only be done by admin,
 		//then its appropriate to give back all the tasks available in the DB
 		//If user has just login privilege then return his own task
-		if authResp.StatusCode == http.StatusOK && statusConfigureUsers.StatusCode != http.StatusOK {
+		//if user has configureusers privelege then return all tasks
+		configureUsers := statusConfigureUsers.StatusCode == http.StatusOK
+		if !configureUsers || filter != nil || scope != nil {
 			task, err := ts.GetTaskStatusModel(ctx, taskID, common.InMemory)
 			if err != nil {
 				l.LogWithFields(ctx).Error("error getting task status : " + err.Error())
@@ -641,16 +687,19 @@ func (ts *TasksRPC) TaskCollection(ctx context.Context, req *taskproto.GetTaskRe
 				return &rsp, nil
 			}
 			//Check if the task belongs to user
-			if task.UserName == sessionUserName {
-				member := tresponse.ListMember{OdataID: "/redfish/v1/TaskService/Tasks/" + taskID}
-				listMembers = append(listMembers, member)
+			if !configureUsers && task.UserName != sessionUserName {
+				continue
+			}
+			if filter != nil && !filter.Match(taskFilterProperty(task)) {
+				continue
+			}
+			// the tasks on the resources out of the scope of the session are not listed
+			if !scope.Allows(task.Payload.TargetURI) {
+				continue
 			}
 		}
-		//if user has configureusers privelege then return all tasks
-		if statusConfigureUsers.StatusCode == http.StatusOK {
-			member := tresponse.ListMember{OdataID: "/redfish/v1/TaskService/Tasks/" + taskID}
-			listMembers = append(listMembers, member)
-		}
+		member := tresponse.ListMember{OdataID: "/redfish/v1/TaskService/Tasks/" + taskID}
+		listMembers = append(listMembers, member)
 	}
 
 	// return response with status OK
@@ -658,10 +707,12 @@ func (ts *TasksRPC) TaskCollection(ctx context.Context, req *taskproto.GetTaskRe
 	rsp.StatusMessage = response.Success
 
 	//Frame the Response to send it back as response body
+	start, end := paging.Bounds(len(listMembers))
 	taskResp := tresponse.TaskCollectionResponse{
-		Response:     commonResponse,
-		MembersCount: len(listMembers),
-		Members:      listMembers,
+		Response:        commonResponse,
+		MembersCount:    len(listMembers),
+		Members:         listMembers[start:end],
+		MembersNextLink: paging.NextLink(commonResponse.OdataID, len(listMembers)),
 	}
 	respBody := generateResponse(ctx, taskResp)
 	rsp.Body = respBody
@@ -669,6 +720,26 @@ func (ts *TasksRPC) TaskCollection(ctx context.Context, req *taskproto.GetTaskRe
 	return &rsp, nil
 }
 
+// taskFilterProperty returns the function for reading the searchable properties of the task,
+// where Owner is the user who created the task
+func taskFilterProperty(task *tmodel.Task) func(string) (interface{}, bool) {
+	return func(property string) (interface{}, bool) {
+		switch property {
+		case "TaskState":
+			return task.TaskState, true
+		case "TaskStatus":
+			return task.TaskStatus, true
+		case "StartTime":
+			return task.StartTime, !task.StartTime.IsZero()
+		case "EndTime":
+			return task.EndTime, !task.EndTime.IsZero()
+		case "Owner":
+			return task.UserName, true
+		}
+		return nil, false
+	}
+}
+
 // GetTasks is an API end point to get the task status and response body.
 // Takes X-Auth-Token and authorize the request.
 // If X-Auth-Token is empty or invalid then it returns "StatusUnauthorized".
@@ -16,6 +16,7 @@ package thandle
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -28,6 +29,7 @@ import (
 	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/svc-task/tcommon"
 	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
+	"github.com/ODIM-Project/ODIM/svc-task/tresponse"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/crypto/sha3"
@@ -309,6 +311,42 @@ func TestTasksRPC_TaskCollection(t *testing.T) {
 				StatusCode: http.StatusUnauthorized,
 			},
 		},
+		{
+			name: "Positive test case, paged collection.",
+			ts: &TasksRPC{
+				AuthenticationRPC:     mockIsAuthorized,
+				GetSessionUserNameRPC: mockGetSessionUserName,
+				GetAllTaskKeysModel:   mockGetAllTaskKeysModel,
+			},
+			args: args{
+				req: &taskproto.GetTaskRequest{
+					SessionToken: "validToken",
+					URL:          "/redfish/v1/TaskService/Tasks?$top=1&$skip=1",
+				},
+				rsp: &taskproto.TaskResponse{},
+			},
+			want: taskproto.TaskResponse{
+				StatusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "Negative test case, invalid $top.",
+			ts: &TasksRPC{
+				AuthenticationRPC:     mockIsAuthorized,
+				GetSessionUserNameRPC: mockGetSessionUserName,
+				GetAllTaskKeysModel:   mockGetAllTaskKeysModel,
+			},
+			args: args{
+				req: &taskproto.GetTaskRequest{
+					SessionToken: "validToken",
+					URL:          "/redfish/v1/TaskService/Tasks?$top=abc",
+				},
+				rsp: &taskproto.TaskResponse{},
+			},
+			want: taskproto.TaskResponse{
+				StatusCode: http.StatusBadRequest,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -320,6 +358,43 @@ func TestTasksRPC_TaskCollection(t *testing.T) {
 	}
 }
 
+func TestTasksRPC_TaskCollection_Filter(t *testing.T) {
+	common.SetFilterSchema(common.TasksFilterResource, common.FilterSchema{
+		SearchKeys: []map[string]map[string]string{
+			{"TaskState": {"type": "string"}},
+			{"StartTime": {"type": "datetime"}},
+			{"Owner": {"type": "string"}},
+		},
+	})
+	ts := &TasksRPC{
+		AuthenticationRPC:     mockIsAuthorized,
+		GetSessionUserNameRPC: mockGetSessionUserName,
+		GetAllTaskKeysModel: func(ctx context.Context) ([]string, error) {
+			return []string{"RunningTaskID", "CompletedTaskID"}, nil
+		},
+		GetTaskStatusModel: mockGetTaskStatusModel,
+	}
+	rsp, _ := ts.TaskCollection(mockContext(), &taskproto.GetTaskRequest{
+		SessionToken: "validToken",
+		URL:          "/redfish/v1/TaskService/Tasks?$filter=TaskState%20eq%20Completed%20and%20Owner%20eq%20validUser%20and%20StartTime%20gt%202020-01-01T00:00:00Z",
+	})
+	if rsp.StatusCode != http.StatusOK {
+		t.Fatalf("TasksRPC.TaskCollection() status = %v, want %v", rsp.StatusCode, http.StatusOK)
+	}
+	var collection tresponse.TaskCollectionResponse
+	json.Unmarshal(rsp.Body, &collection)
+	if len(collection.Members) != 1 || collection.Members[0].OdataID != "/redfish/v1/TaskService/Tasks/CompletedTaskID" {
+		t.Errorf("TasksRPC.TaskCollection() members = %v, want only the completed task", collection.Members)
+	}
+	rsp, _ = ts.TaskCollection(mockContext(), &taskproto.GetTaskRequest{
+		SessionToken: "validToken",
+		URL:          "/redfish/v1/TaskService/Tasks?$filter=PercentComplete%20eq%2010",
+	})
+	if rsp.StatusCode != http.StatusBadRequest {
+		t.Errorf("TasksRPC.TaskCollection() status = %v, want %v", rsp.StatusCode, http.StatusBadRequest)
+	}
+}
+
 func TestTasksRPC_GetTaskService(t *testing.T) {
 	type args struct {
 		req *taskproto.GetTaskRequest
@@ -625,6 +700,69 @@ func TestTasksRPC_GetSubTask(t *testing.T) {
 	}
 }
 
+// mockScopedContext returns the context of a session restricted to the aggregate
+// whose member systems are passed, the scope is resolved without the DB
+func mockScopedContext(t *testing.T, aggregateID string, systems ...string) context.Context {
+	readResourceScopeData := common.ReadResourceScopeData
+	t.Cleanup(func() { common.ReadResourceScopeData = readResourceScopeData })
+	aggregateURI := "/redfish/v1/AggregationService/Aggregates/" + aggregateID
+	common.ReadResourceScopeData = func(dbFlag common.DbType, table, key string) (string, *errors.Error) {
+		if table != "Aggregate" || key != aggregateURI {
+			return "", errors.PackError(errors.DBKeyNotFound, "no data with the key ", key, " found")
+		}
+		elements := []map[string]string{}
+		for _, system := range systems {
+			elements = append(elements, map[string]string{"@odata.id": system})
+		}
+		data, _ := json.Marshal(map[string]interface{}{"Elements": elements})
+		return string(data), nil
+	}
+	return context.WithValue(mockContext(), common.SessionAggregates, aggregateURI)
+}
+
+func TestTasksRPC_TaskOutOfScope(t *testing.T) {
+	ts := &TasksRPC{
+		AuthenticationRPC:     mockIsAuthorized,
+		GetSessionUserNameRPC: mockGetSessionUserName,
+		GetTaskStatusModel: func(ctx context.Context, taskID string, db common.DbType) (*tmodel.Task, error) {
+			task, err := mockGetTaskStatusModel(ctx, taskID, db)
+			if err == nil {
+				task.Payload.TargetURI = "/redfish/v1/Systems/uuid.1/Actions/ComputerSystem.Reset"
+			}
+			return task, err
+		},
+		TransactionModel: mockTransactionModel,
+	}
+	req := &taskproto.GetTaskRequest{
+		TaskID:       "RunningTaskID",
+		SubTaskID:    "RunningSubTaskID",
+		SessionToken: "validToken",
+	}
+
+	// the task on the system of another aggregate is not found
+	ctx := mockScopedContext(t, "tasks1", "/redfish/v1/Systems/uuid.2")
+	if rsp, _ := ts.GetTasks(ctx, req); rsp.StatusCode != http.StatusNotFound {
+		t.Errorf("TasksRPC.GetTasks() got = %v, want %v", rsp.StatusCode, http.StatusNotFound)
+	}
+	if rsp, _ := ts.GetSubTasks(ctx, req); rsp.StatusCode != http.StatusNotFound {
+		t.Errorf("TasksRPC.GetSubTasks() got = %v, want %v", rsp.StatusCode, http.StatusNotFound)
+	}
+	if rsp, _ := ts.GetSubTask(ctx, req); rsp.StatusCode != http.StatusNotFound {
+		t.Errorf("TasksRPC.GetSubTask() got = %v, want %v", rsp.StatusCode, http.StatusNotFound)
+	}
+	if rsp, _ := ts.DeleteTask(ctx, req); rsp.StatusCode != http.StatusNotFound {
+		t.Errorf("TasksRPC.DeleteTask() got = %v, want %v", rsp.StatusCode, http.StatusNotFound)
+	}
+
+	ctx = mockScopedContext(t, "tasks2", "/redfish/v1/Systems/uuid.1")
+	if rsp, _ := ts.GetTasks(ctx, req); rsp.StatusCode != http.StatusAccepted {
+		t.Errorf("TasksRPC.GetTasks() got = %v, want %v", rsp.StatusCode, http.StatusAccepted)
+	}
+	if rsp, _ := ts.GetSubTask(ctx, req); rsp.StatusCode != http.StatusAccepted {
+		t.Errorf("TasksRPC.GetSubTask() got = %v, want %v", rsp.StatusCode, http.StatusAccepted)
+	}
+}
+
 func TestTasksRPC_DeleteTask(t *testing.T) {
 	type args struct {
 		req *taskproto.GetTaskRequest
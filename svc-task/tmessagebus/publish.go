@@ -23,6 +23,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	l "github.com/ODIM-Project/ODIM/lib-utilities/logs"
+	"github.com/ODIM-Project/ODIM/lib-utilities/tracing"
 	uuid "github.com/satori/go.uuid"
 )
 
@@ -55,12 +56,16 @@ func Publish(ctx context.Context, taskURI, messageID, eventType, taskMessage str
 		Events:    events,
 	}
 	data, _ := json.Marshal(messageData)
+	ctx, span := tracing.StartSpan(ctx, topicName+" publish", tracing.SpanKindProducer)
+	defer span.End()
 	var mbevent = common.Events{
-		IP:      "TasksCollection",
-		Request: data,
+		IP:          "TasksCollection",
+		Request:     data,
+		TraceParent: tracing.TraceParent(ctx),
 	}
 
 	if err := k.Distribute(mbevent); err != nil {
+		span.SetError(err)
 		l.LogWithFields(ctx).Error("TaskURI:" + taskURI + ", EventID:" + eventID + ", MessageID:" + messageID + " : unable to publish the event to message bus: " + err.Error())
 		return
 	}
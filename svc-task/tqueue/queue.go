@@ -1,6 +1,7 @@
 package tqueue
 
 import (
+	"github.com/ODIM-Project/ODIM/lib-utilities/metrics"
 	"github.com/ODIM-Project/ODIM/svc-task/tmodel"
 )
 
@@ -12,6 +13,30 @@ type taskQueue struct {
 // TaskQueue is an instance of taskQueue struct which act as the queue for update task requests
 var TaskQueue *taskQueue
 
+// queue depth metrics, collected on every scrape
+var (
+	_ = metrics.NewGaugeFunc("odim_task_queue_depth",
+		"Number of task updates waiting in the task queue", nil,
+		func(emit func(float64, ...string)) {
+			emit(float64(QueueDepth()))
+		})
+	_ = metrics.NewGaugeFunc("odim_task_queue_capacity",
+		"Maximum number of task updates the task queue can hold", nil,
+		func(emit func(float64, ...string)) {
+			if TaskQueue != nil {
+				emit(float64(cap(TaskQueue.queue)))
+			}
+		})
+)
+
+// QueueDepth returns the number of task updates waiting in the queue
+func QueueDepth() int {
+	if TaskQueue == nil {
+		return 0
+	}
+	return len(TaskQueue.queue)
+}
+
 // NewTaskQueue creates an instance of taskQueue if it is not already created.
 func NewTaskQueue(size int) {
 	if TaskQueue != nil {
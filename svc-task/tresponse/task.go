@@ -82,8 +82,9 @@ type Payload struct {
 // TaskCollectionResponse is used to give back the response
 type TaskCollectionResponse struct {
 	response.Response
-	MembersCount int          `json:"Members@odata.count"`
-	Members      []ListMember `json:"Members"`
+	MembersCount    int          `json:"Members@odata.count"`
+	Members         []ListMember `json:"Members"`
+	MembersNextLink string       `json:"Members@odata.nextLink,omitempty"`
 }
 
 // TaskServiceResponse is used to give baxk the response
@@ -44,6 +44,8 @@ var (
 	JSONMarshalFunc = json.Marshal
 	//StringsEqualFoldFunc ...
 	StringsEqualFoldFunc = strings.EqualFold
+	//TargetsOutOfScopeFunc ...
+	TargetsOutOfScopeFunc = common.TargetsOutOfScope
 )
 
 // SimpleUpdate function handler for simpe update process
@@ -83,6 +85,19 @@ func (e *ExternalInterface) SimpleUpdate(ctx context.Context, taskID string, ses
 		return
 	}
 
+	outOfScope, err := TargetsOutOfScopeFunc(ctx, updateRequest.Targets)
+	if err != nil {
+		errMsg := "Unable to resolve the aggregates of the session: " + err.Error()
+		l.LogWithFields(ctx).Error(errMsg)
+		common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return
+	} else if len(outOfScope) > 0 {
+		errMsg := fmt.Sprintf("Targets %v are not in the scope of the session", outOfScope)
+		l.LogWithFields(ctx).Warn(errMsg)
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"Target", outOfScope[0]}, taskInfo)
+		return
+	}
+
 	targetList, err := sortTargetList(ctx, updateRequest.Targets)
 	if err != nil {
 		errorMessage := "SystemUUID not found"
@@ -206,6 +206,44 @@ func Test_SimpleUpdate(t *testing.T) {
 	}
 }
 
+func TestSimpleUpdate_TargetsOutOfScope(t *testing.T) {
+	ctx := mockContext()
+	config.SetUpMockConfig(t)
+	defer func() {
+		TargetsOutOfScopeFunc = common.TargetsOutOfScope
+	}()
+	req := &updateproto.UpdateRequest{
+		SessionToken: "validToken",
+		RequestBody:  []byte(`{"ImageURI":"abc","Targets":["/redfish/v1/Systems/uuid.1/target1"]}`),
+	}
+	tests := []struct {
+		name       string
+		outOfScope []string
+		err        error
+		want       int32
+	}{
+		{name: "target out of scope", outOfScope: []string{"/redfish/v1/Systems/uuid.1/target1"}, want: http.StatusNotFound},
+		{name: "scope not resolved", err: errors.New("DB down"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TargetsOutOfScopeFunc = func(ctx context.Context, targets []string) ([]string, error) {
+				return tt.outOfScope, tt.err
+			}
+			var got int32
+			e := mockGetExternalInterface()
+			e.External.UpdateTask = func(ctx context.Context, task common.TaskData) error {
+				got = task.Response.StatusCode
+				return nil
+			}
+			e.SimpleUpdate(ctx, "someID", "someUser", req)
+			if got != tt.want {
+				t.Errorf("SimpleUpdate() task status = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestExternalInterface_sendRequestPreferedAuthType(t *testing.T) {
 	config.SetUpMockConfig(t)
 	ctx := mockContext()